@@ -0,0 +1,733 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: crm/v1/account.proto
+
+package crmv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Account struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 帳戶ID
+	AccountId string `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// 帳戶名稱
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// 帳戶電話
+	PhoneNumber string `protobuf:"bytes,3,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	// 帳戶類型
+	Type []string `protobuf:"bytes,4,rep,name=type,proto3" json:"type,omitempty"`
+	// 行業ID
+	IndustryId string `protobuf:"bytes,5,opt,name=industry_id,json=industryId,proto3" json:"industry_id,omitempty"`
+	// 行業名稱
+	IndustryName string `protobuf:"bytes,6,opt,name=industry_name,json=industryName,proto3" json:"industry_name,omitempty"`
+	// 父系帳戶ID
+	ParentAccountId string `protobuf:"bytes,7,opt,name=parent_account_id,json=parentAccountId,proto3" json:"parent_account_id,omitempty"`
+	// 父系帳戶名稱
+	ParentAccountName string `protobuf:"bytes,8,opt,name=parent_account_name,json=parentAccountName,proto3" json:"parent_account_name,omitempty"`
+	// 業務員ID
+	SalespersonId string `protobuf:"bytes,9,opt,name=salesperson_id,json=salespersonId,proto3" json:"salesperson_id,omitempty"`
+	// 業務員名稱
+	SalespersonName string `protobuf:"bytes,10,opt,name=salesperson_name,json=salespersonName,proto3" json:"salesperson_name,omitempty"`
+	// 創建者
+	CreatedBy string `protobuf:"bytes,11,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	// 更新者
+	UpdatedBy string `protobuf:"bytes,12,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	// 創建時間
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// 更新時間
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// 版本,更新時帶入 if_match
+	Etag          string `protobuf:"bytes,15,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	mi := &file_crm_v1_account_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_account_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_crm_v1_account_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Account) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Account) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Account) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *Account) GetType() []string {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *Account) GetIndustryId() string {
+	if x != nil {
+		return x.IndustryId
+	}
+	return ""
+}
+
+func (x *Account) GetIndustryName() string {
+	if x != nil {
+		return x.IndustryName
+	}
+	return ""
+}
+
+func (x *Account) GetParentAccountId() string {
+	if x != nil {
+		return x.ParentAccountId
+	}
+	return ""
+}
+
+func (x *Account) GetParentAccountName() string {
+	if x != nil {
+		return x.ParentAccountName
+	}
+	return ""
+}
+
+func (x *Account) GetSalespersonId() string {
+	if x != nil {
+		return x.SalespersonId
+	}
+	return ""
+}
+
+func (x *Account) GetSalespersonName() string {
+	if x != nil {
+		return x.SalespersonName
+	}
+	return ""
+}
+
+func (x *Account) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Account) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *Account) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Account) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Account) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+type GetAccountRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 帳戶ID
+	AccountId     string `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountRequest) Reset() {
+	*x = GetAccountRequest{}
+	mi := &file_crm_v1_account_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountRequest) ProtoMessage() {}
+
+func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_account_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_account_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type ListAccountsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 多筆
+	Accounts []*Account `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	// 頁數
+	Page int64 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// 筆數
+	Limit int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// 總筆數(未計算時為-1)
+	Total int64 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	// 總頁數
+	Pages int64 `protobuf:"varint,5,opt,name=pages,proto3" json:"pages,omitempty"`
+	// 下一頁游標
+	NextCursor string `protobuf:"bytes,6,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// 上一頁游標
+	PrevCursor    string `protobuf:"bytes,7,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountsResponse) Reset() {
+	*x = ListAccountsResponse{}
+	mi := &file_crm_v1_account_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsResponse) ProtoMessage() {}
+
+func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_account_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_crm_v1_account_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListAccountsResponse) GetAccounts() []*Account {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+func (x *ListAccountsResponse) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListAccountsResponse) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListAccountsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListAccountsResponse) GetPages() int64 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+func (x *ListAccountsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListAccountsResponse) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+type CreateAccountRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 帳戶名稱
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 帳戶電話
+	PhoneNumber string `protobuf:"bytes,2,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	// 帳戶類型
+	Type []string `protobuf:"bytes,3,rep,name=type,proto3" json:"type,omitempty"`
+	// 行業ID
+	IndustryId string `protobuf:"bytes,4,opt,name=industry_id,json=industryId,proto3" json:"industry_id,omitempty"`
+	// 父系帳戶ID
+	ParentAccountId string `protobuf:"bytes,5,opt,name=parent_account_id,json=parentAccountId,proto3" json:"parent_account_id,omitempty"`
+	// 業務員ID,不帶入時為創建者
+	SalespersonId string `protobuf:"bytes,6,opt,name=salesperson_id,json=salespersonId,proto3" json:"salesperson_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccountRequest) Reset() {
+	*x = CreateAccountRequest{}
+	mi := &file_crm_v1_account_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccountRequest) ProtoMessage() {}
+
+func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_account_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccountRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_account_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateAccountRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAccountRequest) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *CreateAccountRequest) GetType() []string {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *CreateAccountRequest) GetIndustryId() string {
+	if x != nil {
+		return x.IndustryId
+	}
+	return ""
+}
+
+func (x *CreateAccountRequest) GetParentAccountId() string {
+	if x != nil {
+		return x.ParentAccountId
+	}
+	return ""
+}
+
+func (x *CreateAccountRequest) GetSalespersonId() string {
+	if x != nil {
+		return x.SalespersonId
+	}
+	return ""
+}
+
+type UpdateAccountRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 帳戶ID
+	AccountId string `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// 版本(If-Match)
+	IfMatch string `protobuf:"bytes,2,opt,name=if_match,json=ifMatch,proto3" json:"if_match,omitempty"`
+	// 帳戶名稱
+	Name *string `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// 帳戶電話
+	PhoneNumber *string `protobuf:"bytes,4,opt,name=phone_number,json=phoneNumber,proto3,oneof" json:"phone_number,omitempty"`
+	// 帳戶類型,不帶入時不更新
+	Type []string `protobuf:"bytes,5,rep,name=type,proto3" json:"type,omitempty"`
+	// 行業ID
+	IndustryId *string `protobuf:"bytes,6,opt,name=industry_id,json=industryId,proto3,oneof" json:"industry_id,omitempty"`
+	// 父系帳戶ID
+	ParentAccountId *string `protobuf:"bytes,7,opt,name=parent_account_id,json=parentAccountId,proto3,oneof" json:"parent_account_id,omitempty"`
+	// 業務員ID
+	SalespersonId *string `protobuf:"bytes,8,opt,name=salesperson_id,json=salespersonId,proto3,oneof" json:"salesperson_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAccountRequest) Reset() {
+	*x = UpdateAccountRequest{}
+	mi := &file_crm_v1_account_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAccountRequest) ProtoMessage() {}
+
+func (x *UpdateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_account_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAccountRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_account_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *UpdateAccountRequest) GetIfMatch() string {
+	if x != nil {
+		return x.IfMatch
+	}
+	return ""
+}
+
+func (x *UpdateAccountRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateAccountRequest) GetPhoneNumber() string {
+	if x != nil && x.PhoneNumber != nil {
+		return *x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *UpdateAccountRequest) GetType() []string {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *UpdateAccountRequest) GetIndustryId() string {
+	if x != nil && x.IndustryId != nil {
+		return *x.IndustryId
+	}
+	return ""
+}
+
+func (x *UpdateAccountRequest) GetParentAccountId() string {
+	if x != nil && x.ParentAccountId != nil {
+		return *x.ParentAccountId
+	}
+	return ""
+}
+
+func (x *UpdateAccountRequest) GetSalespersonId() string {
+	if x != nil && x.SalespersonId != nil {
+		return *x.SalespersonId
+	}
+	return ""
+}
+
+type DeleteAccountRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 帳戶ID
+	AccountId     string `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
+	mi := &file_crm_v1_account_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountRequest) ProtoMessage() {}
+
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_account_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_account_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+var File_crm_v1_account_proto protoreflect.FileDescriptor
+
+const file_crm_v1_account_proto_rawDesc = "" +
+	"\n" +
+	"\x14crm/v1/account.proto\x12\x06crm.v1\x1a\x13crm/v1/common.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xaf\x04\n" +
+	"\aAccount\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\fphone_number\x18\x03 \x01(\tR\vphoneNumber\x12\x12\n" +
+	"\x04type\x18\x04 \x03(\tR\x04type\x12\x1f\n" +
+	"\vindustry_id\x18\x05 \x01(\tR\n" +
+	"industryId\x12#\n" +
+	"\rindustry_name\x18\x06 \x01(\tR\findustryName\x12*\n" +
+	"\x11parent_account_id\x18\a \x01(\tR\x0fparentAccountId\x12.\n" +
+	"\x13parent_account_name\x18\b \x01(\tR\x11parentAccountName\x12%\n" +
+	"\x0esalesperson_id\x18\t \x01(\tR\rsalespersonId\x12)\n" +
+	"\x10salesperson_name\x18\n" +
+	" \x01(\tR\x0fsalespersonName\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\v \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\f \x01(\tR\tupdatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x12\n" +
+	"\x04etag\x18\x0f \x01(\tR\x04etag\"2\n" +
+	"\x11GetAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"\xdb\x01\n" +
+	"\x14ListAccountsResponse\x12+\n" +
+	"\baccounts\x18\x01 \x03(\v2\x0f.crm.v1.AccountR\baccounts\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x03R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\x12\x14\n" +
+	"\x05pages\x18\x05 \x01(\x03R\x05pages\x12\x1f\n" +
+	"\vnext_cursor\x18\x06 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\a \x01(\tR\n" +
+	"prevCursor\"\xd5\x01\n" +
+	"\x14CreateAccountRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fphone_number\x18\x02 \x01(\tR\vphoneNumber\x12\x12\n" +
+	"\x04type\x18\x03 \x03(\tR\x04type\x12\x1f\n" +
+	"\vindustry_id\x18\x04 \x01(\tR\n" +
+	"industryId\x12*\n" +
+	"\x11parent_account_id\x18\x05 \x01(\tR\x0fparentAccountId\x12%\n" +
+	"\x0esalesperson_id\x18\x06 \x01(\tR\rsalespersonId\"\xfb\x02\n" +
+	"\x14UpdateAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x19\n" +
+	"\bif_match\x18\x02 \x01(\tR\aifMatch\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x00R\x04name\x88\x01\x01\x12&\n" +
+	"\fphone_number\x18\x04 \x01(\tH\x01R\vphoneNumber\x88\x01\x01\x12\x12\n" +
+	"\x04type\x18\x05 \x03(\tR\x04type\x12$\n" +
+	"\vindustry_id\x18\x06 \x01(\tH\x02R\n" +
+	"industryId\x88\x01\x01\x12/\n" +
+	"\x11parent_account_id\x18\a \x01(\tH\x03R\x0fparentAccountId\x88\x01\x01\x12*\n" +
+	"\x0esalesperson_id\x18\b \x01(\tH\x04R\rsalespersonId\x88\x01\x01B\a\n" +
+	"\x05_nameB\x0f\n" +
+	"\r_phone_numberB\x0e\n" +
+	"\f_industry_idB\x14\n" +
+	"\x12_parent_account_idB\x11\n" +
+	"\x0f_salesperson_id\"5\n" +
+	"\x14DeleteAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId2\x90\x03\n" +
+	"\x0eAccountService\x128\n" +
+	"\n" +
+	"GetAccount\x12\x19.crm.v1.GetAccountRequest\x1a\x0f.crm.v1.Account\x12A\n" +
+	"\fListAccounts\x12\x13.crm.v1.ListRequest\x1a\x1c.crm.v1.ListAccountsResponse\x12:\n" +
+	"\x0eStreamAccounts\x12\x15.crm.v1.StreamRequest\x1a\x0f.crm.v1.Account0\x01\x12>\n" +
+	"\rCreateAccount\x12\x1c.crm.v1.CreateAccountRequest\x1a\x0f.crm.v1.Account\x12>\n" +
+	"\rUpdateAccount\x12\x1c.crm.v1.UpdateAccountRequest\x1a\x0f.crm.v1.Account\x12E\n" +
+	"\rDeleteAccount\x12\x1c.crm.v1.DeleteAccountRequest\x1a\x16.google.protobuf.EmptyB\x1cZ\x1acrm/api/proto/crm/v1;crmv1b\x06proto3"
+
+var (
+	file_crm_v1_account_proto_rawDescOnce sync.Once
+	file_crm_v1_account_proto_rawDescData []byte
+)
+
+func file_crm_v1_account_proto_rawDescGZIP() []byte {
+	file_crm_v1_account_proto_rawDescOnce.Do(func() {
+		file_crm_v1_account_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_crm_v1_account_proto_rawDesc), len(file_crm_v1_account_proto_rawDesc)))
+	})
+	return file_crm_v1_account_proto_rawDescData
+}
+
+var file_crm_v1_account_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_crm_v1_account_proto_goTypes = []any{
+	(*Account)(nil),               // 0: crm.v1.Account
+	(*GetAccountRequest)(nil),     // 1: crm.v1.GetAccountRequest
+	(*ListAccountsResponse)(nil),  // 2: crm.v1.ListAccountsResponse
+	(*CreateAccountRequest)(nil),  // 3: crm.v1.CreateAccountRequest
+	(*UpdateAccountRequest)(nil),  // 4: crm.v1.UpdateAccountRequest
+	(*DeleteAccountRequest)(nil),  // 5: crm.v1.DeleteAccountRequest
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+	(*ListRequest)(nil),           // 7: crm.v1.ListRequest
+	(*StreamRequest)(nil),         // 8: crm.v1.StreamRequest
+	(*emptypb.Empty)(nil),         // 9: google.protobuf.Empty
+}
+var file_crm_v1_account_proto_depIdxs = []int32{
+	6, // 0: crm.v1.Account.created_at:type_name -> google.protobuf.Timestamp
+	6, // 1: crm.v1.Account.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 2: crm.v1.ListAccountsResponse.accounts:type_name -> crm.v1.Account
+	1, // 3: crm.v1.AccountService.GetAccount:input_type -> crm.v1.GetAccountRequest
+	7, // 4: crm.v1.AccountService.ListAccounts:input_type -> crm.v1.ListRequest
+	8, // 5: crm.v1.AccountService.StreamAccounts:input_type -> crm.v1.StreamRequest
+	3, // 6: crm.v1.AccountService.CreateAccount:input_type -> crm.v1.CreateAccountRequest
+	4, // 7: crm.v1.AccountService.UpdateAccount:input_type -> crm.v1.UpdateAccountRequest
+	5, // 8: crm.v1.AccountService.DeleteAccount:input_type -> crm.v1.DeleteAccountRequest
+	0, // 9: crm.v1.AccountService.GetAccount:output_type -> crm.v1.Account
+	2, // 10: crm.v1.AccountService.ListAccounts:output_type -> crm.v1.ListAccountsResponse
+	0, // 11: crm.v1.AccountService.StreamAccounts:output_type -> crm.v1.Account
+	0, // 12: crm.v1.AccountService.CreateAccount:output_type -> crm.v1.Account
+	0, // 13: crm.v1.AccountService.UpdateAccount:output_type -> crm.v1.Account
+	9, // 14: crm.v1.AccountService.DeleteAccount:output_type -> google.protobuf.Empty
+	9, // [9:15] is the sub-list for method output_type
+	3, // [3:9] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_crm_v1_account_proto_init() }
+func file_crm_v1_account_proto_init() {
+	if File_crm_v1_account_proto != nil {
+		return
+	}
+	file_crm_v1_common_proto_init()
+	file_crm_v1_account_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_crm_v1_account_proto_rawDesc), len(file_crm_v1_account_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_crm_v1_account_proto_goTypes,
+		DependencyIndexes: file_crm_v1_account_proto_depIdxs,
+		MessageInfos:      file_crm_v1_account_proto_msgTypes,
+	}.Build()
+	File_crm_v1_account_proto = out.File
+	file_crm_v1_account_proto_goTypes = nil
+	file_crm_v1_account_proto_depIdxs = nil
+}
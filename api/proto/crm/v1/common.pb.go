@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: crm/v1/common.proto
+
+package crmv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ListRequest is a page of a list, it takes the same filter and sort as the list endpoints of the REST API.
+type ListRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 頁數(從1開始,不帶入時使用游標分頁)
+	Page int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	// 筆數(從1開始,最高上限20)
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// 游標(帶入回傳的 next_cursor 或 prev_cursor,第一頁不帶入)
+	Cursor string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// 總筆數計算方式(exact 精確, approximate 估計, none 不計算)
+	Count string `protobuf:"bytes,4,opt,name=count,proto3" json:"count,omitempty"`
+	// 排序,格式為 field:direction 並以逗號分隔,如 name:asc,created_at:desc
+	Sort string `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`
+	// 搜尋欄位,與 REST API 列表的 filter 相同
+	Filter        *structpb.Struct `protobuf:"bytes,6,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_crm_v1_common_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_common_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_common_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListRequest) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+func (x *ListRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListRequest) GetFilter() *structpb.Struct {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// StreamRequest streams every record matching the filter, it is used to sync records in bulk.
+type StreamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 搜尋欄位,與 REST API 列表的 filter 相同
+	Filter *structpb.Struct `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// 只傳送此時間之後更新的資料
+	UpdatedSince *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=updated_since,json=updatedSince,proto3" json:"updated_since,omitempty"`
+	// 排序,預設為 updated_at:asc
+	Sort          string `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamRequest) Reset() {
+	*x = StreamRequest{}
+	mi := &file_crm_v1_common_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRequest) ProtoMessage() {}
+
+func (x *StreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_common_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRequest.ProtoReflect.Descriptor instead.
+func (*StreamRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_common_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StreamRequest) GetFilter() *structpb.Struct {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *StreamRequest) GetUpdatedSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedSince
+	}
+	return nil
+}
+
+func (x *StreamRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+var File_crm_v1_common_proto protoreflect.FileDescriptor
+
+const file_crm_v1_common_proto_rawDesc = "" +
+	"\n" +
+	"\x13crm/v1/common.proto\x12\x06crm.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xaa\x01\n" +
+	"\vListRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\tR\x05count\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sort\x12/\n" +
+	"\x06filter\x18\x06 \x01(\v2\x17.google.protobuf.StructR\x06filter\"\x95\x01\n" +
+	"\rStreamRequest\x12/\n" +
+	"\x06filter\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x06filter\x12?\n" +
+	"\rupdated_since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedSince\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sortB\x1cZ\x1acrm/api/proto/crm/v1;crmv1b\x06proto3"
+
+var (
+	file_crm_v1_common_proto_rawDescOnce sync.Once
+	file_crm_v1_common_proto_rawDescData []byte
+)
+
+func file_crm_v1_common_proto_rawDescGZIP() []byte {
+	file_crm_v1_common_proto_rawDescOnce.Do(func() {
+		file_crm_v1_common_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_crm_v1_common_proto_rawDesc), len(file_crm_v1_common_proto_rawDesc)))
+	})
+	return file_crm_v1_common_proto_rawDescData
+}
+
+var file_crm_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_crm_v1_common_proto_goTypes = []any{
+	(*ListRequest)(nil),           // 0: crm.v1.ListRequest
+	(*StreamRequest)(nil),         // 1: crm.v1.StreamRequest
+	(*structpb.Struct)(nil),       // 2: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_crm_v1_common_proto_depIdxs = []int32{
+	2, // 0: crm.v1.ListRequest.filter:type_name -> google.protobuf.Struct
+	2, // 1: crm.v1.StreamRequest.filter:type_name -> google.protobuf.Struct
+	3, // 2: crm.v1.StreamRequest.updated_since:type_name -> google.protobuf.Timestamp
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_crm_v1_common_proto_init() }
+func file_crm_v1_common_proto_init() {
+	if File_crm_v1_common_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_crm_v1_common_proto_rawDesc), len(file_crm_v1_common_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_crm_v1_common_proto_goTypes,
+		DependencyIndexes: file_crm_v1_common_proto_depIdxs,
+		MessageInfos:      file_crm_v1_common_proto_msgTypes,
+	}.Build()
+	File_crm_v1_common_proto = out.File
+	file_crm_v1_common_proto_goTypes = nil
+	file_crm_v1_common_proto_depIdxs = nil
+}
@@ -0,0 +1,875 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: crm/v1/contact.proto
+
+package crmv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Contact struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 聯絡人ID
+	ContactId string `protobuf:"bytes,1,opt,name=contact_id,json=contactId,proto3" json:"contact_id,omitempty"`
+	// 聯絡人名稱
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// 聯絡人職稱
+	Title string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	// 聯絡人電話
+	PhoneNumber string `protobuf:"bytes,4,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	// 聯絡人行動電話
+	CellPhone string `protobuf:"bytes,5,opt,name=cell_phone,json=cellPhone,proto3" json:"cell_phone,omitempty"`
+	// 聯絡人電子郵件
+	Email string `protobuf:"bytes,6,opt,name=email,proto3" json:"email,omitempty"`
+	// 聯絡人稱謂
+	Salutation string `protobuf:"bytes,7,opt,name=salutation,proto3" json:"salutation,omitempty"`
+	// 聯絡人部門
+	Department string `protobuf:"bytes,8,opt,name=department,proto3" json:"department,omitempty"`
+	// 聯絡人直屬上司ID
+	SupervisorId string `protobuf:"bytes,9,opt,name=supervisor_id,json=supervisorId,proto3" json:"supervisor_id,omitempty"`
+	// 聯絡人直屬上司名稱
+	SupervisorName string `protobuf:"bytes,10,opt,name=supervisor_name,json=supervisorName,proto3" json:"supervisor_name,omitempty"`
+	// 帳戶ID
+	AccountId string `protobuf:"bytes,11,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// 帳戶名稱
+	AccountName string `protobuf:"bytes,12,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	// 業務員ID
+	SalespersonId string `protobuf:"bytes,13,opt,name=salesperson_id,json=salespersonId,proto3" json:"salesperson_id,omitempty"`
+	// 業務員名稱
+	SalespersonName string `protobuf:"bytes,14,opt,name=salesperson_name,json=salespersonName,proto3" json:"salesperson_name,omitempty"`
+	// 創建者
+	CreatedBy string `protobuf:"bytes,15,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	// 更新者
+	UpdatedBy string `protobuf:"bytes,16,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	// 創建時間
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// 更新時間
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// 版本,更新時帶入 if_match
+	Etag          string `protobuf:"bytes,19,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Contact) Reset() {
+	*x = Contact{}
+	mi := &file_crm_v1_contact_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Contact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Contact) ProtoMessage() {}
+
+func (x *Contact) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_contact_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Contact.ProtoReflect.Descriptor instead.
+func (*Contact) Descriptor() ([]byte, []int) {
+	return file_crm_v1_contact_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Contact) GetContactId() string {
+	if x != nil {
+		return x.ContactId
+	}
+	return ""
+}
+
+func (x *Contact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Contact) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Contact) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *Contact) GetCellPhone() string {
+	if x != nil {
+		return x.CellPhone
+	}
+	return ""
+}
+
+func (x *Contact) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Contact) GetSalutation() string {
+	if x != nil {
+		return x.Salutation
+	}
+	return ""
+}
+
+func (x *Contact) GetDepartment() string {
+	if x != nil {
+		return x.Department
+	}
+	return ""
+}
+
+func (x *Contact) GetSupervisorId() string {
+	if x != nil {
+		return x.SupervisorId
+	}
+	return ""
+}
+
+func (x *Contact) GetSupervisorName() string {
+	if x != nil {
+		return x.SupervisorName
+	}
+	return ""
+}
+
+func (x *Contact) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Contact) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *Contact) GetSalespersonId() string {
+	if x != nil {
+		return x.SalespersonId
+	}
+	return ""
+}
+
+func (x *Contact) GetSalespersonName() string {
+	if x != nil {
+		return x.SalespersonName
+	}
+	return ""
+}
+
+func (x *Contact) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Contact) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *Contact) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Contact) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Contact) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+type GetContactRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 聯絡人ID
+	ContactId     string `protobuf:"bytes,1,opt,name=contact_id,json=contactId,proto3" json:"contact_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetContactRequest) Reset() {
+	*x = GetContactRequest{}
+	mi := &file_crm_v1_contact_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContactRequest) ProtoMessage() {}
+
+func (x *GetContactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_contact_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContactRequest.ProtoReflect.Descriptor instead.
+func (*GetContactRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_contact_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetContactRequest) GetContactId() string {
+	if x != nil {
+		return x.ContactId
+	}
+	return ""
+}
+
+type ListContactsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 多筆
+	Contacts []*Contact `protobuf:"bytes,1,rep,name=contacts,proto3" json:"contacts,omitempty"`
+	// 頁數
+	Page int64 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// 筆數
+	Limit int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// 總筆數(未計算時為-1)
+	Total int64 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	// 總頁數
+	Pages int64 `protobuf:"varint,5,opt,name=pages,proto3" json:"pages,omitempty"`
+	// 下一頁游標
+	NextCursor string `protobuf:"bytes,6,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// 上一頁游標
+	PrevCursor    string `protobuf:"bytes,7,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContactsResponse) Reset() {
+	*x = ListContactsResponse{}
+	mi := &file_crm_v1_contact_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContactsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContactsResponse) ProtoMessage() {}
+
+func (x *ListContactsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_contact_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContactsResponse.ProtoReflect.Descriptor instead.
+func (*ListContactsResponse) Descriptor() ([]byte, []int) {
+	return file_crm_v1_contact_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListContactsResponse) GetContacts() []*Contact {
+	if x != nil {
+		return x.Contacts
+	}
+	return nil
+}
+
+func (x *ListContactsResponse) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListContactsResponse) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListContactsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListContactsResponse) GetPages() int64 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+func (x *ListContactsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListContactsResponse) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+type CreateContactRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 聯絡人名稱
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 聯絡人職稱
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// 聯絡人電話
+	PhoneNumber string `protobuf:"bytes,3,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	// 聯絡人行動電話
+	CellPhone string `protobuf:"bytes,4,opt,name=cell_phone,json=cellPhone,proto3" json:"cell_phone,omitempty"`
+	// 聯絡人電子郵件
+	Email string `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	// 聯絡人稱謂
+	Salutation string `protobuf:"bytes,6,opt,name=salutation,proto3" json:"salutation,omitempty"`
+	// 聯絡人部門
+	Department string `protobuf:"bytes,7,opt,name=department,proto3" json:"department,omitempty"`
+	// 聯絡人直屬上司ID
+	SupervisorId string `protobuf:"bytes,8,opt,name=supervisor_id,json=supervisorId,proto3" json:"supervisor_id,omitempty"`
+	// 帳戶ID
+	AccountId string `protobuf:"bytes,9,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// 業務員ID,不帶入時為創建者
+	SalespersonId string `protobuf:"bytes,10,opt,name=salesperson_id,json=salespersonId,proto3" json:"salesperson_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateContactRequest) Reset() {
+	*x = CreateContactRequest{}
+	mi := &file_crm_v1_contact_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateContactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateContactRequest) ProtoMessage() {}
+
+func (x *CreateContactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_contact_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateContactRequest.ProtoReflect.Descriptor instead.
+func (*CreateContactRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_contact_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateContactRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateContactRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateContactRequest) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *CreateContactRequest) GetCellPhone() string {
+	if x != nil {
+		return x.CellPhone
+	}
+	return ""
+}
+
+func (x *CreateContactRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CreateContactRequest) GetSalutation() string {
+	if x != nil {
+		return x.Salutation
+	}
+	return ""
+}
+
+func (x *CreateContactRequest) GetDepartment() string {
+	if x != nil {
+		return x.Department
+	}
+	return ""
+}
+
+func (x *CreateContactRequest) GetSupervisorId() string {
+	if x != nil {
+		return x.SupervisorId
+	}
+	return ""
+}
+
+func (x *CreateContactRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *CreateContactRequest) GetSalespersonId() string {
+	if x != nil {
+		return x.SalespersonId
+	}
+	return ""
+}
+
+type UpdateContactRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 聯絡人ID
+	ContactId string `protobuf:"bytes,1,opt,name=contact_id,json=contactId,proto3" json:"contact_id,omitempty"`
+	// 版本(If-Match)
+	IfMatch string `protobuf:"bytes,2,opt,name=if_match,json=ifMatch,proto3" json:"if_match,omitempty"`
+	// 聯絡人名稱
+	Name *string `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// 聯絡人職稱
+	Title *string `protobuf:"bytes,4,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	// 聯絡人電話
+	PhoneNumber *string `protobuf:"bytes,5,opt,name=phone_number,json=phoneNumber,proto3,oneof" json:"phone_number,omitempty"`
+	// 聯絡人行動電話
+	CellPhone *string `protobuf:"bytes,6,opt,name=cell_phone,json=cellPhone,proto3,oneof" json:"cell_phone,omitempty"`
+	// 聯絡人電子郵件
+	Email *string `protobuf:"bytes,7,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	// 聯絡人稱謂
+	Salutation *string `protobuf:"bytes,8,opt,name=salutation,proto3,oneof" json:"salutation,omitempty"`
+	// 聯絡人部門
+	Department *string `protobuf:"bytes,9,opt,name=department,proto3,oneof" json:"department,omitempty"`
+	// 聯絡人直屬上司ID
+	SupervisorId *string `protobuf:"bytes,10,opt,name=supervisor_id,json=supervisorId,proto3,oneof" json:"supervisor_id,omitempty"`
+	// 帳戶ID
+	AccountId *string `protobuf:"bytes,11,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
+	// 業務員ID
+	SalespersonId *string `protobuf:"bytes,12,opt,name=salesperson_id,json=salespersonId,proto3,oneof" json:"salesperson_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateContactRequest) Reset() {
+	*x = UpdateContactRequest{}
+	mi := &file_crm_v1_contact_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateContactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateContactRequest) ProtoMessage() {}
+
+func (x *UpdateContactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_contact_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateContactRequest.ProtoReflect.Descriptor instead.
+func (*UpdateContactRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_contact_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateContactRequest) GetContactId() string {
+	if x != nil {
+		return x.ContactId
+	}
+	return ""
+}
+
+func (x *UpdateContactRequest) GetIfMatch() string {
+	if x != nil {
+		return x.IfMatch
+	}
+	return ""
+}
+
+func (x *UpdateContactRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateContactRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *UpdateContactRequest) GetPhoneNumber() string {
+	if x != nil && x.PhoneNumber != nil {
+		return *x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *UpdateContactRequest) GetCellPhone() string {
+	if x != nil && x.CellPhone != nil {
+		return *x.CellPhone
+	}
+	return ""
+}
+
+func (x *UpdateContactRequest) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+func (x *UpdateContactRequest) GetSalutation() string {
+	if x != nil && x.Salutation != nil {
+		return *x.Salutation
+	}
+	return ""
+}
+
+func (x *UpdateContactRequest) GetDepartment() string {
+	if x != nil && x.Department != nil {
+		return *x.Department
+	}
+	return ""
+}
+
+func (x *UpdateContactRequest) GetSupervisorId() string {
+	if x != nil && x.SupervisorId != nil {
+		return *x.SupervisorId
+	}
+	return ""
+}
+
+func (x *UpdateContactRequest) GetAccountId() string {
+	if x != nil && x.AccountId != nil {
+		return *x.AccountId
+	}
+	return ""
+}
+
+func (x *UpdateContactRequest) GetSalespersonId() string {
+	if x != nil && x.SalespersonId != nil {
+		return *x.SalespersonId
+	}
+	return ""
+}
+
+type DeleteContactRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 聯絡人ID
+	ContactId     string `protobuf:"bytes,1,opt,name=contact_id,json=contactId,proto3" json:"contact_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteContactRequest) Reset() {
+	*x = DeleteContactRequest{}
+	mi := &file_crm_v1_contact_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteContactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteContactRequest) ProtoMessage() {}
+
+func (x *DeleteContactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_contact_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteContactRequest.ProtoReflect.Descriptor instead.
+func (*DeleteContactRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_contact_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteContactRequest) GetContactId() string {
+	if x != nil {
+		return x.ContactId
+	}
+	return ""
+}
+
+var File_crm_v1_contact_proto protoreflect.FileDescriptor
+
+const file_crm_v1_contact_proto_rawDesc = "" +
+	"\n" +
+	"\x14crm/v1/contact.proto\x12\x06crm.v1\x1a\x13crm/v1/common.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x94\x05\n" +
+	"\aContact\x12\x1d\n" +
+	"\n" +
+	"contact_id\x18\x01 \x01(\tR\tcontactId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12!\n" +
+	"\fphone_number\x18\x04 \x01(\tR\vphoneNumber\x12\x1d\n" +
+	"\n" +
+	"cell_phone\x18\x05 \x01(\tR\tcellPhone\x12\x14\n" +
+	"\x05email\x18\x06 \x01(\tR\x05email\x12\x1e\n" +
+	"\n" +
+	"salutation\x18\a \x01(\tR\n" +
+	"salutation\x12\x1e\n" +
+	"\n" +
+	"department\x18\b \x01(\tR\n" +
+	"department\x12#\n" +
+	"\rsupervisor_id\x18\t \x01(\tR\fsupervisorId\x12'\n" +
+	"\x0fsupervisor_name\x18\n" +
+	" \x01(\tR\x0esupervisorName\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\v \x01(\tR\taccountId\x12!\n" +
+	"\faccount_name\x18\f \x01(\tR\vaccountName\x12%\n" +
+	"\x0esalesperson_id\x18\r \x01(\tR\rsalespersonId\x12)\n" +
+	"\x10salesperson_name\x18\x0e \x01(\tR\x0fsalespersonName\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x0f \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x10 \x01(\tR\tupdatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x12\n" +
+	"\x04etag\x18\x13 \x01(\tR\x04etag\"2\n" +
+	"\x11GetContactRequest\x12\x1d\n" +
+	"\n" +
+	"contact_id\x18\x01 \x01(\tR\tcontactId\"\xdb\x01\n" +
+	"\x14ListContactsResponse\x12+\n" +
+	"\bcontacts\x18\x01 \x03(\v2\x0f.crm.v1.ContactR\bcontacts\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x03R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\x12\x14\n" +
+	"\x05pages\x18\x05 \x01(\x03R\x05pages\x12\x1f\n" +
+	"\vnext_cursor\x18\x06 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\a \x01(\tR\n" +
+	"prevCursor\"\xc3\x02\n" +
+	"\x14CreateContactRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12!\n" +
+	"\fphone_number\x18\x03 \x01(\tR\vphoneNumber\x12\x1d\n" +
+	"\n" +
+	"cell_phone\x18\x04 \x01(\tR\tcellPhone\x12\x14\n" +
+	"\x05email\x18\x05 \x01(\tR\x05email\x12\x1e\n" +
+	"\n" +
+	"salutation\x18\x06 \x01(\tR\n" +
+	"salutation\x12\x1e\n" +
+	"\n" +
+	"department\x18\a \x01(\tR\n" +
+	"department\x12#\n" +
+	"\rsupervisor_id\x18\b \x01(\tR\fsupervisorId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\t \x01(\tR\taccountId\x12%\n" +
+	"\x0esalesperson_id\x18\n" +
+	" \x01(\tR\rsalespersonId\"\xbe\x04\n" +
+	"\x14UpdateContactRequest\x12\x1d\n" +
+	"\n" +
+	"contact_id\x18\x01 \x01(\tR\tcontactId\x12\x19\n" +
+	"\bif_match\x18\x02 \x01(\tR\aifMatch\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x19\n" +
+	"\x05title\x18\x04 \x01(\tH\x01R\x05title\x88\x01\x01\x12&\n" +
+	"\fphone_number\x18\x05 \x01(\tH\x02R\vphoneNumber\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"cell_phone\x18\x06 \x01(\tH\x03R\tcellPhone\x88\x01\x01\x12\x19\n" +
+	"\x05email\x18\a \x01(\tH\x04R\x05email\x88\x01\x01\x12#\n" +
+	"\n" +
+	"salutation\x18\b \x01(\tH\x05R\n" +
+	"salutation\x88\x01\x01\x12#\n" +
+	"\n" +
+	"department\x18\t \x01(\tH\x06R\n" +
+	"department\x88\x01\x01\x12(\n" +
+	"\rsupervisor_id\x18\n" +
+	" \x01(\tH\aR\fsupervisorId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"account_id\x18\v \x01(\tH\bR\taccountId\x88\x01\x01\x12*\n" +
+	"\x0esalesperson_id\x18\f \x01(\tH\tR\rsalespersonId\x88\x01\x01B\a\n" +
+	"\x05_nameB\b\n" +
+	"\x06_titleB\x0f\n" +
+	"\r_phone_numberB\r\n" +
+	"\v_cell_phoneB\b\n" +
+	"\x06_emailB\r\n" +
+	"\v_salutationB\r\n" +
+	"\v_departmentB\x10\n" +
+	"\x0e_supervisor_idB\r\n" +
+	"\v_account_idB\x11\n" +
+	"\x0f_salesperson_id\"5\n" +
+	"\x14DeleteContactRequest\x12\x1d\n" +
+	"\n" +
+	"contact_id\x18\x01 \x01(\tR\tcontactId2\x90\x03\n" +
+	"\x0eContactService\x128\n" +
+	"\n" +
+	"GetContact\x12\x19.crm.v1.GetContactRequest\x1a\x0f.crm.v1.Contact\x12A\n" +
+	"\fListContacts\x12\x13.crm.v1.ListRequest\x1a\x1c.crm.v1.ListContactsResponse\x12:\n" +
+	"\x0eStreamContacts\x12\x15.crm.v1.StreamRequest\x1a\x0f.crm.v1.Contact0\x01\x12>\n" +
+	"\rCreateContact\x12\x1c.crm.v1.CreateContactRequest\x1a\x0f.crm.v1.Contact\x12>\n" +
+	"\rUpdateContact\x12\x1c.crm.v1.UpdateContactRequest\x1a\x0f.crm.v1.Contact\x12E\n" +
+	"\rDeleteContact\x12\x1c.crm.v1.DeleteContactRequest\x1a\x16.google.protobuf.EmptyB\x1cZ\x1acrm/api/proto/crm/v1;crmv1b\x06proto3"
+
+var (
+	file_crm_v1_contact_proto_rawDescOnce sync.Once
+	file_crm_v1_contact_proto_rawDescData []byte
+)
+
+func file_crm_v1_contact_proto_rawDescGZIP() []byte {
+	file_crm_v1_contact_proto_rawDescOnce.Do(func() {
+		file_crm_v1_contact_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_crm_v1_contact_proto_rawDesc), len(file_crm_v1_contact_proto_rawDesc)))
+	})
+	return file_crm_v1_contact_proto_rawDescData
+}
+
+var file_crm_v1_contact_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_crm_v1_contact_proto_goTypes = []any{
+	(*Contact)(nil),               // 0: crm.v1.Contact
+	(*GetContactRequest)(nil),     // 1: crm.v1.GetContactRequest
+	(*ListContactsResponse)(nil),  // 2: crm.v1.ListContactsResponse
+	(*CreateContactRequest)(nil),  // 3: crm.v1.CreateContactRequest
+	(*UpdateContactRequest)(nil),  // 4: crm.v1.UpdateContactRequest
+	(*DeleteContactRequest)(nil),  // 5: crm.v1.DeleteContactRequest
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+	(*ListRequest)(nil),           // 7: crm.v1.ListRequest
+	(*StreamRequest)(nil),         // 8: crm.v1.StreamRequest
+	(*emptypb.Empty)(nil),         // 9: google.protobuf.Empty
+}
+var file_crm_v1_contact_proto_depIdxs = []int32{
+	6, // 0: crm.v1.Contact.created_at:type_name -> google.protobuf.Timestamp
+	6, // 1: crm.v1.Contact.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 2: crm.v1.ListContactsResponse.contacts:type_name -> crm.v1.Contact
+	1, // 3: crm.v1.ContactService.GetContact:input_type -> crm.v1.GetContactRequest
+	7, // 4: crm.v1.ContactService.ListContacts:input_type -> crm.v1.ListRequest
+	8, // 5: crm.v1.ContactService.StreamContacts:input_type -> crm.v1.StreamRequest
+	3, // 6: crm.v1.ContactService.CreateContact:input_type -> crm.v1.CreateContactRequest
+	4, // 7: crm.v1.ContactService.UpdateContact:input_type -> crm.v1.UpdateContactRequest
+	5, // 8: crm.v1.ContactService.DeleteContact:input_type -> crm.v1.DeleteContactRequest
+	0, // 9: crm.v1.ContactService.GetContact:output_type -> crm.v1.Contact
+	2, // 10: crm.v1.ContactService.ListContacts:output_type -> crm.v1.ListContactsResponse
+	0, // 11: crm.v1.ContactService.StreamContacts:output_type -> crm.v1.Contact
+	0, // 12: crm.v1.ContactService.CreateContact:output_type -> crm.v1.Contact
+	0, // 13: crm.v1.ContactService.UpdateContact:output_type -> crm.v1.Contact
+	9, // 14: crm.v1.ContactService.DeleteContact:output_type -> google.protobuf.Empty
+	9, // [9:15] is the sub-list for method output_type
+	3, // [3:9] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_crm_v1_contact_proto_init() }
+func file_crm_v1_contact_proto_init() {
+	if File_crm_v1_contact_proto != nil {
+		return
+	}
+	file_crm_v1_common_proto_init()
+	file_crm_v1_contact_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_crm_v1_contact_proto_rawDesc), len(file_crm_v1_contact_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_crm_v1_contact_proto_goTypes,
+		DependencyIndexes: file_crm_v1_contact_proto_depIdxs,
+		MessageInfos:      file_crm_v1_contact_proto_msgTypes,
+	}.Build()
+	File_crm_v1_contact_proto = out.File
+	file_crm_v1_contact_proto_goTypes = nil
+	file_crm_v1_contact_proto_depIdxs = nil
+}
@@ -0,0 +1,769 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: crm/v1/contract.proto
+
+package crmv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Contract struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 契約ID
+	ContractId string `protobuf:"bytes,1,opt,name=contract_id,json=contractId,proto3" json:"contract_id,omitempty"`
+	// 契約狀態
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// 契約開始日期
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// 契約有效期限(月)
+	Term int32 `protobuf:"varint,4,opt,name=term,proto3" json:"term,omitempty"`
+	// 契約結束日期
+	EndDate *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	// 商機ID
+	OpportunityId string `protobuf:"bytes,6,opt,name=opportunity_id,json=opportunityId,proto3" json:"opportunity_id,omitempty"`
+	// 商機名稱
+	OpportunityName string `protobuf:"bytes,7,opt,name=opportunity_name,json=opportunityName,proto3" json:"opportunity_name,omitempty"`
+	// 帳戶ID
+	AccountId string `protobuf:"bytes,8,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// 帳戶名稱
+	AccountName string `protobuf:"bytes,9,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	// 契約描述
+	Description string `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	// 契約號碼
+	Code string `protobuf:"bytes,11,opt,name=code,proto3" json:"code,omitempty"`
+	// 業務員ID
+	SalespersonId string `protobuf:"bytes,12,opt,name=salesperson_id,json=salespersonId,proto3" json:"salesperson_id,omitempty"`
+	// 業務員名稱
+	SalespersonName string `protobuf:"bytes,13,opt,name=salesperson_name,json=salespersonName,proto3" json:"salesperson_name,omitempty"`
+	// 創建者
+	CreatedBy string `protobuf:"bytes,14,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	// 更新者
+	UpdatedBy string `protobuf:"bytes,15,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	// 創建時間
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// 更新時間
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// 版本,更新時帶入 if_match
+	Etag          string `protobuf:"bytes,18,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Contract) Reset() {
+	*x = Contract{}
+	mi := &file_crm_v1_contract_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Contract) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Contract) ProtoMessage() {}
+
+func (x *Contract) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_contract_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Contract.ProtoReflect.Descriptor instead.
+func (*Contract) Descriptor() ([]byte, []int) {
+	return file_crm_v1_contract_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Contract) GetContractId() string {
+	if x != nil {
+		return x.ContractId
+	}
+	return ""
+}
+
+func (x *Contract) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Contract) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *Contract) GetTerm() int32 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *Contract) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *Contract) GetOpportunityId() string {
+	if x != nil {
+		return x.OpportunityId
+	}
+	return ""
+}
+
+func (x *Contract) GetOpportunityName() string {
+	if x != nil {
+		return x.OpportunityName
+	}
+	return ""
+}
+
+func (x *Contract) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Contract) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *Contract) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Contract) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Contract) GetSalespersonId() string {
+	if x != nil {
+		return x.SalespersonId
+	}
+	return ""
+}
+
+func (x *Contract) GetSalespersonName() string {
+	if x != nil {
+		return x.SalespersonName
+	}
+	return ""
+}
+
+func (x *Contract) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Contract) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *Contract) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Contract) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Contract) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+type GetContractRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 契約ID
+	ContractId    string `protobuf:"bytes,1,opt,name=contract_id,json=contractId,proto3" json:"contract_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetContractRequest) Reset() {
+	*x = GetContractRequest{}
+	mi := &file_crm_v1_contract_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContractRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContractRequest) ProtoMessage() {}
+
+func (x *GetContractRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_contract_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContractRequest.ProtoReflect.Descriptor instead.
+func (*GetContractRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_contract_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetContractRequest) GetContractId() string {
+	if x != nil {
+		return x.ContractId
+	}
+	return ""
+}
+
+type ListContractsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 多筆
+	Contracts []*Contract `protobuf:"bytes,1,rep,name=contracts,proto3" json:"contracts,omitempty"`
+	// 頁數
+	Page int64 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// 筆數
+	Limit int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// 總筆數(未計算時為-1)
+	Total int64 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	// 總頁數
+	Pages int64 `protobuf:"varint,5,opt,name=pages,proto3" json:"pages,omitempty"`
+	// 下一頁游標
+	NextCursor string `protobuf:"bytes,6,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// 上一頁游標
+	PrevCursor    string `protobuf:"bytes,7,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContractsResponse) Reset() {
+	*x = ListContractsResponse{}
+	mi := &file_crm_v1_contract_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContractsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContractsResponse) ProtoMessage() {}
+
+func (x *ListContractsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_contract_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContractsResponse.ProtoReflect.Descriptor instead.
+func (*ListContractsResponse) Descriptor() ([]byte, []int) {
+	return file_crm_v1_contract_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListContractsResponse) GetContracts() []*Contract {
+	if x != nil {
+		return x.Contracts
+	}
+	return nil
+}
+
+func (x *ListContractsResponse) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListContractsResponse) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListContractsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListContractsResponse) GetPages() int64 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+func (x *ListContractsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListContractsResponse) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+type CreateContractRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 契約狀態
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// 契約開始日期
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// 契約有效期限(月)
+	Term int32 `protobuf:"varint,3,opt,name=term,proto3" json:"term,omitempty"`
+	// 商機ID
+	OpportunityId string `protobuf:"bytes,4,opt,name=opportunity_id,json=opportunityId,proto3" json:"opportunity_id,omitempty"`
+	// 契約描述
+	Description   string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateContractRequest) Reset() {
+	*x = CreateContractRequest{}
+	mi := &file_crm_v1_contract_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateContractRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateContractRequest) ProtoMessage() {}
+
+func (x *CreateContractRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_contract_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateContractRequest.ProtoReflect.Descriptor instead.
+func (*CreateContractRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_contract_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateContractRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateContractRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *CreateContractRequest) GetTerm() int32 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *CreateContractRequest) GetOpportunityId() string {
+	if x != nil {
+		return x.OpportunityId
+	}
+	return ""
+}
+
+func (x *CreateContractRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type UpdateContractRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 契約ID
+	ContractId string `protobuf:"bytes,1,opt,name=contract_id,json=contractId,proto3" json:"contract_id,omitempty"`
+	// 版本(If-Match)
+	IfMatch string `protobuf:"bytes,2,opt,name=if_match,json=ifMatch,proto3" json:"if_match,omitempty"`
+	// 契約狀態
+	Status *string `protobuf:"bytes,3,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	// 契約開始日期
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// 契約有效期限(月)
+	Term *int32 `protobuf:"varint,5,opt,name=term,proto3,oneof" json:"term,omitempty"`
+	// 商機ID
+	OpportunityId *string `protobuf:"bytes,6,opt,name=opportunity_id,json=opportunityId,proto3,oneof" json:"opportunity_id,omitempty"`
+	// 帳戶ID
+	AccountId *string `protobuf:"bytes,7,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
+	// 契約描述
+	Description *string `protobuf:"bytes,8,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// 業務員ID
+	SalespersonId *string `protobuf:"bytes,9,opt,name=salesperson_id,json=salespersonId,proto3,oneof" json:"salesperson_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateContractRequest) Reset() {
+	*x = UpdateContractRequest{}
+	mi := &file_crm_v1_contract_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateContractRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateContractRequest) ProtoMessage() {}
+
+func (x *UpdateContractRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_contract_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateContractRequest.ProtoReflect.Descriptor instead.
+func (*UpdateContractRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_contract_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateContractRequest) GetContractId() string {
+	if x != nil {
+		return x.ContractId
+	}
+	return ""
+}
+
+func (x *UpdateContractRequest) GetIfMatch() string {
+	if x != nil {
+		return x.IfMatch
+	}
+	return ""
+}
+
+func (x *UpdateContractRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *UpdateContractRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *UpdateContractRequest) GetTerm() int32 {
+	if x != nil && x.Term != nil {
+		return *x.Term
+	}
+	return 0
+}
+
+func (x *UpdateContractRequest) GetOpportunityId() string {
+	if x != nil && x.OpportunityId != nil {
+		return *x.OpportunityId
+	}
+	return ""
+}
+
+func (x *UpdateContractRequest) GetAccountId() string {
+	if x != nil && x.AccountId != nil {
+		return *x.AccountId
+	}
+	return ""
+}
+
+func (x *UpdateContractRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateContractRequest) GetSalespersonId() string {
+	if x != nil && x.SalespersonId != nil {
+		return *x.SalespersonId
+	}
+	return ""
+}
+
+type DeleteContractRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 契約ID
+	ContractId    string `protobuf:"bytes,1,opt,name=contract_id,json=contractId,proto3" json:"contract_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteContractRequest) Reset() {
+	*x = DeleteContractRequest{}
+	mi := &file_crm_v1_contract_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteContractRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteContractRequest) ProtoMessage() {}
+
+func (x *DeleteContractRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_contract_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteContractRequest.ProtoReflect.Descriptor instead.
+func (*DeleteContractRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_contract_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteContractRequest) GetContractId() string {
+	if x != nil {
+		return x.ContractId
+	}
+	return ""
+}
+
+var File_crm_v1_contract_proto protoreflect.FileDescriptor
+
+const file_crm_v1_contract_proto_rawDesc = "" +
+	"\n" +
+	"\x15crm/v1/contract.proto\x12\x06crm.v1\x1a\x13crm/v1/common.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xad\x05\n" +
+	"\bContract\x12\x1f\n" +
+	"\vcontract_id\x18\x01 \x01(\tR\n" +
+	"contractId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x12\x12\n" +
+	"\x04term\x18\x04 \x01(\x05R\x04term\x125\n" +
+	"\bend_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12%\n" +
+	"\x0eopportunity_id\x18\x06 \x01(\tR\ropportunityId\x12)\n" +
+	"\x10opportunity_name\x18\a \x01(\tR\x0fopportunityName\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\b \x01(\tR\taccountId\x12!\n" +
+	"\faccount_name\x18\t \x01(\tR\vaccountName\x12 \n" +
+	"\vdescription\x18\n" +
+	" \x01(\tR\vdescription\x12\x12\n" +
+	"\x04code\x18\v \x01(\tR\x04code\x12%\n" +
+	"\x0esalesperson_id\x18\f \x01(\tR\rsalespersonId\x12)\n" +
+	"\x10salesperson_name\x18\r \x01(\tR\x0fsalespersonName\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x0e \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x0f \x01(\tR\tupdatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x12\n" +
+	"\x04etag\x18\x12 \x01(\tR\x04etag\"5\n" +
+	"\x12GetContractRequest\x12\x1f\n" +
+	"\vcontract_id\x18\x01 \x01(\tR\n" +
+	"contractId\"\xdf\x01\n" +
+	"\x15ListContractsResponse\x12.\n" +
+	"\tcontracts\x18\x01 \x03(\v2\x10.crm.v1.ContractR\tcontracts\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x03R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\x12\x14\n" +
+	"\x05pages\x18\x05 \x01(\x03R\x05pages\x12\x1f\n" +
+	"\vnext_cursor\x18\x06 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\a \x01(\tR\n" +
+	"prevCursor\"\xc7\x01\n" +
+	"\x15CreateContractRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x12\x12\n" +
+	"\x04term\x18\x03 \x01(\x05R\x04term\x12%\n" +
+	"\x0eopportunity_id\x18\x04 \x01(\tR\ropportunityId\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\"\xc0\x03\n" +
+	"\x15UpdateContractRequest\x12\x1f\n" +
+	"\vcontract_id\x18\x01 \x01(\tR\n" +
+	"contractId\x12\x19\n" +
+	"\bif_match\x18\x02 \x01(\tR\aifMatch\x12\x1b\n" +
+	"\x06status\x18\x03 \x01(\tH\x00R\x06status\x88\x01\x01\x129\n" +
+	"\n" +
+	"start_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x12\x17\n" +
+	"\x04term\x18\x05 \x01(\x05H\x01R\x04term\x88\x01\x01\x12*\n" +
+	"\x0eopportunity_id\x18\x06 \x01(\tH\x02R\ropportunityId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"account_id\x18\a \x01(\tH\x03R\taccountId\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\b \x01(\tH\x04R\vdescription\x88\x01\x01\x12*\n" +
+	"\x0esalesperson_id\x18\t \x01(\tH\x05R\rsalespersonId\x88\x01\x01B\t\n" +
+	"\a_statusB\a\n" +
+	"\x05_termB\x11\n" +
+	"\x0f_opportunity_idB\r\n" +
+	"\v_account_idB\x0e\n" +
+	"\f_descriptionB\x11\n" +
+	"\x0f_salesperson_id\"8\n" +
+	"\x15DeleteContractRequest\x12\x1f\n" +
+	"\vcontract_id\x18\x01 \x01(\tR\n" +
+	"contractId2\xa0\x03\n" +
+	"\x0fContractService\x12;\n" +
+	"\vGetContract\x12\x1a.crm.v1.GetContractRequest\x1a\x10.crm.v1.Contract\x12C\n" +
+	"\rListContracts\x12\x13.crm.v1.ListRequest\x1a\x1d.crm.v1.ListContractsResponse\x12<\n" +
+	"\x0fStreamContracts\x12\x15.crm.v1.StreamRequest\x1a\x10.crm.v1.Contract0\x01\x12A\n" +
+	"\x0eCreateContract\x12\x1d.crm.v1.CreateContractRequest\x1a\x10.crm.v1.Contract\x12A\n" +
+	"\x0eUpdateContract\x12\x1d.crm.v1.UpdateContractRequest\x1a\x10.crm.v1.Contract\x12G\n" +
+	"\x0eDeleteContract\x12\x1d.crm.v1.DeleteContractRequest\x1a\x16.google.protobuf.EmptyB\x1cZ\x1acrm/api/proto/crm/v1;crmv1b\x06proto3"
+
+var (
+	file_crm_v1_contract_proto_rawDescOnce sync.Once
+	file_crm_v1_contract_proto_rawDescData []byte
+)
+
+func file_crm_v1_contract_proto_rawDescGZIP() []byte {
+	file_crm_v1_contract_proto_rawDescOnce.Do(func() {
+		file_crm_v1_contract_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_crm_v1_contract_proto_rawDesc), len(file_crm_v1_contract_proto_rawDesc)))
+	})
+	return file_crm_v1_contract_proto_rawDescData
+}
+
+var file_crm_v1_contract_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_crm_v1_contract_proto_goTypes = []any{
+	(*Contract)(nil),              // 0: crm.v1.Contract
+	(*GetContractRequest)(nil),    // 1: crm.v1.GetContractRequest
+	(*ListContractsResponse)(nil), // 2: crm.v1.ListContractsResponse
+	(*CreateContractRequest)(nil), // 3: crm.v1.CreateContractRequest
+	(*UpdateContractRequest)(nil), // 4: crm.v1.UpdateContractRequest
+	(*DeleteContractRequest)(nil), // 5: crm.v1.DeleteContractRequest
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+	(*ListRequest)(nil),           // 7: crm.v1.ListRequest
+	(*StreamRequest)(nil),         // 8: crm.v1.StreamRequest
+	(*emptypb.Empty)(nil),         // 9: google.protobuf.Empty
+}
+var file_crm_v1_contract_proto_depIdxs = []int32{
+	6,  // 0: crm.v1.Contract.start_date:type_name -> google.protobuf.Timestamp
+	6,  // 1: crm.v1.Contract.end_date:type_name -> google.protobuf.Timestamp
+	6,  // 2: crm.v1.Contract.created_at:type_name -> google.protobuf.Timestamp
+	6,  // 3: crm.v1.Contract.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: crm.v1.ListContractsResponse.contracts:type_name -> crm.v1.Contract
+	6,  // 5: crm.v1.CreateContractRequest.start_date:type_name -> google.protobuf.Timestamp
+	6,  // 6: crm.v1.UpdateContractRequest.start_date:type_name -> google.protobuf.Timestamp
+	1,  // 7: crm.v1.ContractService.GetContract:input_type -> crm.v1.GetContractRequest
+	7,  // 8: crm.v1.ContractService.ListContracts:input_type -> crm.v1.ListRequest
+	8,  // 9: crm.v1.ContractService.StreamContracts:input_type -> crm.v1.StreamRequest
+	3,  // 10: crm.v1.ContractService.CreateContract:input_type -> crm.v1.CreateContractRequest
+	4,  // 11: crm.v1.ContractService.UpdateContract:input_type -> crm.v1.UpdateContractRequest
+	5,  // 12: crm.v1.ContractService.DeleteContract:input_type -> crm.v1.DeleteContractRequest
+	0,  // 13: crm.v1.ContractService.GetContract:output_type -> crm.v1.Contract
+	2,  // 14: crm.v1.ContractService.ListContracts:output_type -> crm.v1.ListContractsResponse
+	0,  // 15: crm.v1.ContractService.StreamContracts:output_type -> crm.v1.Contract
+	0,  // 16: crm.v1.ContractService.CreateContract:output_type -> crm.v1.Contract
+	0,  // 17: crm.v1.ContractService.UpdateContract:output_type -> crm.v1.Contract
+	9,  // 18: crm.v1.ContractService.DeleteContract:output_type -> google.protobuf.Empty
+	13, // [13:19] is the sub-list for method output_type
+	7,  // [7:13] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_crm_v1_contract_proto_init() }
+func file_crm_v1_contract_proto_init() {
+	if File_crm_v1_contract_proto != nil {
+		return
+	}
+	file_crm_v1_common_proto_init()
+	file_crm_v1_contract_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_crm_v1_contract_proto_rawDesc), len(file_crm_v1_contract_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_crm_v1_contract_proto_goTypes,
+		DependencyIndexes: file_crm_v1_contract_proto_depIdxs,
+		MessageInfos:      file_crm_v1_contract_proto_msgTypes,
+	}.Build()
+	File_crm_v1_contract_proto = out.File
+	file_crm_v1_contract_proto_goTypes = nil
+	file_crm_v1_contract_proto_depIdxs = nil
+}
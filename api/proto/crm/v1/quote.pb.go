@@ -0,0 +1,859 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: crm/v1/quote.proto
+
+package crmv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Quote struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 報價ID
+	QuoteId string `protobuf:"bytes,1,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	// 報價名稱
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// 報價狀態
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// 報價與商機是否同步化
+	IsSyncing bool `protobuf:"varint,4,opt,name=is_syncing,json=isSyncing,proto3" json:"is_syncing,omitempty"`
+	// 報價是否為最終版
+	IsFinal bool `protobuf:"varint,5,opt,name=is_final,json=isFinal,proto3" json:"is_final,omitempty"`
+	// 商機ID
+	OpportunityId string `protobuf:"bytes,6,opt,name=opportunity_id,json=opportunityId,proto3" json:"opportunity_id,omitempty"`
+	// 商機名稱
+	OpportunityName string `protobuf:"bytes,7,opt,name=opportunity_name,json=opportunityName,proto3" json:"opportunity_name,omitempty"`
+	// 帳戶ID
+	AccountId string `protobuf:"bytes,8,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// 報價到期日期
+	ExpirationDate *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expiration_date,json=expirationDate,proto3" json:"expiration_date,omitempty"`
+	// 報價描述
+	Description string `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	// 報價稅額
+	Tax float64 `protobuf:"fixed64,11,opt,name=tax,proto3" json:"tax,omitempty"`
+	// 報價運費及其他費用
+	ShippingAndHandling float64 `protobuf:"fixed64,12,opt,name=shipping_and_handling,json=shippingAndHandling,proto3" json:"shipping_and_handling,omitempty"`
+	// 報價小計
+	SubTotal float64 `protobuf:"fixed64,13,opt,name=sub_total,json=subTotal,proto3" json:"sub_total,omitempty"`
+	// 報價總價
+	TotalPrice float64 `protobuf:"fixed64,14,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	// 報價折扣
+	Discount float64 `protobuf:"fixed64,15,opt,name=discount,proto3" json:"discount,omitempty"`
+	// 報價總計
+	GrandTotal float64 `protobuf:"fixed64,16,opt,name=grand_total,json=grandTotal,proto3" json:"grand_total,omitempty"`
+	// 報價號碼
+	Code string `protobuf:"bytes,17,opt,name=code,proto3" json:"code,omitempty"`
+	// 創建者
+	CreatedBy string `protobuf:"bytes,18,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	// 更新者
+	UpdatedBy string `protobuf:"bytes,19,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	// 創建時間
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// 更新時間
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// 版本,更新時帶入 if_match
+	Etag          string `protobuf:"bytes,22,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Quote) Reset() {
+	*x = Quote{}
+	mi := &file_crm_v1_quote_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote) ProtoMessage() {}
+
+func (x *Quote) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_quote_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
+func (*Quote) Descriptor() ([]byte, []int) {
+	return file_crm_v1_quote_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Quote) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *Quote) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Quote) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Quote) GetIsSyncing() bool {
+	if x != nil {
+		return x.IsSyncing
+	}
+	return false
+}
+
+func (x *Quote) GetIsFinal() bool {
+	if x != nil {
+		return x.IsFinal
+	}
+	return false
+}
+
+func (x *Quote) GetOpportunityId() string {
+	if x != nil {
+		return x.OpportunityId
+	}
+	return ""
+}
+
+func (x *Quote) GetOpportunityName() string {
+	if x != nil {
+		return x.OpportunityName
+	}
+	return ""
+}
+
+func (x *Quote) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Quote) GetExpirationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpirationDate
+	}
+	return nil
+}
+
+func (x *Quote) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Quote) GetTax() float64 {
+	if x != nil {
+		return x.Tax
+	}
+	return 0
+}
+
+func (x *Quote) GetShippingAndHandling() float64 {
+	if x != nil {
+		return x.ShippingAndHandling
+	}
+	return 0
+}
+
+func (x *Quote) GetSubTotal() float64 {
+	if x != nil {
+		return x.SubTotal
+	}
+	return 0
+}
+
+func (x *Quote) GetTotalPrice() float64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+func (x *Quote) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *Quote) GetGrandTotal() float64 {
+	if x != nil {
+		return x.GrandTotal
+	}
+	return 0
+}
+
+func (x *Quote) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Quote) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Quote) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *Quote) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Quote) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Quote) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+type GetQuoteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 報價ID
+	QuoteId       string `protobuf:"bytes,1,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuoteRequest) Reset() {
+	*x = GetQuoteRequest{}
+	mi := &file_crm_v1_quote_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteRequest) ProtoMessage() {}
+
+func (x *GetQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_quote_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteRequest.ProtoReflect.Descriptor instead.
+func (*GetQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_quote_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetQuoteRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+type ListQuotesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 多筆
+	Quotes []*Quote `protobuf:"bytes,1,rep,name=quotes,proto3" json:"quotes,omitempty"`
+	// 頁數
+	Page int64 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// 筆數
+	Limit int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// 總筆數(未計算時為-1)
+	Total int64 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	// 總頁數
+	Pages int64 `protobuf:"varint,5,opt,name=pages,proto3" json:"pages,omitempty"`
+	// 下一頁游標
+	NextCursor string `protobuf:"bytes,6,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// 上一頁游標
+	PrevCursor    string `protobuf:"bytes,7,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQuotesResponse) Reset() {
+	*x = ListQuotesResponse{}
+	mi := &file_crm_v1_quote_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQuotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuotesResponse) ProtoMessage() {}
+
+func (x *ListQuotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_quote_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuotesResponse.ProtoReflect.Descriptor instead.
+func (*ListQuotesResponse) Descriptor() ([]byte, []int) {
+	return file_crm_v1_quote_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListQuotesResponse) GetQuotes() []*Quote {
+	if x != nil {
+		return x.Quotes
+	}
+	return nil
+}
+
+func (x *ListQuotesResponse) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListQuotesResponse) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListQuotesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListQuotesResponse) GetPages() int64 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+func (x *ListQuotesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListQuotesResponse) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+type CreateQuoteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 報價名稱
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 報價狀態
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// 報價與商機是否同步化
+	IsSyncing bool `protobuf:"varint,3,opt,name=is_syncing,json=isSyncing,proto3" json:"is_syncing,omitempty"`
+	// 商機ID
+	OpportunityId string `protobuf:"bytes,4,opt,name=opportunity_id,json=opportunityId,proto3" json:"opportunity_id,omitempty"`
+	// 報價到期日期
+	ExpirationDate *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expiration_date,json=expirationDate,proto3" json:"expiration_date,omitempty"`
+	// 報價描述
+	Description string `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	// 報價稅額
+	Tax float64 `protobuf:"fixed64,7,opt,name=tax,proto3" json:"tax,omitempty"`
+	// 報價運費及其他費用
+	ShippingAndHandling float64 `protobuf:"fixed64,8,opt,name=shipping_and_handling,json=shippingAndHandling,proto3" json:"shipping_and_handling,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CreateQuoteRequest) Reset() {
+	*x = CreateQuoteRequest{}
+	mi := &file_crm_v1_quote_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateQuoteRequest) ProtoMessage() {}
+
+func (x *CreateQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_quote_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateQuoteRequest.ProtoReflect.Descriptor instead.
+func (*CreateQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_quote_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateQuoteRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateQuoteRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateQuoteRequest) GetIsSyncing() bool {
+	if x != nil {
+		return x.IsSyncing
+	}
+	return false
+}
+
+func (x *CreateQuoteRequest) GetOpportunityId() string {
+	if x != nil {
+		return x.OpportunityId
+	}
+	return ""
+}
+
+func (x *CreateQuoteRequest) GetExpirationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpirationDate
+	}
+	return nil
+}
+
+func (x *CreateQuoteRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateQuoteRequest) GetTax() float64 {
+	if x != nil {
+		return x.Tax
+	}
+	return 0
+}
+
+func (x *CreateQuoteRequest) GetShippingAndHandling() float64 {
+	if x != nil {
+		return x.ShippingAndHandling
+	}
+	return 0
+}
+
+type UpdateQuoteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 報價ID
+	QuoteId string `protobuf:"bytes,1,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	// 版本(If-Match)
+	IfMatch string `protobuf:"bytes,2,opt,name=if_match,json=ifMatch,proto3" json:"if_match,omitempty"`
+	// 報價名稱
+	Name *string `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// 報價狀態
+	Status *string `protobuf:"bytes,4,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	// 報價與商機是否同步化
+	IsSyncing *bool `protobuf:"varint,5,opt,name=is_syncing,json=isSyncing,proto3,oneof" json:"is_syncing,omitempty"`
+	// 報價是否為最終版
+	IsFinal *bool `protobuf:"varint,6,opt,name=is_final,json=isFinal,proto3,oneof" json:"is_final,omitempty"`
+	// 商機ID
+	OpportunityId *string `protobuf:"bytes,7,opt,name=opportunity_id,json=opportunityId,proto3,oneof" json:"opportunity_id,omitempty"`
+	// 報價到期日期
+	ExpirationDate *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expiration_date,json=expirationDate,proto3" json:"expiration_date,omitempty"`
+	// 報價描述
+	Description *string `protobuf:"bytes,9,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// 報價稅額
+	Tax *float64 `protobuf:"fixed64,10,opt,name=tax,proto3,oneof" json:"tax,omitempty"`
+	// 報價運費及其他費用
+	ShippingAndHandling *float64 `protobuf:"fixed64,11,opt,name=shipping_and_handling,json=shippingAndHandling,proto3,oneof" json:"shipping_and_handling,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *UpdateQuoteRequest) Reset() {
+	*x = UpdateQuoteRequest{}
+	mi := &file_crm_v1_quote_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateQuoteRequest) ProtoMessage() {}
+
+func (x *UpdateQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_quote_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateQuoteRequest.ProtoReflect.Descriptor instead.
+func (*UpdateQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_quote_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateQuoteRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *UpdateQuoteRequest) GetIfMatch() string {
+	if x != nil {
+		return x.IfMatch
+	}
+	return ""
+}
+
+func (x *UpdateQuoteRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateQuoteRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *UpdateQuoteRequest) GetIsSyncing() bool {
+	if x != nil && x.IsSyncing != nil {
+		return *x.IsSyncing
+	}
+	return false
+}
+
+func (x *UpdateQuoteRequest) GetIsFinal() bool {
+	if x != nil && x.IsFinal != nil {
+		return *x.IsFinal
+	}
+	return false
+}
+
+func (x *UpdateQuoteRequest) GetOpportunityId() string {
+	if x != nil && x.OpportunityId != nil {
+		return *x.OpportunityId
+	}
+	return ""
+}
+
+func (x *UpdateQuoteRequest) GetExpirationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpirationDate
+	}
+	return nil
+}
+
+func (x *UpdateQuoteRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateQuoteRequest) GetTax() float64 {
+	if x != nil && x.Tax != nil {
+		return *x.Tax
+	}
+	return 0
+}
+
+func (x *UpdateQuoteRequest) GetShippingAndHandling() float64 {
+	if x != nil && x.ShippingAndHandling != nil {
+		return *x.ShippingAndHandling
+	}
+	return 0
+}
+
+type DeleteQuoteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 報價ID
+	QuoteId       string `protobuf:"bytes,1,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteQuoteRequest) Reset() {
+	*x = DeleteQuoteRequest{}
+	mi := &file_crm_v1_quote_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteQuoteRequest) ProtoMessage() {}
+
+func (x *DeleteQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crm_v1_quote_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteQuoteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_crm_v1_quote_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteQuoteRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+var File_crm_v1_quote_proto protoreflect.FileDescriptor
+
+const file_crm_v1_quote_proto_rawDesc = "" +
+	"\n" +
+	"\x12crm/v1/quote.proto\x12\x06crm.v1\x1a\x13crm/v1/common.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfd\x05\n" +
+	"\x05Quote\x12\x19\n" +
+	"\bquote_id\x18\x01 \x01(\tR\aquoteId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"is_syncing\x18\x04 \x01(\bR\tisSyncing\x12\x19\n" +
+	"\bis_final\x18\x05 \x01(\bR\aisFinal\x12%\n" +
+	"\x0eopportunity_id\x18\x06 \x01(\tR\ropportunityId\x12)\n" +
+	"\x10opportunity_name\x18\a \x01(\tR\x0fopportunityName\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\b \x01(\tR\taccountId\x12C\n" +
+	"\x0fexpiration_date\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x0eexpirationDate\x12 \n" +
+	"\vdescription\x18\n" +
+	" \x01(\tR\vdescription\x12\x10\n" +
+	"\x03tax\x18\v \x01(\x01R\x03tax\x122\n" +
+	"\x15shipping_and_handling\x18\f \x01(\x01R\x13shippingAndHandling\x12\x1b\n" +
+	"\tsub_total\x18\r \x01(\x01R\bsubTotal\x12\x1f\n" +
+	"\vtotal_price\x18\x0e \x01(\x01R\n" +
+	"totalPrice\x12\x1a\n" +
+	"\bdiscount\x18\x0f \x01(\x01R\bdiscount\x12\x1f\n" +
+	"\vgrand_total\x18\x10 \x01(\x01R\n" +
+	"grandTotal\x12\x12\n" +
+	"\x04code\x18\x11 \x01(\tR\x04code\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x12 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x13 \x01(\tR\tupdatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x12\n" +
+	"\x04etag\x18\x16 \x01(\tR\x04etag\",\n" +
+	"\x0fGetQuoteRequest\x12\x19\n" +
+	"\bquote_id\x18\x01 \x01(\tR\aquoteId\"\xd3\x01\n" +
+	"\x12ListQuotesResponse\x12%\n" +
+	"\x06quotes\x18\x01 \x03(\v2\r.crm.v1.QuoteR\x06quotes\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x03R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\x12\x14\n" +
+	"\x05pages\x18\x05 \x01(\x03R\x05pages\x12\x1f\n" +
+	"\vnext_cursor\x18\x06 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\a \x01(\tR\n" +
+	"prevCursor\"\xb3\x02\n" +
+	"\x12CreateQuoteRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"is_syncing\x18\x03 \x01(\bR\tisSyncing\x12%\n" +
+	"\x0eopportunity_id\x18\x04 \x01(\tR\ropportunityId\x12C\n" +
+	"\x0fexpiration_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0eexpirationDate\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x10\n" +
+	"\x03tax\x18\a \x01(\x01R\x03tax\x122\n" +
+	"\x15shipping_and_handling\x18\b \x01(\x01R\x13shippingAndHandling\"\xa1\x04\n" +
+	"\x12UpdateQuoteRequest\x12\x19\n" +
+	"\bquote_id\x18\x01 \x01(\tR\aquoteId\x12\x19\n" +
+	"\bif_match\x18\x02 \x01(\tR\aifMatch\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x1b\n" +
+	"\x06status\x18\x04 \x01(\tH\x01R\x06status\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"is_syncing\x18\x05 \x01(\bH\x02R\tisSyncing\x88\x01\x01\x12\x1e\n" +
+	"\bis_final\x18\x06 \x01(\bH\x03R\aisFinal\x88\x01\x01\x12*\n" +
+	"\x0eopportunity_id\x18\a \x01(\tH\x04R\ropportunityId\x88\x01\x01\x12C\n" +
+	"\x0fexpiration_date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x0eexpirationDate\x12%\n" +
+	"\vdescription\x18\t \x01(\tH\x05R\vdescription\x88\x01\x01\x12\x15\n" +
+	"\x03tax\x18\n" +
+	" \x01(\x01H\x06R\x03tax\x88\x01\x01\x127\n" +
+	"\x15shipping_and_handling\x18\v \x01(\x01H\aR\x13shippingAndHandling\x88\x01\x01B\a\n" +
+	"\x05_nameB\t\n" +
+	"\a_statusB\r\n" +
+	"\v_is_syncingB\v\n" +
+	"\t_is_finalB\x11\n" +
+	"\x0f_opportunity_idB\x0e\n" +
+	"\f_descriptionB\x06\n" +
+	"\x04_taxB\x18\n" +
+	"\x16_shipping_and_handling\"/\n" +
+	"\x12DeleteQuoteRequest\x12\x19\n" +
+	"\bquote_id\x18\x01 \x01(\tR\aquoteId2\xf0\x02\n" +
+	"\fQuoteService\x122\n" +
+	"\bGetQuote\x12\x17.crm.v1.GetQuoteRequest\x1a\r.crm.v1.Quote\x12=\n" +
+	"\n" +
+	"ListQuotes\x12\x13.crm.v1.ListRequest\x1a\x1a.crm.v1.ListQuotesResponse\x126\n" +
+	"\fStreamQuotes\x12\x15.crm.v1.StreamRequest\x1a\r.crm.v1.Quote0\x01\x128\n" +
+	"\vCreateQuote\x12\x1a.crm.v1.CreateQuoteRequest\x1a\r.crm.v1.Quote\x128\n" +
+	"\vUpdateQuote\x12\x1a.crm.v1.UpdateQuoteRequest\x1a\r.crm.v1.Quote\x12A\n" +
+	"\vDeleteQuote\x12\x1a.crm.v1.DeleteQuoteRequest\x1a\x16.google.protobuf.EmptyB\x1cZ\x1acrm/api/proto/crm/v1;crmv1b\x06proto3"
+
+var (
+	file_crm_v1_quote_proto_rawDescOnce sync.Once
+	file_crm_v1_quote_proto_rawDescData []byte
+)
+
+func file_crm_v1_quote_proto_rawDescGZIP() []byte {
+	file_crm_v1_quote_proto_rawDescOnce.Do(func() {
+		file_crm_v1_quote_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_crm_v1_quote_proto_rawDesc), len(file_crm_v1_quote_proto_rawDesc)))
+	})
+	return file_crm_v1_quote_proto_rawDescData
+}
+
+var file_crm_v1_quote_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_crm_v1_quote_proto_goTypes = []any{
+	(*Quote)(nil),                 // 0: crm.v1.Quote
+	(*GetQuoteRequest)(nil),       // 1: crm.v1.GetQuoteRequest
+	(*ListQuotesResponse)(nil),    // 2: crm.v1.ListQuotesResponse
+	(*CreateQuoteRequest)(nil),    // 3: crm.v1.CreateQuoteRequest
+	(*UpdateQuoteRequest)(nil),    // 4: crm.v1.UpdateQuoteRequest
+	(*DeleteQuoteRequest)(nil),    // 5: crm.v1.DeleteQuoteRequest
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+	(*ListRequest)(nil),           // 7: crm.v1.ListRequest
+	(*StreamRequest)(nil),         // 8: crm.v1.StreamRequest
+	(*emptypb.Empty)(nil),         // 9: google.protobuf.Empty
+}
+var file_crm_v1_quote_proto_depIdxs = []int32{
+	6,  // 0: crm.v1.Quote.expiration_date:type_name -> google.protobuf.Timestamp
+	6,  // 1: crm.v1.Quote.created_at:type_name -> google.protobuf.Timestamp
+	6,  // 2: crm.v1.Quote.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: crm.v1.ListQuotesResponse.quotes:type_name -> crm.v1.Quote
+	6,  // 4: crm.v1.CreateQuoteRequest.expiration_date:type_name -> google.protobuf.Timestamp
+	6,  // 5: crm.v1.UpdateQuoteRequest.expiration_date:type_name -> google.protobuf.Timestamp
+	1,  // 6: crm.v1.QuoteService.GetQuote:input_type -> crm.v1.GetQuoteRequest
+	7,  // 7: crm.v1.QuoteService.ListQuotes:input_type -> crm.v1.ListRequest
+	8,  // 8: crm.v1.QuoteService.StreamQuotes:input_type -> crm.v1.StreamRequest
+	3,  // 9: crm.v1.QuoteService.CreateQuote:input_type -> crm.v1.CreateQuoteRequest
+	4,  // 10: crm.v1.QuoteService.UpdateQuote:input_type -> crm.v1.UpdateQuoteRequest
+	5,  // 11: crm.v1.QuoteService.DeleteQuote:input_type -> crm.v1.DeleteQuoteRequest
+	0,  // 12: crm.v1.QuoteService.GetQuote:output_type -> crm.v1.Quote
+	2,  // 13: crm.v1.QuoteService.ListQuotes:output_type -> crm.v1.ListQuotesResponse
+	0,  // 14: crm.v1.QuoteService.StreamQuotes:output_type -> crm.v1.Quote
+	0,  // 15: crm.v1.QuoteService.CreateQuote:output_type -> crm.v1.Quote
+	0,  // 16: crm.v1.QuoteService.UpdateQuote:output_type -> crm.v1.Quote
+	9,  // 17: crm.v1.QuoteService.DeleteQuote:output_type -> google.protobuf.Empty
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_crm_v1_quote_proto_init() }
+func file_crm_v1_quote_proto_init() {
+	if File_crm_v1_quote_proto != nil {
+		return
+	}
+	file_crm_v1_common_proto_init()
+	file_crm_v1_quote_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_crm_v1_quote_proto_rawDesc), len(file_crm_v1_quote_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_crm_v1_quote_proto_goTypes,
+		DependencyIndexes: file_crm_v1_quote_proto_depIdxs,
+		MessageInfos:      file_crm_v1_quote_proto_msgTypes,
+	}.Build()
+	File_crm_v1_quote_proto = out.File
+	file_crm_v1_quote_proto_goTypes = nil
+	file_crm_v1_quote_proto_depIdxs = nil
+}
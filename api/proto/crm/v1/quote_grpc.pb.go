@@ -0,0 +1,322 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: crm/v1/quote.proto
+
+package crmv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	QuoteService_GetQuote_FullMethodName     = "/crm.v1.QuoteService/GetQuote"
+	QuoteService_ListQuotes_FullMethodName   = "/crm.v1.QuoteService/ListQuotes"
+	QuoteService_StreamQuotes_FullMethodName = "/crm.v1.QuoteService/StreamQuotes"
+	QuoteService_CreateQuote_FullMethodName  = "/crm.v1.QuoteService/CreateQuote"
+	QuoteService_UpdateQuote_FullMethodName  = "/crm.v1.QuoteService/UpdateQuote"
+	QuoteService_DeleteQuote_FullMethodName  = "/crm.v1.QuoteService/DeleteQuote"
+)
+
+// QuoteServiceClient is the client API for QuoteService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// QuoteService reads and writes quotes through the same rules as the REST API.
+type QuoteServiceClient interface {
+	GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*Quote, error)
+	ListQuotes(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListQuotesResponse, error)
+	// StreamQuotes sends every matching quote, page by page.
+	StreamQuotes(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Quote], error)
+	CreateQuote(ctx context.Context, in *CreateQuoteRequest, opts ...grpc.CallOption) (*Quote, error)
+	UpdateQuote(ctx context.Context, in *UpdateQuoteRequest, opts ...grpc.CallOption) (*Quote, error)
+	DeleteQuote(ctx context.Context, in *DeleteQuoteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type quoteServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewQuoteServiceClient(cc grpc.ClientConnInterface) QuoteServiceClient {
+	return &quoteServiceClient{cc}
+}
+
+func (c *quoteServiceClient) GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*Quote, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Quote)
+	err := c.cc.Invoke(ctx, QuoteService_GetQuote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quoteServiceClient) ListQuotes(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListQuotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListQuotesResponse)
+	err := c.cc.Invoke(ctx, QuoteService_ListQuotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quoteServiceClient) StreamQuotes(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Quote], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &QuoteService_ServiceDesc.Streams[0], QuoteService_StreamQuotes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamRequest, Quote]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QuoteService_StreamQuotesClient = grpc.ServerStreamingClient[Quote]
+
+func (c *quoteServiceClient) CreateQuote(ctx context.Context, in *CreateQuoteRequest, opts ...grpc.CallOption) (*Quote, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Quote)
+	err := c.cc.Invoke(ctx, QuoteService_CreateQuote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quoteServiceClient) UpdateQuote(ctx context.Context, in *UpdateQuoteRequest, opts ...grpc.CallOption) (*Quote, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Quote)
+	err := c.cc.Invoke(ctx, QuoteService_UpdateQuote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quoteServiceClient) DeleteQuote(ctx context.Context, in *DeleteQuoteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, QuoteService_DeleteQuote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QuoteServiceServer is the server API for QuoteService service.
+// All implementations must embed UnimplementedQuoteServiceServer
+// for forward compatibility.
+//
+// QuoteService reads and writes quotes through the same rules as the REST API.
+type QuoteServiceServer interface {
+	GetQuote(context.Context, *GetQuoteRequest) (*Quote, error)
+	ListQuotes(context.Context, *ListRequest) (*ListQuotesResponse, error)
+	// StreamQuotes sends every matching quote, page by page.
+	StreamQuotes(*StreamRequest, grpc.ServerStreamingServer[Quote]) error
+	CreateQuote(context.Context, *CreateQuoteRequest) (*Quote, error)
+	UpdateQuote(context.Context, *UpdateQuoteRequest) (*Quote, error)
+	DeleteQuote(context.Context, *DeleteQuoteRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedQuoteServiceServer()
+}
+
+// UnimplementedQuoteServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedQuoteServiceServer struct{}
+
+func (UnimplementedQuoteServiceServer) GetQuote(context.Context, *GetQuoteRequest) (*Quote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuote not implemented")
+}
+func (UnimplementedQuoteServiceServer) ListQuotes(context.Context, *ListRequest) (*ListQuotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListQuotes not implemented")
+}
+func (UnimplementedQuoteServiceServer) StreamQuotes(*StreamRequest, grpc.ServerStreamingServer[Quote]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamQuotes not implemented")
+}
+func (UnimplementedQuoteServiceServer) CreateQuote(context.Context, *CreateQuoteRequest) (*Quote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateQuote not implemented")
+}
+func (UnimplementedQuoteServiceServer) UpdateQuote(context.Context, *UpdateQuoteRequest) (*Quote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateQuote not implemented")
+}
+func (UnimplementedQuoteServiceServer) DeleteQuote(context.Context, *DeleteQuoteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteQuote not implemented")
+}
+func (UnimplementedQuoteServiceServer) mustEmbedUnimplementedQuoteServiceServer() {}
+func (UnimplementedQuoteServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeQuoteServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to QuoteServiceServer will
+// result in compilation errors.
+type UnsafeQuoteServiceServer interface {
+	mustEmbedUnimplementedQuoteServiceServer()
+}
+
+func RegisterQuoteServiceServer(s grpc.ServiceRegistrar, srv QuoteServiceServer) {
+	// If the following call pancis, it indicates UnimplementedQuoteServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&QuoteService_ServiceDesc, srv)
+}
+
+func _QuoteService_GetQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuoteServiceServer).GetQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuoteService_GetQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuoteServiceServer).GetQuote(ctx, req.(*GetQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuoteService_ListQuotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuoteServiceServer).ListQuotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuoteService_ListQuotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuoteServiceServer).ListQuotes(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuoteService_StreamQuotes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QuoteServiceServer).StreamQuotes(m, &grpc.GenericServerStream[StreamRequest, Quote]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QuoteService_StreamQuotesServer = grpc.ServerStreamingServer[Quote]
+
+func _QuoteService_CreateQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuoteServiceServer).CreateQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuoteService_CreateQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuoteServiceServer).CreateQuote(ctx, req.(*CreateQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuoteService_UpdateQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuoteServiceServer).UpdateQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuoteService_UpdateQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuoteServiceServer).UpdateQuote(ctx, req.(*UpdateQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuoteService_DeleteQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuoteServiceServer).DeleteQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuoteService_DeleteQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuoteServiceServer).DeleteQuote(ctx, req.(*DeleteQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// QuoteService_ServiceDesc is the grpc.ServiceDesc for QuoteService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var QuoteService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "crm.v1.QuoteService",
+	HandlerType: (*QuoteServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetQuote",
+			Handler:    _QuoteService_GetQuote_Handler,
+		},
+		{
+			MethodName: "ListQuotes",
+			Handler:    _QuoteService_ListQuotes_Handler,
+		},
+		{
+			MethodName: "CreateQuote",
+			Handler:    _QuoteService_CreateQuote_Handler,
+		},
+		{
+			MethodName: "UpdateQuote",
+			Handler:    _QuoteService_UpdateQuote_Handler,
+		},
+		{
+			MethodName: "DeleteQuote",
+			Handler:    _QuoteService_DeleteQuote_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamQuotes",
+			Handler:       _QuoteService_StreamQuotes_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "crm/v1/quote.proto",
+}
@@ -5,23 +5,35 @@ import (
 	"crm/internal/interactor/pkg/util/log"
 	"crm/internal/router"
 	"crm/internal/router/account"
+	"crm/internal/router/api_key"
 	"crm/internal/router/campaign"
+	"crm/internal/router/company_setting"
 	"crm/internal/router/contact"
 	"crm/internal/router/contract"
+	"crm/internal/router/custom_field"
+	"crm/internal/router/duplicate_rule"
+	"crm/internal/router/enum"
 	"crm/internal/router/event"
+	"crm/internal/router/graphql"
 	"crm/internal/router/historical_record"
+	"crm/internal/router/import_mapping"
+	"crm/internal/router/importer"
 	"crm/internal/router/industry"
+	"crm/internal/router/job"
 	"crm/internal/router/lead"
 	"crm/internal/router/login"
 	"crm/internal/router/opportunity"
 	"crm/internal/router/opportunity_campaign"
 	"crm/internal/router/order"
 	"crm/internal/router/order_product"
+	"crm/internal/router/picklist"
 	"crm/internal/router/policy"
 	"crm/internal/router/product"
 	"crm/internal/router/quote"
 	"crm/internal/router/quote_product"
+	"crm/internal/router/recycle_bin"
 	"crm/internal/router/role"
+	"crm/internal/router/search"
 	"crm/internal/router/user"
 
 	"github.com/apex/gateway"
@@ -33,7 +45,12 @@ func main() {
 		log.Error(err)
 		return
 	}
-	engine := router.Default()
+	engine, err := router.Default()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
 	engine = user.GetRouter(engine, db)
 	engine = login.GetRouter(engine, db)
 	engine = lead.GetRouter(engine, db)
@@ -53,5 +70,17 @@ func main() {
 	engine = role.GetRouter(engine, db)
 	engine = historical_record.GetRouter(engine, db)
 	engine = event.GetRouter(engine, db)
+	engine = search.GetRouter(engine, db)
+	engine = importer.GetRouter(engine, db)
+	engine = import_mapping.GetRouter(engine, db)
+	engine = job.GetRouter(engine, db)
+	engine = duplicate_rule.GetRouter(engine, db)
+	engine = recycle_bin.GetRouter(engine, db)
+	engine = graphql.GetRouter(engine, db)
+	engine = api_key.GetRouter(engine, db)
+	engine = enum.GetRouter(engine, db)
+	engine = picklist.GetRouter(engine, db)
+	engine = custom_field.GetRouter(engine, db)
+	engine = company_setting.GetRouter(engine, db)
 	log.Fatal(gateway.ListenAndServe(":8080", engine))
 }
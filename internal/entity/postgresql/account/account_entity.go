@@ -1,11 +1,17 @@
 package account
 
 import (
+	"cmp"
 	"encoding/json"
 
 	"github.com/lib/pq"
 
 	model "crm/internal/entity/postgresql/db/accounts"
+	"crm/internal/entity/postgresql/keyset"
+	"crm/internal/entity/postgresql/relation"
+	"crm/internal/interactor/models/accounts"
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/page"
 	"crm/internal/interactor/pkg/util/log"
 
 	"gorm.io/gorm"
@@ -15,7 +21,7 @@ import (
 type Entity interface {
 	WithTrx(trx *gorm.DB) Entity
 	Create(input *model.Base) (err error)
-	GetByList(input *model.Base) (quantity int64, output []*model.Table, err error)
+	GetByList(input *model.Base) (quantity int64, output []*model.Table, cursors page.Cursors, err error)
 	GetByListNoPagination(input *model.Base) (output []*model.Table, err error)
 	GetBySingle(input *model.Base) (output *model.Table, err error)
 	GetByQuantity(input *model.Base) (quantity int64, err error)
@@ -23,6 +29,19 @@ type Entity interface {
 	Update(input *model.Base) (err error)
 }
 
+// filterFields are the fields the list can be filtered on.
+var filterFields = filter.Fields{
+	"name":              {Name: "accounts.name", Type: filter.Text},
+	"phone_number":      {Name: "accounts.phone_number", Type: filter.Text},
+	"type":              {Name: "accounts.type", Type: filter.Text},
+	"industry_id":       {Name: "accounts.industry_id", Type: filter.UUID},
+	"parent_account_id": {Name: "accounts.parent_account_id", Type: filter.UUID},
+	"salesperson_id":    {Name: "accounts.salesperson_id", Type: filter.UUID},
+	"created_at":        {Name: "accounts.created_at", Type: filter.Time},
+	"updated_at":        {Name: "accounts.updated_at", Type: filter.Time},
+	"salesperson_name":  {Name: `"Salespeople".name`, Type: filter.Text},
+}
+
 type storage struct {
 	db *gorm.DB
 }
@@ -53,6 +72,11 @@ func (s *storage) Create(input *model.Base) (err error) {
 		return err
 	}
 
+	// 未帶入自訂欄位時存入空物件
+	if data.CustomFields == nil {
+		data.CustomFields = json.RawMessage("{}")
+	}
+
 	err = s.db.Model(&model.Table{}).Omit(clause.Associations).Create(&data).Error
 	if err != nil {
 		log.Error(err)
@@ -62,18 +86,21 @@ func (s *storage) Create(input *model.Base) (err error) {
 	return nil
 }
 
-func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.Table, err error) {
-	query := s.db.Model(&model.Table{}).Count(&quantity).Joins("Salespeople").Preload(clause.Associations)
+func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.Table, cursors page.Cursors, err error) {
+	query := s.db.Model(&model.Table{})
 
 	if input.AccountID != nil {
 		query.Where("account_id = ?", input.AccountID)
 	}
-	if input.Sort.Field != "" && input.Sort.Direction != "" {
-		if input.Sort.Field == "salesperson_name" && input.Sort.Direction != "" {
-			query.Order(`"Salespeople".name` + " " + input.Sort.Direction)
-		} else {
-			query.Order(input.Sort.Field + " " + input.Sort.Direction)
-		}
+	columns := filterFields.With(input.CustomColumns)
+	orderBy, err := input.Sort.Clause(columns.Columns(), "accounts.created_at", "accounts.account_id")
+	if err != nil {
+		return 0, nil, cursors, err
+	}
+
+	query, err = relation.Load(query, input.Projection, accounts.Relations, orderBy, "parent_account_id")
+	if err != nil {
+		return 0, nil, cursors, err
 	}
 
 	// filter
@@ -109,15 +136,45 @@ func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.
 	}
 
 	query.Where(filter)
+	if input.Where != nil {
+		where, err := input.Where.Clause(columns)
+		if err != nil {
+			return 0, nil, cursors, err
+		}
+
+		query.Where(where)
+	}
 
-	err = query.Count(&quantity).Offset(int((input.Page - 1) * input.Limit)).
-		Limit(int(input.Limit)).Order("created_at desc").Find(&output).Error
+	if input.Page == 0 {
+		quantity, err = keyset.Count(query, input.Count)
+		if err != nil {
+			log.Error(err)
+			return 0, nil, cursors, err
+		}
+
+		output, cursors, err = keyset.Find[model.Table](query, orderBy, input.Cursor, input.Limit)
+		if err != nil {
+			log.Error(err)
+			return 0, nil, cursors, err
+		}
+
+		return quantity, output, cursors, nil
+	}
+
+	quantity, err = keyset.Count(query, cmp.Or(input.Count, keyset.Exact))
+	if err != nil {
+		log.Error(err)
+		return 0, nil, cursors, err
+	}
+
+	err = query.Offset(int((input.Page - 1) * input.Limit)).
+		Limit(int(input.Limit)).Order(orderBy).Find(&output).Error
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
-	return quantity, output, nil
+	return quantity, output, cursors, nil
 }
 
 func (s *storage) GetByListNoPagination(input *model.Base) (output []*model.Table, err error) {
@@ -215,6 +272,16 @@ func (s *storage) Update(input *model.Base) (err error) {
 		data["salesperson_id"] = input.SalespersonID
 	}
 
+	if input.CustomFields != nil {
+		customFields, err := json.Marshal(input.CustomFields)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		data["custom_fields"] = string(customFields)
+	}
+
 	if input.UpdatedBy != nil {
 		data["updated_by"] = input.UpdatedBy
 	}
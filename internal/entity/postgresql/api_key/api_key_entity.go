@@ -0,0 +1,113 @@
+package api_key
+
+import (
+	"encoding/json"
+
+	model "crm/internal/entity/postgresql/db/api_keys"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+)
+
+type Entity interface {
+	WithTrx(trx *gorm.DB) Entity
+	Create(input *model.Base) (err error)
+	GetByList(input *model.Base) (output []*model.Table, err error)
+	GetBySingle(input *model.Base) (output *model.Table, err error)
+	Delete(input *model.Base) (err error)
+}
+
+type storage struct {
+	db *gorm.DB
+}
+
+func Init(db *gorm.DB) Entity {
+	return &storage{
+		db: db,
+	}
+}
+
+func (s *storage) WithTrx(trx *gorm.DB) Entity {
+	return &storage{
+		db: trx,
+	}
+}
+
+func (s *storage) Create(input *model.Base) (err error) {
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	data := &model.Table{}
+	err = json.Unmarshal(marshal, data)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	err = s.db.Model(&model.Table{}).Create(&data).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *storage) GetByList(input *model.Base) (output []*model.Table, err error) {
+	query := s.db.Model(&model.Table{}).Omit("key_hash")
+	if input.CompanyID != nil {
+		query.Where("company_id = ?", input.CompanyID)
+	}
+
+	err = query.Order("created_at desc").Find(&output).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *storage) GetBySingle(input *model.Base) (output *model.Table, err error) {
+	query := s.db.Model(&model.Table{})
+	if input.APIKeyID != nil {
+		query.Where("api_key_id = ?", input.APIKeyID)
+	}
+
+	if input.CompanyID != nil {
+		query.Where("company_id = ?", input.CompanyID)
+	}
+
+	if input.KeyHash != nil {
+		query.Where("key_hash = ?", input.KeyHash)
+	}
+
+	err = query.First(&output).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *storage) Delete(input *model.Base) (err error) {
+	query := s.db.Model(&model.Table{})
+	if input.APIKeyID != nil {
+		query.Where("api_key_id = ?", input.APIKeyID)
+	}
+
+	if input.CompanyID != nil {
+		query.Where("company_id = ?", input.CompanyID)
+	}
+
+	err = query.Delete(&model.Table{}).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
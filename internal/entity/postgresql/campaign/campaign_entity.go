@@ -1,9 +1,15 @@
 package campaign
 
 import (
+	"cmp"
 	"encoding/json"
 
 	model "crm/internal/entity/postgresql/db/campaigns"
+	"crm/internal/entity/postgresql/keyset"
+	"crm/internal/entity/postgresql/relation"
+	"crm/internal/interactor/models/campaigns"
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/page"
 	"crm/internal/interactor/pkg/util/log"
 
 	"gorm.io/gorm"
@@ -13,7 +19,7 @@ import (
 type Entity interface {
 	WithTrx(trx *gorm.DB) Entity
 	Create(input *model.Base) (err error)
-	GetByList(input *model.Base) (quantity int64, output []*model.Table, err error)
+	GetByList(input *model.Base) (quantity int64, output []*model.Table, cursors page.Cursors, err error)
 	GetByListNoPagination(input *model.Base) (output []*model.Table, err error)
 	GetBySingle(input *model.Base) (output *model.Table, err error)
 	GetByQuantity(input *model.Base) (quantity int64, err error)
@@ -21,6 +27,26 @@ type Entity interface {
 	Update(input *model.Base) (err error)
 }
 
+// filterFields are the fields the list can be filtered on.
+var filterFields = filter.Fields{
+	"name":               {Name: "campaigns.name", Type: filter.Text},
+	"status":             {Name: "campaigns.status", Type: filter.Text},
+	"type":               {Name: "campaigns.type", Type: filter.Text},
+	"is_enable":          {Name: "campaigns.is_enable", Type: filter.Bool},
+	"parent_campaign_id": {Name: "campaigns.parent_campaign_id", Type: filter.UUID},
+	"start_date":         {Name: "campaigns.start_date", Type: filter.Time},
+	"end_date":           {Name: "campaigns.end_date", Type: filter.Time},
+	"sent":               {Name: "campaigns.sent", Type: filter.Number},
+	"budget_cost":        {Name: "campaigns.budget_cost", Type: filter.Number},
+	"expected_responses": {Name: "campaigns.expected_responses", Type: filter.Number},
+	"actual_cost":        {Name: "campaigns.actual_cost", Type: filter.Number},
+	"expected_income":    {Name: "campaigns.expected_income", Type: filter.Number},
+	"salesperson_id":     {Name: "campaigns.salesperson_id", Type: filter.UUID},
+	"created_at":         {Name: "campaigns.created_at", Type: filter.Time},
+	"updated_at":         {Name: "campaigns.updated_at", Type: filter.Time},
+	"salesperson_name":   {Name: `"Salespeople".name`, Type: filter.Text},
+}
+
 type storage struct {
 	db *gorm.DB
 }
@@ -60,21 +86,21 @@ func (s *storage) Create(input *model.Base) (err error) {
 	return nil
 }
 
-func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.Table, err error) {
-	query := s.db.Model(&model.Table{}).Count(&quantity).Joins("Salespeople").Preload(clause.Associations)
+func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.Table, cursors page.Cursors, err error) {
+	query := s.db.Model(&model.Table{})
 
 	if input.CampaignID != nil {
 		query.Where("campaign_id = ?", input.CampaignID)
 	}
 
-	if input.Sort.Field != "" && input.Sort.Direction != "" {
-		if input.Sort.Field == "salesperson_name" && input.Sort.Direction != "" {
-			query.Order(`"Salespeople".name` + " " + input.Sort.Direction)
-		} else if input.Sort.Field == "parent_campaign_name" && input.Sort.Direction != "" {
-			query.Order("name" + " " + input.Sort.Direction)
-		} else {
-			query.Order(input.Sort.Field + " " + input.Sort.Direction)
-		}
+	orderBy, err := input.Sort.Clause(filterFields.Columns(), "campaigns.created_at", "campaigns.campaign_id")
+	if err != nil {
+		return 0, nil, cursors, err
+	}
+
+	query, err = relation.Load(query, input.Projection, campaigns.Relations, orderBy, "parent_campaign_id")
+	if err != nil {
+		return 0, nil, cursors, err
 	}
 
 	// filter
@@ -110,15 +136,45 @@ func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.
 	}
 
 	query.Where(filter)
+	if input.Where != nil {
+		where, err := input.Where.Clause(filterFields)
+		if err != nil {
+			return 0, nil, cursors, err
+		}
+
+		query.Where(where)
+	}
+
+	if input.Page == 0 {
+		quantity, err = keyset.Count(query, input.Count)
+		if err != nil {
+			log.Error(err)
+			return 0, nil, cursors, err
+		}
+
+		output, cursors, err = keyset.Find[model.Table](query, orderBy, input.Cursor, input.Limit)
+		if err != nil {
+			log.Error(err)
+			return 0, nil, cursors, err
+		}
+
+		return quantity, output, cursors, nil
+	}
+
+	quantity, err = keyset.Count(query, cmp.Or(input.Count, keyset.Exact))
+	if err != nil {
+		log.Error(err)
+		return 0, nil, cursors, err
+	}
 
-	err = query.Count(&quantity).Offset(int((input.Page - 1) * input.Limit)).
-		Limit(int(input.Limit)).Order("created_at desc").Find(&output).Error
+	err = query.Offset(int((input.Page - 1) * input.Limit)).
+		Limit(int(input.Limit)).Order(orderBy).Find(&output).Error
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
-	return quantity, output, nil
+	return quantity, output, cursors, nil
 }
 
 func (s *storage) GetByListNoPagination(input *model.Base) (output []*model.Table, err error) {
@@ -0,0 +1,79 @@
+package company_setting
+
+import (
+	"encoding/json"
+
+	model "crm/internal/entity/postgresql/db/company_settings"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Entity interface {
+	WithTrx(trx *gorm.DB) Entity
+	GetBySingle(input *model.Base) (output *model.Table, err error)
+	Save(input *model.Base) (err error)
+}
+
+type storage struct {
+	db *gorm.DB
+}
+
+func Init(db *gorm.DB) Entity {
+	return &storage{
+		db: db,
+	}
+}
+
+func (s *storage) WithTrx(trx *gorm.DB) Entity {
+	return &storage{
+		db: trx,
+	}
+}
+
+func (s *storage) GetBySingle(input *model.Base) (output *model.Table, err error) {
+	query := s.db.Model(&model.Table{})
+	if input.CompanyID != nil {
+		query.Where("company_id = ?", input.CompanyID)
+	}
+
+	err = query.First(&output).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// Save creates the settings of the company or updates the given settings when they already exist.
+func (s *storage) Save(input *model.Base) (err error) {
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	data := &model.Table{}
+	err = json.Unmarshal(marshal, data)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	columns := []string{"updated_at", "updated_by"}
+	if input.IfMatchRequired != nil {
+		columns = append(columns, "if_match_required")
+	}
+
+	err = s.db.Model(&model.Table{}).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "company_id"}},
+		DoUpdates: clause.AssignmentColumns(columns),
+	}).Create(data).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
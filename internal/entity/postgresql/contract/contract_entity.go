@@ -1,9 +1,15 @@
 package contract
 
 import (
+	"cmp"
 	"encoding/json"
 
 	model "crm/internal/entity/postgresql/db/contracts"
+	"crm/internal/entity/postgresql/keyset"
+	"crm/internal/entity/postgresql/relation"
+	"crm/internal/interactor/models/contracts"
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/page"
 	"crm/internal/interactor/pkg/util/log"
 
 	"gorm.io/gorm"
@@ -13,7 +19,7 @@ import (
 type Entity interface {
 	WithTrx(trx *gorm.DB) Entity
 	Create(input *model.Base) (err error)
-	GetByList(input *model.Base) (quantity int64, output []*model.Table, err error)
+	GetByList(input *model.Base) (quantity int64, output []*model.Table, cursors page.Cursors, err error)
 	GetByListNoPagination(input *model.Base) (output []*model.Table, err error)
 	GetBySingle(input *model.Base) (output *model.Table, err error)
 	GetByQuantity(input *model.Base) (quantity int64, err error)
@@ -21,6 +27,22 @@ type Entity interface {
 	Update(input *model.Base) (err error)
 }
 
+// filterFields are the fields the list can be filtered on.
+var filterFields = filter.Fields{
+	"code":           {Name: "contracts.code", Type: filter.Text},
+	"status":         {Name: "contracts.status", Type: filter.Text},
+	"description":    {Name: "contracts.description", Type: filter.Text},
+	"start_date":     {Name: "contracts.start_date", Type: filter.Time},
+	"end_date":       {Name: "contracts.end_date", Type: filter.Time},
+	"term":           {Name: "contracts.term", Type: filter.Number},
+	"opportunity_id": {Name: "contracts.opportunity_id", Type: filter.UUID},
+	"account_id":     {Name: "contracts.account_id", Type: filter.UUID},
+	"salesperson_id": {Name: "contracts.salesperson_id", Type: filter.UUID},
+	"created_at":     {Name: "contracts.created_at", Type: filter.Time},
+	"updated_at":     {Name: "contracts.updated_at", Type: filter.Time},
+	"account_name":   {Name: `"Accounts".name`, Type: filter.Text},
+}
+
 type storage struct {
 	db *gorm.DB
 }
@@ -60,21 +82,21 @@ func (s *storage) Create(input *model.Base) (err error) {
 	return nil
 }
 
-func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.Table, err error) {
-	query := s.db.Model(&model.Table{}).Count(&quantity).
-		Joins("Accounts").
-		Preload(clause.Associations)
+func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.Table, cursors page.Cursors, err error) {
+	query := s.db.Model(&model.Table{})
 
 	if input.ContractID != nil {
 		query.Where("contract_id = ?", input.ContractID)
 	}
 
-	if input.Sort.Field != "" && input.Sort.Direction != "" {
-		if input.Sort.Field == "account_name" && input.Sort.Direction != "" {
-			query.Order(`"Accounts".name` + " " + input.Sort.Direction)
-		} else {
-			query.Order(input.Sort.Field + " " + input.Sort.Direction)
-		}
+	orderBy, err := input.Sort.Clause(filterFields.Columns(), "contracts.created_at", "contracts.contract_id")
+	if err != nil {
+		return 0, nil, cursors, err
+	}
+
+	query, err = relation.Load(query, input.Projection, contracts.Relations, orderBy)
+	if err != nil {
+		return 0, nil, cursors, err
 	}
 
 	// filter
@@ -102,15 +124,45 @@ func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.
 	}
 
 	query.Where(filter)
+	if input.Where != nil {
+		where, err := input.Where.Clause(filterFields)
+		if err != nil {
+			return 0, nil, cursors, err
+		}
+
+		query.Where(where)
+	}
+
+	if input.Page == 0 {
+		quantity, err = keyset.Count(query, input.Count)
+		if err != nil {
+			log.Error(err)
+			return 0, nil, cursors, err
+		}
+
+		output, cursors, err = keyset.Find[model.Table](query, orderBy, input.Cursor, input.Limit)
+		if err != nil {
+			log.Error(err)
+			return 0, nil, cursors, err
+		}
+
+		return quantity, output, cursors, nil
+	}
+
+	quantity, err = keyset.Count(query, cmp.Or(input.Count, keyset.Exact))
+	if err != nil {
+		log.Error(err)
+		return 0, nil, cursors, err
+	}
 
-	err = query.Count(&quantity).Offset(int((input.Page - 1) * input.Limit)).
-		Limit(int(input.Limit)).Order("created_at desc").Find(&output).Error
+	err = query.Offset(int((input.Page - 1) * input.Limit)).
+		Limit(int(input.Limit)).Order(orderBy).Find(&output).Error
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
-	return quantity, output, nil
+	return quantity, output, cursors, nil
 }
 
 func (s *storage) GetByListNoPagination(input *model.Base) (output []*model.Table, err error) {
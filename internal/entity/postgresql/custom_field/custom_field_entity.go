@@ -0,0 +1,196 @@
+package custom_field
+
+import (
+	"encoding/json"
+
+	model "crm/internal/entity/postgresql/db/custom_fields"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+)
+
+type Entity interface {
+	WithTrx(trx *gorm.DB) Entity
+	Create(input *model.Base) (err error)
+	GetByList(input *model.Base) (output []*model.Table, err error)
+	GetBySingle(input *model.Base) (output *model.Table, err error)
+	Delete(input *model.Base) (err error)
+	Update(input *model.Base) (err error)
+	Purge(input *model.Base) (err error)
+}
+
+type storage struct {
+	db *gorm.DB
+}
+
+func Init(db *gorm.DB) Entity {
+	return &storage{
+		db: db,
+	}
+}
+
+func (s *storage) WithTrx(trx *gorm.DB) Entity {
+	return &storage{
+		db: trx,
+	}
+}
+
+func (s *storage) Create(input *model.Base) (err error) {
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	data := &model.Table{}
+	err = json.Unmarshal(marshal, data)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	err = s.db.Model(&model.Table{}).Create(&data).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *storage) GetByList(input *model.Base) (output []*model.Table, err error) {
+	query := s.db.Model(&model.Table{})
+	if input.CompanyID != nil {
+		query.Where("company_id = ?", input.CompanyID)
+	}
+
+	if input.Entity != nil {
+		query.Where("entity = ?", input.Entity)
+	}
+
+	if input.Key != nil {
+		query.Where("key = ?", input.Key)
+	}
+
+	err = query.Order("entity, sequence, key").Find(&output).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *storage) GetBySingle(input *model.Base) (output *model.Table, err error) {
+	query := s.db.Model(&model.Table{})
+	if input.CustomFieldID != nil {
+		query.Where("custom_field_id = ?", input.CustomFieldID)
+	}
+
+	if input.CompanyID != nil {
+		query.Where("company_id = ?", input.CompanyID)
+	}
+
+	if input.Entity != nil {
+		query.Where("entity = ?", input.Entity)
+	}
+
+	if input.Key != nil {
+		query.Where("key = ?", input.Key)
+	}
+
+	err = query.First(&output).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *storage) Update(input *model.Base) (err error) {
+	query := s.db.Model(&model.Table{})
+	data := map[string]any{}
+
+	if input.Label != nil {
+		data["label"] = input.Label
+	}
+
+	if input.Required != nil {
+		data["required"] = input.Required
+	}
+
+	if input.Options != nil {
+		data["options"] = input.Options
+	}
+
+	if input.DefaultValue != nil {
+		defaultValue, err := json.Marshal(input.DefaultValue)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		data["default_value"] = string(defaultValue)
+	}
+
+	if input.Sequence != nil {
+		data["sequence"] = input.Sequence
+	}
+
+	if input.UpdatedBy != nil {
+		data["updated_by"] = input.UpdatedBy
+	}
+
+	if input.UpdatedAt != nil {
+		data["updated_at"] = input.UpdatedAt
+	}
+
+	if input.CustomFieldID != nil {
+		query.Where("custom_field_id = ?", input.CustomFieldID)
+	}
+
+	if input.CompanyID != nil {
+		query.Where("company_id = ?", input.CompanyID)
+	}
+
+	err = query.Updates(data).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *storage) Delete(input *model.Base) (err error) {
+	query := s.db.Model(&model.Table{})
+	if input.CustomFieldID != nil {
+		query.Where("custom_field_id = ?", input.CustomFieldID)
+	}
+
+	if input.CompanyID != nil {
+		query.Where("company_id = ?", input.CompanyID)
+	}
+
+	err = query.Delete(&model.Table{}).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Purge removes the values of a custom field from the records of its company, deleted records included,
+// so that a field created again under the same key never reads them. The records belong to the company of their creators.
+func (s *storage) Purge(input *model.Base) (err error) {
+	err = s.db.Exec("update "+*input.Entity+" set custom_fields = custom_fields - ?::text "+
+		"where custom_fields -> ?::text is not null and created_by in (select user_id from users where company_id = ?)",
+		input.Key, input.Key, input.CompanyID).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package api_keys
+
+import (
+	"time"
+)
+
+// Table struct is api_keys database table struct
+type Table struct {
+	// API key ID
+	APIKeyID string `gorm:"<-:create;column:api_key_id;type:uuid;not null;primaryKey;" json:"api_key_id"`
+	// 公司ID
+	CompanyID string `gorm:"column:company_id;type:uuid;not null;" json:"company_id"`
+	// 使用者ID(以此使用者及其角色呼叫)
+	UserID string `gorm:"column:user_id;type:uuid;not null;" json:"user_id"`
+	// 名稱
+	Name string `gorm:"column:name;type:text;not null;" json:"name"`
+	// 前綴(供辨識,不可用於驗證)
+	Prefix string `gorm:"column:prefix;type:text;not null;" json:"prefix"`
+	// 雜湊值
+	KeyHash string `gorm:"column:key_hash;type:text;not null;" json:"key_hash"`
+	// 到期時間
+	ExpiredAt *time.Time `gorm:"column:expired_at;type:timestamp;" json:"expired_at"`
+	// 創建時間
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;not null;" json:"created_at"`
+	// 創建者
+	CreatedBy string `gorm:"column:created_by;type:uuid;not null;" json:"created_by"`
+}
+
+// Base struct is corresponding to api_keys table structure file
+type Base struct {
+	// API key ID
+	APIKeyID *string `json:"api_key_id,omitempty"`
+	// 公司ID
+	CompanyID *string `json:"company_id,omitempty"`
+	// 使用者ID(以此使用者及其角色呼叫)
+	UserID *string `json:"user_id,omitempty"`
+	// 名稱
+	Name *string `json:"name,omitempty"`
+	// 前綴(供辨識,不可用於驗證)
+	Prefix *string `json:"prefix,omitempty"`
+	// 雜湊值
+	KeyHash *string `json:"key_hash,omitempty"`
+	// 到期時間
+	ExpiredAt *time.Time `json:"expired_at,omitempty"`
+	// 創建時間
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// 創建者
+	CreatedBy *string `json:"created_by,omitempty"`
+}
+
+// TableName sets the insert table name for this struct type
+func (t *Table) TableName() string {
+	return "api_keys"
+}
@@ -0,0 +1,42 @@
+package company_settings
+
+import (
+	"time"
+)
+
+// Table struct is company_settings database table struct
+type Table struct {
+	// 公司ID
+	CompanyID string `gorm:"<-:create;column:company_id;type:uuid;not null;primaryKey;" json:"company_id"`
+	// 更新資料時是否必須帶入 If-Match
+	IfMatchRequired bool `gorm:"column:if_match_required;type:boolean;not null;" json:"if_match_required"`
+	// 創建時間
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;not null;" json:"created_at"`
+	// 創建者
+	CreatedBy string `gorm:"column:created_by;type:uuid;not null;" json:"created_by"`
+	// 更新時間
+	UpdatedAt *time.Time `gorm:"column:updated_at;type:timestamp;not null;" json:"updated_at"`
+	// 更新者
+	UpdatedBy string `gorm:"column:updated_by;type:uuid;not null;" json:"updated_by"`
+}
+
+// Base struct is corresponding to company_settings table structure file
+type Base struct {
+	// 公司ID
+	CompanyID *string `json:"company_id,omitempty"`
+	// 更新資料時是否必須帶入 If-Match
+	IfMatchRequired *bool `json:"if_match_required,omitempty"`
+	// 創建時間
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// 創建者
+	CreatedBy *string `json:"created_by,omitempty"`
+	// 更新時間
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	// 更新者
+	UpdatedBy *string `json:"updated_by,omitempty"`
+}
+
+// TableName sets the insert table name for this struct type
+func (t *Table) TableName() string {
+	return "company_settings"
+}
@@ -1,9 +1,12 @@
 package contacts
 
 import (
+	"encoding/json"
+
 	"crm/internal/entity/postgresql/db/accounts"
 	"crm/internal/entity/postgresql/db/users"
 	model "crm/internal/interactor/models/contacts"
+	"crm/internal/interactor/models/filter"
 	"crm/internal/interactor/models/sort"
 	"crm/internal/interactor/models/special"
 )
@@ -36,6 +39,8 @@ type Table struct {
 	SalespersonID string `gorm:"column:salesperson_id;type:uuid;not null;" json:"salesperson_id"`
 	// salespeople  data
 	Salespeople users.Table `gorm:"foreignKey:SalespersonID;references:UserID" json:"salespeople,omitempty"`
+	// 自訂欄位
+	CustomFields json.RawMessage `gorm:"column:custom_fields;type:jsonb;not null;" json:"custom_fields"`
 	// create_users data
 	CreatedByUsers users.Table `gorm:"foreignKey:CreatedBy;references:UserID" json:"created_by_users,omitempty"`
 	// update_users data
@@ -71,6 +76,8 @@ type Base struct {
 	SalespersonID *string `json:"salesperson_id,omitempty"`
 	// salespeople  data
 	Salespeople users.Base `json:"salespeople,omitempty"`
+	// 自訂欄位
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 	// create_users data
 	CreatedByUsers users.Base `json:"created_by_users,omitempty"`
 	// update_users data
@@ -80,6 +87,8 @@ type Base struct {
 	model.Filter `json:"filter"`
 	// 排序欄位
 	sort.Sort `json:"sort"`
+	// 自訂欄位的搜尋及排序欄位
+	CustomColumns filter.Fields `json:"-"`
 }
 
 // TableName sets the insert table name for this struct type
@@ -0,0 +1,77 @@
+package custom_fields
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Table struct is custom_fields database table struct
+type Table struct {
+	// 自訂欄位ID
+	CustomFieldID string `gorm:"<-:create;column:custom_field_id;type:uuid;not null;primaryKey;" json:"custom_field_id"`
+	// 公司ID
+	CompanyID string `gorm:"column:company_id;type:uuid;not null;" json:"company_id"`
+	// 資料類型
+	Entity string `gorm:"column:entity;type:text;not null;" json:"entity"`
+	// 欄位鍵值
+	Key string `gorm:"column:key;type:text;not null;" json:"key"`
+	// 顯示名稱
+	Label string `gorm:"column:label;type:text;not null;" json:"label"`
+	// 欄位型別
+	Type string `gorm:"column:type;type:text;not null;" json:"type"`
+	// 是否必填
+	Required bool `gorm:"column:required;type:boolean;not null;" json:"required"`
+	// 選項
+	Options pq.StringArray `gorm:"column:options;type:text[];not null;" json:"options"`
+	// 預設值
+	DefaultValue json.RawMessage `gorm:"column:default_value;type:jsonb;" json:"default_value"`
+	// 排序
+	Sequence int `gorm:"column:sequence;type:integer;not null;" json:"sequence"`
+	// 創建時間
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;not null;" json:"created_at"`
+	// 創建者
+	CreatedBy string `gorm:"column:created_by;type:uuid;not null;" json:"created_by"`
+	// 更新時間
+	UpdatedAt *time.Time `gorm:"column:updated_at;type:timestamp;not null;" json:"updated_at"`
+	// 更新者
+	UpdatedBy string `gorm:"column:updated_by;type:uuid;not null;" json:"updated_by"`
+}
+
+// Base struct is corresponding to custom_fields table structure file
+type Base struct {
+	// 自訂欄位ID
+	CustomFieldID *string `json:"custom_field_id,omitempty"`
+	// 公司ID
+	CompanyID *string `json:"company_id,omitempty"`
+	// 資料類型
+	Entity *string `json:"entity,omitempty"`
+	// 欄位鍵值
+	Key *string `json:"key,omitempty"`
+	// 顯示名稱
+	Label *string `json:"label,omitempty"`
+	// 欄位型別
+	Type *string `json:"type,omitempty"`
+	// 是否必填
+	Required *bool `json:"required,omitempty"`
+	// 選項
+	Options *[]string `json:"options,omitempty"`
+	// 預設值
+	DefaultValue any `json:"default_value,omitempty"`
+	// 排序
+	Sequence *int `json:"sequence,omitempty"`
+	// 創建時間
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// 創建者
+	CreatedBy *string `json:"created_by,omitempty"`
+	// 更新時間
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	// 更新者
+	UpdatedBy *string `json:"updated_by,omitempty"`
+}
+
+// TableName sets the insert table name for this struct type
+func (t *Table) TableName() string {
+	return "custom_fields"
+}
@@ -0,0 +1,62 @@
+package duplicate_rules
+
+import (
+	"time"
+)
+
+// Table struct is duplicate_rules database table struct
+type Table struct {
+	// 重複規則ID
+	DuplicateRuleID string `gorm:"<-:create;column:duplicate_rule_id;type:uuid;not null;primaryKey;" json:"duplicate_rule_id"`
+	// 公司ID
+	CompanyID string `gorm:"column:company_id;type:uuid;not null;" json:"company_id"`
+	// 資料類型
+	Entity string `gorm:"column:entity;type:text;not null;" json:"entity"`
+	// 比對欄位
+	Field string `gorm:"column:field;type:text;not null;" json:"field"`
+	// 比對方式(exact, normalized, trigram)
+	Method string `gorm:"column:method;type:text;not null;" json:"method"`
+	// 相似度門檻(trigram)
+	Threshold float64 `gorm:"column:threshold;type:numeric;not null;" json:"threshold"`
+	// 是否啟用
+	IsEnable bool `gorm:"column:is_enable;type:boolean;not null;" json:"is_enable"`
+	// 創建時間
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;not null;" json:"created_at"`
+	// 創建者
+	CreatedBy string `gorm:"column:created_by;type:uuid;not null;" json:"created_by"`
+	// 更新時間
+	UpdatedAt *time.Time `gorm:"column:updated_at;type:timestamp;not null;" json:"updated_at"`
+	// 更新者
+	UpdatedBy string `gorm:"column:updated_by;type:uuid;not null;" json:"updated_by"`
+}
+
+// Base struct is corresponding to duplicate_rules table structure file
+type Base struct {
+	// 重複規則ID
+	DuplicateRuleID *string `json:"duplicate_rule_id,omitempty"`
+	// 公司ID
+	CompanyID *string `json:"company_id,omitempty"`
+	// 資料類型
+	Entity *string `json:"entity,omitempty"`
+	// 比對欄位
+	Field *string `json:"field,omitempty"`
+	// 比對方式(exact, normalized, trigram)
+	Method *string `json:"method,omitempty"`
+	// 相似度門檻(trigram)
+	Threshold *float64 `json:"threshold,omitempty"`
+	// 是否啟用
+	IsEnable *bool `json:"is_enable,omitempty"`
+	// 創建時間
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// 創建者
+	CreatedBy *string `json:"created_by,omitempty"`
+	// 更新時間
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	// 更新者
+	UpdatedBy *string `json:"updated_by,omitempty"`
+}
+
+// TableName sets the insert table name for this struct type
+func (t *Table) TableName() string {
+	return "duplicate_rules"
+}
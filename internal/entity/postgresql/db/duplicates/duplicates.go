@@ -0,0 +1,47 @@
+package duplicates
+
+import (
+	"time"
+)
+
+// Table struct is a record matched by a duplicate rule
+type Table struct {
+	// 資料ID
+	ID string `gorm:"column:id;" json:"id"`
+	// 標題
+	Title string `gorm:"column:title;" json:"title"`
+	// 相似度
+	Score float64 `gorm:"column:score;" json:"score"`
+}
+
+// Base struct is corresponding to the duplicate search structure file
+type Base struct {
+	// 資料類型
+	Entity *string `json:"entity,omitempty"`
+	// 資料ID
+	ID *string `json:"id,omitempty"`
+	// 資料ID(多筆)
+	IDs []string `json:"ids,omitempty"`
+	// 比對欄位
+	Field *string `json:"field,omitempty"`
+	// 比對方式(exact, normalized, trigram)
+	Method *string `json:"method,omitempty"`
+	// 相似度門檻(trigram)
+	Threshold *float64 `json:"threshold,omitempty"`
+}
+
+// Merge struct is used to merge records into a surviving record
+type Merge struct {
+	// 資料類型
+	Entity string `json:"entity,omitempty"`
+	// 保留的資料ID
+	SurvivorID string `json:"survivor_id,omitempty"`
+	// 併入後刪除的資料ID
+	MergedIDs []string `json:"merged_ids,omitempty"`
+	// 保留資料欄位值的來源資料ID(欄位:資料ID)
+	Sources map[string]string `json:"sources,omitempty"`
+	// 更新時間
+	UpdatedAt time.Time `json:"updated_at"`
+	// 更新者
+	UpdatedBy string `json:"updated_by,omitempty"`
+}
@@ -0,0 +1,52 @@
+package idempotency_keys
+
+import (
+	"time"
+)
+
+// Table struct is idempotency_keys database table struct
+type Table struct {
+	// 冪等鍵ID
+	IdempotencyKeyID string `gorm:"<-:create;column:idempotency_key_id;type:uuid;not null;primaryKey;" json:"idempotency_key_id"`
+	// 公司ID
+	CompanyID string `gorm:"column:company_id;type:uuid;not null;" json:"company_id"`
+	// 使用者ID
+	UserID string `gorm:"column:user_id;type:uuid;not null;" json:"user_id"`
+	// 冪等鍵
+	Key string `gorm:"column:key;type:text;not null;" json:"key"`
+	// 請求內容雜湊值
+	RequestHash string `gorm:"column:request_hash;type:text;not null;" json:"request_hash"`
+	// 回應狀態碼
+	StatusCode *int `gorm:"column:status_code;type:integer;" json:"status_code"`
+	// 回應內容
+	ResponseBody *string `gorm:"column:response_body;type:text;" json:"response_body"`
+	// 創建時間
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;not null;" json:"created_at"`
+}
+
+// Base struct is corresponding to idempotency_keys table structure file
+type Base struct {
+	// 冪等鍵ID
+	IdempotencyKeyID *string `json:"idempotency_key_id,omitempty"`
+	// 公司ID
+	CompanyID *string `json:"company_id,omitempty"`
+	// 使用者ID
+	UserID *string `json:"user_id,omitempty"`
+	// 冪等鍵
+	Key *string `json:"key,omitempty"`
+	// 請求內容雜湊值
+	RequestHash *string `json:"request_hash,omitempty"`
+	// 回應狀態碼
+	StatusCode *int `json:"status_code,omitempty"`
+	// 回應內容
+	ResponseBody *string `json:"response_body,omitempty"`
+	// 創建時間
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// 保留期限起始時間
+	ExpiredBefore *time.Time `json:"expired_before,omitempty"`
+}
+
+// TableName sets the insert table name for this struct type
+func (t *Table) TableName() string {
+	return "idempotency_keys"
+}
@@ -0,0 +1,55 @@
+package import_mappings
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Table struct is import_mappings database table struct
+type Table struct {
+	// 匯入對應ID
+	ImportMappingID string `gorm:"<-:create;column:import_mapping_id;type:uuid;not null;primaryKey;" json:"import_mapping_id"`
+	// 公司ID
+	CompanyID string `gorm:"column:company_id;type:uuid;not null;" json:"company_id"`
+	// 匯入資料類型
+	Entity string `gorm:"column:entity;type:text;not null;" json:"entity"`
+	// 匯入對應名稱
+	Name string `gorm:"column:name;type:text;not null;" json:"name"`
+	// 欄位對應(檔案欄位名稱:資料欄位)
+	Columns json.RawMessage `gorm:"column:columns;type:jsonb;not null;" json:"columns"`
+	// 創建時間
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;not null;" json:"created_at"`
+	// 創建者
+	CreatedBy string `gorm:"column:created_by;type:uuid;not null;" json:"created_by"`
+	// 更新時間
+	UpdatedAt *time.Time `gorm:"column:updated_at;type:timestamp;not null;" json:"updated_at"`
+	// 更新者
+	UpdatedBy string `gorm:"column:updated_by;type:uuid;not null;" json:"updated_by"`
+}
+
+// Base struct is corresponding to import_mappings table structure file
+type Base struct {
+	// 匯入對應ID
+	ImportMappingID *string `json:"import_mapping_id,omitempty"`
+	// 公司ID
+	CompanyID *string `json:"company_id,omitempty"`
+	// 匯入資料類型
+	Entity *string `json:"entity,omitempty"`
+	// 匯入對應名稱
+	Name *string `json:"name,omitempty"`
+	// 欄位對應(檔案欄位名稱:資料欄位)
+	Columns map[string]string `json:"columns,omitempty"`
+	// 創建時間
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// 創建者
+	CreatedBy *string `json:"created_by,omitempty"`
+	// 更新時間
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	// 更新者
+	UpdatedBy *string `json:"updated_by,omitempty"`
+}
+
+// TableName sets the insert table name for this struct type
+func (t *Table) TableName() string {
+	return "import_mappings"
+}
@@ -0,0 +1,78 @@
+package jobs
+
+import (
+	"time"
+)
+
+// Table struct is jobs database table struct
+type Table struct {
+	// 工作ID
+	JobID string `gorm:"<-:create;column:job_id;type:uuid;not null;primaryKey;" json:"job_id"`
+	// 公司ID
+	CompanyID string `gorm:"column:company_id;type:uuid;not null;" json:"company_id"`
+	// 工作種類(import, export)
+	Kind string `gorm:"column:kind;type:text;not null;" json:"kind"`
+	// 資料類型
+	Entity string `gorm:"column:entity;type:text;not null;" json:"entity"`
+	// 狀態(pending, running, succeeded, failed)
+	Status string `gorm:"column:status;type:text;not null;" json:"status"`
+	// 新增筆數
+	Inserted int `gorm:"column:inserted;type:integer;not null;" json:"inserted"`
+	// 更新筆數
+	Updated int `gorm:"column:updated;type:integer;not null;" json:"updated"`
+	// 拒絕筆數
+	Rejected int `gorm:"column:rejected;type:integer;not null;" json:"rejected"`
+	// 檔案名稱
+	FileName *string `gorm:"column:file_name;type:text;" json:"file_name"`
+	// 檔案類型
+	ContentType *string `gorm:"column:content_type;type:text;" json:"content_type"`
+	// 檔案內容(匯入錯誤檔或匯出檔)
+	File []byte `gorm:"column:file;type:bytea;" json:"file"`
+	// 錯誤訊息
+	Error *string `gorm:"column:error;type:text;" json:"error"`
+	// 創建時間
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;not null;" json:"created_at"`
+	// 創建者
+	CreatedBy string `gorm:"column:created_by;type:uuid;not null;" json:"created_by"`
+	// 完成時間
+	FinishedAt *time.Time `gorm:"column:finished_at;type:timestamp;" json:"finished_at"`
+}
+
+// Base struct is corresponding to jobs table structure file
+type Base struct {
+	// 工作ID
+	JobID *string `json:"job_id,omitempty"`
+	// 公司ID
+	CompanyID *string `json:"company_id,omitempty"`
+	// 工作種類(import, export)
+	Kind *string `json:"kind,omitempty"`
+	// 資料類型
+	Entity *string `json:"entity,omitempty"`
+	// 狀態(pending, running, succeeded, failed)
+	Status *string `json:"status,omitempty"`
+	// 新增筆數
+	Inserted *int `json:"inserted,omitempty"`
+	// 更新筆數
+	Updated *int `json:"updated,omitempty"`
+	// 拒絕筆數
+	Rejected *int `json:"rejected,omitempty"`
+	// 檔案名稱
+	FileName *string `json:"file_name,omitempty"`
+	// 檔案類型
+	ContentType *string `json:"content_type,omitempty"`
+	// 檔案內容(匯入錯誤檔或匯出檔)
+	File []byte `json:"file,omitempty"`
+	// 錯誤訊息
+	Error *string `json:"error,omitempty"`
+	// 創建時間
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// 創建者
+	CreatedBy *string `json:"created_by,omitempty"`
+	// 完成時間
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// TableName sets the insert table name for this struct type
+func (t *Table) TableName() string {
+	return "jobs"
+}
@@ -0,0 +1,13 @@
+package loaders
+
+// Base struct is corresponding to the batch loading structure file
+type Base struct {
+	// 資料表
+	Table *string `json:"table,omitempty"`
+	// 比對欄位
+	Column *string `json:"column,omitempty"`
+	// 比對值
+	Keys []string `json:"keys,omitempty"`
+	// 公司ID(讀取使用者時)
+	CompanyID *string `json:"company_id,omitempty"`
+}
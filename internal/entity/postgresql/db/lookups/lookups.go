@@ -0,0 +1,23 @@
+package lookups
+
+// Table struct is a record found by one of its names
+type Table struct {
+	// 資料ID
+	ID string `gorm:"column:id;" json:"id"`
+	// 名稱
+	Name string `gorm:"column:name;" json:"name"`
+}
+
+// Base struct is corresponding to the lookup results structure file
+type Base struct {
+	// 資料ID
+	ID *string `json:"id,omitempty"`
+	// 名稱
+	Name *string `json:"name,omitempty"`
+	// 查詢類型(industry, account, salesperson, contact_email)
+	Kind *string `json:"kind,omitempty"`
+	// 公司ID(查詢使用者時)
+	CompanyID *string `json:"company_id,omitempty"`
+	// 查詢名稱
+	Names []string `json:"names,omitempty"`
+}
@@ -1,11 +1,14 @@
 package opportunities
 
 import (
+	"encoding/json"
+
 	"time"
 
 	"crm/internal/entity/postgresql/db/leads"
 
 	"crm/internal/entity/postgresql/db/opportunity_campaigns"
+	"crm/internal/interactor/models/filter"
 	model "crm/internal/interactor/models/opportunities"
 	"crm/internal/interactor/models/sort"
 
@@ -42,6 +45,8 @@ type Table struct {
 	SalespersonID string `gorm:"column:salesperson_id;type:uuid;not null;" json:"salesperson_id"`
 	// salespeople  data
 	Salespeople users.Table `gorm:"foreignKey:SalespersonID;references:UserID" json:"salespeople,omitempty"`
+	// 自訂欄位
+	CustomFields json.RawMessage `gorm:"column:custom_fields;type:jsonb;not null;" json:"custom_fields"`
 	// create_users data
 	CreatedByUsers users.Table `gorm:"foreignKey:CreatedBy;references:UserID" json:"created_by_users,omitempty"`
 	// update_users data
@@ -77,6 +82,8 @@ type Base struct {
 	SalespersonID *string `json:"salesperson_id,omitempty"`
 	// salespeople  data
 	Salespeople users.Base `json:"salespeople,omitempty"`
+	// 自訂欄位
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 	// create_users data
 	CreatedByUsers users.Base `json:"created_by_users,omitempty"`
 	// update_users data
@@ -88,6 +95,8 @@ type Base struct {
 	model.Filter `json:"filter"`
 	// 排序欄位
 	sort.Sort `json:"sort"`
+	// 自訂欄位的搜尋及排序欄位
+	CustomColumns filter.Fields `json:"-"`
 }
 
 // TableName sets the insert table name for this struct type
@@ -0,0 +1,75 @@
+package picklists
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Table struct is picklists database table struct
+type Table struct {
+	// 選項清單ID
+	PicklistID string `gorm:"<-:create;column:picklist_id;type:uuid;not null;primaryKey;" json:"picklist_id"`
+	// 公司ID
+	CompanyID string `gorm:"column:company_id;type:uuid;not null;" json:"company_id"`
+	// 資料類型
+	Entity string `gorm:"column:entity;type:text;not null;" json:"entity"`
+	// 欄位
+	Field string `gorm:"column:field;type:text;not null;" json:"field"`
+	// 控制欄位
+	ControllingField string `gorm:"column:controlling_field;type:text;not null;" json:"controlling_field"`
+	// 選項
+	Values json.RawMessage `gorm:"column:values;type:jsonb;not null;" json:"values"`
+	// 創建時間
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;not null;" json:"created_at"`
+	// 創建者
+	CreatedBy string `gorm:"column:created_by;type:uuid;not null;" json:"created_by"`
+	// 更新時間
+	UpdatedAt *time.Time `gorm:"column:updated_at;type:timestamp;not null;" json:"updated_at"`
+	// 更新者
+	UpdatedBy string `gorm:"column:updated_by;type:uuid;not null;" json:"updated_by"`
+}
+
+// Base struct is corresponding to picklists table structure file
+type Base struct {
+	// 選項清單ID
+	PicklistID *string `json:"picklist_id,omitempty"`
+	// 公司ID
+	CompanyID *string `json:"company_id,omitempty"`
+	// 資料類型
+	Entity *string `json:"entity,omitempty"`
+	// 欄位
+	Field *string `json:"field,omitempty"`
+	// 控制欄位
+	ControllingField *string `json:"controlling_field,omitempty"`
+	// 選項
+	Values []*Value `json:"values,omitempty"`
+	// 創建時間
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// 創建者
+	CreatedBy *string `json:"created_by,omitempty"`
+	// 更新時間
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	// 更新者
+	UpdatedBy *string `json:"updated_by,omitempty"`
+}
+
+// Value is an option of a picklist
+type Value struct {
+	// 選項值
+	Value string `json:"value"`
+	// 顯示名稱
+	Label string `json:"label"`
+	// 排序
+	Sequence int `json:"sequence"`
+	// 是否為預設值
+	IsDefault bool `json:"is_default"`
+	// 是否啟用
+	IsActive bool `json:"is_active"`
+	// 可用的控制欄位值,空白表示不限
+	ControllingValues []string `json:"controlling_values,omitempty"`
+}
+
+// TableName sets the insert table name for this struct type
+func (t *Table) TableName() string {
+	return "picklists"
+}
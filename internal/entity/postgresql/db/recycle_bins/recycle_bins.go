@@ -0,0 +1,63 @@
+package recycle_bins
+
+import (
+	"time"
+)
+
+// Table is a deleted record read from the table of its entity
+type Table struct {
+	// 資料ID
+	ID string `json:"id"`
+	// 標題
+	Title string `json:"title"`
+	// 刪除時間
+	DeletedAt time.Time `json:"deleted_at"`
+	// 刪除者
+	DeletedBy string `json:"deleted_by"`
+	// 刪除者名稱
+	DeletedByName string `json:"deleted_by_name"`
+}
+
+// Base is the search and change structure of the deleted records of an entity
+type Base struct {
+	// 資料類型
+	Entity string `json:"entity,omitempty"`
+	// 資料ID
+	IDs []string `json:"ids,omitempty"`
+	// 刪除的開始時間
+	DelStartAt *time.Time `json:"del_start_at,omitempty"`
+	// 刪除的結束時間
+	DelEndAt *time.Time `json:"del_end_at,omitempty"`
+	// 頁數
+	Page int64 `json:"page,omitempty"`
+	// 筆數
+	Limit int64 `json:"limit,omitempty"`
+	// 異動時間
+	ModifiedAt time.Time `json:"modified_at,omitempty"`
+	// 異動者
+	ModifiedBy string `json:"modified_by,omitempty"`
+}
+
+// Step is the records of a reference affected by deleting records
+type Step struct {
+	// 資料類型,關聯表為空
+	Entity string `json:"entity"`
+	// 資料表
+	Table string `json:"table"`
+	// 主鍵
+	PrimaryKey string `json:"primary_key"`
+	// 關聯欄位
+	Column string `json:"column"`
+	// 刪除規則
+	Policy string `json:"policy"`
+	// 資料ID
+	IDs []string `json:"ids"`
+}
+
+// Plan is what deleting, restoring or purging records affects, by the delete policies of their entity
+type Plan struct {
+	// 受影響的關聯資料
+	Steps []*Step `json:"steps"`
+	// 還原的資料所關聯、仍在資源回收筒的資料,須先還原(關聯欄位為還原資料的欄位)
+	Parents []*Step `json:"parents"`
+}
@@ -0,0 +1,35 @@
+package searches
+
+// Table struct is a row of the search results
+type Table struct {
+	// 資料類型
+	Type string `gorm:"column:type;" json:"type"`
+	// 資料ID
+	ID string `gorm:"column:id;" json:"id"`
+	// 標題
+	Title string `gorm:"column:title;" json:"title"`
+	// 搜尋欄位內容(JSON)
+	Fields string `gorm:"column:fields;" json:"fields"`
+	// 相關度
+	Rank float64 `gorm:"column:rank;" json:"rank"`
+}
+
+// Base struct is corresponding to the search results structure file
+type Base struct {
+	// 資料類型
+	Type *string `json:"type,omitempty"`
+	// 資料ID
+	ID *string `json:"id,omitempty"`
+	// 標題
+	Title *string `json:"title,omitempty"`
+	// 搜尋欄位內容(JSON)
+	Fields *string `json:"fields,omitempty"`
+	// 相關度
+	Rank *float64 `json:"rank,omitempty"`
+	// 搜尋字串
+	Q *string `json:"q,omitempty"`
+	// 搜尋類型
+	Types []string `json:"types,omitempty"`
+	// 筆數
+	Limit *int64 `json:"limit,omitempty"`
+}
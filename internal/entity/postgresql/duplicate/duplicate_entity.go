@@ -0,0 +1,249 @@
+package duplicate
+
+import (
+	"fmt"
+
+	model "crm/internal/entity/postgresql/db/duplicates"
+	duplicateModel "crm/internal/interactor/models/duplicates"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Entity interface {
+	WithTrx(trx *gorm.DB) Entity
+	GetByList(input *model.Base) (output []*model.Table, err error)
+	GetByRecords(input *model.Base, fields []string) (output []map[string]any, err error)
+	Merge(input *model.Merge) (reparented map[string]int64, err error)
+}
+
+// child is a column referencing the merged records.
+type child struct {
+	// 資料表
+	table string
+	// 關聯欄位
+	column string
+	// 主鍵,關聯表及自身關聯使用
+	primaryKey string
+	// 關聯表的另一個欄位,移轉後重複的關聯只保留一筆
+	pair string
+	// 是否記錄更新者
+	stamp bool
+}
+
+// target is an entity whose records can be matched and merged.
+type target struct {
+	// 資料表
+	table string
+	// 主鍵
+	primaryKey string
+	// 標題欄位
+	title string
+	// 關聯的資料
+	children []child
+}
+
+var targets = map[string]target{
+	"accounts": {table: "accounts", primaryKey: "account_id", title: "name", children: []child{
+		{table: "accounts", column: "parent_account_id", primaryKey: "account_id", stamp: true},
+		{table: "leads", column: "account_id", stamp: true},
+		{table: "contacts", column: "account_id", stamp: true},
+		{table: "opportunities", column: "account_id", stamp: true},
+		{table: "contracts", column: "account_id", stamp: true},
+		{table: "orders", column: "account_id", stamp: true},
+		{table: "quotes", column: "account_id", stamp: true},
+		{table: "events", column: "account_id", stamp: true},
+		{table: "account_contacts", column: "account_id", primaryKey: "account_contact_id", pair: "contact_id", stamp: true},
+		{table: "historical_records", column: "source_id"},
+	}},
+	"contacts": {table: "contacts", primaryKey: "contact_id", title: "name", children: []child{
+		{table: "contacts", column: "supervisor_id", primaryKey: "contact_id", stamp: true},
+		{table: "account_contacts", column: "contact_id", primaryKey: "account_contact_id", pair: "account_id", stamp: true},
+		{table: "event_contacts", column: "contact_id", primaryKey: "event_contact_id", pair: "event_id", stamp: true},
+		{table: "historical_records", column: "source_id"},
+	}},
+	"leads": {table: "leads", primaryKey: "lead_id", title: "description", children: []child{
+		{table: "opportunities", column: "lead_id", stamp: true},
+		{table: "historical_records", column: "source_id"},
+	}},
+}
+
+// normalizations are the expressions of the normalized method, they match the indexes of the duplicate rules migration.
+var normalizations = map[string]string{
+	duplicateModel.Name:  `regexp_replace(regexp_replace(lower(%s), '\m(co|corp|corporation|inc|ltd|limited|llc|company)\M|股份有限公司|有限公司|公司', '', 'g'), '[^[:alnum:]]', '', 'g')`,
+	duplicateModel.Email: `lower(trim(%s))`,
+	duplicateModel.Phone: `regexp_replace(%s, '\D', '', 'g')`,
+}
+
+type storage struct {
+	db *gorm.DB
+}
+
+func Init(db *gorm.DB) Entity {
+	return &storage{
+		db: db,
+	}
+}
+
+func (s *storage) WithTrx(trx *gorm.DB) Entity {
+	return &storage{
+		db: trx,
+	}
+}
+
+// GetByList returns the records matching the record of input by the rule of input, best first.
+func (s *storage) GetByList(input *model.Base) (output []*model.Table, err error) {
+	t, ok := targets[*input.Entity]
+	if !ok {
+		return nil, fmt.Errorf("duplicate: unknown entity %s", *input.Entity)
+	}
+
+	normalization, ok := duplicateModel.Fields[*input.Entity][*input.Field]
+	if !ok {
+		return nil, fmt.Errorf("duplicate: unknown field %s of %s", *input.Field, *input.Entity)
+	}
+
+	candidate, record := "c."+*input.Field, "r."+*input.Field
+	score := "1.0"
+	var condition string
+	var args []any
+	switch *input.Method {
+	case duplicateModel.Exact:
+		condition = candidate + " = " + record + " and " + record + " <> ''"
+	case duplicateModel.Normalized:
+		candidate = fmt.Sprintf(normalizations[normalization], candidate)
+		record = fmt.Sprintf(normalizations[normalization], record)
+		condition = candidate + " = " + record + " and " + record + " <> ''"
+	case duplicateModel.Trigram:
+		// % 以 gin_trgm_ops 索引篩選,門檻不低於預設的 0.3
+		score = "similarity(" + candidate + ", " + record + ")"
+		condition = candidate + " % " + record + " and " + score + " >= ?"
+		args = append(args, input.Threshold)
+	default:
+		return nil, fmt.Errorf("duplicate: unknown method %s", *input.Method)
+	}
+
+	err = s.db.Table(t.table+" as c").
+		Joins("join "+t.table+" as r on r."+t.primaryKey+" = ?", input.ID).
+		Select("c."+t.primaryKey+"::text as id, c."+t.title+" as title, "+score+" as score").
+		Where("c."+t.primaryKey+" <> r."+t.primaryKey).
+		Where("c.deleted_at is null").
+		Where(condition, args...).
+		Order("score desc").
+		Limit(20).
+		Scan(&output).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// GetByRecords returns the fields of the records of input as text, locking them until the transaction ends.
+func (s *storage) GetByRecords(input *model.Base, fields []string) (output []map[string]any, err error) {
+	t, ok := targets[*input.Entity]
+	if !ok {
+		return nil, fmt.Errorf("duplicate: unknown entity %s", *input.Entity)
+	}
+
+	columns := []string{t.primaryKey + "::text as id", t.title + " as title"}
+	for _, field := range fields {
+		columns = append(columns, field+"::text as "+field)
+	}
+
+	err = s.db.Table(t.table).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select(columns).
+		Where(t.primaryKey+" in ?", input.IDs).
+		Where("deleted_at is null").
+		Find(&output).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// Merge copies the picked values into the surviving record, moves every child record of the merged records to it
+// and deletes the merged records. It returns the number of moved records by table.column.
+func (s *storage) Merge(input *model.Merge) (reparented map[string]int64, err error) {
+	t, ok := targets[input.Entity]
+	if !ok {
+		return nil, fmt.Errorf("duplicate: unknown entity %s", input.Entity)
+	}
+
+	if len(input.Sources) > 0 {
+		data := map[string]any{"updated_at": input.UpdatedAt, "updated_by": input.UpdatedBy}
+		for field, sourceID := range input.Sources {
+			data[field] = gorm.Expr("(select "+field+" from "+t.table+" where "+t.primaryKey+" = ?)", sourceID)
+		}
+
+		err = s.db.Table(t.table).Where(t.primaryKey+" = ?", input.SurvivorID).Updates(data).Error
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+	}
+
+	reparented = map[string]int64{}
+	for _, c := range t.children {
+		data := map[string]any{c.column: input.SurvivorID}
+		if c.stamp {
+			data["updated_at"] = input.UpdatedAt
+			data["updated_by"] = input.UpdatedBy
+		}
+
+		query := s.db.Table(c.table).Where(c.column+" in ?", input.MergedIDs)
+		if c.table == t.table {
+			query = query.Where(c.primaryKey+" <> ?", input.SurvivorID)
+		}
+
+		result := query.Updates(data)
+		if result.Error != nil {
+			log.Error(result.Error)
+			return nil, result.Error
+		}
+
+		reparented[c.table+"."+c.column] = result.RowsAffected
+
+		// 保留的資料不可關聯自己或併入的資料
+		if c.table == t.table {
+			err = s.db.Table(c.table).
+				Where(c.primaryKey+" = ?", input.SurvivorID).
+				Where(c.column+" in ?", append([]string{input.SurvivorID}, input.MergedIDs...)).
+				Update(c.column, nil).Error
+			if err != nil {
+				log.Error(err)
+				return nil, err
+			}
+		}
+
+		// 移轉後重複的關聯只保留最早建立的一筆
+		if c.pair != "" {
+			err = s.db.Exec("update "+c.table+" as t set deleted_at = ?, updated_at = ?, updated_by = ? "+
+				"where t."+c.column+" = ? and t.deleted_at is null and exists (select 1 from "+c.table+" as o "+
+				"where o."+c.column+" = t."+c.column+" and o."+c.pair+" = t."+c.pair+" and o.deleted_at is null "+
+				"and (o.created_at, o."+c.primaryKey+") < (t.created_at, t."+c.primaryKey+"))",
+				input.UpdatedAt, input.UpdatedAt, input.UpdatedBy, input.SurvivorID).Error
+			if err != nil {
+				log.Error(err)
+				return nil, err
+			}
+		}
+	}
+
+	err = s.db.Table(t.table).Where(t.primaryKey+" in ?", input.MergedIDs).Updates(map[string]any{
+		"deleted_at": input.UpdatedAt,
+		"updated_at": input.UpdatedAt,
+		"updated_by": input.UpdatedBy,
+	}).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return reparented, nil
+}
@@ -0,0 +1,159 @@
+package duplicate_rule
+
+import (
+	"encoding/json"
+
+	model "crm/internal/entity/postgresql/db/duplicate_rules"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+)
+
+type Entity interface {
+	WithTrx(trx *gorm.DB) Entity
+	Create(input *model.Base) (err error)
+	GetByList(input *model.Base) (output []*model.Table, err error)
+	GetBySingle(input *model.Base) (output *model.Table, err error)
+	Delete(input *model.Base) (err error)
+	Update(input *model.Base) (err error)
+}
+
+type storage struct {
+	db *gorm.DB
+}
+
+func Init(db *gorm.DB) Entity {
+	return &storage{
+		db: db,
+	}
+}
+
+func (s *storage) WithTrx(trx *gorm.DB) Entity {
+	return &storage{
+		db: trx,
+	}
+}
+
+func (s *storage) Create(input *model.Base) (err error) {
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	data := &model.Table{}
+	err = json.Unmarshal(marshal, data)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	err = s.db.Model(&model.Table{}).Create(&data).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *storage) GetByList(input *model.Base) (output []*model.Table, err error) {
+	query := s.db.Model(&model.Table{})
+	if input.CompanyID != nil {
+		query.Where("company_id = ?", input.CompanyID)
+	}
+
+	if input.Entity != nil {
+		query.Where("entity = ?", input.Entity)
+	}
+
+	if input.IsEnable != nil {
+		query.Where("is_enable = ?", input.IsEnable)
+	}
+
+	err = query.Order("entity, field, method").Find(&output).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *storage) GetBySingle(input *model.Base) (output *model.Table, err error) {
+	query := s.db.Model(&model.Table{})
+	if input.DuplicateRuleID != nil {
+		query.Where("duplicate_rule_id = ?", input.DuplicateRuleID)
+	}
+
+	if input.CompanyID != nil {
+		query.Where("company_id = ?", input.CompanyID)
+	}
+
+	if input.Entity != nil {
+		query.Where("entity = ?", input.Entity)
+	}
+
+	if input.Field != nil {
+		query.Where("field = ?", input.Field)
+	}
+
+	if input.Method != nil {
+		query.Where("method = ?", input.Method)
+	}
+
+	err = query.First(&output).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *storage) Update(input *model.Base) (err error) {
+	query := s.db.Model(&model.Table{})
+	data := map[string]any{}
+
+	if input.Threshold != nil {
+		data["threshold"] = input.Threshold
+	}
+
+	if input.IsEnable != nil {
+		data["is_enable"] = input.IsEnable
+	}
+
+	if input.UpdatedBy != nil {
+		data["updated_by"] = input.UpdatedBy
+	}
+
+	if input.UpdatedAt != nil {
+		data["updated_at"] = input.UpdatedAt
+	}
+
+	if input.DuplicateRuleID != nil {
+		query.Where("duplicate_rule_id = ?", input.DuplicateRuleID)
+	}
+
+	err = query.Updates(data).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *storage) Delete(input *model.Base) (err error) {
+	query := s.db.Model(&model.Table{})
+	if input.DuplicateRuleID != nil {
+		query.Where("duplicate_rule_id = ?", input.DuplicateRuleID)
+	}
+
+	err = query.Delete(&model.Table{}).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
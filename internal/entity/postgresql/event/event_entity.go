@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	model "crm/internal/entity/postgresql/db/events"
+	"crm/internal/interactor/models/filter"
 	"crm/internal/interactor/pkg/util/log"
 
 	"gorm.io/gorm"
@@ -20,6 +21,20 @@ type Entity interface {
 	Update(input *model.Base) (err error)
 }
 
+// filterFields are the fields the list can be filtered on.
+var filterFields = filter.Fields{
+	"subject":     {Name: "events.subject", Type: filter.Text},
+	"type":        {Name: "events.type", Type: filter.Text},
+	"location":    {Name: "events.location", Type: filter.Text},
+	"description": {Name: "events.description", Type: filter.Text},
+	"is_whole":    {Name: "events.is_whole", Type: filter.Bool},
+	"start_date":  {Name: "events.start_date", Type: filter.Time},
+	"end_date":    {Name: "events.end_date", Type: filter.Time},
+	"account_id":  {Name: "events.account_id", Type: filter.UUID},
+	"created_at":  {Name: "events.created_at", Type: filter.Time},
+	"updated_at":  {Name: "events.updated_at", Type: filter.Time},
+}
+
 type storage struct {
 	db *gorm.DB
 }
@@ -111,6 +126,14 @@ func (s *storage) GetByList(input *model.Base) (output []*model.Table, err error
 	}
 
 	query.Where(filter)
+	if input.Where != nil {
+		where, err := input.Where.Clause(filterFields)
+		if err != nil {
+			return nil, err
+		}
+
+		query.Where(where)
+	}
 
 	err = query.Order("created_at desc").Find(&output).Error
 	if err != nil {
@@ -13,6 +13,7 @@ import (
 type Entity interface {
 	WithTrx(trx *gorm.DB) Entity
 	Create(input *model.Base) (err error)
+	CreateAll(input []*model.Base) (err error)
 	GetByList(input *model.Base) (quantity int64, output []*model.Table, err error)
 	GetBySingle(input *model.Base) (output *model.Table, err error)
 	GetByQuantity(input *model.Base) (quantity int64, err error)
@@ -57,6 +58,30 @@ func (s *storage) Create(input *model.Base) (err error) {
 	return nil
 }
 
+// CreateAll inserts the records in batches of 100 rows.
+func (s *storage) CreateAll(input []*model.Base) (err error) {
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	var data []*model.Table
+	err = json.Unmarshal(marshal, &data)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	err = s.db.Model(&model.Table{}).Omit(clause.Associations).CreateInBatches(data, 100).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
 func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.Table, err error) {
 	query := s.db.Model(&model.Table{}).Preload(clause.Associations)
 	if input.HistoricalRecordID != nil {
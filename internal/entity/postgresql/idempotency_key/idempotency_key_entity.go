@@ -0,0 +1,139 @@
+package idempotency_key
+
+import (
+	"encoding/json"
+
+	model "crm/internal/entity/postgresql/db/idempotency_keys"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+)
+
+type Entity interface {
+	WithTrx(trx *gorm.DB) Entity
+	Create(input *model.Base) (err error)
+	GetBySingle(input *model.Base) (output *model.Table, err error)
+	Delete(input *model.Base) (err error)
+	Update(input *model.Base) (err error)
+}
+
+type storage struct {
+	db *gorm.DB
+}
+
+func Init(db *gorm.DB) Entity {
+	return &storage{
+		db: db,
+	}
+}
+
+func (s *storage) WithTrx(trx *gorm.DB) Entity {
+	return &storage{
+		db: trx,
+	}
+}
+
+func (s *storage) Create(input *model.Base) (err error) {
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	data := &model.Table{}
+	err = json.Unmarshal(marshal, data)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	err = s.db.Model(&model.Table{}).Create(&data).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *storage) GetBySingle(input *model.Base) (output *model.Table, err error) {
+	query := s.db.Model(&model.Table{})
+	if input.IdempotencyKeyID != nil {
+		query.Where("idempotency_key_id = ?", input.IdempotencyKeyID)
+	}
+
+	if input.CompanyID != nil {
+		query.Where("company_id = ?", input.CompanyID)
+	}
+
+	if input.UserID != nil {
+		query.Where("user_id = ?", input.UserID)
+	}
+
+	if input.Key != nil {
+		query.Where("key = ?", input.Key)
+	}
+
+	err = query.First(&output).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *storage) Update(input *model.Base) (err error) {
+	query := s.db.Model(&model.Table{})
+	data := map[string]any{}
+
+	if input.StatusCode != nil {
+		data["status_code"] = input.StatusCode
+	}
+
+	if input.ResponseBody != nil {
+		data["response_body"] = input.ResponseBody
+	}
+
+	if input.IdempotencyKeyID != nil {
+		query.Where("idempotency_key_id = ?", input.IdempotencyKeyID)
+	}
+
+	err = query.Updates(data).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *storage) Delete(input *model.Base) (err error) {
+	query := s.db.Model(&model.Table{})
+	if input.IdempotencyKeyID != nil {
+		query.Where("idempotency_key_id = ?", input.IdempotencyKeyID)
+	}
+
+	if input.CompanyID != nil {
+		query.Where("company_id = ?", input.CompanyID)
+	}
+
+	if input.UserID != nil {
+		query.Where("user_id = ?", input.UserID)
+	}
+
+	if input.Key != nil {
+		query.Where("key = ?", input.Key)
+	}
+
+	if input.ExpiredBefore != nil {
+		query.Where("created_at < ?", input.ExpiredBefore)
+	}
+
+	err = query.Delete(&model.Table{}).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package import_mapping
+
+import (
+	"encoding/json"
+
+	model "crm/internal/entity/postgresql/db/import_mappings"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+)
+
+type Entity interface {
+	WithTrx(trx *gorm.DB) Entity
+	Create(input *model.Base) (err error)
+	GetByList(input *model.Base) (output []*model.Table, err error)
+	GetBySingle(input *model.Base) (output *model.Table, err error)
+	Delete(input *model.Base) (err error)
+}
+
+type storage struct {
+	db *gorm.DB
+}
+
+func Init(db *gorm.DB) Entity {
+	return &storage{
+		db: db,
+	}
+}
+
+func (s *storage) WithTrx(trx *gorm.DB) Entity {
+	return &storage{
+		db: trx,
+	}
+}
+
+func (s *storage) Create(input *model.Base) (err error) {
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	data := &model.Table{}
+	err = json.Unmarshal(marshal, data)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	err = s.db.Model(&model.Table{}).Create(&data).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *storage) GetByList(input *model.Base) (output []*model.Table, err error) {
+	query := s.db.Model(&model.Table{})
+	if input.CompanyID != nil {
+		query.Where("company_id = ?", input.CompanyID)
+	}
+
+	if input.Entity != nil {
+		query.Where("entity = ?", input.Entity)
+	}
+
+	err = query.Order("entity, name").Find(&output).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *storage) GetBySingle(input *model.Base) (output *model.Table, err error) {
+	query := s.db.Model(&model.Table{})
+	if input.ImportMappingID != nil {
+		query.Where("import_mapping_id = ?", input.ImportMappingID)
+	}
+
+	if input.CompanyID != nil {
+		query.Where("company_id = ?", input.CompanyID)
+	}
+
+	if input.Entity != nil {
+		query.Where("entity = ?", input.Entity)
+	}
+
+	if input.Name != nil {
+		query.Where("name = ?", input.Name)
+	}
+
+	err = query.First(&output).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *storage) Delete(input *model.Base) (err error) {
+	query := s.db.Model(&model.Table{})
+	if input.ImportMappingID != nil {
+		query.Where("import_mapping_id = ?", input.ImportMappingID)
+	}
+
+	if input.CompanyID != nil {
+		query.Where("company_id = ?", input.CompanyID)
+	}
+
+	err = query.Delete(&model.Table{}).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
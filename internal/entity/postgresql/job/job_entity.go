@@ -0,0 +1,131 @@
+package job
+
+import (
+	"encoding/json"
+
+	model "crm/internal/entity/postgresql/db/jobs"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+)
+
+type Entity interface {
+	WithTrx(trx *gorm.DB) Entity
+	Create(input *model.Base) (err error)
+	GetBySingle(input *model.Base) (output *model.Table, err error)
+	Update(input *model.Base) (err error)
+}
+
+type storage struct {
+	db *gorm.DB
+}
+
+func Init(db *gorm.DB) Entity {
+	return &storage{
+		db: db,
+	}
+}
+
+func (s *storage) WithTrx(trx *gorm.DB) Entity {
+	return &storage{
+		db: trx,
+	}
+}
+
+func (s *storage) Create(input *model.Base) (err error) {
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	data := &model.Table{}
+	err = json.Unmarshal(marshal, data)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	err = s.db.Model(&model.Table{}).Create(&data).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *storage) GetBySingle(input *model.Base) (output *model.Table, err error) {
+	query := s.db.Model(&model.Table{})
+	if input.JobID != nil {
+		query.Where("job_id = ?", input.JobID)
+	}
+
+	if input.CreatedBy != nil {
+		query.Where("created_by = ?", input.CreatedBy)
+	}
+
+	if input.Kind != nil {
+		query.Where("kind = ?", input.Kind)
+	}
+
+	err = query.First(&output).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *storage) Update(input *model.Base) (err error) {
+	query := s.db.Model(&model.Table{})
+	data := map[string]any{}
+
+	if input.Status != nil {
+		data["status"] = input.Status
+	}
+
+	if input.Inserted != nil {
+		data["inserted"] = input.Inserted
+	}
+
+	if input.Updated != nil {
+		data["updated"] = input.Updated
+	}
+
+	if input.Rejected != nil {
+		data["rejected"] = input.Rejected
+	}
+
+	if input.FileName != nil {
+		data["file_name"] = input.FileName
+	}
+
+	if input.ContentType != nil {
+		data["content_type"] = input.ContentType
+	}
+
+	if input.File != nil {
+		data["file"] = input.File
+	}
+
+	if input.Error != nil {
+		data["error"] = input.Error
+	}
+
+	if input.FinishedAt != nil {
+		data["finished_at"] = input.FinishedAt
+	}
+
+	if input.JobID != nil {
+		query.Where("job_id = ?", input.JobID)
+	}
+
+	err = query.Updates(data).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
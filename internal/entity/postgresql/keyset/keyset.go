@@ -0,0 +1,369 @@
+package keyset
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/pkg/util/hash"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Count modes of a list.
+const (
+	Exact       = "exact"
+	Approximate = "approximate"
+	None        = "none"
+)
+
+// cursor is the decoded form of the opaque cursor handed to clients.
+type cursor struct {
+	// 排序指紋,排序變更後游標失效
+	Order string
+	// 邊界資料列的排序值
+	Values []any
+	// 是否往前一頁
+	Backward bool
+}
+
+// Count counts the rows of query in the requested mode, -1 when they are not counted.
+// It must be called before Find because Find narrows the query.
+func Count(query *gorm.DB, mode string) (quantity int64, err error) {
+	switch mode {
+	case "", None:
+		return -1, nil
+	case Exact:
+		err = query.Count(&quantity).Error
+		return quantity, err
+	case Approximate:
+		return estimate(query)
+	}
+
+	return 0, fmt.Errorf("%w: count must be exact, approximate or none", page.ErrInvalid)
+}
+
+// Find reads the page after (or before) the cursor, ordered by orderBy whose last column must be the primary key.
+func Find[T any](query *gorm.DB, orderBy clause.OrderBy, input page.Cursor, limit int64) (output []*T, cursors page.Cursors, err error) {
+	if err = query.Statement.Parse(query.Statement.Model); err != nil {
+		return nil, cursors, err
+	}
+
+	fields := make([]*field, len(orderBy.Columns))
+	for i, column := range orderBy.Columns {
+		fields[i], err = lookUp(query.Statement.Schema, column.Column.Name)
+		if err != nil {
+			return nil, cursors, err
+		}
+	}
+
+	order := fingerprint(orderBy)
+	current := &cursor{}
+	if input.Cursor != "" {
+		current, err = decode(input.Cursor, order, fields)
+		if err != nil {
+			return nil, cursors, fmt.Errorf("%w: cursor does not belong to this list", page.ErrInvalid)
+		}
+
+		query = query.Where(after(orderBy, current.Values, current.Backward))
+	}
+
+	if current.Backward {
+		orderBy = reverse(orderBy)
+	}
+
+	err = query.Order(nulls(orderBy)).Limit(int(limit) + 1).Find(&output).Error
+	if err != nil {
+		return nil, cursors, err
+	}
+
+	more := int64(len(output)) > limit
+	if more {
+		output = output[:limit]
+	}
+
+	if current.Backward {
+		slices.Reverse(output)
+	}
+
+	if len(output) == 0 {
+		return output, cursors, nil
+	}
+
+	if more || current.Backward {
+		cursors.NextCursor, err = encode(fields, output[len(output)-1], order, false)
+		if err != nil {
+			return nil, cursors, err
+		}
+	}
+
+	if input.Cursor != "" && (more || !current.Backward) {
+		cursors.PrevCursor, err = encode(fields, output[0], order, true)
+		if err != nil {
+			return nil, cursors, err
+		}
+	}
+
+	return output, cursors, nil
+}
+
+// after is the keyset condition of the rows following values in the order of orderBy, or preceding them when backward.
+// Postgres puts nulls last when ascending and first when descending.
+func after(orderBy clause.OrderBy, values []any, backward bool) clause.Expression {
+	var or []clause.Expression
+	var equal []clause.Expression
+	for i, column := range orderBy.Columns {
+		name := column.Column.Name
+		value := values[i]
+		var greater clause.Expression
+		switch desc := column.Desc != backward; {
+		case !desc && value == nil:
+			greater = nil
+		case !desc:
+			greater = clause.Expr{SQL: "(" + name + " > ? or " + name + " is null)", Vars: []any{value}}
+		case value == nil:
+			greater = clause.Expr{SQL: name + " is not null"}
+		default:
+			greater = clause.Expr{SQL: name + " < ?", Vars: []any{value}}
+		}
+
+		if greater != nil {
+			or = append(or, clause.And(append(slices.Clone(equal), greater)...))
+		}
+
+		if value == nil {
+			equal = append(equal, clause.Expr{SQL: name + " is null"})
+		} else {
+			equal = append(equal, clause.Expr{SQL: name + " = ?", Vars: []any{value}})
+		}
+	}
+
+	if len(or) == 0 {
+		return clause.Expr{SQL: "false"}
+	}
+
+	return clause.Or(or...)
+}
+
+func reverse(orderBy clause.OrderBy) clause.OrderBy {
+	reversed := clause.OrderBy{Columns: slices.Clone(orderBy.Columns)}
+	for i := range reversed.Columns {
+		reversed.Columns[i].Desc = !reversed.Columns[i].Desc
+	}
+
+	return reversed
+}
+
+// nulls spells out the null ordering after assumes.
+func nulls(orderBy clause.OrderBy) clause.OrderBy {
+	ordered := clause.OrderBy{Columns: slices.Clone(orderBy.Columns)}
+	for i, column := range ordered.Columns {
+		if column.Desc {
+			ordered.Columns[i].Column.Name += " desc nulls first"
+		} else {
+			ordered.Columns[i].Column.Name += " nulls last"
+		}
+
+		ordered.Columns[i].Desc = false
+	}
+
+	return ordered
+}
+
+func fingerprint(orderBy clause.OrderBy) string {
+	parts := make([]string, len(orderBy.Columns))
+	for i, column := range orderBy.Columns {
+		parts[i] = fmt.Sprintf("%s %t", column.Column.Name, column.Desc)
+	}
+
+	return hash.Sha256(strings.Join(parts, ","))[:16]
+}
+
+func encode(fields []*field, row any, order string, backward bool) (string, error) {
+	values := make([]any, len(fields))
+	for i, field := range fields {
+		values[i] = field.valueOf(reflect.ValueOf(row))
+	}
+
+	marshal, err := json.Marshal(&struct {
+		Order    string `json:"o"`
+		Values   []any  `json:"v"`
+		Backward bool   `json:"b,omitempty"`
+	}{Order: order, Values: values, Backward: backward})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(marshal), nil
+}
+
+// decode reads a cursor of the list ordered as order, typing its values after fields.
+func decode(s string, order string, fields []*field) (*cursor, error) {
+	marshal, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := &struct {
+		Order    string            `json:"o"`
+		Values   []json.RawMessage `json:"v"`
+		Backward bool              `json:"b,omitempty"`
+	}{}
+	if err = json.Unmarshal(marshal, raw); err != nil {
+		return nil, err
+	}
+
+	if raw.Order != order || len(raw.Values) != len(fields) {
+		return nil, errors.New("keyset: cursor of another order")
+	}
+
+	output := &cursor{Order: raw.Order, Values: make([]any, len(fields)), Backward: raw.Backward}
+	for i, field := range fields {
+		if string(raw.Values[i]) == "null" {
+			continue
+		}
+
+		value := reflect.New(field.Field.IndirectFieldType)
+		if field.Key != "" {
+			value = reflect.New(reflect.TypeFor[any]())
+		}
+
+		if err = json.Unmarshal(raw.Values[i], value.Interface()); err != nil {
+			return nil, err
+		}
+
+		output.Values[i] = value.Elem().Interface()
+	}
+
+	return output, nil
+}
+
+// field is an order column, either table.column, "Relation".column of a joined relation
+// or a key of a JSONB column of the table (see filter.Custom).
+type field struct {
+	*schema.Field
+	Relation *schema.Relationship
+	// JSONB 欄位的鍵值及型別
+	Key, Type string
+}
+
+func lookUp(s *schema.Schema, column string) (*field, error) {
+	output := &field{}
+	if document, key, typ, ok := filter.CustomKey(column); ok {
+		_, name, _ := strings.Cut(document, ".")
+		output.Field, output.Key, output.Type = s.LookUpField(name), key, typ
+		if output.Field == nil {
+			return nil, fmt.Errorf("keyset: column %s is not a field of %s", document, s.Name)
+		}
+
+		return output, nil
+	}
+
+	prefix, name, _ := strings.Cut(column, ".")
+	if relation, ok := s.Relationships.Relations[strings.Trim(prefix, `"`)]; ok && strings.HasPrefix(prefix, `"`) {
+		output.Relation = relation
+		s = relation.FieldSchema
+	}
+
+	output.Field = s.LookUpField(name)
+	if output.Field == nil {
+		return nil, fmt.Errorf("keyset: column %s is not a field of %s", column, s.Name)
+	}
+
+	return output, nil
+}
+
+func (f *field) valueOf(row reflect.Value) any {
+	if f.Relation != nil {
+		row = reflect.Indirect(f.Relation.Field.ReflectValueOf(context.Background(), row))
+		if !row.IsValid() {
+			return nil
+		}
+	}
+
+	value := reflect.ValueOf(f.Field.ReflectValueOf(context.Background(), row).Interface())
+	if f.Key != "" {
+		return f.custom(value)
+	}
+
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+
+		value = value.Elem()
+	}
+
+	return value.Interface()
+}
+
+// custom returns the value of the key of a JSONB column, null when it does not have the type of the column
+// since the column reads such a value as null.
+func (f *field) custom(value reflect.Value) any {
+	document := map[string]any{}
+	if raw, ok := value.Interface().(json.RawMessage); !ok || json.Unmarshal(raw, &document) != nil {
+		return nil
+	}
+
+	switch output := document[f.Key].(type) {
+	case float64:
+		if f.Type == filter.Number {
+			return output
+		}
+	case bool:
+		if f.Type == filter.Bool {
+			return output
+		}
+	case string:
+		if f.Type == filter.Text {
+			return output
+		}
+
+		if _, err := time.Parse(time.DateOnly, output); err == nil && f.Type == filter.Time {
+			return output
+		}
+	}
+
+	return nil
+}
+
+// estimate reads the row estimate of the planner instead of counting.
+func estimate(query *gorm.DB) (int64, error) {
+	statement := query.Session(&gorm.Session{DryRun: true}).Count(new(int64)).Statement
+	var plan string
+	err := query.Session(&gorm.Session{NewDB: true}).
+		Raw("explain (format json) "+statement.SQL.String(), statement.Vars...).Row().Scan(&plan)
+	if err != nil {
+		return 0, err
+	}
+
+	type node struct {
+		Type  string  `json:"Node Type"`
+		Rows  float64 `json:"Plan Rows"`
+		Plans []node  `json:"Plans"`
+	}
+	var plans []struct {
+		Plan node `json:"Plan"`
+	}
+	if err = json.Unmarshal([]byte(plan), &plans); err != nil || len(plans) == 0 {
+		return 0, err
+	}
+
+	// count(*) is an aggregate over the rows being estimated
+	root := plans[0].Plan
+	for root.Type == "Aggregate" && len(root.Plans) > 0 {
+		root = root.Plans[0]
+	}
+
+	return int64(root.Rows), nil
+}
@@ -0,0 +1,171 @@
+package keyset
+
+import (
+	"encoding/base64"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+type row struct {
+	ID        string `gorm:"column:id;primaryKey"`
+	Name      *string
+	Amount    float64
+	CreatedAt time.Time
+}
+
+var orderBy = clause.OrderBy{Columns: []clause.OrderByColumn{
+	{Column: clause.Column{Name: "rows.created_at", Raw: true}, Desc: true},
+	{Column: clause.Column{Name: "rows.name", Raw: true}},
+	{Column: clause.Column{Name: "rows.amount", Raw: true}},
+	{Column: clause.Column{Name: "rows.id", Raw: true}},
+}}
+
+func fieldsOf(t *testing.T) []*field {
+	t.Helper()
+	s, err := schema.Parse(&row{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("parse schema: %v", err)
+	}
+
+	fields := make([]*field, len(orderBy.Columns))
+	for i, column := range orderBy.Columns {
+		fields[i], err = lookUp(s, column.Column.Name)
+		if err != nil {
+			t.Fatalf("lookUp(%s): %v", column.Column.Name, err)
+		}
+	}
+
+	return fields
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	fields := fieldsOf(t)
+	order := fingerprint(orderBy)
+	name := "Acme"
+	createdAt := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		row      *row
+		backward bool
+		want     []any
+	}{
+		{"forward", &row{ID: "a1", Name: &name, Amount: 12.5, CreatedAt: createdAt}, false, []any{createdAt, "Acme", 12.5, "a1"}},
+		{"backward", &row{ID: "a2", Name: &name, Amount: 0, CreatedAt: createdAt}, true, []any{createdAt, "Acme", 0.0, "a2"}},
+		{"null value", &row{ID: "a3", Amount: 3, CreatedAt: createdAt}, false, []any{createdAt, nil, 3.0, "a3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := encode(fields, tt.row, order, tt.backward)
+			if err != nil {
+				t.Fatalf("encode() error = %v", err)
+			}
+
+			got, err := decode(encoded, order, fields)
+			if err != nil {
+				t.Fatalf("decode() error = %v", err)
+			}
+
+			if got.Backward != tt.backward || !reflect.DeepEqual(got.Values, tt.want) {
+				t.Errorf("decode() = %v %v, want %v %v", got.Values, got.Backward, tt.want, tt.backward)
+			}
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	fields := fieldsOf(t)
+	order := fingerprint(orderBy)
+	valid, err := encode(fields, &row{ID: "a1", CreatedAt: time.Now()}, order, false)
+	if err != nil {
+		t.Fatalf("encode() error = %v", err)
+	}
+
+	raw := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+	tests := []struct {
+		name   string
+		cursor string
+		order  string
+	}{
+		{"not base64", "!!!", order},
+		{"not json", raw("cursor"), order},
+		{"another order", valid, fingerprint(reverse(orderBy))},
+		{"too few values", raw(`{"o":"` + order + `","v":["a1"]}`), order},
+		{"value of the wrong type", raw(`{"o":"` + order + `","v":["2024-03-01T08:30:00Z",null,"twelve","a1"]}`), order},
+		{"bad time", raw(`{"o":"` + order + `","v":["yesterday",null,1,"a1"]}`), order},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decode(tt.cursor, tt.order, fields); err == nil {
+				t.Error("decode() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestAfter(t *testing.T) {
+	two := clause.OrderBy{Columns: []clause.OrderByColumn{
+		{Column: clause.Column{Name: "a", Raw: true}},
+		{Column: clause.Column{Name: "id", Raw: true}},
+	}}
+	desc := reverse(two)
+	tests := []struct {
+		name     string
+		orderBy  clause.OrderBy
+		values   []any
+		backward bool
+		want     clause.Expression
+	}{
+		{"ascending", two, []any{1, "x"}, false, clause.Or(
+			clause.And(clause.Expr{SQL: "(a > ? or a is null)", Vars: []any{1}}),
+			clause.And(clause.Expr{SQL: "a = ?", Vars: []any{1}}, clause.Expr{SQL: "(id > ? or id is null)", Vars: []any{"x"}}),
+		)},
+		{"descending", desc, []any{1, "x"}, false, clause.Or(
+			clause.And(clause.Expr{SQL: "a < ?", Vars: []any{1}}),
+			clause.And(clause.Expr{SQL: "a = ?", Vars: []any{1}}, clause.Expr{SQL: "id < ?", Vars: []any{"x"}}),
+		)},
+		{"backward flips the comparison", two, []any{1, "x"}, true, clause.Or(
+			clause.And(clause.Expr{SQL: "a < ?", Vars: []any{1}}),
+			clause.And(clause.Expr{SQL: "a = ?", Vars: []any{1}}, clause.Expr{SQL: "id < ?", Vars: []any{"x"}}),
+		)},
+		{"null ascending is last", two, []any{nil, "x"}, false, clause.Or(
+			clause.And(clause.Expr{SQL: "a is null"}, clause.Expr{SQL: "(id > ? or id is null)", Vars: []any{"x"}}),
+		)},
+		{"null descending is first", desc, []any{nil, "x"}, false, clause.Or(
+			clause.And(clause.Expr{SQL: "a is not null"}),
+			clause.And(clause.Expr{SQL: "a is null"}, clause.Expr{SQL: "id < ?", Vars: []any{"x"}}),
+		)},
+		{"nothing after nulls", two, []any{nil, nil}, false, clause.Expr{SQL: "false"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := after(tt.orderBy, tt.values, tt.backward)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("after() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNulls(t *testing.T) {
+	got := nulls(orderBy)
+	want := []string{"rows.created_at desc nulls first", "rows.name nulls last", "rows.amount nulls last", "rows.id nulls last"}
+	for i, column := range got.Columns {
+		if column.Column.Name != want[i] || column.Desc {
+			t.Errorf("nulls() column %d = %s %t, want %s", i, column.Column.Name, column.Desc, want[i])
+		}
+	}
+
+	if orderBy.Columns[0].Column.Name != "rows.created_at" {
+		t.Error("nulls() changed the order it was given")
+	}
+}
@@ -0,0 +1,137 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"crm/internal/entity/postgresql/db/account_contacts"
+	"crm/internal/entity/postgresql/db/accounts"
+	"crm/internal/entity/postgresql/db/campaigns"
+	"crm/internal/entity/postgresql/db/contacts"
+	"crm/internal/entity/postgresql/db/contracts"
+	"crm/internal/entity/postgresql/db/event_contacts"
+	"crm/internal/entity/postgresql/db/event_user_attendees"
+	"crm/internal/entity/postgresql/db/event_user_mains"
+	"crm/internal/entity/postgresql/db/events"
+	"crm/internal/entity/postgresql/db/historical_records"
+	"crm/internal/entity/postgresql/db/industries"
+	"crm/internal/entity/postgresql/db/leads"
+	model "crm/internal/entity/postgresql/db/loaders"
+	"crm/internal/entity/postgresql/db/opportunities"
+	"crm/internal/entity/postgresql/db/opportunity_campaigns"
+	"crm/internal/entity/postgresql/db/order_products"
+	"crm/internal/entity/postgresql/db/orders"
+	"crm/internal/entity/postgresql/db/products"
+	"crm/internal/entity/postgresql/db/quote_products"
+	"crm/internal/entity/postgresql/db/quotes"
+	"crm/internal/entity/postgresql/db/users"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+)
+
+type Entity interface {
+	WithTrx(trx *gorm.DB) Entity
+	GetByList(input *model.Base) (output []map[string]any, err error)
+}
+
+// target is a table whose records are loaded in batches by the values of one of its columns.
+type target struct {
+	// 資料表結構檔的切片
+	records func() any
+	// 排序
+	order string
+	// 不讀取的欄位
+	omit []string
+	// 是否依公司區分
+	company bool
+}
+
+var targets = map[string]target{
+	"accounts":              {records: func() any { return &[]*accounts.Table{} }, order: "created_at desc"},
+	"contacts":              {records: func() any { return &[]*contacts.Table{} }, order: "created_at desc"},
+	"leads":                 {records: func() any { return &[]*leads.Table{} }, order: "created_at desc"},
+	"opportunities":         {records: func() any { return &[]*opportunities.Table{} }, order: "created_at desc"},
+	"quotes":                {records: func() any { return &[]*quotes.Table{} }, order: "created_at desc"},
+	"quote_products":        {records: func() any { return &[]*quote_products.Table{} }, order: "created_at"},
+	"contracts":             {records: func() any { return &[]*contracts.Table{} }, order: "created_at desc"},
+	"orders":                {records: func() any { return &[]*orders.Table{} }, order: "created_at desc"},
+	"order_products":        {records: func() any { return &[]*order_products.Table{} }, order: "created_at"},
+	"events":                {records: func() any { return &[]*events.Table{} }, order: "start_date desc"},
+	"products":              {records: func() any { return &[]*products.Table{} }, order: "created_at desc"},
+	"campaigns":             {records: func() any { return &[]*campaigns.Table{} }, order: "created_at desc"},
+	"industries":            {records: func() any { return &[]*industries.Table{} }, order: "name"},
+	"users":                 {records: func() any { return &[]*users.Table{} }, order: "name", omit: []string{"password"}, company: true},
+	"historical_records":    {records: func() any { return &[]*historical_records.Table{} }, order: "modified_at desc"},
+	"account_contacts":      {records: func() any { return &[]*account_contacts.Table{} }, order: "created_at"},
+	"event_contacts":        {records: func() any { return &[]*event_contacts.Table{} }, order: "created_at"},
+	"event_user_mains":      {records: func() any { return &[]*event_user_mains.Table{} }, order: "created_at"},
+	"event_user_attendees":  {records: func() any { return &[]*event_user_attendees.Table{} }, order: "created_at"},
+	"opportunity_campaigns": {records: func() any { return &[]*opportunity_campaigns.Table{} }, order: "created_at"},
+}
+
+type storage struct {
+	db *gorm.DB
+}
+
+func Init(db *gorm.DB) Entity {
+	return &storage{
+		db: db,
+	}
+}
+
+func (s *storage) WithTrx(trx *gorm.DB) Entity {
+	return &storage{
+		db: trx,
+	}
+}
+
+// GetByList returns the records of the table of input whose column holds one of the keys, by their json names.
+// Deleted records are skipped.
+func (s *storage) GetByList(input *model.Base) (output []map[string]any, err error) {
+	t, ok := targets[*input.Table]
+	if !ok {
+		return nil, fmt.Errorf("loader: unknown table %s", *input.Table)
+	}
+
+	if len(input.Keys) == 0 {
+		return output, nil
+	}
+
+	records := t.records()
+	query := s.db.Where(*input.Column+" in ?", input.Keys).Order(t.order)
+	if len(t.omit) > 0 {
+		query = query.Omit(t.omit...)
+	}
+
+	if t.company && input.CompanyID != nil {
+		query = query.Where("company_id = ?", input.CompanyID)
+	}
+
+	err = query.Find(records).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	marshal, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+
+	// 數字保留原本的精度
+	decoder := json.NewDecoder(bytes.NewReader(marshal))
+	decoder.UseNumber()
+	if err = decoder.Decode(&output); err != nil {
+		return nil, err
+	}
+
+	for _, record := range output {
+		for _, name := range t.omit {
+			delete(record, name)
+		}
+	}
+
+	return output, nil
+}
@@ -0,0 +1,82 @@
+package lookup
+
+import (
+	"fmt"
+
+	model "crm/internal/entity/postgresql/db/lookups"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+)
+
+type Entity interface {
+	WithTrx(trx *gorm.DB) Entity
+	GetByList(input *model.Base) (output []*model.Table, err error)
+}
+
+// target is a table whose records are looked up by a name column.
+type target struct {
+	// 資料表
+	table string
+	// 主鍵
+	primaryKey string
+	// 名稱欄位
+	column string
+	// 是否有刪除時間
+	softDelete bool
+	// 是否依公司區分
+	company bool
+}
+
+var targets = map[string]target{
+	"industry":      {table: "industries", primaryKey: "industry_id", column: "name"},
+	"account":       {table: "accounts", primaryKey: "account_id", column: "name", softDelete: true},
+	"salesperson":   {table: "users", primaryKey: "user_id", column: "user_name", softDelete: true, company: true},
+	"contact_email": {table: "contacts", primaryKey: "contact_id", column: "email", softDelete: true},
+}
+
+type storage struct {
+	db *gorm.DB
+}
+
+func Init(db *gorm.DB) Entity {
+	return &storage{
+		db: db,
+	}
+}
+
+func (s *storage) WithTrx(trx *gorm.DB) Entity {
+	return &storage{
+		db: trx,
+	}
+}
+
+func (s *storage) GetByList(input *model.Base) (output []*model.Table, err error) {
+	t, ok := targets[*input.Kind]
+	if !ok {
+		return nil, fmt.Errorf("lookup: unknown kind %s", *input.Kind)
+	}
+
+	if len(input.Names) == 0 {
+		return output, nil
+	}
+
+	query := s.db.Table(t.table).
+		Select(t.primaryKey+"::text as id, "+t.column+" as name").
+		Where(t.column+" in ?", input.Names)
+	if t.softDelete {
+		query = query.Where("deleted_at is null")
+	}
+
+	if t.company && input.CompanyID != nil {
+		query = query.Where("company_id = ?", input.CompanyID)
+	}
+
+	err = query.Scan(&output).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -1,9 +1,15 @@
 package opportunity
 
 import (
+	"cmp"
 	"encoding/json"
 
 	model "crm/internal/entity/postgresql/db/opportunities"
+	"crm/internal/entity/postgresql/keyset"
+	"crm/internal/entity/postgresql/relation"
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/opportunities"
+	"crm/internal/interactor/models/page"
 	"crm/internal/interactor/pkg/util/log"
 
 	"gorm.io/gorm"
@@ -13,7 +19,7 @@ import (
 type Entity interface {
 	WithTrx(trx *gorm.DB) Entity
 	Create(input *model.Base) (err error)
-	GetByList(input *model.Base) (quantity int64, output []*model.Table, err error)
+	GetByList(input *model.Base) (quantity int64, output []*model.Table, cursors page.Cursors, err error)
 	GetByListNoPagination(input *model.Base) (output []*model.Table, err error)
 	GetBySingle(input *model.Base) (output *model.Table, err error)
 	GetByQuantity(input *model.Base) (quantity int64, err error)
@@ -21,6 +27,22 @@ type Entity interface {
 	Update(input *model.Base) (err error)
 }
 
+// filterFields are the fields the list can be filtered on.
+var filterFields = filter.Fields{
+	"name":              {Name: "opportunities.name", Type: filter.Text},
+	"stage":             {Name: "opportunities.stage", Type: filter.Text},
+	"forecast_category": {Name: "opportunities.forecast_category", Type: filter.Text},
+	"close_date":        {Name: "opportunities.close_date", Type: filter.Time},
+	"amount":            {Name: "opportunities.amount", Type: filter.Number},
+	"lead_id":           {Name: "opportunities.lead_id", Type: filter.UUID},
+	"account_id":        {Name: "opportunities.account_id", Type: filter.UUID},
+	"salesperson_id":    {Name: "opportunities.salesperson_id", Type: filter.UUID},
+	"created_at":        {Name: "opportunities.created_at", Type: filter.Time},
+	"updated_at":        {Name: "opportunities.updated_at", Type: filter.Time},
+	"account_name":      {Name: `"Accounts".name`, Type: filter.Text},
+	"salesperson_name":  {Name: `"Salespeople".name`, Type: filter.Text},
+}
+
 type storage struct {
 	db *gorm.DB
 }
@@ -55,6 +77,11 @@ func (s *storage) Create(input *model.Base) (err error) {
 		data.LeadID = nil
 	}
 
+	// 未帶入自訂欄位時存入空物件
+	if data.CustomFields == nil {
+		data.CustomFields = json.RawMessage("{}")
+	}
+
 	err = s.db.Model(&model.Table{}).Omit(clause.Associations).Create(&data).Error
 	if err != nil {
 		log.Error(err)
@@ -64,21 +91,22 @@ func (s *storage) Create(input *model.Base) (err error) {
 	return nil
 }
 
-func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.Table, err error) {
-	query := s.db.Model(&model.Table{}).Count(&quantity).Joins("Salespeople").Joins("Accounts").Preload(clause.Associations)
+func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.Table, cursors page.Cursors, err error) {
+	query := s.db.Model(&model.Table{})
 
 	if input.OpportunityID != nil {
 		query.Where("opportunity_id = ?", input.OpportunityID)
 	}
 
-	if input.Sort.Field != "" && input.Sort.Direction != "" {
-		if input.Sort.Field == "salesperson_name" && input.Sort.Direction != "" {
-			query.Order(`"Salespeople".name` + " " + input.Sort.Direction)
-		} else if input.Sort.Field == "account_name" && input.Sort.Direction != "" {
-			query.Order(`"Accounts".name` + " " + input.Sort.Direction)
-		} else {
-			query.Order(input.Sort.Field + " " + input.Sort.Direction)
-		}
+	columns := filterFields.With(input.CustomColumns)
+	orderBy, err := input.Sort.Clause(columns.Columns(), "opportunities.created_at", "opportunities.opportunity_id")
+	if err != nil {
+		return 0, nil, cursors, err
+	}
+
+	query, err = relation.Load(query, input.Projection, opportunities.Relations, orderBy)
+	if err != nil {
+		return 0, nil, cursors, err
 	}
 
 	// filter
@@ -114,15 +142,45 @@ func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.
 	}
 
 	query.Where(filter)
+	if input.Where != nil {
+		where, err := input.Where.Clause(columns)
+		if err != nil {
+			return 0, nil, cursors, err
+		}
+
+		query.Where(where)
+	}
 
-	err = query.Count(&quantity).Offset(int((input.Page - 1) * input.Limit)).
-		Limit(int(input.Limit)).Order("created_at desc").Find(&output).Error
+	if input.Page == 0 {
+		quantity, err = keyset.Count(query, input.Count)
+		if err != nil {
+			log.Error(err)
+			return 0, nil, cursors, err
+		}
+
+		output, cursors, err = keyset.Find[model.Table](query, orderBy, input.Cursor, input.Limit)
+		if err != nil {
+			log.Error(err)
+			return 0, nil, cursors, err
+		}
+
+		return quantity, output, cursors, nil
+	}
+
+	quantity, err = keyset.Count(query, cmp.Or(input.Count, keyset.Exact))
+	if err != nil {
+		log.Error(err)
+		return 0, nil, cursors, err
+	}
+
+	err = query.Offset(int((input.Page - 1) * input.Limit)).
+		Limit(int(input.Limit)).Order(orderBy).Find(&output).Error
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
-	return quantity, output, nil
+	return quantity, output, cursors, nil
 }
 
 func (s *storage) GetByListNoPagination(input *model.Base) (output []*model.Table, err error) {
@@ -216,6 +274,16 @@ func (s *storage) Update(input *model.Base) (err error) {
 		data["salesperson_id"] = input.SalespersonID
 	}
 
+	if input.CustomFields != nil {
+		customFields, err := json.Marshal(input.CustomFields)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		data["custom_fields"] = string(customFields)
+	}
+
 	if input.UpdatedBy != nil {
 		data["updated_by"] = input.UpdatedBy
 	}
@@ -1,9 +1,15 @@
 package order
 
 import (
+	"cmp"
 	"encoding/json"
 
 	model "crm/internal/entity/postgresql/db/orders"
+	"crm/internal/entity/postgresql/keyset"
+	"crm/internal/entity/postgresql/relation"
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/orders"
+	"crm/internal/interactor/models/page"
 	"crm/internal/interactor/pkg/util/log"
 
 	"gorm.io/gorm"
@@ -13,7 +19,7 @@ import (
 type Entity interface {
 	WithTrx(trx *gorm.DB) Entity
 	Create(input *model.Base) (err error)
-	GetByList(input *model.Base) (quantity int64, output []*model.Table, err error)
+	GetByList(input *model.Base) (quantity int64, output []*model.Table, cursors page.Cursors, err error)
 	GetByListNoPagination(input *model.Base) (output []*model.Table, err error)
 	GetBySingle(input *model.Base) (output *model.Table, err error)
 	GetByQuantity(input *model.Base) (quantity int64, err error)
@@ -21,6 +27,21 @@ type Entity interface {
 	Update(input *model.Base) (err error)
 }
 
+// filterFields are the fields the list can be filtered on.
+var filterFields = filter.Fields{
+	"code":          {Name: "orders.code", Type: filter.Text},
+	"status":        {Name: "orders.status", Type: filter.Text},
+	"description":   {Name: "orders.description", Type: filter.Text},
+	"start_date":    {Name: "orders.start_date", Type: filter.Time},
+	"activated_at":  {Name: "orders.activated_at", Type: filter.Time},
+	"account_id":    {Name: "orders.account_id", Type: filter.UUID},
+	"contract_id":   {Name: "orders.contract_id", Type: filter.UUID},
+	"created_at":    {Name: "orders.created_at", Type: filter.Time},
+	"updated_at":    {Name: "orders.updated_at", Type: filter.Time},
+	"account_name":  {Name: `"Accounts".name`, Type: filter.Text},
+	"contract_code": {Name: `"Contracts".code`, Type: filter.Text},
+}
+
 type storage struct {
 	db *gorm.DB
 }
@@ -60,8 +81,8 @@ func (s *storage) Create(input *model.Base) (err error) {
 	return nil
 }
 
-func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.Table, err error) {
-	query := s.db.Model(&model.Table{}).Count(&quantity).Joins("Accounts").Joins("Contracts").Preload(clause.Associations)
+func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.Table, cursors page.Cursors, err error) {
+	query := s.db.Model(&model.Table{})
 
 	if input.OrderID != nil {
 		query.Where("order_id = ?", input.OrderID)
@@ -71,14 +92,14 @@ func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.
 		query.Where("orders.contract_id = ?", input.ContractID)
 	}
 
-	if input.Sort.Field != "" && input.Sort.Direction != "" {
-		if input.Sort.Field == "account_name" && input.Sort.Direction != "" {
-			query.Order(`"Accounts".name` + " " + input.Sort.Direction)
-		} else if input.Sort.Field == "contract_code" && input.Sort.Direction != "" {
-			query.Order(`"Contracts".code` + " " + input.Sort.Direction)
-		} else {
-			query.Order(input.Sort.Field + " " + input.Sort.Direction)
-		}
+	orderBy, err := input.Sort.Clause(filterFields.Columns(), "orders.created_at", "orders.order_id")
+	if err != nil {
+		return 0, nil, cursors, err
+	}
+
+	query, err = relation.Load(query, input.Projection, orders.Relations, orderBy)
+	if err != nil {
+		return 0, nil, cursors, err
 	}
 
 	// filter
@@ -114,15 +135,45 @@ func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.
 	}
 
 	query.Where(filter)
+	if input.Where != nil {
+		where, err := input.Where.Clause(filterFields)
+		if err != nil {
+			return 0, nil, cursors, err
+		}
+
+		query.Where(where)
+	}
+
+	if input.Page == 0 {
+		quantity, err = keyset.Count(query, input.Count)
+		if err != nil {
+			log.Error(err)
+			return 0, nil, cursors, err
+		}
+
+		output, cursors, err = keyset.Find[model.Table](query, orderBy, input.Cursor, input.Limit)
+		if err != nil {
+			log.Error(err)
+			return 0, nil, cursors, err
+		}
+
+		return quantity, output, cursors, nil
+	}
+
+	quantity, err = keyset.Count(query, cmp.Or(input.Count, keyset.Exact))
+	if err != nil {
+		log.Error(err)
+		return 0, nil, cursors, err
+	}
 
-	err = query.Count(&quantity).Offset(int((input.Page - 1) * input.Limit)).
-		Limit(int(input.Limit)).Order("created_at desc").Find(&output).Error
+	err = query.Offset(int((input.Page - 1) * input.Limit)).
+		Limit(int(input.Limit)).Order(orderBy).Find(&output).Error
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
-	return quantity, output, nil
+	return quantity, output, cursors, nil
 }
 
 func (s *storage) GetByListNoPagination(input *model.Base) (output []*model.Table, err error) {
@@ -1,9 +1,15 @@
 package product
 
 import (
+	"cmp"
 	"encoding/json"
 
 	model "crm/internal/entity/postgresql/db/products"
+	"crm/internal/entity/postgresql/keyset"
+	"crm/internal/entity/postgresql/relation"
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/products"
 	"crm/internal/interactor/pkg/util/log"
 
 	"gorm.io/gorm"
@@ -13,13 +19,24 @@ import (
 type Entity interface {
 	WithTrx(trx *gorm.DB) Entity
 	Create(input *model.Base) (err error)
-	GetByList(input *model.Base) (quantity int64, output []*model.Table, err error)
+	GetByList(input *model.Base) (quantity int64, output []*model.Table, cursors page.Cursors, err error)
 	GetBySingle(input *model.Base) (output *model.Table, err error)
 	GetByQuantity(input *model.Base) (quantity int64, err error)
 	Delete(input *model.Base) (err error)
 	Update(input *model.Base) (err error)
 }
 
+// filterFields are the fields the list can be filtered on.
+var filterFields = filter.Fields{
+	"name":        {Name: "products.name", Type: filter.Text},
+	"code":        {Name: "products.code", Type: filter.Text},
+	"description": {Name: "products.description", Type: filter.Text},
+	"is_enable":   {Name: "products.is_enable", Type: filter.Bool},
+	"price":       {Name: "products.price", Type: filter.Number},
+	"created_at":  {Name: "products.created_at", Type: filter.Time},
+	"updated_at":  {Name: "products.updated_at", Type: filter.Time},
+}
+
 type storage struct {
 	db *gorm.DB
 }
@@ -59,50 +76,86 @@ func (s *storage) Create(input *model.Base) (err error) {
 	return nil
 }
 
-func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.Table, err error) {
-	query := s.db.Model(&model.Table{}).Preload(clause.Associations)
+func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.Table, cursors page.Cursors, err error) {
+	query := s.db.Model(&model.Table{})
 	if input.ProductID != nil {
-		query.Where("product_id = ?", input.ProductID)
+		query.Where("products.product_id = ?", input.ProductID)
 	}
 
-	if input.Sort.Field != "" && input.Sort.Direction != "" {
-		query.Order(input.Sort.Field + " " + input.Sort.Direction)
+	orderBy, err := input.Sort.Clause(filterFields.Columns(), "products.created_at", "products.product_id")
+	if err != nil {
+		return 0, nil, cursors, err
+	}
+
+	query, err = relation.Load(query, input.Projection, products.Relations, orderBy)
+	if err != nil {
+		return 0, nil, cursors, err
 	}
 
 	// filter
 	isFiltered := false
 	filter := s.db.Model(&model.Table{})
 	if input.FilterName != "" {
-		filter.Where("name like ?", "%"+input.FilterName+"%")
+		filter.Where("products.name like ?", "%"+input.FilterName+"%")
 		isFiltered = true
 	}
 
 	if input.FilterCode != "" {
 		if isFiltered {
-			filter.Or("code like ?", "%"+input.FilterCode+"%")
+			filter.Or("products.code like ?", "%"+input.FilterCode+"%")
 		} else {
-			filter.Where("code like ?", "%"+input.FilterCode+"%")
+			filter.Where("products.code like ?", "%"+input.FilterCode+"%")
 		}
 	}
 
 	if input.FilterDescription != "" {
 		if isFiltered {
-			filter.Or("description like ?", "%"+input.FilterDescription+"%")
+			filter.Or("products.description like ?", "%"+input.FilterDescription+"%")
 		} else {
-			filter.Where("description like ?", "%"+input.FilterDescription+"%")
+			filter.Where("products.description like ?", "%"+input.FilterDescription+"%")
 		}
 	}
 
 	query.Where(filter)
+	if input.Where != nil {
+		where, err := input.Where.Clause(filterFields)
+		if err != nil {
+			return 0, nil, cursors, err
+		}
+
+		query.Where(where)
+	}
+
+	if input.Page == 0 {
+		quantity, err = keyset.Count(query, input.Count)
+		if err != nil {
+			log.Error(err)
+			return 0, nil, cursors, err
+		}
+
+		output, cursors, err = keyset.Find[model.Table](query, orderBy, input.Cursor, input.Limit)
+		if err != nil {
+			log.Error(err)
+			return 0, nil, cursors, err
+		}
+
+		return quantity, output, cursors, nil
+	}
+
+	quantity, err = keyset.Count(query, cmp.Or(input.Count, keyset.Exact))
+	if err != nil {
+		log.Error(err)
+		return 0, nil, cursors, err
+	}
 
-	err = query.Count(&quantity).Offset(int((input.Page - 1) * input.Limit)).
-		Limit(int(input.Limit)).Order("created_at desc").Find(&output).Error
+	err = query.Offset(int((input.Page - 1) * input.Limit)).
+		Limit(int(input.Limit)).Order(orderBy).Find(&output).Error
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
-	return quantity, output, nil
+	return quantity, output, cursors, nil
 }
 
 func (s *storage) GetBySingle(input *model.Base) (output *model.Table, err error) {
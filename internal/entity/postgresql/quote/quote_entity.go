@@ -1,9 +1,15 @@
 package quote
 
 import (
+	"cmp"
 	"encoding/json"
 
 	model "crm/internal/entity/postgresql/db/quotes"
+	"crm/internal/entity/postgresql/keyset"
+	"crm/internal/entity/postgresql/relation"
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/quotes"
 	"crm/internal/interactor/pkg/util/log"
 
 	"gorm.io/gorm"
@@ -13,13 +19,31 @@ import (
 type Entity interface {
 	WithTrx(trx *gorm.DB) Entity
 	Create(input *model.Base) (err error)
-	GetByList(input *model.Base) (quantity int64, output []*model.Table, err error)
+	GetByList(input *model.Base) (quantity int64, output []*model.Table, cursors page.Cursors, err error)
 	GetBySingle(input *model.Base) (output *model.Table, err error)
 	GetByQuantity(input *model.Base) (quantity int64, err error)
 	Delete(input *model.Base) (err error)
 	Update(input *model.Base) (err error)
 }
 
+// filterFields are the fields the list can be filtered on.
+var filterFields = filter.Fields{
+	"name":                  {Name: "quotes.name", Type: filter.Text},
+	"code":                  {Name: "quotes.code", Type: filter.Text},
+	"status":                {Name: "quotes.status", Type: filter.Text},
+	"description":           {Name: "quotes.description", Type: filter.Text},
+	"is_syncing":            {Name: "quotes.is_syncing", Type: filter.Bool},
+	"is_final":              {Name: "quotes.is_final", Type: filter.Bool},
+	"expiration_date":       {Name: "quotes.expiration_date", Type: filter.Time},
+	"tax":                   {Name: "quotes.tax", Type: filter.Number},
+	"shipping_and_handling": {Name: "quotes.shipping_and_handling", Type: filter.Number},
+	"opportunity_id":        {Name: "quotes.opportunity_id", Type: filter.UUID},
+	"account_id":            {Name: "quotes.account_id", Type: filter.UUID},
+	"created_at":            {Name: "quotes.created_at", Type: filter.Time},
+	"updated_at":            {Name: "quotes.updated_at", Type: filter.Time},
+	"opportunity_name":      {Name: `"Opportunities".name`, Type: filter.Text},
+}
+
 type storage struct {
 	db *gorm.DB
 }
@@ -59,19 +83,21 @@ func (s *storage) Create(input *model.Base) (err error) {
 	return nil
 }
 
-func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.Table, err error) {
-	query := s.db.Model(&model.Table{}).Count(&quantity).Joins("Opportunities").Preload(clause.Associations)
+func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.Table, cursors page.Cursors, err error) {
+	query := s.db.Model(&model.Table{})
 
 	if input.QuoteID != nil {
 		query.Where("quote_id = ?", input.QuoteID)
 	}
 
-	if input.Sort.Field != "" && input.Sort.Direction != "" {
-		if input.Sort.Field == "opportunity_name" && input.Sort.Direction != "" {
-			query.Order(`"Opportunities".name` + " " + input.Sort.Direction)
-		} else {
-			query.Order(input.Sort.Field + " " + input.Sort.Direction)
-		}
+	orderBy, err := input.Sort.Clause(filterFields.Columns(), "quotes.created_at", "quotes.quote_id")
+	if err != nil {
+		return 0, nil, cursors, err
+	}
+
+	query, err = relation.Load(query, input.Projection, quotes.Relations, orderBy, "shipping_and_handling", "tax")
+	if err != nil {
+		return 0, nil, cursors, err
 	}
 
 	// filter
@@ -99,15 +125,45 @@ func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.
 	}
 
 	query.Where(filter)
+	if input.Where != nil {
+		where, err := input.Where.Clause(filterFields)
+		if err != nil {
+			return 0, nil, cursors, err
+		}
+
+		query.Where(where)
+	}
+
+	if input.Page == 0 {
+		quantity, err = keyset.Count(query, input.Count)
+		if err != nil {
+			log.Error(err)
+			return 0, nil, cursors, err
+		}
+
+		output, cursors, err = keyset.Find[model.Table](query, orderBy, input.Cursor, input.Limit)
+		if err != nil {
+			log.Error(err)
+			return 0, nil, cursors, err
+		}
+
+		return quantity, output, cursors, nil
+	}
+
+	quantity, err = keyset.Count(query, cmp.Or(input.Count, keyset.Exact))
+	if err != nil {
+		log.Error(err)
+		return 0, nil, cursors, err
+	}
 
-	err = query.Count(&quantity).Offset(int((input.Page - 1) * input.Limit)).
-		Limit(int(input.Limit)).Order("created_at desc").Find(&output).Error
+	err = query.Offset(int((input.Page - 1) * input.Limit)).
+		Limit(int(input.Limit)).Order(orderBy).Find(&output).Error
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
-	return quantity, output, nil
+	return quantity, output, cursors, nil
 }
 
 func (s *storage) GetBySingle(input *model.Base) (output *model.Table, err error) {
@@ -0,0 +1,474 @@
+package recycle_bin
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	model "crm/internal/entity/postgresql/db/recycle_bins"
+	policyModel "crm/internal/interactor/models/delete_policies"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+)
+
+type Entity interface {
+	WithTrx(trx *gorm.DB) Entity
+	GetByPlan(input *model.Base) (output *model.Plan, err error)
+	Delete(input *model.Base, plan *model.Plan) (err error)
+	GetByList(input *model.Base) (quantity int64, output []*model.Table, err error)
+	GetByRecords(input *model.Base) (output []*model.Table, err error)
+	GetByRestore(input *model.Base) (output *model.Plan, err error)
+	Restore(input *model.Base, plan *model.Plan) (err error)
+	GetByPurge(input *model.Base) (output *model.Plan, err error)
+	Purge(plan *model.Plan) (err error)
+}
+
+// target is an entity whose records are deleted by the delete policies.
+type target struct {
+	// 資料表
+	table string
+	// 主鍵
+	primaryKey string
+	// 標題欄位
+	title string
+	// 沒有刪除時間,直接刪除且無法還原
+	permanent bool
+	// 不在資源回收筒,刪除後無法還原
+	hidden bool
+}
+
+var targets = map[string]target{
+	"accounts":      {table: "accounts", primaryKey: "account_id", title: "name"},
+	"contacts":      {table: "contacts", primaryKey: "contact_id", title: "name"},
+	"leads":         {table: "leads", primaryKey: "lead_id", title: "description"},
+	"contracts":     {table: "contracts", primaryKey: "contract_id", title: "code"},
+	"opportunities": {table: "opportunities", primaryKey: "opportunity_id", title: "name"},
+	"orders":        {table: "orders", primaryKey: "order_id", title: "code"},
+	"quotes":        {table: "quotes", primaryKey: "quote_id", title: "name"},
+	"products":      {table: "products", primaryKey: "product_id", title: "name"},
+	"campaigns":     {table: "campaigns", primaryKey: "campaign_id", title: "name"},
+	"events":        {table: "events", primaryKey: "event_id", title: "subject"},
+	"industries":    {table: "industries", primaryKey: "industry_id", title: "name", permanent: true},
+	"roles":         {table: "roles", primaryKey: "role_id", title: "display_name", hidden: true},
+	"users":         {table: "users", primaryKey: "user_id", title: "name", hidden: true},
+}
+
+type storage struct {
+	db *gorm.DB
+}
+
+func Init(db *gorm.DB) Entity {
+	return &storage{
+		db: db,
+	}
+}
+
+func (s *storage) WithTrx(trx *gorm.DB) Entity {
+	return &storage{
+		db: trx,
+	}
+}
+
+func lookup(entity string) (target, error) {
+	t, ok := targets[entity]
+	if !ok {
+		return target{}, fmt.Errorf("recycle bin: unknown entity %s", entity)
+	}
+
+	return t, nil
+}
+
+// node is the records of an entity whose references are being walked.
+type node struct {
+	entity string
+	ids    []string
+}
+
+// GetByPlan walks the delete policies from the records of input and returns every record affected by deleting them.
+// It returns gorm.ErrRecordNotFound when a record does not exist or is already deleted.
+func (s *storage) GetByPlan(input *model.Base) (output *model.Plan, err error) {
+	t, err := lookup(input.Entity)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []string
+	query := "select " + t.primaryKey + "::text from " + t.table + " where " + t.primaryKey + " in ?"
+	if !t.permanent {
+		query += " and deleted_at is null"
+	}
+
+	err = s.db.Raw(query, input.IDs).Scan(&found).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	for _, id := range input.IDs {
+		if !slices.Contains(found, id) {
+			return nil, gorm.ErrRecordNotFound
+		}
+	}
+
+	deleted := map[string]map[string]bool{t.table: {}}
+	for _, id := range input.IDs {
+		deleted[t.table][id] = true
+	}
+
+	output = &model.Plan{}
+	for queue := []node{{entity: input.Entity, ids: input.IDs}}; len(queue) > 0; queue = queue[1:] {
+		for _, reference := range policyModel.Policies[queue[0].entity] {
+			var ids []string
+			err = s.db.Raw("select "+reference.PrimaryKey+"::text from "+reference.Table+
+				" where "+reference.Column+" in ? and deleted_at is null", queue[0].ids).Scan(&ids).Error
+			if err != nil {
+				log.Error(err)
+				return nil, err
+			}
+
+			ids = slices.DeleteFunc(ids, func(id string) bool { return deleted[reference.Table][id] })
+			if len(ids) == 0 {
+				continue
+			}
+
+			output.Steps = append(output.Steps, &model.Step{
+				Entity:     reference.Entity,
+				Table:      reference.Table,
+				PrimaryKey: reference.PrimaryKey,
+				Column:     reference.Column,
+				Policy:     reference.Policy,
+				IDs:        ids,
+			})
+
+			if reference.Policy == policyModel.Cascade {
+				if deleted[reference.Table] == nil {
+					deleted[reference.Table] = map[string]bool{}
+				}
+
+				for _, id := range ids {
+					deleted[reference.Table][id] = true
+				}
+
+				if reference.Entity != "" {
+					queue = append(queue, node{entity: reference.Entity, ids: ids})
+				}
+			}
+		}
+	}
+
+	// 一併刪除的資料不需清除關聯,也不限制刪除
+	steps := output.Steps[:0]
+	for _, step := range output.Steps {
+		if step.Policy != policyModel.Cascade {
+			step.IDs = slices.DeleteFunc(step.IDs, func(id string) bool { return deleted[step.Table][id] })
+			if len(step.IDs) == 0 {
+				continue
+			}
+		}
+
+		steps = append(steps, step)
+	}
+
+	output.Steps = steps
+
+	return output, nil
+}
+
+// Delete deletes the records of input by plan, they are soft deleted with their cascaded records at the same time
+// so they are restored together.
+func (s *storage) Delete(input *model.Base, plan *model.Plan) (err error) {
+	t, err := lookup(input.Entity)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]any{
+		"deleted_at": input.ModifiedAt,
+		"updated_at": input.ModifiedAt,
+		"updated_by": input.ModifiedBy,
+	}
+
+	for _, step := range plan.Steps {
+		query := s.db.Table(step.Table).Where(step.PrimaryKey+" in ?", step.IDs)
+		switch step.Policy {
+		case policyModel.Cascade:
+			err = query.Updates(data).Error
+		case policyModel.Nullify:
+			err = query.Updates(map[string]any{
+				step.Column:  nil,
+				"updated_at": input.ModifiedAt,
+				"updated_by": input.ModifiedBy,
+			}).Error
+		default:
+			err = fmt.Errorf("recycle bin: %s.%s restricts the delete", step.Table, step.Column)
+		}
+
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	if t.permanent {
+		err = s.db.Exec("delete from "+t.table+" where "+t.primaryKey+" in ?", input.IDs).Error
+	} else {
+		err = s.db.Table(t.table).Where(t.primaryKey+" in ?", input.IDs).Where("deleted_at is null").Updates(data).Error
+	}
+
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// cascaded returns the records deleted at deletedAt by cascading the delete of the records of entity.
+// With links, every row of the link tables is returned whenever it was deleted.
+func (s *storage) cascaded(entity string, ids []string, deletedAt time.Time, links bool) (output []*model.Step, err error) {
+	for queue := []node{{entity: entity, ids: ids}}; len(queue) > 0; queue = queue[1:] {
+		for _, reference := range policyModel.Policies[queue[0].entity] {
+			if reference.Policy != policyModel.Cascade {
+				continue
+			}
+
+			query := "select " + reference.PrimaryKey + "::text from " + reference.Table + " where " + reference.Column + " in ?"
+			args := []any{queue[0].ids}
+			if !links || reference.Entity != "" {
+				query += " and deleted_at = ?"
+				args = append(args, deletedAt)
+			}
+
+			var found []string
+			err = s.db.Raw(query, args...).Scan(&found).Error
+			if err != nil {
+				log.Error(err)
+				return nil, err
+			}
+
+			if len(found) == 0 {
+				continue
+			}
+
+			output = append(output, &model.Step{
+				Entity:     reference.Entity,
+				Table:      reference.Table,
+				PrimaryKey: reference.PrimaryKey,
+				Column:     reference.Column,
+				Policy:     reference.Policy,
+				IDs:        found,
+			})
+
+			if reference.Entity != "" {
+				queue = append(queue, node{entity: reference.Entity, ids: found})
+			}
+		}
+	}
+
+	return output, nil
+}
+
+// deletions returns the IDs of the records of input by their deletion time.
+func (s *storage) deletions(input *model.Base) (output map[time.Time][]string, err error) {
+	records, err := s.GetByRecords(input)
+	if err != nil {
+		return nil, err
+	}
+
+	output = map[time.Time][]string{}
+	for _, record := range records {
+		output[record.DeletedAt] = append(output[record.DeletedAt], record.ID)
+	}
+
+	return output, nil
+}
+
+// GetByList returns a page of the deleted records of input, the latest deleted first.
+func (s *storage) GetByList(input *model.Base) (quantity int64, output []*model.Table, err error) {
+	t, err := lookup(input.Entity)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	query := s.db.Table(t.table + " as r").Where("r.deleted_at is not null")
+	if input.DelStartAt != nil {
+		query.Where("r.deleted_at >= ?", input.DelStartAt)
+	}
+
+	if input.DelEndAt != nil {
+		query.Where("r.deleted_at <= ?", input.DelEndAt)
+	}
+
+	err = query.Count(&quantity).Error
+	if err != nil {
+		log.Error(err)
+		return 0, nil, err
+	}
+
+	err = query.Joins("left join users as u on u.user_id = r.updated_by").
+		Select("r." + t.primaryKey + "::text as id, coalesce(r." + t.title + ", '') as title, r.deleted_at, " +
+			"r.updated_by::text as deleted_by, coalesce(u.name, '') as deleted_by_name").
+		Order("r.deleted_at desc, r." + t.primaryKey).
+		Offset(int((input.Page - 1) * input.Limit)).
+		Limit(int(input.Limit)).
+		Scan(&output).Error
+	if err != nil {
+		log.Error(err)
+		return 0, nil, err
+	}
+
+	return quantity, output, nil
+}
+
+// GetByRecords returns the deleted records among the IDs of input, locking them until the transaction ends.
+func (s *storage) GetByRecords(input *model.Base) (output []*model.Table, err error) {
+	t, err := lookup(input.Entity)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.Raw("select "+t.primaryKey+"::text as id, coalesce("+t.title+", '') as title, deleted_at, updated_by::text as deleted_by "+
+		"from "+t.table+" where "+t.primaryKey+" in ? and deleted_at is not null for update", input.IDs).
+		Scan(&output).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// affected returns the records of input with the records cascaded by their delete, the records of input last.
+// With links, every row of the link tables is returned whenever it was deleted.
+func (s *storage) affected(input *model.Base, links bool) (output *model.Plan, err error) {
+	t, err := lookup(input.Entity)
+	if err != nil {
+		return nil, err
+	}
+
+	deletions, err := s.deletions(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// 只包含同時刪除的資料,先前已刪除的維持刪除
+	output = &model.Plan{}
+	var ids []string
+	for deletedAt, deleted := range deletions {
+		steps, err := s.cascaded(input.Entity, deleted, deletedAt, links)
+		if err != nil {
+			return nil, err
+		}
+
+		output.Steps = append(output.Steps, steps...)
+		ids = append(ids, deleted...)
+	}
+
+	output.Steps = append(output.Steps, &model.Step{Entity: input.Entity, Table: t.table, PrimaryKey: t.primaryKey, IDs: ids})
+
+	return output, nil
+}
+
+// GetByRestore returns the records restored with the records of input, and the deleted records they reference
+// which must be restored first.
+func (s *storage) GetByRestore(input *model.Base) (output *model.Plan, err error) {
+	output, err = s.affected(input, false)
+	if err != nil {
+		return nil, err
+	}
+
+	restored := map[string]map[string]bool{}
+	for _, step := range output.Steps {
+		if restored[step.Table] == nil {
+			restored[step.Table] = map[string]bool{}
+		}
+
+		for _, id := range step.IDs {
+			restored[step.Table][id] = true
+		}
+	}
+
+	for _, step := range output.Steps {
+		if step.Entity == "" {
+			continue
+		}
+
+		for _, entity := range slices.Sorted(maps.Keys(policyModel.Policies)) {
+			t := targets[entity]
+			if t.permanent || t.hidden {
+				continue
+			}
+
+			for _, reference := range policyModel.Policies[entity] {
+				if reference.Entity != step.Entity {
+					continue
+				}
+
+				var ids []string
+				err = s.db.Raw("select distinct p."+t.primaryKey+"::text from "+reference.Table+" as r "+
+					"join "+t.table+" as p on p."+t.primaryKey+" = r."+reference.Column+
+					" where r."+reference.PrimaryKey+" in ? and p.deleted_at is not null", step.IDs).Scan(&ids).Error
+				if err != nil {
+					log.Error(err)
+					return nil, err
+				}
+
+				ids = slices.DeleteFunc(ids, func(id string) bool { return restored[t.table][id] })
+				if len(ids) == 0 {
+					continue
+				}
+
+				output.Parents = append(output.Parents, &model.Step{
+					Entity:     entity,
+					Table:      t.table,
+					PrimaryKey: t.primaryKey,
+					Column:     reference.Column,
+					Policy:     reference.Policy,
+					IDs:        ids,
+				})
+			}
+		}
+	}
+
+	return output, nil
+}
+
+// Restore restores the records of plan.
+func (s *storage) Restore(input *model.Base, plan *model.Plan) (err error) {
+	data := map[string]any{
+		"deleted_at": nil,
+		"updated_at": input.ModifiedAt,
+		"updated_by": input.ModifiedBy,
+	}
+
+	for _, step := range plan.Steps {
+		err = s.db.Table(step.Table).Where(step.PrimaryKey+" in ?", step.IDs).Updates(data).Error
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetByPurge returns the records purged with the records of input, the records cascaded by their delete and every
+// row of their link tables.
+func (s *storage) GetByPurge(input *model.Base) (output *model.Plan, err error) {
+	return s.affected(input, true)
+}
+
+// Purge permanently deletes the records of plan.
+func (s *storage) Purge(plan *model.Plan) (err error) {
+	for _, step := range plan.Steps {
+		err = s.db.Exec("delete from "+step.Table+" where "+step.PrimaryKey+" in ?", step.IDs).Error
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package relation
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/projection"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Load narrows query to the requested columns and loads the relations of its model:
+// expanded relations are joined whole or preloaded, the others are joined for their display column only.
+// The primary key, created_at, the main table columns of orderBy and required are always selected.
+func Load(query *gorm.DB, input projection.Projection, relations projection.Relations, orderBy clause.OrderBy, required ...string) (*gorm.DB, error) {
+	expanded, err := input.Expanded(relations)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = query.Statement.Parse(query.Statement.Model); err != nil {
+		return nil, err
+	}
+
+	if columns := input.Columns(); len(columns) > 0 {
+		query = query.Select(selected(query, columns, orderBy, required))
+	}
+
+	names := make([]string, 0, len(relations))
+	for name := range relations {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		relation := relations[name]
+		switch {
+		case relation.Display == "" && (relation.Preload || slices.Contains(expanded, name)):
+			query = query.Preload(relation.Association)
+		case relation.Display == "":
+		case slices.Contains(expanded, name):
+			query = query.Joins(relation.Association)
+		default:
+			query = query.Joins(relation.Association, query.Session(&gorm.Session{NewDB: true}).Select(relation.Display))
+		}
+	}
+
+	return query, nil
+}
+
+// selected returns the table qualified columns of the main table to read.
+func selected(query *gorm.DB, columns []string, orderBy clause.OrderBy, required []string) []string {
+	table := query.Statement.Schema.Table
+	var output []string
+	add := func(column string) {
+		if field := query.Statement.Schema.LookUpField(column); field != nil && field.DBName != "" {
+			if name := table + "." + field.DBName; !slices.Contains(output, name) {
+				output = append(output, name)
+			}
+		}
+	}
+
+	for _, field := range query.Statement.Schema.PrimaryFields {
+		add(field.DBName)
+	}
+
+	add("created_at")
+	for _, column := range orderBy.Columns {
+		name := column.Column.Name
+		// 自訂欄位的排序讀取其 JSONB 欄位
+		if document, _, _, ok := filter.CustomKey(name); ok {
+			name = document
+		}
+
+		if name, ok := strings.CutPrefix(name, table+"."); ok {
+			add(name)
+		}
+	}
+
+	for _, column := range append(required, columns...) {
+		add(column)
+	}
+
+	return output
+}
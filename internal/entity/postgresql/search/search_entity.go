@@ -0,0 +1,129 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	model "crm/internal/entity/postgresql/db/searches"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+)
+
+type Entity interface {
+	WithTrx(trx *gorm.DB) Entity
+	GetByList(input *model.Base) (output []*model.Table, err error)
+}
+
+// target is a searchable table, its columns match the indexes of the search migration.
+type target struct {
+	// 資料表
+	table string
+	// 主鍵
+	primaryKey string
+	// 標題欄位
+	title string
+	// 搜尋欄位
+	columns []string
+}
+
+var targets = map[string]target{
+	"account":     {table: "accounts", primaryKey: "account_id", title: "name", columns: []string{"name", "phone_number"}},
+	"contact":     {table: "contacts", primaryKey: "contact_id", title: "name", columns: []string{"name", "phone_number", "cell_phone", "email"}},
+	"lead":        {table: "leads", primaryKey: "lead_id", title: "description", columns: []string{"description"}},
+	"opportunity": {table: "opportunities", primaryKey: "opportunity_id", title: "name", columns: []string{"name"}},
+	"quote":       {table: "quotes", primaryKey: "quote_id", title: "name", columns: []string{"code", "name"}},
+	"contract":    {table: "contracts", primaryKey: "contract_id", title: "code", columns: []string{"code", "description"}},
+	"order":       {table: "orders", primaryKey: "order_id", title: "code", columns: []string{"code", "description"}},
+	"product":     {table: "products", primaryKey: "product_id", title: "name", columns: []string{"name", "code"}},
+}
+
+type storage struct {
+	db *gorm.DB
+}
+
+func Init(db *gorm.DB) Entity {
+	return &storage{
+		db: db,
+	}
+}
+
+func (s *storage) WithTrx(trx *gorm.DB) Entity {
+	return &storage{
+		db: trx,
+	}
+}
+
+func (s *storage) GetByList(input *model.Base) (output []*model.Table, err error) {
+	branches := make([]string, 0, len(input.Types))
+	for _, name := range input.Types {
+		t, ok := targets[name]
+		if !ok {
+			return nil, fmt.Errorf("search: unknown type %s", name)
+		}
+
+		branches = append(branches, t.sql(name))
+	}
+
+	if len(branches) == 0 {
+		return output, nil
+	}
+
+	tsquery := prefixQuery(*input.Q)
+	err = s.db.Raw("select * from ("+strings.Join(branches, " union all ")+") as results order by rank desc, title limit @limit",
+		map[string]any{
+			"q":       *input.Q,
+			"like":    "%" + escape(*input.Q) + "%",
+			"tsquery": tsquery,
+			"matched": tsquery != "",
+			"limit":   *input.Limit,
+		}).Scan(&output).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// sql selects the best matches of a table: trigram indexes serve the substring matches, also for CJK text,
+// and the tsvector index serves the word prefix matches.
+func (t target) sql(name string) string {
+	document := make([]string, len(t.columns))
+	fields := make([]string, len(t.columns))
+	ranks := make([]string, len(t.columns))
+	likes := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		document[i] = "coalesce(" + column + ", '')"
+		fields[i] = "'" + column + "', " + column
+		ranks[i] = "word_similarity(@q, " + column + ")"
+		likes[i] = column + " ilike @like"
+	}
+
+	vector := "to_tsvector('simple', " + strings.Join(document, " || ' ' || ") + ")"
+	return fmt.Sprintf("(select '%s' as type, %s::text as id, coalesce(%s, '') as title, json_build_object(%s)::text as fields, "+
+		"greatest(%s, case when @matched then ts_rank(%s, to_tsquery('simple', @tsquery)) else 0 end) as rank "+
+		"from %s where deleted_at is null and (%s or (@matched and %s @@ to_tsquery('simple', @tsquery))) "+
+		"order by rank desc limit @limit)",
+		name, t.primaryKey, t.title, strings.Join(fields, ", "),
+		strings.Join(ranks, ", "), vector,
+		t.table, strings.Join(likes, " or "), vector)
+}
+
+// prefixQuery turns the words of q into a tsquery matching all of them as prefixes.
+func prefixQuery(q string) string {
+	words := strings.FieldsFunc(q, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
+
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,121 @@
+package helpers
+
+import (
+	"cmp"
+	"errors"
+
+	bulkModel "crm/internal/interactor/models/bulk"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+	historicalRecordService "crm/internal/interactor/service/historical_record"
+
+	"github.com/gin-gonic/gin/binding"
+	"gorm.io/gorm"
+)
+
+// Operation is an item of a bulk request.
+type Operation struct {
+	// 動作
+	Action string
+	// 項目序號
+	Index int
+	// 資料ID,新增時由結果取得
+	ID string
+	// 執行前驗證的輸入
+	Input any
+	// 更新項目未帶入公司要求的版本(if_match)
+	Unversioned bool
+	// 執行項目,回傳值與單筆的 manager 方法相同
+	Run func(trx *gorm.DB) (int, any)
+}
+
+var errOperationFailed = errors.New("bulk operation failed")
+
+// Bulk runs each operation in its own savepoint of trx and writes the history held by history, if any, in batches.
+// In best-effort mode a failed operation is rolled back alone. In all-or-nothing mode every operation is still
+// run to report its result, and the request fails with 422 so middleware.Transaction rolls back all of them.
+func Bulk(trx *gorm.DB, mode string, history historicalRecordService.Batch, operations []*Operation) (int, any) {
+	output := &bulkModel.List{
+		Mode:    cmp.Or(mode, bulkModel.AllOrNothing),
+		Results: make([]*bulkModel.Result, 0, len(operations)),
+	}
+
+	for _, operation := range operations {
+		result := &bulkModel.Result{
+			Action: operation.Action,
+			Index:  operation.Index,
+			ID:     operation.ID,
+		}
+
+		held := 0
+		if history != nil {
+			held = history.Len()
+		}
+
+		err := Savepoint(trx, func(trx *gorm.DB) error {
+			if operation.Action != bulkModel.Create && operation.ID == "" {
+				result.Status, result.Error = code.FormatError, "id is required"
+				return errOperationFailed
+			}
+
+			if operation.Unversioned {
+				result.Status, result.Error = code.PreconditionRequired, "if_match is required"
+				return errOperationFailed
+			}
+
+			if err := binding.Validator.ValidateStruct(operation.Input); err != nil {
+				result.Status, result.Error = code.FormatError, err.Error()
+				return errOperationFailed
+			}
+
+			status, codeMessage := operation.Run(trx)
+			result.Status = status
+			switch message := codeMessage.(type) {
+			case *code.SuccessfulMessage:
+				if id, ok := message.Body.(*string); ok && id != nil {
+					result.ID = *id
+				}
+
+				result.Warnings = message.Warnings
+			case *code.ErrorMessage:
+				result.Error = message.Detailed
+			}
+
+			if status != code.Successful {
+				return errOperationFailed
+			}
+
+			return nil
+		})
+		if err != nil {
+			if !errors.Is(err, errOperationFailed) {
+				log.Error(err)
+				result.Status, result.Error = code.InternalServerError, err.Error()
+			}
+
+			if history != nil {
+				history.Truncate(held)
+			}
+
+			output.Failed++
+		} else {
+			output.Succeeded++
+		}
+
+		output.Results = append(output.Results, result)
+	}
+
+	if output.Failed > 0 && output.Mode == bulkModel.AllOrNothing {
+		return code.UnprocessableEntity, code.GetCodeMessage(code.UnprocessableEntity, output)
+	}
+
+	// 批次寫入保留項目的歷程記錄
+	if history != nil {
+		if err := history.Flush(trx); err != nil {
+			log.Error(err)
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, output)
+}
@@ -0,0 +1,141 @@
+package helpers
+
+import (
+	"maps"
+	"slices"
+
+	customFieldModel "crm/internal/interactor/models/custom_fields"
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+	customFieldService "crm/internal/interactor/service/custom_field"
+	userService "crm/internal/interactor/service/user"
+)
+
+// customTypes are the filter types of the custom field types.
+var customTypes = map[string]string{
+	customFieldModel.Text:     filter.Text,
+	customFieldModel.Number:   filter.Number,
+	customFieldModel.Boolean:  filter.Bool,
+	customFieldModel.Date:     filter.Time,
+	customFieldModel.Picklist: filter.Text,
+}
+
+// CheckCustomFields validates the custom fields of a record of entity against the custom fields defined by the company
+// of userID and replaces values with the document to store. values holds the custom fields written by the request,
+// a null value clears a field. current holds the stored custom fields of an updated record and is nil for a new record,
+// whose missing fields then receive their defaults. A required field must be set on a new record and cannot be cleared.
+// It returns 200 or 422 with the invalid fields.
+func CheckCustomFields(customFields customFieldService.Service, users userService.Service, userID, entity string, values *map[string]any, current map[string]any) (int, any) {
+	if current != nil && *values == nil {
+		return code.Successful, nil
+	}
+
+	companyID, err := companyOf(users, userID)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	customFieldBase, err := customFields.GetByCache(companyID, entity)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	document := maps.Clone(current)
+	if document == nil {
+		document = map[string]any{}
+	}
+
+	var invalid []*code.FieldError
+	defined := map[string]bool{}
+	for _, customField := range customFieldBase {
+		key := *customField.Key
+		defined[key] = true
+		value, written := (*values)[key]
+		if !written {
+			if current != nil {
+				continue
+			}
+
+			value = customField.DefaultValue
+		}
+
+		if value == nil {
+			if *customField.Required {
+				invalid = append(invalid, &code.FieldError{Field: customFieldModel.Prefix + key, Code: "required", Message: "is required."})
+			}
+
+			delete(document, key)
+			continue
+		}
+
+		var options []string
+		if customField.Options != nil {
+			options = *customField.Options
+		}
+
+		checked, err := customFieldModel.Check(*customField.Type, options, value)
+		if err != nil {
+			rule := "type"
+			if *customField.Type == customFieldModel.Picklist {
+				rule = "picklist"
+			}
+
+			invalid = append(invalid, &code.FieldError{Field: customFieldModel.Prefix + key, Code: rule, Message: err.Error()})
+			continue
+		}
+
+		document[key] = checked
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(*values)) {
+		if !defined[key] {
+			invalid = append(invalid, &code.FieldError{Field: customFieldModel.Prefix + key, Code: "unknown", Message: "is not a custom field of " + entity + "."})
+		}
+	}
+
+	if len(invalid) > 0 {
+		return code.UnprocessableEntity, code.GetCodeMessage(code.UnprocessableEntity, invalid)
+	}
+
+	*values = document
+	return code.Successful, nil
+}
+
+// CustomColumns returns the custom fields of entity defined by a company as filter and sort columns,
+// keyed by custom_fields.<key>. The table of entity holds their values in its custom_fields column.
+func CustomColumns(customFields customFieldService.Service, companyID, entity string) (filter.Fields, error) {
+	if companyID == "" {
+		return nil, nil
+	}
+
+	customFieldBase, err := customFields.GetByCache(companyID, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := filter.Fields{}
+	for _, customField := range customFieldBase {
+		if customFieldModel.ValidKey(*customField.Key) {
+			columns[customFieldModel.Prefix+*customField.Key] = filter.Custom(entity+".custom_fields", *customField.Key, customTypes[*customField.Type])
+		}
+	}
+
+	return columns, nil
+}
+
+// companyOf returns the company of a user, the records of a company are the ones created by its users.
+func companyOf(users userService.Service, userID string) (string, error) {
+	userBase, err := users.GetByCache(userID)
+	if err != nil {
+		return "", err
+	}
+
+	if userBase.CompanyID == nil {
+		return "", nil
+	}
+
+	return *userBase.CompanyID, nil
+}
@@ -0,0 +1,105 @@
+package helpers
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	customFieldDB "crm/internal/entity/postgresql/db/custom_fields"
+	customFieldModel "crm/internal/interactor/models/custom_fields"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/code"
+	customFieldService "crm/internal/interactor/service/custom_field"
+)
+
+type stubCustomFields struct {
+	customFieldService.Service
+	customFields []*customFieldDB.Base
+	err          error
+}
+
+func (s stubCustomFields) GetByCache(companyID, entity string) ([]*customFieldDB.Base, error) {
+	return s.customFields, s.err
+}
+
+var customFields = []*customFieldDB.Base{
+	{Key: util.PointerString("tax_id"), Type: util.PointerString(customFieldModel.Text), Required: util.PointerBool(true)},
+	{Key: util.PointerString("employees"), Type: util.PointerString(customFieldModel.Number), Required: util.PointerBool(false)},
+	{Key: util.PointerString("vip"), Type: util.PointerString(customFieldModel.Boolean), Required: util.PointerBool(false), DefaultValue: false},
+	{Key: util.PointerString("renewal"), Type: util.PointerString(customFieldModel.Date), Required: util.PointerBool(false)},
+	{Key: util.PointerString("tier"), Type: util.PointerString(customFieldModel.Picklist), Required: util.PointerBool(false), Options: &[]string{"gold", "silver"}},
+}
+
+func TestCheckCustomFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      map[string]any
+		current     map[string]any
+		wantCode    int
+		want        map[string]any
+		wantInvalid []string
+	}{
+		{"create with defaults", map[string]any{"tax_id": "12345678"}, nil,
+			code.Successful, map[string]any{"tax_id": "12345678", "vip": false}, nil},
+		{"create with every type", map[string]any{"tax_id": "1", "employees": 30, "vip": true, "renewal": "2024-03-01", "tier": "gold"}, nil,
+			code.Successful, map[string]any{"tax_id": "1", "employees": 30.0, "vip": true, "renewal": "2024-03-01", "tier": "gold"}, nil},
+		{"create without a required field", map[string]any{}, nil,
+			code.UnprocessableEntity, nil, []string{"custom_fields.tax_id"}},
+		{"values of the wrong type", map[string]any{"tax_id": 1, "employees": "many", "vip": "yes", "renewal": "01/03/2024", "tier": "bronze"}, nil,
+			code.UnprocessableEntity, nil, []string{"custom_fields.tax_id", "custom_fields.employees", "custom_fields.vip", "custom_fields.renewal", "custom_fields.tier"}},
+		{"unknown keys", map[string]any{"tax_id": "1", "nickname": "a", "color": "b"}, nil,
+			code.UnprocessableEntity, nil, []string{"custom_fields.color", "custom_fields.nickname"}},
+		{"update merges into the stored fields", map[string]any{"employees": 40}, map[string]any{"tax_id": "1", "tier": "gold"},
+			code.Successful, map[string]any{"tax_id": "1", "employees": 40.0, "tier": "gold"}, nil},
+		{"update does not apply defaults", map[string]any{"tier": "silver"}, map[string]any{"tax_id": "1"},
+			code.Successful, map[string]any{"tax_id": "1", "tier": "silver"}, nil},
+		{"update clears a field", map[string]any{"tier": nil}, map[string]any{"tax_id": "1", "tier": "gold"},
+			code.Successful, map[string]any{"tax_id": "1"}, nil},
+		{"update cannot clear a required field", map[string]any{"tax_id": nil}, map[string]any{"tax_id": "1"},
+			code.UnprocessableEntity, nil, []string{"custom_fields.tax_id"}},
+		{"update without custom fields", nil, map[string]any{"tax_id": "1"},
+			code.Successful, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := tt.values
+			httpCode, codeMessage := CheckCustomFields(stubCustomFields{customFields: customFields}, stubUsers{}, "user", "accounts", &values, tt.current)
+			if httpCode != tt.wantCode {
+				t.Fatalf("CheckCustomFields() = %d %#v, want %d", httpCode, codeMessage, tt.wantCode)
+			}
+
+			if httpCode == code.UnprocessableEntity {
+				if !reflect.DeepEqual(invalidFields(t, codeMessage), tt.wantInvalid) {
+					t.Errorf("CheckCustomFields() invalid = %v, want %v", invalidFields(t, codeMessage), tt.wantInvalid)
+				}
+
+				return
+			}
+
+			if !reflect.DeepEqual(values, tt.want) {
+				t.Errorf("CheckCustomFields() document = %#v, want %#v", values, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckCustomFieldsKeepsCurrent(t *testing.T) {
+	current := map[string]any{"tax_id": "1", "tier": "gold"}
+	values := map[string]any{"tier": nil}
+	if httpCode, _ := CheckCustomFields(stubCustomFields{customFields: customFields}, stubUsers{}, "user", "accounts", &values, current); httpCode != code.Successful {
+		t.Fatalf("CheckCustomFields() = %d", httpCode)
+	}
+
+	if current["tier"] != "gold" {
+		t.Errorf("CheckCustomFields() changed the stored fields to %#v", current)
+	}
+}
+
+func TestCheckCustomFieldsError(t *testing.T) {
+	values := map[string]any{"tax_id": "1"}
+	httpCode, _ := CheckCustomFields(stubCustomFields{err: errors.New("down")}, stubUsers{}, "user", "accounts", &values, nil)
+	if httpCode != code.InternalServerError {
+		t.Errorf("CheckCustomFields() = %d, want %d", httpCode, code.InternalServerError)
+	}
+}
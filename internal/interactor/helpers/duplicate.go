@@ -0,0 +1,50 @@
+package helpers
+
+import (
+	duplicateModel "crm/internal/interactor/models/duplicates"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+	duplicateService "crm/internal/interactor/service/duplicate"
+	userService "crm/internal/interactor/service/user"
+
+	"gorm.io/gorm"
+)
+
+// WarnDuplicates adds a warning to a successful code message when the record looks like existing records by the rules
+// of the company of userID. The check runs in a savepoint of trx, it is skipped when service is nil and never fails the request.
+func WarnDuplicates(trx *gorm.DB, service duplicateService.Service, users userService.Service, userID, entity, id string, httpCode int, codeMessage any) (int, any) {
+	message, ok := codeMessage.(*code.SuccessfulMessage)
+	if service == nil || httpCode != code.Successful || !ok {
+		return httpCode, codeMessage
+	}
+
+	var candidates []*duplicateModel.Candidate
+	err := Savepoint(trx, func(trx *gorm.DB) (err error) {
+		companyID, err := companyOf(users, userID)
+		if err != nil {
+			return err
+		}
+
+		candidates, err = service.WithTrx(trx).GetByList(&duplicateModel.Field{
+			Entity:    entity,
+			ID:        id,
+			CompanyID: companyID,
+		})
+
+		return err
+	})
+	if err != nil {
+		log.Error(err)
+		return httpCode, codeMessage
+	}
+
+	if len(candidates) > 0 {
+		message.Warnings = append(message.Warnings, &duplicateModel.Warning{
+			Type:       "duplicate",
+			Message:    "疑似重複的資料",
+			Candidates: candidates,
+		})
+	}
+
+	return httpCode, codeMessage
+}
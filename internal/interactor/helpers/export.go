@@ -0,0 +1,32 @@
+package helpers
+
+import (
+	"mime"
+	"net/http"
+
+	exportModel "crm/internal/interactor/models/exports"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WriteExport writes the result of an export, the file itself when it is streamed and the code message otherwise.
+func WriteExport(ctx *gin.Context, httpCode int, codeMessage any) {
+	message, ok := codeMessage.(*code.SuccessfulMessage)
+	if !ok || httpCode != http.StatusOK {
+		ctx.JSON(httpCode, codeMessage)
+
+		return
+	}
+
+	stream := message.Body.(*exportModel.Stream)
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": stream.FileName}))
+	ctx.Header("Content-Type", stream.ContentType)
+	ctx.Status(http.StatusOK)
+
+	// 標頭已送出,中途失敗只能記錄並中斷檔案
+	if err := stream.Write(ctx.Writer); err != nil {
+		log.Error(err)
+	}
+}
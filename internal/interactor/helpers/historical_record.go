@@ -1,7 +1,12 @@
 package helpers
 
 import (
+	"maps"
+	"slices"
+
+	"crm/internal/interactor/models/enums"
 	historicalRecordModel "crm/internal/interactor/models/historical_records"
+	historicalRecordService "crm/internal/interactor/service/historical_record"
 )
 
 // AddHistoricalRecord is Helper function to create historical_record.
@@ -12,3 +17,28 @@ func AddHistoricalRecord(input *[]historicalRecordModel.AddHistoricalRecord, act
 		Values:  value,
 	})
 }
+
+// RecordCascaded adds a historical record of action to every record deleted, restored or purged with another record.
+// cascaded holds their IDs by entity, the entities without historical records are skipped.
+func RecordCascaded(history historicalRecordService.Service, cascaded map[string][]string, action, modifiedBy string) error {
+	for _, entity := range slices.Sorted(maps.Keys(cascaded)) {
+		sourceType, ok := enums.SourceTypes[entity]
+		if !ok {
+			continue
+		}
+
+		for _, id := range cascaded[entity] {
+			_, err := history.Create(&historicalRecordModel.Create{
+				SourceID:   id,
+				Action:     action,
+				SourceType: sourceType,
+				ModifiedBy: modifiedBy,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package helpers
+
+import (
+	"slices"
+	"strings"
+
+	picklistDB "crm/internal/entity/postgresql/db/picklists"
+	picklistModel "crm/internal/interactor/models/picklists"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+	picklistService "crm/internal/interactor/service/picklist"
+	userService "crm/internal/interactor/service/user"
+)
+
+// CheckPicklists validates the picklist fields of a record of entity against the picklists of the company of userID.
+// fields holds the fields written by the request as *string or *[]string, a nil pointer is a field left out of an update.
+// current holds the stored fields of an updated record and is nil for a new record, whose empty fields then receive
+// the default of their picklist. A value must be an active option allowed by the value of its controlling field,
+// a record keeps an inactive value it already has, and the system values of a field are always valid.
+// It returns 200 or 422 with the invalid fields.
+func CheckPicklists(picklists picklistService.Service, users userService.Service, userID, entity string, fields, current map[string]any) (int, any) {
+	companyID, err := companyOf(users, userID)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	picklistBase, err := picklists.GetByCache(companyID, entity)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	byField := make(map[string]*picklistDB.Base, len(picklistBase))
+	for _, picklist := range picklistBase {
+		byField[*picklist.Field] = picklist
+	}
+
+	var invalid []*code.FieldError
+	// 控制欄位排在相依欄位之前,先取得控制欄位的預設值
+	for _, field := range picklistModel.Entities[entity] {
+		value, ok := fields[field]
+		if !ok {
+			continue
+		}
+
+		picklist := byField[field]
+		system := picklistModel.SystemValues[entity][field]
+		controllingField := ""
+		if picklist != nil && picklist.ControllingField != nil {
+			controllingField = *picklist.ControllingField
+		}
+
+		controlling := ""
+		if values := written(fields[controllingField]); values != nil {
+			controlling = first(values)
+		} else {
+			controlling = first(written(current[controllingField]))
+		}
+
+		values := written(value)
+		if current == nil && first(values) == "" && picklist != nil {
+			values = defaultValue(picklist, controlling, value)
+		}
+
+		kept := written(current[field])
+		// 控制欄位異動時,未異動的相依欄位也須符合新的控制欄位值
+		if values == nil {
+			changed := written(fields[controllingField])
+			if controllingField == "" || changed == nil || slices.Equal(changed, written(current[controllingField])) {
+				continue
+			}
+
+			values = kept
+		}
+
+		for _, v := range values {
+			if v != "" && !allowed(picklist, system, v, controlling, slices.Contains(kept, v)) {
+				message := "is not allowed when " + controllingField + " is " + controlling + "."
+				if allowedValues := options(picklist, system, controlling); len(allowedValues) > 0 {
+					message = "must be one of " + strings.Join(allowedValues, ", ") + "."
+				}
+
+				invalid = append(invalid, &code.FieldError{
+					Field:   field,
+					Code:    "picklist",
+					Message: message,
+				})
+				break
+			}
+		}
+	}
+
+	if len(invalid) > 0 {
+		return code.UnprocessableEntity, code.GetCodeMessage(code.UnprocessableEntity, invalid)
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, nil)
+}
+
+// written returns the values held by a *string or *[]string field, nil when the field is not written.
+func written(value any) []string {
+	switch value := value.(type) {
+	case *string:
+		if value != nil {
+			return []string{*value}
+		}
+	case *[]string:
+		if value != nil {
+			return *value
+		}
+	}
+
+	return nil
+}
+
+// first returns the first of values, or an empty string.
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// defaultValue writes the default option of picklist allowed by controlling into value and returns it.
+func defaultValue(picklist *picklistDB.Base, controlling string, value any) []string {
+	for _, option := range picklist.Values {
+		if !option.IsDefault || !option.IsActive || !controlledBy(picklist, option, controlling) {
+			continue
+		}
+
+		switch value := value.(type) {
+		case *string:
+			*value = option.Value
+		case *[]string:
+			*value = []string{option.Value}
+		}
+
+		return []string{option.Value}
+	}
+
+	return written(value)
+}
+
+// allowed reports whether value can be written, a field without a picklist accepts any value unless it has system values.
+func allowed(picklist *picklistDB.Base, system []string, value, controlling string, kept bool) bool {
+	if slices.Contains(system, value) {
+		return true
+	}
+
+	if picklist == nil {
+		return system == nil
+	}
+
+	for _, option := range picklist.Values {
+		if option.Value == value {
+			return (option.IsActive || kept) && controlledBy(picklist, option, controlling)
+		}
+	}
+
+	return false
+}
+
+// controlledBy reports whether the value of the controlling field allows option.
+func controlledBy(picklist *picklistDB.Base, option *picklistDB.Value, controlling string) bool {
+	if picklist.ControllingField == nil || *picklist.ControllingField == "" || len(option.ControllingValues) == 0 {
+		return true
+	}
+
+	return slices.Contains(option.ControllingValues, controlling)
+}
+
+// options lists the values that can be written, the active options allowed by controlling and the system values.
+func options(picklist *picklistDB.Base, system []string, controlling string) []string {
+	output := slices.Clone(system)
+	if picklist == nil {
+		return output
+	}
+
+	for _, option := range picklist.Values {
+		if option.IsActive && controlledBy(picklist, option, controlling) && !slices.Contains(output, option.Value) {
+			output = append(output, option.Value)
+		}
+	}
+
+	return output
+}
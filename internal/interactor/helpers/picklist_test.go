@@ -0,0 +1,199 @@
+package helpers
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	picklistDB "crm/internal/entity/postgresql/db/picklists"
+	userDB "crm/internal/entity/postgresql/db/users"
+	"crm/internal/interactor/models/enums"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/code"
+	picklistService "crm/internal/interactor/service/picklist"
+	userService "crm/internal/interactor/service/user"
+)
+
+// stubUsers finds every user in the same company.
+type stubUsers struct {
+	userService.Service
+}
+
+func (stubUsers) GetByCache(userID string) (*userDB.Base, error) {
+	return &userDB.Base{UserID: util.PointerString(userID), CompanyID: util.PointerString("company")}, nil
+}
+
+type stubPicklists struct {
+	picklistService.Service
+	picklists map[string][]*picklistDB.Base
+	err       error
+}
+
+func (s stubPicklists) GetByCache(companyID, entity string) ([]*picklistDB.Base, error) {
+	return s.picklists[entity], s.err
+}
+
+// invalidFields returns the fields of a 422 answer.
+func invalidFields(t *testing.T, codeMessage any) []string {
+	t.Helper()
+	message, ok := codeMessage.(*code.ErrorMessage)
+	if !ok {
+		t.Fatalf("answer %#v is not an error", codeMessage)
+	}
+
+	var fields []string
+	for _, invalid := range message.Detailed.([]*code.FieldError) {
+		fields = append(fields, invalid.Field)
+	}
+
+	return fields
+}
+
+var picklists = map[string][]*picklistDB.Base{
+	"opportunities": {
+		{
+			Field: util.PointerString("stage"),
+			Values: []*picklistDB.Value{
+				{Value: "prospecting", IsDefault: true, IsActive: true},
+				{Value: "closed_won", IsActive: true},
+				{Value: "legacy"},
+			},
+		},
+		{
+			Field:            util.PointerString("forecast_category"),
+			ControllingField: util.PointerString("stage"),
+			Values: []*picklistDB.Value{
+				{Value: "pipeline", IsDefault: true, IsActive: true, ControllingValues: []string{"prospecting"}},
+				{Value: "closed", IsActive: true, ControllingValues: []string{"closed_won"}},
+				{Value: "omitted", IsActive: true},
+			},
+		},
+	},
+	"accounts": {
+		{
+			Field: util.PointerString("type"),
+			Values: []*picklistDB.Value{
+				{Value: "customer", IsActive: true},
+				{Value: "partner", IsActive: true},
+			},
+		},
+	},
+}
+
+func TestCheckPicklists(t *testing.T) {
+	tests := []struct {
+		name        string
+		entity      string
+		fields      map[string]any
+		current     map[string]any
+		wantCode    int
+		wantInvalid []string
+	}{
+		{"active values", "opportunities", map[string]any{
+			"stage": util.PointerString("prospecting"), "forecast_category": util.PointerString("pipeline"),
+		}, nil, code.Successful, nil},
+		{"unknown value", "opportunities", map[string]any{
+			"stage": util.PointerString("won"), "forecast_category": util.PointerString("omitted"),
+		}, nil, code.UnprocessableEntity, []string{"stage"}},
+		{"inactive value on create", "opportunities", map[string]any{
+			"stage": util.PointerString("legacy"), "forecast_category": util.PointerString("omitted"),
+		}, nil, code.UnprocessableEntity, []string{"stage"}},
+		{"inactive value the record already has", "opportunities", map[string]any{
+			"stage": util.PointerString("legacy"), "forecast_category": (*string)(nil),
+		}, map[string]any{
+			"stage": util.PointerString("legacy"), "forecast_category": util.PointerString("omitted"),
+		}, code.Successful, nil},
+		{"inactive value the record does not have", "opportunities", map[string]any{
+			"stage": util.PointerString("legacy"), "forecast_category": (*string)(nil),
+		}, map[string]any{
+			"stage": util.PointerString("prospecting"), "forecast_category": util.PointerString("omitted"),
+		}, code.UnprocessableEntity, []string{"stage"}},
+		{"dependent value not allowed by the controlling value", "opportunities", map[string]any{
+			"stage": util.PointerString("closed_won"), "forecast_category": util.PointerString("pipeline"),
+		}, nil, code.UnprocessableEntity, []string{"forecast_category"}},
+		{"dependent value allowed by the stored controlling value", "opportunities", map[string]any{
+			"stage": (*string)(nil), "forecast_category": util.PointerString("closed"),
+		}, map[string]any{
+			"stage": util.PointerString("closed_won"), "forecast_category": util.PointerString("omitted"),
+		}, code.Successful, nil},
+		{"controlling change invalidates the stored dependent value", "opportunities", map[string]any{
+			"stage": util.PointerString("closed_won"), "forecast_category": (*string)(nil),
+		}, map[string]any{
+			"stage": util.PointerString("prospecting"), "forecast_category": util.PointerString("pipeline"),
+		}, code.UnprocessableEntity, []string{"forecast_category"}},
+		{"controlling change keeps an unrestricted dependent value", "opportunities", map[string]any{
+			"stage": util.PointerString("closed_won"), "forecast_category": (*string)(nil),
+		}, map[string]any{
+			"stage": util.PointerString("prospecting"), "forecast_category": util.PointerString("omitted"),
+		}, code.Successful, nil},
+		{"fields left out of an update", "opportunities", map[string]any{
+			"stage": (*string)(nil), "forecast_category": (*string)(nil),
+		}, map[string]any{
+			"stage": util.PointerString("legacy"), "forecast_category": util.PointerString("pipeline"),
+		}, code.Successful, nil},
+		{"list values", "accounts", map[string]any{
+			"type": &[]string{"customer", "partner"},
+		}, nil, code.Successful, nil},
+		{"list with an unknown value", "accounts", map[string]any{
+			"type": &[]string{"customer", "vendor"},
+		}, nil, code.UnprocessableEntity, []string{"type"}},
+		{"system value without picklist", "leads", map[string]any{
+			"status": util.PointerString(enums.LeadStatusNew), "source": util.PointerString("web"),
+		}, nil, code.Successful, nil},
+		{"only system values without picklist", "leads", map[string]any{
+			"status": util.PointerString("lost"),
+		}, nil, code.UnprocessableEntity, []string{"status"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpCode, codeMessage := CheckPicklists(stubPicklists{picklists: picklists}, stubUsers{}, "user", tt.entity, tt.fields, tt.current)
+			if httpCode != tt.wantCode {
+				t.Fatalf("CheckPicklists() = %d %#v, want %d", httpCode, codeMessage, tt.wantCode)
+			}
+
+			if httpCode == code.UnprocessableEntity && !reflect.DeepEqual(invalidFields(t, codeMessage), tt.wantInvalid) {
+				t.Errorf("CheckPicklists() invalid = %v, want %v", invalidFields(t, codeMessage), tt.wantInvalid)
+			}
+		})
+	}
+}
+
+func TestCheckPicklistsDefaults(t *testing.T) {
+	tests := []struct {
+		name             string
+		stage            string
+		forecastCategory string
+		wantStage        string
+		wantForecast     string
+	}{
+		{"empty fields receive their defaults", "", "", "prospecting", "pipeline"},
+		{"default of the dependent field follows the written controlling value", "closed_won", "", "closed_won", ""},
+		{"written values are kept", "closed_won", "closed", "closed_won", "closed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stage, forecastCategory := tt.stage, tt.forecastCategory
+			httpCode, codeMessage := CheckPicklists(stubPicklists{picklists: picklists}, stubUsers{}, "user", "opportunities", map[string]any{
+				"stage": &stage, "forecast_category": &forecastCategory,
+			}, nil)
+			if httpCode != code.Successful {
+				t.Fatalf("CheckPicklists() = %d %#v", httpCode, codeMessage)
+			}
+
+			if stage != tt.wantStage || forecastCategory != tt.wantForecast {
+				t.Errorf("CheckPicklists() wrote %q, %q, want %q, %q", stage, forecastCategory, tt.wantStage, tt.wantForecast)
+			}
+		})
+	}
+}
+
+func TestCheckPicklistsError(t *testing.T) {
+	httpCode, _ := CheckPicklists(stubPicklists{err: errors.New("down")}, stubUsers{}, "user", "opportunities", map[string]any{
+		"stage": util.PointerString("prospecting"),
+	}, nil)
+	if httpCode != code.InternalServerError {
+		t.Errorf("CheckPicklists() = %d, want %d", httpCode, code.InternalServerError)
+	}
+}
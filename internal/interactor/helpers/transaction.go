@@ -0,0 +1,54 @@
+package helpers
+
+import (
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// Savepoint runs fn in a savepoint of trx, only the work of fn is rolled back when it returns an error.
+// The enclosing transaction is still committed or rolled back by middleware.Transaction.
+func Savepoint(trx *gorm.DB, fn func(trx *gorm.DB) error) error {
+	// gorm creates a savepoint for a transaction started inside another one
+	return trx.Transaction(fn)
+}
+
+type afterCommitKey struct{}
+
+// afterCommit holds the functions to run once a transaction is committed.
+type afterCommit struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+// WithAfterCommit returns a context for a transaction collecting the functions given to AfterCommit,
+// and the function running them, which is called once the transaction is committed.
+func WithAfterCommit(ctx context.Context) (context.Context, func()) {
+	hooks := &afterCommit{}
+	return context.WithValue(ctx, afterCommitKey{}, hooks), func() {
+		hooks.mu.Lock()
+		fns := hooks.fns
+		hooks.fns = nil
+		hooks.mu.Unlock()
+
+		for _, fn := range fns {
+			fn()
+		}
+	}
+}
+
+// AfterCommit runs fn once the transaction of trx is committed and drops it when the transaction is rolled back.
+// Outside a transaction opened by WithAfterCommit, fn runs right away.
+func AfterCommit(trx *gorm.DB, fn func()) {
+	if trx != nil && trx.Statement != nil && trx.Statement.Context != nil {
+		if hooks, ok := trx.Statement.Context.Value(afterCommitKey{}).(*afterCommit); ok {
+			hooks.mu.Lock()
+			hooks.fns = append(hooks.fns, fn)
+			hooks.mu.Unlock()
+			return
+		}
+	}
+
+	fn()
+}
@@ -0,0 +1,75 @@
+package helpers
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"crm/internal/interactor/pkg/util/code"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// versioned is implemented by return structures embedding section.TimeAt.
+type versioned interface {
+	Version() *time.Time
+}
+
+// ETag returns the strong entity tag of a record version, the version is its updated_at.
+func ETag(updatedAt time.Time) string {
+	return `"` + strconv.FormatInt(updatedAt.UnixMicro(), 36) + `"`
+}
+
+// SetETag writes the ETag header for the record carried by a code message.
+// The record is the body of a successful message or the detail of a 412 message.
+func SetETag(ctx *gin.Context, codeMessage any) {
+	var record any
+	switch message := codeMessage.(type) {
+	case *code.SuccessfulMessage:
+		record = message.Body
+	case *code.ErrorMessage:
+		record = message.Detailed
+	}
+
+	if record, ok := record.(versioned); ok && record.Version() != nil {
+		ctx.Header("ETag", ETag(*record.Version()))
+	}
+}
+
+// CheckVersion locks the record in trx until the transaction ends and reports whether ifMatch names its version.
+// ifMatch is the value of the If-Match header, "*" matches any version.
+func CheckVersion(trx *gorm.DB, table, column, id, ifMatch string) (bool, error) {
+	var updatedAt []time.Time
+	err := trx.Table(table).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(column+" = ?", id).Where("deleted_at is null").
+		Pluck("updated_at", &updatedAt).Error
+	if err != nil {
+		return false, err
+	}
+
+	if len(updatedAt) == 0 {
+		return false, gorm.ErrRecordNotFound
+	}
+
+	etag := ETag(updatedAt[0])
+	for _, tag := range strings.Split(ifMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == etag {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// PreconditionFailed turns the result of GetBySingle into a 412 answer carrying the current record.
+func PreconditionFailed(httpCode int, codeMessage any) (int, any) {
+	message, ok := codeMessage.(*code.SuccessfulMessage)
+	if httpCode != code.Successful || !ok {
+		return httpCode, codeMessage
+	}
+
+	return code.PreconditionFailed, code.GetCodeMessage(code.PreconditionFailed, message.Body)
+}
@@ -7,12 +7,16 @@ import (
 	"strings"
 
 	"crm/internal/interactor/helpers"
+	bulkModel "crm/internal/interactor/models/bulk"
+	"crm/internal/interactor/models/enums"
 
 	historicalRecordModel "crm/internal/interactor/models/historical_records"
-	industryModel "crm/internal/interactor/models/industries"
-	userModel "crm/internal/interactor/models/users"
+	customFieldService "crm/internal/interactor/service/custom_field"
+	duplicateService "crm/internal/interactor/service/duplicate"
 	historicalRecordService "crm/internal/interactor/service/historical_record"
 	industryService "crm/internal/interactor/service/industry"
+	picklistService "crm/internal/interactor/service/picklist"
+	recycleBinService "crm/internal/interactor/service/recycle_bin"
 	userService "crm/internal/interactor/service/user"
 
 	contactModel "crm/internal/interactor/models/contacts"
@@ -24,6 +28,9 @@ import (
 
 	"gorm.io/gorm"
 
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/page"
+	sortModel "crm/internal/interactor/models/sort"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
 )
@@ -34,8 +41,9 @@ type Manager interface {
 	GetByListNoPagination(input *accountModel.FieldsNoPagination) (int, any)
 	GetBySingle(input *accountModel.Field) (int, any)
 	GetBySingleContacts(input *accountModel.Field) (int, any)
-	Delete(input *accountModel.Field) (int, any)
+	Delete(trx *gorm.DB, input *accountModel.Update) (int, any)
 	Update(trx *gorm.DB, input *accountModel.Update) (int, any)
+	Bulk(trx *gorm.DB, input *accountModel.Bulk) (int, any)
 }
 
 type manager struct {
@@ -44,6 +52,10 @@ type manager struct {
 	HistoricalRecordService historicalRecordService.Service
 	IndustryService         industryService.Service
 	UserService             userService.Service
+	DuplicateService        duplicateService.Service
+	RecycleBinService       recycleBinService.Service
+	PicklistService         picklistService.Service
+	CustomFieldService      customFieldService.Service
 }
 
 func Init(db *gorm.DB) Manager {
@@ -53,16 +65,30 @@ func Init(db *gorm.DB) Manager {
 		HistoricalRecordService: historicalRecordService.Init(db),
 		IndustryService:         industryService.Init(db),
 		UserService:             userService.Init(db),
+		DuplicateService:        duplicateService.Init(db),
+		RecycleBinService:       recycleBinService.Init(db),
+		PicklistService:         picklistService.Init(db),
+		CustomFieldService:      customFieldService.Init(db),
 	}
 }
 
-const sourceType = "帳戶"
+const sourceType = enums.SourceTypeAccount
 
 func (m *manager) Create(trx *gorm.DB, input *accountModel.Create) (int, any) {
-	defer trx.Rollback()
-
 	// 陣列排序
 	sort.Strings(input.Type)
+	// 依公司的選項清單驗證,未帶入的欄位使用預設值
+	if httpCode, codeMessage := helpers.CheckPicklists(m.PicklistService, m.UserService, input.CreatedBy, "accounts", map[string]any{
+		"type": &input.Type,
+	}, nil); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
+
+	// 依公司的自訂欄位驗證,未帶入的欄位使用預設值
+	if httpCode, codeMessage := helpers.CheckCustomFields(m.CustomFieldService, m.UserService, input.CreatedBy, "accounts", &input.CustomFields, nil); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
+
 	accountBase, err := m.AccountService.WithTrx(trx).Create(input)
 	if err != nil {
 		log.Error(err)
@@ -72,7 +98,7 @@ func (m *manager) Create(trx *gorm.DB, input *accountModel.Create) (int, any) {
 	// 同步新增帳戶歷程記錄
 	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
 		SourceID:   *accountBase.AccountID,
-		Action:     "建立",
+		Action:     enums.ActionCreated,
 		SourceType: sourceType,
 		ModifiedBy: *accountBase.CreatedBy,
 	})
@@ -81,20 +107,37 @@ func (m *manager) Create(trx *gorm.DB, input *accountModel.Create) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	trx.Commit()
-	return code.Successful, code.GetCodeMessage(code.Successful, accountBase.AccountID)
+	return helpers.WarnDuplicates(trx, m.DuplicateService, m.UserService, input.CreatedBy, "accounts", *accountBase.AccountID,
+		code.Successful, code.GetCodeMessage(code.Successful, accountBase.AccountID))
 }
 
 func (m *manager) GetByList(input *accountModel.Fields) (int, any) {
 	output := &accountModel.List{}
+	if err := input.Projection.Validate(output, "accounts", accountModel.Relations); err != nil {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+	}
+
 	output.Limit = input.Limit
 	output.Page = input.Page
-	quantity, accountBase, err := m.AccountService.GetByList(input)
+	// 公司的自訂欄位可用於搜尋及排序
+	customColumns, err := helpers.CustomColumns(m.CustomFieldService, input.CompanyID, "accounts")
 	if err != nil {
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
+
+	input.CustomColumns = customColumns
+	quantity, accountBase, cursors, err := m.AccountService.GetByList(input)
+	if err != nil {
+		if errors.Is(err, filter.ErrInvalid) || errors.Is(err, sortModel.ErrInvalid) || errors.Is(err, page.ErrInvalid) {
+			return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
 	output.Total.Total = quantity
+	output.Cursors = cursors
 	accountByte, err := json.Marshal(accountBase)
 	if err != nil {
 		log.Error(err)
@@ -124,7 +167,14 @@ func (m *manager) GetByList(input *accountModel.Fields) (int, any) {
 		}
 	}
 
-	return code.Successful, code.GetCodeMessage(code.Successful, output)
+	// 篩選回傳欄位並嵌入展開的關聯
+	body, err := input.Projection.Shape(output, "accounts", accountBase, accountModel.Relations)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, body)
 }
 
 func (m *manager) GetByListNoPagination(input *accountModel.FieldsNoPagination) (int, any) {
@@ -234,7 +284,7 @@ func (m *manager) GetBySingleContacts(input *accountModel.Field) (int, any) {
 	return code.Successful, code.GetCodeMessage(code.Successful, output)
 }
 
-func (m *manager) Delete(input *accountModel.Field) (int, any) {
+func (m *manager) Delete(trx *gorm.DB, input *accountModel.Update) (int, any) {
 	_, err := m.AccountService.GetBySingle(&accountModel.Field{
 		AccountID: input.AccountID,
 	})
@@ -247,7 +297,31 @@ func (m *manager) Delete(input *accountModel.Field) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	err = m.AccountService.Delete(input)
+	// 依刪除規則刪除並移至資源回收筒,有限制刪除的關聯資料時回傳受影響的資料
+	preview, cascaded, err := m.RecycleBinService.WithTrx(trx).Delete("accounts", input.AccountID, *input.UpdatedBy)
+	if err != nil {
+		if errors.Is(err, recycleBinService.ErrRestricted) {
+			return code.Conflict, code.GetCodeMessage(code.Conflict, preview)
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 同步新增刪除歷程記錄
+	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
+		SourceID:   input.AccountID,
+		Action:     enums.ActionDeleted,
+		SourceType: sourceType,
+		ModifiedBy: *input.UpdatedBy,
+	})
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 同步新增一併刪除資料的刪除歷程記錄
+	err = helpers.RecordCascaded(m.HistoricalRecordService.WithTrx(trx), cascaded, enums.ActionDeleted, *input.UpdatedBy)
 	if err != nil {
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
@@ -257,8 +331,6 @@ func (m *manager) Delete(input *accountModel.Field) (int, any) {
 }
 
 func (m *manager) Update(trx *gorm.DB, input *accountModel.Update) (int, any) {
-	defer trx.Rollback()
-
 	accountBase, err := m.AccountService.GetBySingle(&accountModel.Field{
 		AccountID: input.AccountID,
 	})
@@ -271,6 +343,21 @@ func (m *manager) Update(trx *gorm.DB, input *accountModel.Update) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	// 樂觀鎖定,版本不符時回傳目前資料
+	if input.IfMatch != "" {
+		matched, err := helpers.CheckVersion(trx, "accounts", "account_id", input.AccountID, input.IfMatch)
+		if err != nil {
+			log.Error(err)
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+
+		if !matched {
+			return helpers.PreconditionFailed(m.GetBySingle(&accountModel.Field{
+				AccountID: input.AccountID,
+			}))
+		}
+	}
+
 	// 比對帳戶類型是否變更
 	if input.Type != nil {
 		if len(*input.Type) != len(*accountBase.Type) {
@@ -284,6 +371,19 @@ func (m *manager) Update(trx *gorm.DB, input *accountModel.Update) (int, any) {
 		}
 	}
 
+	if httpCode, codeMessage := helpers.CheckPicklists(m.PicklistService, m.UserService, *input.UpdatedBy, "accounts", map[string]any{
+		"type": input.Type,
+	}, map[string]any{
+		"type": accountBase.Type,
+	}); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
+
+	// 依公司的自訂欄位驗證,僅更新帶入的欄位
+	if httpCode, codeMessage := helpers.CheckCustomFields(m.CustomFieldService, m.UserService, *input.UpdatedBy, "accounts", &input.CustomFields, accountBase.CustomFields); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
+
 	err = m.AccountService.WithTrx(trx).Update(input)
 	if err != nil {
 		log.Error(err)
@@ -294,7 +394,7 @@ func (m *manager) Update(trx *gorm.DB, input *accountModel.Update) (int, any) {
 	var records []historicalRecordModel.AddHistoricalRecord
 
 	if input.Name != nil && *input.Name != *accountBase.Name {
-		helpers.AddHistoricalRecord(&records, "修改", "名稱為", *input.Name)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldName, *input.Name)
 	}
 
 	// 比對帳戶類型是否變更
@@ -312,29 +412,27 @@ func (m *manager) Update(trx *gorm.DB, input *accountModel.Update) (int, any) {
 	}
 
 	if inputType != "" {
-		helpers.AddHistoricalRecord(&records, "修改", "類型為", inputType)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldType, inputType)
 	}
 
 	if input.PhoneNumber != nil {
 		if *input.PhoneNumber != *accountBase.PhoneNumber {
 			if *input.PhoneNumber == "" {
-				helpers.AddHistoricalRecord(&records, "清除", "電話號碼", "")
+				helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldPhoneNumber, "")
 			} else {
-				helpers.AddHistoricalRecord(&records, "修改", "電話號碼為", *input.PhoneNumber)
+				helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldPhoneNumber, *input.PhoneNumber)
 			}
 		}
 	} else if *accountBase.PhoneNumber != "" {
-		helpers.AddHistoricalRecord(&records, "清除", "電話號碼", "")
+		helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldPhoneNumber, "")
 	}
 
 	if accountBase.IndustryID != nil {
 		if input.IndustryID != nil && *input.IndustryID != *accountBase.IndustryID {
-			industryBase, _ := m.IndustryService.GetBySingle(&industryModel.Field{
-				IndustryID: *input.IndustryID,
-			})
-			helpers.AddHistoricalRecord(&records, "修改", "行業為", *industryBase.Name)
+			industryBase, _ := m.IndustryService.GetByCache(*input.IndustryID)
+			helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldIndustry, *industryBase.Name)
 		} else {
-			helpers.AddHistoricalRecord(&records, "移除", "行業", "")
+			helpers.AddHistoricalRecord(&records, enums.ActionRemoved, enums.FieldIndustry, "")
 		}
 	}
 
@@ -343,17 +441,15 @@ func (m *manager) Update(trx *gorm.DB, input *accountModel.Update) (int, any) {
 			parentAccountBase, _ := m.AccountService.GetBySingle(&accountModel.Field{
 				AccountID: input.AccountID,
 			})
-			helpers.AddHistoricalRecord(&records, "修改", "父系帳戶為", *parentAccountBase.Name)
+			helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldParentAccount, *parentAccountBase.Name)
 		} else {
-			helpers.AddHistoricalRecord(&records, "移除", "父系帳戶", "")
+			helpers.AddHistoricalRecord(&records, enums.ActionRemoved, enums.FieldParentAccount, "")
 		}
 	}
 
 	if input.SalespersonID != nil && *input.SalespersonID != *accountBase.SalespersonID {
-		salespersonBase, _ := m.UserService.GetBySingle(&userModel.Field{
-			UserID: *input.SalespersonID,
-		})
-		helpers.AddHistoricalRecord(&records, "修改", "業務員為", *salespersonBase.Name)
+		salespersonBase, _ := m.UserService.GetByCache(*input.SalespersonID)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldSalesperson, *salespersonBase.Name)
 	}
 
 	for _, record := range records {
@@ -371,6 +467,74 @@ func (m *manager) Update(trx *gorm.DB, input *accountModel.Update) (int, any) {
 		}
 	}
 
-	trx.Commit()
-	return code.Successful, code.GetCodeMessage(code.Successful, accountBase.AccountID)
+	return helpers.WarnDuplicates(trx, m.DuplicateService, m.UserService, *input.UpdatedBy, "accounts", *accountBase.AccountID,
+		code.Successful, code.GetCodeMessage(code.Successful, accountBase.AccountID))
+}
+
+// Bulk creates, updates and deletes accounts at once, see helpers.Bulk for the modes.
+func (m *manager) Bulk(trx *gorm.DB, input *accountModel.Bulk) (int, any) {
+	history := m.HistoricalRecordService.Batch()
+	var operations []*helpers.Operation
+	for i, create := range input.Create {
+		create.CreatedBy = input.UserID
+		operations = append(operations, &helpers.Operation{
+			Action: bulkModel.Create,
+			Index:  i,
+			Input:  create,
+			Run: func(trx *gorm.DB) (int, any) {
+				return m.scoped(trx, history).Create(trx, create)
+			},
+		})
+	}
+
+	for i, item := range input.Update {
+		update := &item.Update
+		update.UpdatedBy = util.PointerString(input.UserID)
+		update.IfMatch = item.IfMatch
+		operations = append(operations, &helpers.Operation{
+			Action: bulkModel.Update,
+			Index:  i,
+			ID:     update.AccountID,
+			Input:  update,
+			// 公司要求 If-Match 時,未帶版本的項目不更新,版本不符的項目由 Update 回傳 412
+			Unversioned: input.IfMatchRequired && item.IfMatch == "",
+			Run: func(trx *gorm.DB) (int, any) {
+				return m.scoped(trx, history).Update(trx, update)
+			},
+		})
+	}
+
+	for i, accountID := range input.Delete {
+		update := &accountModel.Update{
+			AccountID: accountID,
+			UpdatedBy: util.PointerString(input.UserID),
+		}
+		operations = append(operations, &helpers.Operation{
+			Action: bulkModel.Delete,
+			Index:  i,
+			ID:     accountID,
+			Input:  update,
+			Run: func(trx *gorm.DB) (int, any) {
+				return m.scoped(trx, history).Delete(trx, update)
+			},
+		})
+	}
+
+	return helpers.Bulk(trx, input.Mode.Mode, history, operations)
+}
+
+// scoped returns a copy of the manager whose services run in trx, every service is carried over.
+// Its historical records are held by history.
+func (m *manager) scoped(trx *gorm.DB, history historicalRecordService.Batch) *manager {
+	scoped := *m
+	scoped.AccountService = m.AccountService.WithTrx(trx)
+	scoped.ContactService = m.ContactService.WithTrx(trx)
+	scoped.IndustryService = m.IndustryService.WithTrx(trx)
+	scoped.UserService = m.UserService.WithTrx(trx)
+	scoped.DuplicateService = m.DuplicateService.WithTrx(trx)
+	scoped.RecycleBinService = m.RecycleBinService.WithTrx(trx)
+	scoped.PicklistService = m.PicklistService.WithTrx(trx)
+	scoped.CustomFieldService = m.CustomFieldService.WithTrx(trx)
+	scoped.HistoricalRecordService = history
+	return &scoped
 }
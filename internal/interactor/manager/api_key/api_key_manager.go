@@ -0,0 +1,109 @@
+package api_key
+
+import (
+	"encoding/json"
+	"errors"
+
+	apiKeyModel "crm/internal/interactor/models/api_keys"
+	userModel "crm/internal/interactor/models/users"
+	apiKeyService "crm/internal/interactor/service/api_key"
+	userService "crm/internal/interactor/service/user"
+
+	"gorm.io/gorm"
+
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+)
+
+type Manager interface {
+	Create(input *apiKeyModel.Create) (int, any)
+	GetByList(input *apiKeyModel.Field) (int, any)
+	Delete(input *apiKeyModel.Field) (int, any)
+}
+
+type manager struct {
+	APIKeyService apiKeyService.Service
+	UserService   userService.Service
+}
+
+func Init(db *gorm.DB) Manager {
+	return &manager{
+		APIKeyService: apiKeyService.Init(db),
+		UserService:   userService.Init(db),
+	}
+}
+
+func (m *manager) Create(input *apiKeyModel.Create) (int, any) {
+	// the key acts as a user of the same company
+	if input.UserID != "" {
+		userBase, err := m.UserService.GetBySingle(&userModel.Field{
+			UserID: input.UserID,
+		})
+		if err == nil && (userBase.CompanyID == nil || *userBase.CompanyID != input.CompanyID) {
+			err = gorm.ErrRecordNotFound
+		}
+
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, "user "+input.UserID+" does not exist")
+			}
+
+			log.Error(err)
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+	}
+
+	apiKeyBase, key, err := m.APIKeyService.Create(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, &apiKeyModel.Issued{
+		APIKeyID: *apiKeyBase.APIKeyID,
+		Key:      key,
+	})
+}
+
+func (m *manager) GetByList(input *apiKeyModel.Field) (int, any) {
+	output := &apiKeyModel.List{}
+	apiKeyBase, err := m.APIKeyService.GetByList(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	apiKeyByte, err := json.Marshal(apiKeyBase)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	err = json.Unmarshal(apiKeyByte, &output.APIKeys)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, output)
+}
+
+func (m *manager) Delete(input *apiKeyModel.Field) (int, any) {
+	_, err := m.APIKeyService.GetBySingle(input)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, err.Error())
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	err = m.APIKeyService.Delete(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, "Delete ok!")
+}
@@ -4,16 +4,26 @@ import (
 	"encoding/json"
 	"errors"
 
+	"crm/internal/interactor/helpers"
+	"crm/internal/interactor/models/enums"
 	opportunityModel "crm/internal/interactor/models/opportunities"
 	opportunityService "crm/internal/interactor/service/opportunity"
+	picklistService "crm/internal/interactor/service/picklist"
+	userService "crm/internal/interactor/service/user"
 
 	"crm/internal/interactor/pkg/util"
 
 	campaignModel "crm/internal/interactor/models/campaigns"
 	campaignService "crm/internal/interactor/service/campaign"
 
+	historicalRecordModel "crm/internal/interactor/models/historical_records"
+	historicalRecordService "crm/internal/interactor/service/historical_record"
+	recycleBinService "crm/internal/interactor/service/recycle_bin"
 	"gorm.io/gorm"
 
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/sort"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
 )
@@ -24,24 +34,38 @@ type Manager interface {
 	GetByListNoPagination(input *campaignModel.Field) (int, any)
 	GetBySingle(input *campaignModel.Field) (int, any)
 	GetBySingleOpportunities(input *campaignModel.Field) (int, any)
-	Delete(input *campaignModel.Field) (int, any)
+	Delete(trx *gorm.DB, input *campaignModel.Update) (int, any)
 	Update(input *campaignModel.Update) (int, any)
 }
 
 type manager struct {
-	CampaignService    campaignService.Service
-	OpportunityService opportunityService.Service
+	CampaignService         campaignService.Service
+	OpportunityService      opportunityService.Service
+	RecycleBinService       recycleBinService.Service
+	HistoricalRecordService historicalRecordService.Service
+	PicklistService         picklistService.Service
+	UserService             userService.Service
 }
 
 func Init(db *gorm.DB) Manager {
 	return &manager{
-		CampaignService:    campaignService.Init(db),
-		OpportunityService: opportunityService.Init(db),
+		CampaignService:         campaignService.Init(db),
+		OpportunityService:      opportunityService.Init(db),
+		RecycleBinService:       recycleBinService.Init(db),
+		HistoricalRecordService: historicalRecordService.Init(db),
+		PicklistService:         picklistService.Init(db),
+		UserService:             userService.Init(db),
 	}
 }
 
 func (m *manager) Create(trx *gorm.DB, input *campaignModel.Create) (int, any) {
-	defer trx.Rollback()
+	// 依公司的選項清單驗證,未帶入的欄位使用預設值
+	if httpCode, codeMessage := helpers.CheckPicklists(m.PicklistService, m.UserService, input.CreatedBy, "campaigns", map[string]any{
+		"type":   &input.Type,
+		"status": &input.Status,
+	}, nil); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
 
 	campaignBase, err := m.CampaignService.WithTrx(trx).Create(input)
 	if err != nil {
@@ -49,20 +73,28 @@ func (m *manager) Create(trx *gorm.DB, input *campaignModel.Create) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, campaignBase.CampaignID)
 }
 
 func (m *manager) GetByList(input *campaignModel.Fields) (int, any) {
 	output := &campaignModel.List{}
+	if err := input.Projection.Validate(output, "campaigns", campaignModel.Relations); err != nil {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+	}
+
 	output.Limit = input.Limit
 	output.Page = input.Page
-	quantity, campaignBase, err := m.CampaignService.GetByList(input)
+	quantity, campaignBase, cursors, err := m.CampaignService.GetByList(input)
 	if err != nil {
+		if errors.Is(err, filter.ErrInvalid) || errors.Is(err, sort.ErrInvalid) || errors.Is(err, page.ErrInvalid) {
+			return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+		}
+
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 	output.Total.Total = quantity
+	output.Cursors = cursors
 	campaignByte, err := json.Marshal(campaignBase)
 	if err != nil {
 		log.Error(err)
@@ -91,7 +123,14 @@ func (m *manager) GetByList(input *campaignModel.Fields) (int, any) {
 		}
 	}
 
-	return code.Successful, code.GetCodeMessage(code.Successful, output)
+	// 篩選回傳欄位並嵌入展開的關聯
+	body, err := input.Projection.Shape(output, "campaigns", campaignBase, campaignModel.Relations)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, body)
 }
 
 func (m *manager) GetByListNoPagination(input *campaignModel.Field) (int, any) {
@@ -193,7 +232,7 @@ func (m *manager) GetBySingleOpportunities(input *campaignModel.Field) (int, any
 	return code.Successful, code.GetCodeMessage(code.Successful, output)
 }
 
-func (m *manager) Delete(input *campaignModel.Field) (int, any) {
+func (m *manager) Delete(trx *gorm.DB, input *campaignModel.Update) (int, any) {
 	_, err := m.CampaignService.GetBySingle(&campaignModel.Field{
 		CampaignID: input.CampaignID,
 	})
@@ -206,7 +245,31 @@ func (m *manager) Delete(input *campaignModel.Field) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	err = m.CampaignService.Delete(input)
+	// 依刪除規則刪除並移至資源回收筒,有限制刪除的關聯資料時回傳受影響的資料
+	preview, cascaded, err := m.RecycleBinService.WithTrx(trx).Delete("campaigns", input.CampaignID, *input.UpdatedBy)
+	if err != nil {
+		if errors.Is(err, recycleBinService.ErrRestricted) {
+			return code.Conflict, code.GetCodeMessage(code.Conflict, preview)
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 同步新增刪除歷程記錄
+	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
+		SourceID:   input.CampaignID,
+		Action:     enums.ActionDeleted,
+		SourceType: enums.SourceTypeCampaign,
+		ModifiedBy: *input.UpdatedBy,
+	})
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 同步新增一併刪除資料的刪除歷程記錄
+	err = helpers.RecordCascaded(m.HistoricalRecordService.WithTrx(trx), cascaded, enums.ActionDeleted, *input.UpdatedBy)
 	if err != nil {
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
@@ -228,6 +291,16 @@ func (m *manager) Update(input *campaignModel.Update) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	if httpCode, codeMessage := helpers.CheckPicklists(m.PicklistService, m.UserService, *input.UpdatedBy, "campaigns", map[string]any{
+		"type":   input.Type,
+		"status": input.Status,
+	}, map[string]any{
+		"type":   campaignBase.Type,
+		"status": campaignBase.Status,
+	}); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
+
 	err = m.CampaignService.Update(input)
 	if err != nil {
 		log.Error(err)
@@ -0,0 +1,66 @@
+package company_setting
+
+import (
+	"encoding/json"
+
+	companySettingModel "crm/internal/interactor/models/company_settings"
+	companySettingService "crm/internal/interactor/service/company_setting"
+
+	"gorm.io/gorm"
+
+	"crm/internal/interactor/helpers"
+	"crm/internal/interactor/pkg/cache"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+)
+
+type Manager interface {
+	GetBySingle(input *companySettingModel.Field) (int, any)
+	Update(trx *gorm.DB, input *companySettingModel.Update) (int, any)
+}
+
+type manager struct {
+	CompanySettingService companySettingService.Service
+}
+
+func Init(db *gorm.DB) Manager {
+	return &manager{
+		CompanySettingService: companySettingService.Init(db),
+	}
+}
+
+func (m *manager) GetBySingle(input *companySettingModel.Field) (int, any) {
+	companySettingBase, err := m.CompanySettingService.GetBySingle(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	output := &companySettingModel.Single{}
+	companySettingByte, _ := json.Marshal(companySettingBase)
+	err = json.Unmarshal(companySettingByte, &output)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, output)
+}
+
+func (m *manager) Update(trx *gorm.DB, input *companySettingModel.Update) (int, any) {
+	err := m.CompanySettingService.WithTrx(trx).Update(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 交易提交後才清除快取,以免同時的請求在提交前又快取舊設定
+	helpers.AfterCommit(trx, func() {
+		err := cache.Default().Delete(cache.Key(cache.CompanySetting, input.CompanyID))
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	return code.Successful, code.GetCodeMessage(code.Successful, input.CompanyID)
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 
 	"crm/internal/interactor/helpers"
+	bulkModel "crm/internal/interactor/models/bulk"
+	"crm/internal/interactor/models/enums"
 
 	accountContactModel "crm/internal/interactor/models/account_contacts"
 	accountModel "crm/internal/interactor/models/accounts"
@@ -12,7 +14,10 @@ import (
 	userModel "crm/internal/interactor/models/users"
 	accountService "crm/internal/interactor/service/account"
 	accountContactService "crm/internal/interactor/service/account_contact"
+	customFieldService "crm/internal/interactor/service/custom_field"
+	duplicateService "crm/internal/interactor/service/duplicate"
 	historicalRecordService "crm/internal/interactor/service/historical_record"
+	recycleBinService "crm/internal/interactor/service/recycle_bin"
 	userService "crm/internal/interactor/service/user"
 
 	"crm/internal/interactor/pkg/util"
@@ -22,6 +27,9 @@ import (
 
 	"gorm.io/gorm"
 
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/sort"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
 )
@@ -30,8 +38,9 @@ type Manager interface {
 	Create(trx *gorm.DB, input *contactModel.Create) (int, any)
 	GetByList(input *contactModel.Fields) (int, any)
 	GetBySingle(input *contactModel.Field) (int, any)
-	Delete(input *contactModel.Field) (int, any)
+	Delete(trx *gorm.DB, input *contactModel.Update) (int, any)
 	Update(trx *gorm.DB, input *contactModel.Update) (int, any)
+	Bulk(trx *gorm.DB, input *contactModel.Bulk) (int, any)
 	GetByListNoPagination(input *contactModel.Field) (int, any)
 }
 
@@ -41,6 +50,9 @@ type manager struct {
 	HistoricalRecordService historicalRecordService.Service
 	UserService             userService.Service
 	AccountService          accountService.Service
+	DuplicateService        duplicateService.Service
+	RecycleBinService       recycleBinService.Service
+	CustomFieldService      customFieldService.Service
 }
 
 func Init(db *gorm.DB) Manager {
@@ -50,13 +62,19 @@ func Init(db *gorm.DB) Manager {
 		HistoricalRecordService: historicalRecordService.Init(db),
 		UserService:             userService.Init(db),
 		AccountService:          accountService.Init(db),
+		DuplicateService:        duplicateService.Init(db),
+		RecycleBinService:       recycleBinService.Init(db),
+		CustomFieldService:      customFieldService.Init(db),
 	}
 }
 
-const sourceType = "聯絡人"
+const sourceType = enums.SourceTypeContact
 
 func (m *manager) Create(trx *gorm.DB, input *contactModel.Create) (int, any) {
-	defer trx.Rollback()
+	// 依公司的自訂欄位驗證,未帶入的欄位使用預設值
+	if httpCode, codeMessage := helpers.CheckCustomFields(m.CustomFieldService, m.UserService, input.CreatedBy, "contacts", &input.CustomFields, nil); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
 
 	contactBase, err := m.ContactService.WithTrx(trx).Create(input)
 	if err != nil {
@@ -78,7 +96,7 @@ func (m *manager) Create(trx *gorm.DB, input *contactModel.Create) (int, any) {
 	// 同步新增聯絡人歷程記錄
 	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
 		SourceID:   *contactBase.ContactID,
-		Action:     "建立",
+		Action:     enums.ActionCreated,
 		SourceType: sourceType,
 		ModifiedBy: *contactBase.CreatedBy,
 	})
@@ -87,20 +105,37 @@ func (m *manager) Create(trx *gorm.DB, input *contactModel.Create) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	trx.Commit()
-	return code.Successful, code.GetCodeMessage(code.Successful, contactBase.ContactID)
+	return helpers.WarnDuplicates(trx, m.DuplicateService, m.UserService, input.CreatedBy, "contacts", *contactBase.ContactID,
+		code.Successful, code.GetCodeMessage(code.Successful, contactBase.ContactID))
 }
 
 func (m *manager) GetByList(input *contactModel.Fields) (int, any) {
 	output := &contactModel.List{}
+	if err := input.Projection.Validate(output, "contacts", contactModel.Relations); err != nil {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+	}
+
 	output.Limit = input.Limit
 	output.Page = input.Page
-	quantity, contactBase, err := m.ContactService.GetByList(input)
+	// 公司的自訂欄位可用於搜尋及排序
+	customColumns, err := helpers.CustomColumns(m.CustomFieldService, input.CompanyID, "contacts")
 	if err != nil {
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
+
+	input.CustomColumns = customColumns
+	quantity, contactBase, cursors, err := m.ContactService.GetByList(input)
+	if err != nil {
+		if errors.Is(err, filter.ErrInvalid) || errors.Is(err, sort.ErrInvalid) || errors.Is(err, page.ErrInvalid) {
+			return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
 	output.Total.Total = quantity
+	output.Cursors = cursors
 	contactByte, err := json.Marshal(contactBase)
 	if err != nil {
 		log.Error(err)
@@ -130,7 +165,14 @@ func (m *manager) GetByList(input *contactModel.Fields) (int, any) {
 		}
 	}
 
-	return code.Successful, code.GetCodeMessage(code.Successful, output)
+	// 篩選回傳欄位並嵌入展開的關聯
+	body, err := input.Projection.Shape(output, "contacts", contactBase, contactModel.Relations)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, body)
 }
 
 func (m *manager) GetByListNoPagination(input *contactModel.Field) (int, any) {
@@ -191,7 +233,7 @@ func (m *manager) GetBySingle(input *contactModel.Field) (int, any) {
 	return code.Successful, code.GetCodeMessage(code.Successful, output)
 }
 
-func (m *manager) Delete(input *contactModel.Field) (int, any) {
+func (m *manager) Delete(trx *gorm.DB, input *contactModel.Update) (int, any) {
 	_, err := m.ContactService.GetBySingle(&contactModel.Field{
 		ContactID: input.ContactID,
 	})
@@ -204,30 +246,40 @@ func (m *manager) Delete(input *contactModel.Field) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	err = m.ContactService.Delete(input)
+	// 依刪除規則刪除並移至資源回收筒,有限制刪除的關聯資料時回傳受影響的資料
+	preview, cascaded, err := m.RecycleBinService.WithTrx(trx).Delete("contacts", input.ContactID, *input.UpdatedBy)
 	if err != nil {
+		if errors.Is(err, recycleBinService.ErrRestricted) {
+			return code.Conflict, code.GetCodeMessage(code.Conflict, preview)
+		}
+
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	// 同步刪除帳戶聯絡人關聯
-	accountContactBase, _ := m.AccountContactService.GetBySingle(&accountContactModel.Field{
-		ContactID: util.PointerString(input.ContactID),
-	})
-	err = m.AccountContactService.Delete(&accountContactModel.Field{
-		AccountContactID: *accountContactBase.AccountContactID,
+	// 同步新增刪除歷程記錄
+	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
+		SourceID:   input.ContactID,
+		Action:     enums.ActionDeleted,
+		SourceType: sourceType,
+		ModifiedBy: *input.UpdatedBy,
 	})
 	if err != nil {
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	// 同步新增一併刪除資料的刪除歷程記錄
+	err = helpers.RecordCascaded(m.HistoricalRecordService.WithTrx(trx), cascaded, enums.ActionDeleted, *input.UpdatedBy)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
 	return code.Successful, code.GetCodeMessage(code.Successful, "Delete ok!")
 }
 
 func (m *manager) Update(trx *gorm.DB, input *contactModel.Update) (int, any) {
-	defer trx.Rollback()
-
 	contactBase, err := m.ContactService.GetBySingle(&contactModel.Field{
 		ContactID: input.ContactID,
 	})
@@ -240,6 +292,26 @@ func (m *manager) Update(trx *gorm.DB, input *contactModel.Update) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	// 樂觀鎖定,版本不符時回傳目前資料
+	if input.IfMatch != "" {
+		matched, err := helpers.CheckVersion(trx, "contacts", "contact_id", input.ContactID, input.IfMatch)
+		if err != nil {
+			log.Error(err)
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+
+		if !matched {
+			return helpers.PreconditionFailed(m.GetBySingle(&contactModel.Field{
+				ContactID: input.ContactID,
+			}))
+		}
+	}
+
+	// 依公司的自訂欄位驗證,僅更新帶入的欄位
+	if httpCode, codeMessage := helpers.CheckCustomFields(m.CustomFieldService, m.UserService, *input.UpdatedBy, "contacts", &input.CustomFields, contactBase.CustomFields); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
+
 	err = m.ContactService.WithTrx(trx).Update(input)
 	if err != nil {
 		log.Error(err)
@@ -266,73 +338,73 @@ func (m *manager) Update(trx *gorm.DB, input *contactModel.Update) (int, any) {
 	var records []historicalRecordModel.AddHistoricalRecord
 
 	if input.Name != nil && *input.Name != *contactBase.Name {
-		helpers.AddHistoricalRecord(&records, "修改", "名稱為", *input.Name)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldName, *input.Name)
 	}
 
 	if input.Title != nil {
 		if *input.Title != *contactBase.Title {
 			if *input.Title == "" {
-				helpers.AddHistoricalRecord(&records, "清除", "職稱", "")
+				helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldTitle, "")
 			} else {
-				helpers.AddHistoricalRecord(&records, "修改", "職稱為", *input.Title)
+				helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldTitle, *input.Title)
 
 			}
 		}
 	} else if *contactBase.Title != "" {
-		helpers.AddHistoricalRecord(&records, "清除", "職稱", "")
+		helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldTitle, "")
 	}
 
 	if input.PhoneNumber != nil && *input.PhoneNumber != *contactBase.PhoneNumber {
-		helpers.AddHistoricalRecord(&records, "修改", "電話為", *input.PhoneNumber)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldPhone, *input.PhoneNumber)
 	}
 
 	if input.CellPhone != nil {
 		if *input.CellPhone != *contactBase.CellPhone {
 			if *input.CellPhone == "" {
-				helpers.AddHistoricalRecord(&records, "清除", "行動電話", "")
+				helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldCellPhone, "")
 			} else {
-				helpers.AddHistoricalRecord(&records, "修改", "行動電話為", *input.CellPhone)
+				helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldCellPhone, *input.CellPhone)
 			}
 		}
 	} else if *contactBase.CellPhone != "" {
-		helpers.AddHistoricalRecord(&records, "清除", "行動電話", "")
+		helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldCellPhone, "")
 	}
 
 	if input.Email != nil {
 		if *input.Email != *contactBase.Email {
 			if *input.Email == "" {
-				helpers.AddHistoricalRecord(&records, "清除", "電子郵件", "")
+				helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldEmail, "")
 			} else {
-				helpers.AddHistoricalRecord(&records, "修改", "電子郵件為", *input.Email)
+				helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldEmail, *input.Email)
 			}
 		}
 	} else if *contactBase.Email != "" {
-		helpers.AddHistoricalRecord(&records, "清除", "電子郵件", "")
+		helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldEmail, "")
 	}
 
 	if input.Salutation != nil {
 		if *input.Salutation != *contactBase.Salutation {
 			if *input.Salutation == "" {
-				helpers.AddHistoricalRecord(&records, "清除", "稱謂", "")
+				helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldSalutation, "")
 			} else {
-				helpers.AddHistoricalRecord(&records, "修改", "稱謂為", *input.Salutation)
+				helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldSalutation, *input.Salutation)
 			}
 		}
 	} else if *contactBase.Salutation != "" {
-		helpers.AddHistoricalRecord(&records, "清除", "稱謂", "")
+		helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldSalutation, "")
 	}
 
 	if input.Department != nil {
 		if *input.Department != *contactBase.Department {
 			if *input.Department == "" {
-				helpers.AddHistoricalRecord(&records, "清除", "部門", "")
+				helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldDepartment, "")
 			} else {
-				helpers.AddHistoricalRecord(&records, "修改", "部門為", *input.Department)
+				helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldDepartment, *input.Department)
 
 			}
 		}
 	} else if *contactBase.Department != "" {
-		helpers.AddHistoricalRecord(&records, "清除", "部門", "")
+		helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldDepartment, "")
 	}
 
 	if contactBase.SupervisorID != nil {
@@ -340,9 +412,9 @@ func (m *manager) Update(trx *gorm.DB, input *contactModel.Update) (int, any) {
 			supervisorBase, _ := m.ContactService.GetBySingle(&contactModel.Field{
 				ContactID: *input.SupervisorID,
 			})
-			helpers.AddHistoricalRecord(&records, "修改", "直屬上司為", *supervisorBase.Name)
+			helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldSupervisor, *supervisorBase.Name)
 		} else {
-			helpers.AddHistoricalRecord(&records, "移除", "直屬上司", "")
+			helpers.AddHistoricalRecord(&records, enums.ActionRemoved, enums.FieldSupervisor, "")
 		}
 	}
 
@@ -350,14 +422,14 @@ func (m *manager) Update(trx *gorm.DB, input *contactModel.Update) (int, any) {
 		accountBase, _ := m.AccountService.GetBySingle(&accountModel.Field{
 			AccountID: *input.AccountID,
 		})
-		helpers.AddHistoricalRecord(&records, "修改", "帳戶為", *accountBase.Name)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldAccount, *accountBase.Name)
 	}
 
 	if input.SalespersonID != nil && *input.SalespersonID != *contactBase.SalespersonID {
 		salespersonBase, _ := m.UserService.GetBySingle(&userModel.Field{
 			UserID: *input.SalespersonID,
 		})
-		helpers.AddHistoricalRecord(&records, "修改", "業務員為", *salespersonBase.Name)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldSalesperson, *salespersonBase.Name)
 	}
 
 	for _, record := range records {
@@ -375,6 +447,73 @@ func (m *manager) Update(trx *gorm.DB, input *contactModel.Update) (int, any) {
 		}
 	}
 
-	trx.Commit()
-	return code.Successful, code.GetCodeMessage(code.Successful, contactBase.ContactID)
+	return helpers.WarnDuplicates(trx, m.DuplicateService, m.UserService, *input.UpdatedBy, "contacts", *contactBase.ContactID,
+		code.Successful, code.GetCodeMessage(code.Successful, contactBase.ContactID))
+}
+
+// Bulk creates, updates and deletes contacts at once, see helpers.Bulk for the modes.
+func (m *manager) Bulk(trx *gorm.DB, input *contactModel.Bulk) (int, any) {
+	history := m.HistoricalRecordService.Batch()
+	var operations []*helpers.Operation
+	for i, create := range input.Create {
+		create.CreatedBy = input.UserID
+		operations = append(operations, &helpers.Operation{
+			Action: bulkModel.Create,
+			Index:  i,
+			Input:  create,
+			Run: func(trx *gorm.DB) (int, any) {
+				return m.scoped(trx, history).Create(trx, create)
+			},
+		})
+	}
+
+	for i, item := range input.Update {
+		update := &item.Update
+		update.UpdatedBy = util.PointerString(input.UserID)
+		update.IfMatch = item.IfMatch
+		operations = append(operations, &helpers.Operation{
+			Action: bulkModel.Update,
+			Index:  i,
+			ID:     update.ContactID,
+			Input:  update,
+			// 公司要求 If-Match 時,未帶版本的項目不更新,版本不符的項目由 Update 回傳 412
+			Unversioned: input.IfMatchRequired && item.IfMatch == "",
+			Run: func(trx *gorm.DB) (int, any) {
+				return m.scoped(trx, history).Update(trx, update)
+			},
+		})
+	}
+
+	for i, contactID := range input.Delete {
+		update := &contactModel.Update{
+			ContactID: contactID,
+			UpdatedBy: util.PointerString(input.UserID),
+		}
+		operations = append(operations, &helpers.Operation{
+			Action: bulkModel.Delete,
+			Index:  i,
+			ID:     contactID,
+			Input:  update,
+			Run: func(trx *gorm.DB) (int, any) {
+				return m.scoped(trx, history).Delete(trx, update)
+			},
+		})
+	}
+
+	return helpers.Bulk(trx, input.Mode.Mode, history, operations)
+}
+
+// scoped returns a copy of the manager whose services run in trx, every service is carried over.
+// Its historical records are held by history.
+func (m *manager) scoped(trx *gorm.DB, history historicalRecordService.Batch) *manager {
+	scoped := *m
+	scoped.ContactService = m.ContactService.WithTrx(trx)
+	scoped.AccountContactService = m.AccountContactService.WithTrx(trx)
+	scoped.UserService = m.UserService.WithTrx(trx)
+	scoped.AccountService = m.AccountService.WithTrx(trx)
+	scoped.DuplicateService = m.DuplicateService.WithTrx(trx)
+	scoped.RecycleBinService = m.RecycleBinService.WithTrx(trx)
+	scoped.CustomFieldService = m.CustomFieldService.WithTrx(trx)
+	scoped.HistoricalRecordService = history
+	return &scoped
 }
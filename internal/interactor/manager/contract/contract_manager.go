@@ -7,9 +7,9 @@ import (
 
 	"crm/internal/interactor/helpers"
 
-	userModel "crm/internal/interactor/models/users"
 	userService "crm/internal/interactor/service/user"
 
+	"crm/internal/interactor/models/enums"
 	orderModel "crm/internal/interactor/models/orders"
 	"crm/internal/interactor/pkg/util"
 
@@ -22,9 +22,13 @@ import (
 	historicalRecordService "crm/internal/interactor/service/historical_record"
 	opportunityService "crm/internal/interactor/service/opportunity"
 	orderService "crm/internal/interactor/service/order"
+	recycleBinService "crm/internal/interactor/service/recycle_bin"
 
 	"gorm.io/gorm"
 
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/sort"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
 )
@@ -34,7 +38,7 @@ type Manager interface {
 	GetByList(input *contractModel.Fields) (int, any)
 	GetByListNoPagination(input *contractModel.FieldsNoPagination) (int, any)
 	GetBySingle(input *contractModel.Field) (int, any)
-	Delete(input *contractModel.Field) (int, any)
+	Delete(trx *gorm.DB, input *contractModel.Update) (int, any)
 	Update(trx *gorm.DB, input *contractModel.Update) (int, any)
 }
 
@@ -45,6 +49,7 @@ type manager struct {
 	AccountService          accountService.Service
 	OpportunityService      opportunityService.Service
 	UserService             userService.Service
+	RecycleBinService       recycleBinService.Service
 }
 
 func Init(db *gorm.DB) Manager {
@@ -55,13 +60,15 @@ func Init(db *gorm.DB) Manager {
 		AccountService:          accountService.Init(db),
 		OpportunityService:      opportunityService.Init(db),
 		UserService:             userService.Init(db),
+		RecycleBinService:       recycleBinService.Init(db),
 	}
 }
 
-const sourceType = "契約"
+const sourceType = enums.SourceTypeContract
 
 func (m *manager) Create(trx *gorm.DB, input *contractModel.Create) (int, any) {
-	defer trx.Rollback()
+	// 狀態名稱轉為代碼
+	enums.Normalize(enums.ContractStatus, &input.Status)
 
 	// 同步商機的account_id
 	opportunityBase, _ := m.OpportunityService.GetBySingle(&opportunityModel.Field{
@@ -78,7 +85,7 @@ func (m *manager) Create(trx *gorm.DB, input *contractModel.Create) (int, any) {
 	// 同步新增契約歷程記錄
 	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
 		SourceID:   *contractBase.ContractID,
-		Action:     "建立",
+		Action:     enums.ActionCreated,
 		SourceType: sourceType,
 		ModifiedBy: *contractBase.CreatedBy,
 	})
@@ -87,20 +94,32 @@ func (m *manager) Create(trx *gorm.DB, input *contractModel.Create) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, contractBase.ContractID)
 }
 
 func (m *manager) GetByList(input *contractModel.Fields) (int, any) {
+	for i := range input.FilterStatus {
+		enums.Normalize(enums.ContractStatus, &input.FilterStatus[i])
+	}
+
 	output := &contractModel.List{}
+	if err := input.Projection.Validate(output, "contracts", contractModel.Relations); err != nil {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+	}
+
 	output.Limit = input.Limit
 	output.Page = input.Page
-	quantity, contractBase, err := m.ContractService.GetByList(input)
+	quantity, contractBase, cursors, err := m.ContractService.GetByList(input)
 	if err != nil {
+		if errors.Is(err, filter.ErrInvalid) || errors.Is(err, sort.ErrInvalid) || errors.Is(err, page.ErrInvalid) {
+			return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+		}
+
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 	output.Total.Total = quantity
+	output.Cursors = cursors
 	output.Pages = util.Pagination(quantity, output.Limit)
 	contractByte, err := json.Marshal(contractBase)
 	if err != nil {
@@ -122,10 +141,21 @@ func (m *manager) GetByList(input *contractModel.Fields) (int, any) {
 		contracts.OpportunityName = *contractBase[i].Opportunities.Name
 	}
 
-	return code.Successful, code.GetCodeMessage(code.Successful, output)
+	// 篩選回傳欄位並嵌入展開的關聯
+	body, err := input.Projection.Shape(output, "contracts", contractBase, contractModel.Relations)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, body)
 }
 
 func (m *manager) GetByListNoPagination(input *contractModel.FieldsNoPagination) (int, any) {
+	for i := range input.FilterStatus {
+		enums.Normalize(enums.ContractStatus, &input.FilterStatus[i])
+	}
+
 	output := &contractModel.ListNoPagination{}
 	contractBase, err := m.ContractService.GetByListNoPagination(input)
 	if err != nil {
@@ -174,7 +204,7 @@ func (m *manager) GetBySingle(input *contractModel.Field) (int, any) {
 	return code.Successful, code.GetCodeMessage(code.Successful, output)
 }
 
-func (m *manager) Delete(input *contractModel.Field) (int, any) {
+func (m *manager) Delete(trx *gorm.DB, input *contractModel.Update) (int, any) {
 	_, err := m.ContractService.GetBySingle(&contractModel.Field{
 		ContractID: input.ContractID,
 	})
@@ -187,7 +217,31 @@ func (m *manager) Delete(input *contractModel.Field) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	err = m.ContractService.Delete(input)
+	// 依刪除規則刪除並移至資源回收筒,有限制刪除的關聯資料時回傳受影響的資料
+	preview, cascaded, err := m.RecycleBinService.WithTrx(trx).Delete("contracts", input.ContractID, *input.UpdatedBy)
+	if err != nil {
+		if errors.Is(err, recycleBinService.ErrRestricted) {
+			return code.Conflict, code.GetCodeMessage(code.Conflict, preview)
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 同步新增刪除歷程記錄
+	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
+		SourceID:   input.ContractID,
+		Action:     enums.ActionDeleted,
+		SourceType: sourceType,
+		ModifiedBy: *input.UpdatedBy,
+	})
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 同步新增一併刪除資料的刪除歷程記錄
+	err = helpers.RecordCascaded(m.HistoricalRecordService.WithTrx(trx), cascaded, enums.ActionDeleted, *input.UpdatedBy)
 	if err != nil {
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
@@ -197,7 +251,7 @@ func (m *manager) Delete(input *contractModel.Field) (int, any) {
 }
 
 func (m *manager) Update(trx *gorm.DB, input *contractModel.Update) (int, any) {
-	defer trx.Rollback()
+	enums.Normalize(enums.ContractStatus, input.Status)
 
 	contractBase, err := m.ContractService.GetBySingle(&contractModel.Field{
 		ContractID: input.ContractID,
@@ -211,6 +265,21 @@ func (m *manager) Update(trx *gorm.DB, input *contractModel.Update) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	// 樂觀鎖定,版本不符時回傳目前資料
+	if input.IfMatch != "" {
+		matched, err := helpers.CheckVersion(trx, "contracts", "contract_id", input.ContractID, input.IfMatch)
+		if err != nil {
+			log.Error(err)
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+
+		if !matched {
+			return helpers.PreconditionFailed(m.GetBySingle(&contractModel.Field{
+				ContractID: input.ContractID,
+			}))
+		}
+	}
+
 	// 計算契約結束日期
 	startDate := contractBase.StartDate
 	term := contractBase.Term
@@ -251,7 +320,7 @@ func (m *manager) Update(trx *gorm.DB, input *contractModel.Update) (int, any) {
 		}
 	}
 
-	err = m.ContractService.Update(input)
+	err = m.ContractService.WithTrx(trx).Update(input)
 	if err != nil {
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
@@ -264,45 +333,43 @@ func (m *manager) Update(trx *gorm.DB, input *contractModel.Update) (int, any) {
 		opportunityBase, _ := m.OpportunityService.GetBySingle(&opportunityModel.Field{
 			OpportunityID: *input.OpportunityID,
 		})
-		helpers.AddHistoricalRecord(&records, "修改", "商機為", *opportunityBase.Name)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldOpportunity, *opportunityBase.Name)
 
 		if opportunityBase.AccountID != contractBase.AccountID {
 			accountBase, _ := m.AccountService.GetBySingle(&accountModel.Field{
 				AccountID: *opportunityBase.AccountID,
 			})
-			helpers.AddHistoricalRecord(&records, "修改", "帳戶為", *accountBase.Name)
+			helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldAccount, *accountBase.Name)
 		}
 	}
 
 	if input.Status != nil && *input.Status != *contractBase.Status {
-		helpers.AddHistoricalRecord(&records, "修改", "狀態為", *input.Status)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldStatus, *input.Status)
 	}
 
 	if input.StartDate != nil && *input.StartDate != *contractBase.StartDate {
-		helpers.AddHistoricalRecord(&records, "修改", "開始日期為", input.StartDate.UTC().Format("2006-01-02T15:04:05.999999Z"))
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldStartDate, input.StartDate.UTC().Format("2006-01-02T15:04:05.999999Z"))
 	}
 
 	if input.Term != nil && *input.Term != *contractBase.Term {
-		helpers.AddHistoricalRecord(&records, "修改", "有效期限為", strconv.Itoa(*input.Term)+"個月")
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldTerm, strconv.Itoa(*input.Term))
 	}
 
 	if input.Description != nil {
 		if *input.Description != *contractBase.Description {
 			if *input.Description == "" {
-				helpers.AddHistoricalRecord(&records, "清除", "描述", "")
+				helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldDescription, "")
 			} else {
-				helpers.AddHistoricalRecord(&records, "修改", "描述為", *input.Description)
+				helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldDescription, *input.Description)
 			}
 		}
 	} else if *contractBase.Description != "" {
-		helpers.AddHistoricalRecord(&records, "清除", "描述", "")
+		helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldDescription, "")
 	}
 
 	if input.SalespersonID != nil && *input.SalespersonID != *contractBase.SalespersonID {
-		salespersonBase, _ := m.UserService.GetBySingle(&userModel.Field{
-			UserID: *input.SalespersonID,
-		})
-		helpers.AddHistoricalRecord(&records, "修改", "業務員為", *salespersonBase.Name)
+		salespersonBase, _ := m.UserService.GetByCache(*input.SalespersonID)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldSalesperson, *salespersonBase.Name)
 	}
 
 	for _, record := range records {
@@ -320,6 +387,5 @@ func (m *manager) Update(trx *gorm.DB, input *contractModel.Update) (int, any) {
 		}
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, contractBase.ContractID)
 }
@@ -0,0 +1,247 @@
+package custom_field
+
+import (
+	"encoding/json"
+	"errors"
+
+	customFieldDB "crm/internal/entity/postgresql/db/custom_fields"
+	"crm/internal/interactor/helpers"
+	customFieldModel "crm/internal/interactor/models/custom_fields"
+	"crm/internal/interactor/pkg/cache"
+	"crm/internal/interactor/pkg/util"
+	customFieldService "crm/internal/interactor/service/custom_field"
+
+	"gorm.io/gorm"
+
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+)
+
+type Manager interface {
+	Create(trx *gorm.DB, input *customFieldModel.Create) (int, any)
+	GetByList(input *customFieldModel.Field) (int, any)
+	GetBySingle(input *customFieldModel.Field) (int, any)
+	Update(trx *gorm.DB, input *customFieldModel.Update) (int, any)
+	Delete(trx *gorm.DB, input *customFieldModel.Field) (int, any)
+}
+
+type manager struct {
+	CustomFieldService customFieldService.Service
+}
+
+func Init(db *gorm.DB) Manager {
+	return &manager{
+		CustomFieldService: customFieldService.Init(db),
+	}
+}
+
+func (m *manager) Create(trx *gorm.DB, input *customFieldModel.Create) (int, any) {
+	if !customFieldModel.ValidKey(input.Key) {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, "key must start with a lowercase letter and hold only lowercase letters, digits and underscores")
+	}
+
+	_, err := m.CustomFieldService.WithTrx(trx).GetBySingle(&customFieldModel.Field{
+		CompanyID: input.CompanyID,
+		Entity:    util.PointerString(input.Entity),
+		Key:       util.PointerString(input.Key),
+	})
+	if err == nil {
+		return code.Conflict, code.GetCodeMessage(code.Conflict, "custom field "+input.Key+" of "+input.Entity+" already exists")
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	input.Options, input.DefaultValue, err = check(input.Type, input.Options, input.DefaultValue)
+	if err != nil {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+	}
+
+	customFieldBase, err := m.CustomFieldService.WithTrx(trx).Create(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	m.invalidate(trx, input.CompanyID, input.Entity)
+	return code.Successful, code.GetCodeMessage(code.Successful, customFieldBase.CustomFieldID)
+}
+
+func (m *manager) GetByList(input *customFieldModel.Field) (int, any) {
+	customFieldBase, err := m.CustomFieldService.GetByList(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	output := &customFieldModel.List{CustomFields: make([]*customFieldModel.Single, 0, len(customFieldBase))}
+	customFieldByte, err := json.Marshal(customFieldBase)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	err = json.Unmarshal(customFieldByte, &output.CustomFields)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, output)
+}
+
+func (m *manager) GetBySingle(input *customFieldModel.Field) (int, any) {
+	customFieldBase, err := m.CustomFieldService.GetBySingle(input)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, err.Error())
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	output := &customFieldModel.Single{}
+	customFieldByte, _ := json.Marshal(customFieldBase)
+	err = json.Unmarshal(customFieldByte, &output)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, output)
+}
+
+func (m *manager) Update(trx *gorm.DB, input *customFieldModel.Update) (int, any) {
+	customFieldBase, err := m.CustomFieldService.WithTrx(trx).GetBySingle(&customFieldModel.Field{
+		CustomFieldID: input.CustomFieldID,
+		CompanyID:     input.CompanyID,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, err.Error())
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	var options []string
+	if customFieldBase.Options != nil {
+		options = *customFieldBase.Options
+	}
+
+	if input.Options != nil {
+		options = *input.Options
+	}
+
+	// 未帶入預設值時,以新的選項檢查原本的預設值
+	defaultValue := customFieldBase.DefaultValue
+	if input.DefaultValue != nil {
+		defaultValue = nil
+		if err = json.Unmarshal(input.DefaultValue, &defaultValue); err != nil {
+			return code.BadRequest, code.GetCodeMessage(code.BadRequest, "default_value is not valid JSON")
+		}
+	}
+
+	options, defaultValue, err = check(*customFieldBase.Type, options, defaultValue)
+	if err != nil {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+	}
+
+	if input.Options != nil {
+		input.Options = &options
+	}
+
+	if input.DefaultValue != nil {
+		input.DefaultValue, err = json.Marshal(defaultValue)
+		if err != nil {
+			log.Error(err)
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+	}
+
+	err = m.CustomFieldService.WithTrx(trx).Update(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	m.invalidate(trx, input.CompanyID, *customFieldBase.Entity)
+	return code.Successful, code.GetCodeMessage(code.Successful, customFieldBase.CustomFieldID)
+}
+
+func (m *manager) Delete(trx *gorm.DB, input *customFieldModel.Field) (int, any) {
+	customFieldBase, err := m.CustomFieldService.WithTrx(trx).GetBySingle(input)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, err.Error())
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 移除資料中的欄位值,同鍵值重新建立的欄位不會讀到舊值
+	err = m.CustomFieldService.WithTrx(trx).Purge(&customFieldDB.Base{
+		CompanyID: customFieldBase.CompanyID,
+		Entity:    customFieldBase.Entity,
+		Key:       customFieldBase.Key,
+	})
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	err = m.CustomFieldService.WithTrx(trx).Delete(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	m.invalidate(trx, input.CompanyID, *customFieldBase.Entity)
+	return code.Successful, code.GetCodeMessage(code.Successful, "Delete ok!")
+}
+
+// check validates the options and the default value of a custom field of typ, the options only belong to a picklist.
+// It returns the options and the default value as they are stored.
+func check(typ string, options []string, defaultValue any) ([]string, any, error) {
+	if typ != customFieldModel.Picklist {
+		options = []string{}
+	} else if len(options) == 0 {
+		return nil, nil, errors.New("a picklist custom field needs options")
+	}
+
+	seen := map[string]bool{}
+	for _, option := range options {
+		if seen[option] {
+			return nil, nil, errors.New("option " + option + " is repeated")
+		}
+
+		seen[option] = true
+	}
+
+	if defaultValue == nil {
+		return options, nil, nil
+	}
+
+	defaultValue, err := customFieldModel.Check(typ, options, defaultValue)
+	if err != nil {
+		return nil, nil, errors.New("default_value " + err.Error())
+	}
+
+	return options, defaultValue, nil
+}
+
+// invalidate drops the cached custom fields of entity checked by the managers of the records
+// once trx is committed.
+func (m *manager) invalidate(trx *gorm.DB, companyID, entity string) {
+	helpers.AfterCommit(trx, func() {
+		err := cache.Default().Delete(cache.Key(cache.CustomField, companyID+":"+entity))
+		if err != nil {
+			log.Error(err)
+		}
+	})
+}
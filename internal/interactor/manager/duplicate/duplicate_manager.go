@@ -0,0 +1,209 @@
+package duplicate
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	duplicateModel "crm/internal/interactor/models/duplicates"
+	"crm/internal/interactor/models/enums"
+	historicalRecordModel "crm/internal/interactor/models/historical_records"
+	duplicateService "crm/internal/interactor/service/duplicate"
+	historicalRecordService "crm/internal/interactor/service/historical_record"
+
+	"gorm.io/gorm"
+
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+)
+
+type Manager interface {
+	GetByList(input *duplicateModel.Field) (int, any)
+	Merge(trx *gorm.DB, input *duplicateModel.Merge) (int, any)
+}
+
+type manager struct {
+	DuplicateService        duplicateService.Service
+	HistoricalRecordService historicalRecordService.Service
+}
+
+func Init(db *gorm.DB) Manager {
+	return &manager{
+		DuplicateService:        duplicateService.Init(db),
+		HistoricalRecordService: historicalRecordService.Init(db),
+	}
+}
+
+// sourceTypes are the source types of the historical records by entity.
+var sourceTypes = map[string]string{
+	"accounts": enums.SourceTypeAccount,
+	"contacts": enums.SourceTypeContact,
+	"leads":    enums.SourceTypeLead,
+}
+
+// titleFields are the fields holding the title of a record by entity.
+var titleFields = map[string]string{
+	"accounts": "name",
+	"contacts": "name",
+	"leads":    "description",
+}
+
+var errMerge = errors.New("invalid merge")
+
+func (m *manager) GetByList(input *duplicateModel.Field) (int, any) {
+	records, err := m.DuplicateService.GetByRecords(input.Entity, []string{input.ID}, nil)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	if _, ok := records[input.ID]; !ok {
+		return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, gorm.ErrRecordNotFound.Error())
+	}
+
+	candidates, err := m.DuplicateService.GetByList(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	if candidates == nil {
+		candidates = []*duplicateModel.Candidate{}
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, &duplicateModel.List{Candidates: candidates})
+}
+
+func (m *manager) Merge(trx *gorm.DB, input *duplicateModel.Merge) (int, any) {
+	if err := validate(input); err != nil {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+	}
+
+	fields := duplicateModel.MergeFields[input.Entity]
+	ids := append([]string{input.SurvivorID}, input.MergedIDs...)
+	records, err := m.DuplicateService.WithTrx(trx).GetByRecords(input.Entity, ids, fields)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	for _, id := range ids {
+		if _, ok := records[id]; !ok {
+			return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, "record "+id+" does not exist")
+		}
+	}
+
+	// 指定的欄位取來源資料的值,其餘欄位原值為空時取第一筆有值的併入資料
+	survivor := records[input.SurvivorID]
+	sources := map[string]string{}
+	for _, field := range fields {
+		if sourceID, ok := input.Fields[field]; ok {
+			if sourceID != input.SurvivorID && records[sourceID][field] != survivor[field] {
+				sources[field] = sourceID
+			}
+
+			continue
+		}
+
+		if !empty(survivor[field]) {
+			continue
+		}
+
+		for _, id := range input.MergedIDs {
+			if !empty(records[id][field]) {
+				sources[field] = id
+				break
+			}
+		}
+	}
+
+	reparented, err := m.DuplicateService.WithTrx(trx).Merge(input, sources)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 同步新增保留資料的合併歷程記錄
+	titles := make([]string, 0, len(input.MergedIDs))
+	for _, id := range input.MergedIDs {
+		title, _ := records[id]["title"].(string)
+		titles = append(titles, title)
+	}
+
+	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
+		SourceID:   input.SurvivorID,
+		Action:     enums.ActionMerged,
+		SourceType: sourceTypes[input.Entity],
+		Field:      enums.FieldData,
+		Value:      strings.Join(titles, "、"),
+		ModifiedBy: input.UpdatedBy,
+	})
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 併入後刪除的資料各自新增合併歷程記錄,值為保留資料,須在移轉歷程記錄之後新增
+	survivorTitle, _ := survivor["title"].(string)
+	if sourceID, ok := sources[titleFields[input.Entity]]; ok {
+		survivorTitle, _ = records[sourceID]["title"].(string)
+	}
+
+	for _, id := range input.MergedIDs {
+		_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
+			SourceID:   id,
+			Action:     enums.ActionMerged,
+			SourceType: sourceTypes[input.Entity],
+			Field:      enums.FieldData,
+			Value:      survivorTitle,
+			ModifiedBy: input.UpdatedBy,
+		})
+		if err != nil {
+			log.Error(err)
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, &duplicateModel.Merged{
+		SurvivorID: input.SurvivorID,
+		MergedIDs:  input.MergedIDs,
+		Reparented: reparented,
+	})
+}
+
+// validate checks the records and picked fields of a merge.
+func validate(input *duplicateModel.Merge) error {
+	seen := map[string]bool{input.SurvivorID: true}
+	for _, id := range input.MergedIDs {
+		if seen[id] {
+			return fmt.Errorf("%w: record %s is given twice or is the survivor", errMerge, id)
+		}
+
+		seen[id] = true
+	}
+
+	for field, sourceID := range input.Fields {
+		if !slices.Contains(duplicateModel.MergeFields[input.Entity], field) {
+			return fmt.Errorf("%w: field %s cannot be picked", errMerge, field)
+		}
+
+		if !seen[sourceID] {
+			return fmt.Errorf("%w: field %s is picked from a record not being merged", errMerge, field)
+		}
+	}
+
+	return nil
+}
+
+// empty reports whether a field read as text has no value.
+func empty(value any) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case string:
+		return value == "" || value == "{}"
+	}
+
+	return false
+}
@@ -0,0 +1,131 @@
+package duplicate_rule
+
+import (
+	"encoding/json"
+	"errors"
+
+	duplicateRuleModel "crm/internal/interactor/models/duplicate_rules"
+	duplicateModel "crm/internal/interactor/models/duplicates"
+	duplicateRuleService "crm/internal/interactor/service/duplicate_rule"
+
+	"gorm.io/gorm"
+
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+)
+
+type Manager interface {
+	Create(input *duplicateRuleModel.Create) (int, any)
+	GetByList(input *duplicateRuleModel.Field) (int, any)
+	Update(input *duplicateRuleModel.Update) (int, any)
+	Delete(input *duplicateRuleModel.Field) (int, any)
+}
+
+type manager struct {
+	DuplicateRuleService duplicateRuleService.Service
+}
+
+func Init(db *gorm.DB) Manager {
+	return &manager{
+		DuplicateRuleService: duplicateRuleService.Init(db),
+	}
+}
+
+func (m *manager) Create(input *duplicateRuleModel.Create) (int, any) {
+	if _, ok := duplicateModel.Fields[input.Entity][input.Field]; !ok {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, "field "+input.Field+" of "+input.Entity+" cannot be matched")
+	}
+
+	rules, err := m.DuplicateRuleService.GetByList(&duplicateRuleModel.Field{
+		CompanyID: input.CompanyID,
+		Entity:    &input.Entity,
+	})
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	for _, rule := range rules {
+		if *rule.Field == input.Field && *rule.Method == input.Method {
+			return code.Conflict, code.GetCodeMessage(code.Conflict, "rule "+input.Field+":"+input.Method+" already exists")
+		}
+	}
+
+	duplicateRuleBase, err := m.DuplicateRuleService.Create(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, duplicateRuleBase.DuplicateRuleID)
+}
+
+func (m *manager) GetByList(input *duplicateRuleModel.Field) (int, any) {
+	output := &duplicateRuleModel.List{}
+	duplicateRuleBase, err := m.DuplicateRuleService.GetByList(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	duplicateRuleByte, err := json.Marshal(duplicateRuleBase)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	err = json.Unmarshal(duplicateRuleByte, &output.DuplicateRules)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, output)
+}
+
+func (m *manager) Update(input *duplicateRuleModel.Update) (int, any) {
+	duplicateRuleBase, err := m.DuplicateRuleService.GetBySingle(&duplicateRuleModel.Field{
+		DuplicateRuleID: input.DuplicateRuleID,
+		CompanyID:       input.CompanyID,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, err.Error())
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	if input.Threshold != nil && *duplicateRuleBase.Method != duplicateModel.Trigram {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, "only a trigram rule has a threshold")
+	}
+
+	err = m.DuplicateRuleService.Update(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, duplicateRuleBase.DuplicateRuleID)
+}
+
+func (m *manager) Delete(input *duplicateRuleModel.Field) (int, any) {
+	_, err := m.DuplicateRuleService.GetBySingle(input)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, err.Error())
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	err = m.DuplicateRuleService.Delete(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, "Delete ok!")
+}
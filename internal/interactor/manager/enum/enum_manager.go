@@ -0,0 +1,39 @@
+package enum
+
+import (
+	"crm/internal/interactor/models/enums"
+	"crm/internal/interactor/pkg/util/code"
+)
+
+type Manager interface {
+	GetByList(input *enums.Field) (int, any)
+}
+
+type manager struct{}
+
+func Init() Manager {
+	return &manager{}
+}
+
+// GetByList returns the codes of an enumeration, or of every enumeration, translated to the language of the input.
+func (m *manager) GetByList(input *enums.Field) (int, any) {
+	language := enums.Language(input.Language)
+	output := &enums.List{Enums: []*enums.Single{}}
+	for _, enum := range enums.Enums {
+		if input.Type != "" && enum.Type() != input.Type {
+			continue
+		}
+
+		output.Enums = append(output.Enums, &enums.Single{
+			Type:  enum.Type(),
+			Code:  enum.Code,
+			Label: enum.Label(language),
+		})
+	}
+
+	if len(output.Enums) == 0 {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, "Enumeration type does not exist.")
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, output)
+}
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"time"
 
+	"crm/internal/interactor/helpers"
+	bulkModel "crm/internal/interactor/models/bulk"
+	"crm/internal/interactor/models/enums"
 	eventContactModel "crm/internal/interactor/models/event_contacts"
 	eventUserAttendeeModel "crm/internal/interactor/models/event_user_attendees"
 	eventUserMainModel "crm/internal/interactor/models/event_user_mains"
@@ -13,12 +16,18 @@ import (
 	eventContactService "crm/internal/interactor/service/event_contact"
 	eventUserAttendeeService "crm/internal/interactor/service/event_user_attendee"
 	eventUserMainService "crm/internal/interactor/service/event_user_main"
+	picklistService "crm/internal/interactor/service/picklist"
+	userService "crm/internal/interactor/service/user"
 
 	eventModel "crm/internal/interactor/models/events"
 	eventService "crm/internal/interactor/service/event"
 
+	historicalRecordModel "crm/internal/interactor/models/historical_records"
+	historicalRecordService "crm/internal/interactor/service/historical_record"
+	recycleBinService "crm/internal/interactor/service/recycle_bin"
 	"gorm.io/gorm"
 
+	"crm/internal/interactor/models/filter"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
 )
@@ -29,6 +38,7 @@ type Manager interface {
 	GetBySingle(input *eventModel.Field) (int, any)
 	Delete(trx *gorm.DB, input *eventModel.Update) (int, any)
 	Update(trx *gorm.DB, input *eventModel.Update) (int, any)
+	Bulk(trx *gorm.DB, input *eventModel.Bulk) (int, any)
 }
 
 type manager struct {
@@ -37,6 +47,10 @@ type manager struct {
 	EventUserMainService     eventUserMainService.Service
 	EventUserAttendeeService eventUserAttendeeService.Service
 	EventContactService      eventContactService.Service
+	RecycleBinService        recycleBinService.Service
+	HistoricalRecordService  historicalRecordService.Service
+	PicklistService          picklistService.Service
+	UserService              userService.Service
 }
 
 func Init(db *gorm.DB) Manager {
@@ -46,11 +60,20 @@ func Init(db *gorm.DB) Manager {
 		EventUserMainService:     eventUserMainService.Init(db),
 		EventUserAttendeeService: eventUserAttendeeService.Init(db),
 		EventContactService:      eventContactService.Init(db),
+		RecycleBinService:        recycleBinService.Init(db),
+		HistoricalRecordService:  historicalRecordService.Init(db),
+		PicklistService:          picklistService.Init(db),
+		UserService:              userService.Init(db),
 	}
 }
 
 func (m *manager) Create(trx *gorm.DB, input *eventModel.Create) (int, any) {
-	defer trx.Rollback()
+	// 依公司的選項清單驗證,未帶入的欄位使用預設值
+	if httpCode, codeMessage := helpers.CheckPicklists(m.PicklistService, m.UserService, input.CreatedBy, "events", map[string]any{
+		"type": &input.Type,
+	}, nil); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
 
 	eventBase, err := m.EventService.WithTrx(trx).Create(input)
 	if err != nil {
@@ -103,7 +126,6 @@ func (m *manager) Create(trx *gorm.DB, input *eventModel.Create) (int, any) {
 		}
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, eventBase.EventID)
 }
 
@@ -123,6 +145,10 @@ func (m *manager) GetByList(input *eventModel.Fields) (int, any) {
 
 	eventBase, err := m.EventService.GetByList(input)
 	if err != nil {
+		if errors.Is(err, filter.ErrInvalid) {
+			return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+		}
+
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
@@ -193,9 +219,7 @@ func (m *manager) GetBySingle(input *eventModel.Field) (int, any) {
 }
 
 func (m *manager) Delete(trx *gorm.DB, input *eventModel.Update) (int, any) {
-	defer trx.Rollback()
-
-	eventBase, err := m.EventService.GetBySingle(&eventModel.Field{
+	_, err := m.EventService.GetBySingle(&eventModel.Field{
 		EventID: input.EventID,
 	})
 	if err != nil {
@@ -207,64 +231,40 @@ func (m *manager) Delete(trx *gorm.DB, input *eventModel.Update) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	err = m.EventService.WithTrx(trx).Delete(input)
+	// 依刪除規則刪除並移至資源回收筒,有限制刪除的關聯資料時回傳受影響的資料
+	preview, cascaded, err := m.RecycleBinService.WithTrx(trx).Delete("events", input.EventID, *input.UpdatedBy)
 	if err != nil {
+		if errors.Is(err, recycleBinService.ErrRestricted) {
+			return code.Conflict, code.GetCodeMessage(code.Conflict, preview)
+		}
+
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	// 將舊事件主要人員關聯資料改為刪除
-	eventUserMainBase, err := m.EventUserMainService.GetByListNoPagination(&eventUserMainModel.Field{
-		EventID: eventBase.EventID,
-	})
-	for _, main := range eventUserMainBase {
-		err = m.EventUserMainService.WithTrx(trx).Delete(&eventUserMainModel.Update{
-			EventUserMainID: *main.EventUserMainID,
-			UpdatedBy:       input.UpdatedBy,
-		})
-		if err != nil {
-			log.Error(err)
-			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
-		}
-	}
-
-	// 將舊事件參與人員關聯資料改為刪除
-	eventUserAttendeeBase, err := m.EventUserAttendeeService.GetByListNoPagination(&eventUserAttendeeModel.Field{
-		EventID: eventBase.EventID,
+	// 同步新增刪除歷程記錄
+	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
+		SourceID:   input.EventID,
+		Action:     enums.ActionDeleted,
+		SourceType: enums.SourceTypeEvent,
+		ModifiedBy: *input.UpdatedBy,
 	})
-	for _, attendee := range eventUserAttendeeBase {
-		err = m.EventUserAttendeeService.WithTrx(trx).Delete(&eventUserAttendeeModel.Update{
-			EventUserAttendeeID: *attendee.EventUserAttendeeID,
-			UpdatedBy:           input.UpdatedBy,
-		})
-		if err != nil {
-			log.Error(err)
-			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
-		}
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	// 將舊事件聯絡人關聯資料改為刪除
-	eventContactBase, err := m.EventContactService.GetByListNoPagination(&eventContactModel.Field{
-		EventID: eventBase.EventID,
-	})
-	for _, contact := range eventContactBase {
-		err = m.EventContactService.WithTrx(trx).Delete(&eventContactModel.Update{
-			EventContactID: *contact.EventContactID,
-			UpdatedBy:      input.UpdatedBy,
-		})
-		if err != nil {
-			log.Error(err)
-			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
-		}
+	// 同步新增一併刪除資料的刪除歷程記錄
+	err = helpers.RecordCascaded(m.HistoricalRecordService.WithTrx(trx), cascaded, enums.ActionDeleted, *input.UpdatedBy)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, "Delete ok!")
 }
 
 func (m *manager) Update(trx *gorm.DB, input *eventModel.Update) (int, any) {
-	defer trx.Rollback()
-
 	eventBase, err := m.EventService.GetBySingle(&eventModel.Field{
 		EventID: input.EventID,
 	})
@@ -277,6 +277,21 @@ func (m *manager) Update(trx *gorm.DB, input *eventModel.Update) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	// 樂觀鎖定,版本不符時回傳目前資料
+	if input.IfMatch != "" {
+		matched, err := helpers.CheckVersion(trx, "events", "event_id", input.EventID, input.IfMatch)
+		if err != nil {
+			log.Error(err)
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+
+		if !matched {
+			return helpers.PreconditionFailed(m.GetBySingle(&eventModel.Field{
+				EventID: input.EventID,
+			}))
+		}
+	}
+
 	// 設定修改期限為開始日期前一天
 	deadline := eventBase.StartDate.AddDate(0, 0, -1)
 	// 若現在時間在修改期限之後回傳400
@@ -296,6 +311,14 @@ func (m *manager) Update(trx *gorm.DB, input *eventModel.Update) (int, any) {
 		}
 	}
 
+	if httpCode, codeMessage := helpers.CheckPicklists(m.PicklistService, m.UserService, *input.UpdatedBy, "events", map[string]any{
+		"type": input.Type,
+	}, map[string]any{
+		"type": eventBase.Type,
+	}); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
+
 	err = m.EventService.WithTrx(trx).Update(input)
 	if err != nil {
 		log.Error(err)
@@ -392,6 +415,72 @@ func (m *manager) Update(trx *gorm.DB, input *eventModel.Update) (int, any) {
 		}
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, eventBase.EventID)
 }
+
+// Bulk creates, updates and deletes events at once, see helpers.Bulk for the modes.
+func (m *manager) Bulk(trx *gorm.DB, input *eventModel.Bulk) (int, any) {
+	var operations []*helpers.Operation
+	for i, create := range input.Create {
+		create.CreatedBy = input.UserID
+		operations = append(operations, &helpers.Operation{
+			Action: bulkModel.Create,
+			Index:  i,
+			Input:  create,
+			Run: func(trx *gorm.DB) (int, any) {
+				return m.scoped(trx).Create(trx, create)
+			},
+		})
+	}
+
+	for i, item := range input.Update {
+		update := &item.Update
+		update.UpdatedBy = util.PointerString(input.UserID)
+		update.IfMatch = item.IfMatch
+		operations = append(operations, &helpers.Operation{
+			Action: bulkModel.Update,
+			Index:  i,
+			ID:     update.EventID,
+			Input:  update,
+			// 公司要求 If-Match 時,未帶版本的項目不更新,版本不符的項目由 Update 回傳 412
+			Unversioned: input.IfMatchRequired && item.IfMatch == "",
+			Run: func(trx *gorm.DB) (int, any) {
+				return m.scoped(trx).Update(trx, update)
+			},
+		})
+	}
+
+	for i, eventID := range input.Delete {
+		update := &eventModel.Update{
+			EventID:   eventID,
+			UpdatedBy: util.PointerString(input.UserID),
+		}
+		operations = append(operations, &helpers.Operation{
+			Action: bulkModel.Delete,
+			Index:  i,
+			ID:     eventID,
+			Input:  update,
+			Run: func(trx *gorm.DB) (int, any) {
+				return m.scoped(trx).Delete(trx, update)
+			},
+		})
+	}
+
+	// 事件沒有歷程記錄
+	return helpers.Bulk(trx, input.Mode.Mode, nil, operations)
+}
+
+// scoped returns a copy of the manager whose services run in trx, every service is carried over.
+func (m *manager) scoped(trx *gorm.DB) *manager {
+	scoped := *m
+	scoped.EventService = m.EventService.WithTrx(trx)
+	scoped.ContactService = m.ContactService.WithTrx(trx)
+	scoped.EventUserMainService = m.EventUserMainService.WithTrx(trx)
+	scoped.EventUserAttendeeService = m.EventUserAttendeeService.WithTrx(trx)
+	scoped.EventContactService = m.EventContactService.WithTrx(trx)
+	scoped.RecycleBinService = m.RecycleBinService.WithTrx(trx)
+	scoped.HistoricalRecordService = m.HistoricalRecordService.WithTrx(trx)
+	scoped.PicklistService = m.PicklistService.WithTrx(trx)
+	scoped.UserService = m.UserService.WithTrx(trx)
+	return &scoped
+}
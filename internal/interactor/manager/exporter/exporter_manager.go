@@ -0,0 +1,383 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"crm/config"
+	customFieldModel "crm/internal/interactor/models/custom_fields"
+	"crm/internal/interactor/models/enums"
+	exportModel "crm/internal/interactor/models/exports"
+	jobModel "crm/internal/interactor/models/jobs"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/xlsx"
+	customFieldService "crm/internal/interactor/service/custom_field"
+	jobService "crm/internal/interactor/service/job"
+
+	"gorm.io/gorm"
+
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+)
+
+// List reads the page of a list after cursor, it calls the GetByList of the manager of the entity
+// with the filters and sort of the request.
+type List func(cursor string, limit int64, count string) (int, any)
+
+type Manager interface {
+	Create(input *exportModel.Create, columns exportModel.Columns, list List) (int, any)
+}
+
+type manager struct {
+	JobService         jobService.Service
+	CustomFieldService customFieldService.Service
+}
+
+func Init(db *gorm.DB) Manager {
+	return &manager{
+		JobService:         jobService.Init(db),
+		CustomFieldService: customFieldService.Init(db),
+	}
+}
+
+var errColumn = errors.New("invalid export column")
+
+// bom makes Excel open a csv file as UTF-8.
+var bom = []byte{0xEF, 0xBB, 0xBF}
+
+// export is a list being written, starting from its first page.
+type export struct {
+	input   *exportModel.Create
+	columns exportModel.Columns
+	list    List
+	first   *page
+	name    string
+}
+
+// page is a page of a list.
+type page struct {
+	items []map[string]any
+	total int64
+	next  string
+}
+
+// Create streams a list of up to config.ExportSyncRows rows, a longer list is exported by a background job.
+func (m *manager) Create(input *exportModel.Create, columns exportModel.Columns, list List) (int, any) {
+	// 公司的自訂欄位接在標準欄位之後
+	if slices.Contains(customFieldModel.Entities, input.Entity) {
+		customFieldBase, err := m.CustomFieldService.GetByCache(input.CompanyID, input.Entity)
+		if err != nil {
+			log.Error(err)
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+
+		columns = slices.Clip(columns)
+		for _, customField := range customFieldBase {
+			columns = append(columns, exportModel.Column{
+				Key:     customFieldModel.Prefix + *customField.Key,
+				Chinese: *customField.Label,
+				English: *customField.Label,
+			})
+		}
+	}
+
+	columns, err := selected(columns, input.Fields)
+	if err != nil {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+	}
+
+	e := &export{input: input, columns: columns, list: list}
+	e.name = input.Entity + "_" + util.NowToUTC().Format("20060102150405") + "." + input.Format
+
+	// 第一頁同時計算總筆數
+	var httpCode int
+	var codeMessage any
+	e.first, httpCode, codeMessage = e.read("", "exact")
+	if e.first == nil {
+		return httpCode, codeMessage
+	}
+
+	if e.first.total > config.ExportSyncRows {
+		jobBase, err := m.JobService.Create(&jobModel.Create{
+			CompanyID: input.CompanyID,
+			Kind:      jobModel.Export,
+			Entity:    input.Entity,
+			CreatedBy: input.CreatedBy,
+		})
+		if err != nil {
+			log.Error(err)
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+
+		go m.run(*jobBase.JobID, e)
+
+		return code.Sync, code.GetCodeMessage(code.Sync, jobBase.JobID)
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, &exportModel.Stream{
+		FileName:    e.name,
+		ContentType: e.contentType(),
+		Write:       e.write,
+	})
+}
+
+// selected returns the columns named by fields in their order, every column when fields is empty.
+func selected(columns exportModel.Columns, fields string) (exportModel.Columns, error) {
+	if strings.TrimSpace(fields) == "" {
+		return columns, nil
+	}
+
+	var output exportModel.Columns
+	for _, name := range strings.Split(fields, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, column := range columns {
+			if column.Key == name {
+				output = append(output, column)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("%w: unknown field %s", errColumn, name)
+		}
+	}
+
+	return output, nil
+}
+
+// read returns the page after cursor, or the code message of the list when it fails.
+func (e *export) read(cursor, count string) (*page, int, any) {
+	httpCode, codeMessage := e.list(cursor, config.ExportPageSize, count)
+	message, ok := codeMessage.(*code.SuccessfulMessage)
+	if httpCode != code.Successful || !ok {
+		return nil, httpCode, codeMessage
+	}
+
+	marshal, err := json.Marshal(message.Body)
+	if err != nil {
+		log.Error(err)
+		return nil, code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	body := map[string]json.RawMessage{}
+	if err = json.Unmarshal(marshal, &body); err != nil {
+		log.Error(err)
+		return nil, code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	output := &page{}
+	_ = json.Unmarshal(body["total"], &output.total)
+	_ = json.Unmarshal(body["next_cursor"], &output.next)
+
+	// 數字保留原本的精度
+	decoder := json.NewDecoder(bytes.NewReader(body[e.input.Entity]))
+	decoder.UseNumber()
+	if err = decoder.Decode(&output.items); err != nil {
+		log.Error(err)
+		return nil, code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return output, code.Successful, nil
+}
+
+// rows calls yield with the header and then every row of the list, page by page.
+func (e *export) rows(yield func(row []string) error) error {
+	lang := enums.Language(e.input.Language)
+	header := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		header[i] = column.Header(lang)
+	}
+
+	if err := yield(header); err != nil {
+		return err
+	}
+
+	for current := e.first; ; {
+		for _, item := range current.items {
+			row := make([]string, len(e.columns))
+			for i, column := range e.columns {
+				row[i] = cell(valueOf(item, column.Key), lang)
+			}
+
+			if err := yield(row); err != nil {
+				return err
+			}
+		}
+
+		if current.next == "" {
+			return nil
+		}
+
+		next, _, codeMessage := e.read(current.next, "none")
+		if next == nil {
+			if message, ok := codeMessage.(*code.ErrorMessage); ok {
+				return fmt.Errorf("export: %v", message.Detailed)
+			}
+
+			return errors.New("export: the list failed")
+		}
+
+		current = next
+	}
+}
+
+// write writes the list to w in the format of the export.
+func (e *export) write(w io.Writer) error {
+	if e.input.Format == exportModel.XLSX {
+		writer, err := xlsx.NewWriter(w)
+		if err != nil {
+			return err
+		}
+
+		if err = e.rows(writer.WriteRow); err != nil {
+			return err
+		}
+
+		return writer.Close()
+	}
+
+	if _, err := w.Write(bom); err != nil {
+		return err
+	}
+
+	// 逐列寫出,不保留整份檔案
+	writer := csv.NewWriter(w)
+	if err := e.rows(writer.Write); err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func (e *export) contentType() string {
+	if e.input.Format == exportModel.XLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	return "text/csv; charset=utf-8"
+}
+
+// valueOf returns the value of key in a list item, a custom field is read from the custom_fields of the item.
+func valueOf(item map[string]any, key string) any {
+	if name, ok := strings.CutPrefix(key, customFieldModel.Prefix); ok {
+		customFields, _ := item["custom_fields"].(map[string]any)
+		return customFields[name]
+	}
+
+	return item[key]
+}
+
+// cell formats a value of a list item, times as UTC date times, codes translated to language and lists joined by commas.
+func cell(value any, language string) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case bool:
+		if value {
+			return "TRUE"
+		}
+
+		return "FALSE"
+	case json.Number:
+		return value.String()
+	case string:
+		if parsed, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			if parsed.IsZero() {
+				return ""
+			}
+
+			return parsed.UTC().Format(time.DateTime)
+		}
+
+		value = enums.Label(value, language)
+
+		// 避免試算表將文字當作公式執行,電話號碼除外
+		if formula(value) {
+			return "'" + value
+		}
+
+		return value
+	case []any:
+		output := make([]string, len(value))
+		for i, item := range value {
+			output[i] = cell(item, language)
+		}
+
+		return strings.Join(output, ", ")
+	default:
+		marshal, _ := json.Marshal(value)
+		return string(marshal)
+	}
+}
+
+// formula reports whether a spreadsheet would read value as a formula.
+func formula(value string) bool {
+	switch {
+	case value == "":
+		return false
+	case strings.ContainsRune("=@\t\r", rune(value[0])):
+		return true
+	case value[0] == '+' || value[0] == '-':
+		return strings.Trim(value[1:], "0123456789 -()") != ""
+	}
+
+	return false
+}
+
+func (m *manager) run(jobID string, e *export) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error(r)
+			m.fail(jobID, fmt.Sprint(r))
+		}
+	}()
+
+	err := m.JobService.Update(&jobModel.Update{
+		JobID:  jobID,
+		Status: util.PointerString(jobModel.Running),
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	var buffer bytes.Buffer
+	if err = e.write(&buffer); err != nil {
+		log.Error(err)
+		m.fail(jobID, err.Error())
+		return
+	}
+
+	err = m.JobService.Update(&jobModel.Update{
+		JobID:       jobID,
+		Status:      util.PointerString(jobModel.Succeeded),
+		FileName:    util.PointerString(e.name),
+		ContentType: util.PointerString(e.contentType()),
+		File:        buffer.Bytes(),
+		FinishedAt:  util.PointerTime(util.NowToUTC()),
+	})
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+func (m *manager) fail(jobID string, message string) {
+	err := m.JobService.Update(&jobModel.Update{
+		JobID:      jobID,
+		Status:     util.PointerString(jobModel.Failed),
+		Error:      util.PointerString(message),
+		FinishedAt: util.PointerTime(util.NowToUTC()),
+	})
+	if err != nil {
+		log.Error(err)
+	}
+}
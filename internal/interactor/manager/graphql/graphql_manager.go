@@ -0,0 +1,851 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	constant "crm/internal/interactor/constants"
+	"crm/internal/interactor/helpers"
+	"crm/internal/interactor/manager/account"
+	"crm/internal/interactor/manager/campaign"
+	"crm/internal/interactor/manager/contact"
+	"crm/internal/interactor/manager/contract"
+	"crm/internal/interactor/manager/event"
+	"crm/internal/interactor/manager/lead"
+	"crm/internal/interactor/manager/opportunity"
+	"crm/internal/interactor/manager/order"
+	"crm/internal/interactor/manager/product"
+	"crm/internal/interactor/manager/quote"
+	accountModel "crm/internal/interactor/models/accounts"
+	contactModel "crm/internal/interactor/models/contacts"
+	contractModel "crm/internal/interactor/models/contracts"
+	eventModel "crm/internal/interactor/models/events"
+	graphqlModel "crm/internal/interactor/models/graphql"
+	leadModel "crm/internal/interactor/models/leads"
+	opportunityModel "crm/internal/interactor/models/opportunities"
+	orderModel "crm/internal/interactor/models/orders"
+	quoteModel "crm/internal/interactor/models/quotes"
+	"crm/internal/interactor/pkg/graphql"
+	"crm/internal/interactor/pkg/util"
+	loaderService "crm/internal/interactor/service/loader"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"gorm.io/gorm"
+
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+)
+
+type Manager interface {
+	Execute(trx *gorm.DB, input *graphqlModel.Request) (int, any)
+	GetBySchema() (int, any)
+}
+
+type manager struct {
+	Schema             *graphql.Schema
+	AccountManager     account.Manager
+	ContactManager     contact.Manager
+	LeadManager        lead.Manager
+	OpportunityManager opportunity.Manager
+	QuoteManager       quote.Manager
+	ContractManager    contract.Manager
+	OrderManager       order.Manager
+	EventManager       event.Manager
+	ProductManager     product.Manager
+	CampaignManager    campaign.Manager
+	LoaderService      loaderService.Service
+}
+
+func Init(db *gorm.DB) Manager {
+	m := &manager{
+		AccountManager:     account.Init(db),
+		ContactManager:     contact.Init(db),
+		LeadManager:        lead.Init(db),
+		OpportunityManager: opportunity.Init(db),
+		QuoteManager:       quote.Init(db),
+		ContractManager:    contract.Init(db),
+		OrderManager:       order.Init(db),
+		EventManager:       event.Init(db),
+		ProductManager:     product.Init(db),
+		CampaignManager:    campaign.Init(db),
+		LoaderService:      loaderService.Init(db),
+	}
+
+	m.Schema = graphql.MustSchema(schema, m.resolvers())
+	return m
+}
+
+// object is a type of the schema read from a table.
+type object struct {
+	// 資料表
+	table string
+	// 主鍵
+	primaryKey string
+}
+
+var objects = map[string]object{
+	"Account":          {table: "accounts", primaryKey: "account_id"},
+	"Contact":          {table: "contacts", primaryKey: "contact_id"},
+	"Lead":             {table: "leads", primaryKey: "lead_id"},
+	"Opportunity":      {table: "opportunities", primaryKey: "opportunity_id"},
+	"Quote":            {table: "quotes", primaryKey: "quote_id"},
+	"QuoteProduct":     {table: "quote_products", primaryKey: "quote_product_id"},
+	"Contract":         {table: "contracts", primaryKey: "contract_id"},
+	"Order":            {table: "orders", primaryKey: "order_id"},
+	"OrderProduct":     {table: "order_products", primaryKey: "order_product_id"},
+	"Event":            {table: "events", primaryKey: "event_id"},
+	"Product":          {table: "products", primaryKey: "product_id"},
+	"Campaign":         {table: "campaigns", primaryKey: "campaign_id"},
+	"Industry":         {table: "industries", primaryKey: "industry_id"},
+	"User":             {table: "users", primaryKey: "user_id"},
+	"HistoricalRecord": {table: "historical_records", primaryKey: "historical_record_id"},
+}
+
+// state is the state of a request shared by its resolvers.
+type state struct {
+	trx    *gorm.DB
+	input  *graphqlModel.Request
+	loader loaderService.Service
+}
+
+var errFailed = errors.New("graphql: the manager failed")
+
+// Execute runs a query or mutation, the errors of its fields are returned with the data in a 200 answer.
+func (m *manager) Execute(trx *gorm.DB, input *graphqlModel.Request) (int, any) {
+	response := m.Schema.Execute(&graphql.Request{
+		Query:         input.Query,
+		OperationName: input.OperationName,
+		Variables:     input.Variables,
+		Context: &state{
+			trx:    trx,
+			input:  input,
+			loader: m.LoaderService.WithTrx(trx),
+		},
+	})
+
+	return code.Successful, response
+}
+
+// GetBySchema returns the schema definition.
+func (m *manager) GetBySchema() (int, any) {
+	return code.Successful, code.GetCodeMessage(code.Successful, m.Schema.SDL())
+}
+
+func (m *manager) resolvers() graphql.Resolvers {
+	return graphql.Resolvers{
+		"Query": {
+			"account":       single("Account"),
+			"accounts":      list("Account", m.AccountManager.GetByList, func(input *accountModel.Fields, companyID string) { input.CompanyID = companyID }),
+			"contact":       single("Contact"),
+			"contacts":      list("Contact", m.ContactManager.GetByList, func(input *contactModel.Fields, companyID string) { input.CompanyID = companyID }),
+			"lead":          single("Lead"),
+			"leads":         list("Lead", m.LeadManager.GetByList, func(input *leadModel.Fields, companyID string) { input.CompanyID = companyID }),
+			"opportunity":   single("Opportunity"),
+			"opportunities": list("Opportunity", m.OpportunityManager.GetByList, func(input *opportunityModel.Fields, companyID string) { input.CompanyID = companyID }),
+			"quote":         single("Quote"),
+			"quotes":        list("Quote", m.QuoteManager.GetByList, nil),
+			"contract":      single("Contract"),
+			"contracts":     list("Contract", m.ContractManager.GetByList, nil),
+			"order":         single("Order"),
+			"orders":        list("Order", m.OrderManager.GetByList, nil),
+			"product":       single("Product"),
+			"products":      list("Product", m.ProductManager.GetByList, nil),
+			"campaign":      single("Campaign"),
+			"campaigns":     list("Campaign", m.CampaignManager.GetByList, nil),
+			"event":         single("Event"),
+			"industry":      single("Industry"),
+			"user":          single("User"),
+		},
+		"Mutation": m.mutations(),
+		"Account": {
+			"industry":        belongsTo("Industry", "industry_id"),
+			"parent_account":  belongsTo("Account", "parent_account_id"),
+			"salesperson":     belongsTo("User", "salesperson_id"),
+			"created_by_user": belongsTo("User", "created_by"),
+			"updated_by_user": belongsTo("User", "updated_by"),
+			"contacts":        through("Contact", "account_contacts", "account_id", "contact_id"),
+			"leads":           hasMany("Lead", "account_id", "account_id"),
+			"opportunities":   hasMany("Opportunity", "account_id", "account_id"),
+			"quotes":          hasMany("Quote", "account_id", "account_id"),
+			"contracts":       hasMany("Contract", "account_id", "account_id"),
+			"orders":          hasMany("Order", "account_id", "account_id"),
+			"events":          hasMany("Event", "account_id", "account_id"),
+			"history":         hasMany("HistoricalRecord", "source_id", "account_id"),
+		},
+		"Contact": {
+			"account":         belongsTo("Account", "account_id"),
+			"accounts":        through("Account", "account_contacts", "contact_id", "account_id"),
+			"supervisor":      belongsTo("Contact", "supervisor_id"),
+			"salesperson":     belongsTo("User", "salesperson_id"),
+			"created_by_user": belongsTo("User", "created_by"),
+			"updated_by_user": belongsTo("User", "updated_by"),
+			"events":          through("Event", "event_contacts", "contact_id", "event_id"),
+			"history":         hasMany("HistoricalRecord", "source_id", "contact_id"),
+		},
+		"Lead": {
+			"account":         belongsTo("Account", "account_id"),
+			"salesperson":     belongsTo("User", "salesperson_id"),
+			"created_by_user": belongsTo("User", "created_by"),
+			"updated_by_user": belongsTo("User", "updated_by"),
+			"opportunities":   hasMany("Opportunity", "lead_id", "lead_id"),
+			"history":         hasMany("HistoricalRecord", "source_id", "lead_id"),
+		},
+		"Opportunity": {
+			"lead":            belongsTo("Lead", "lead_id"),
+			"account":         belongsTo("Account", "account_id"),
+			"salesperson":     belongsTo("User", "salesperson_id"),
+			"created_by_user": belongsTo("User", "created_by"),
+			"updated_by_user": belongsTo("User", "updated_by"),
+			"quotes":          hasMany("Quote", "opportunity_id", "opportunity_id"),
+			"contracts":       hasMany("Contract", "opportunity_id", "opportunity_id"),
+			"campaigns":       through("Campaign", "opportunity_campaigns", "opportunity_id", "campaign_id"),
+			"history":         hasMany("HistoricalRecord", "source_id", "opportunity_id"),
+		},
+		"Quote": {
+			"opportunity":     belongsTo("Opportunity", "opportunity_id"),
+			"account":         belongsTo("Account", "account_id"),
+			"created_by_user": belongsTo("User", "created_by"),
+			"updated_by_user": belongsTo("User", "updated_by"),
+			"products":        hasMany("QuoteProduct", "quote_id", "quote_id"),
+			"history":         hasMany("HistoricalRecord", "source_id", "quote_id"),
+		},
+		"QuoteProduct": {
+			"product": belongsTo("Product", "product_id"),
+		},
+		"Contract": {
+			"opportunity":     belongsTo("Opportunity", "opportunity_id"),
+			"account":         belongsTo("Account", "account_id"),
+			"salesperson":     belongsTo("User", "salesperson_id"),
+			"created_by_user": belongsTo("User", "created_by"),
+			"updated_by_user": belongsTo("User", "updated_by"),
+			"orders":          hasMany("Order", "contract_id", "contract_id"),
+			"history":         hasMany("HistoricalRecord", "source_id", "contract_id"),
+		},
+		"Order": {
+			"account":           belongsTo("Account", "account_id"),
+			"contract":          belongsTo("Contract", "contract_id"),
+			"activated_by_user": belongsTo("User", "activated_by"),
+			"created_by_user":   belongsTo("User", "created_by"),
+			"updated_by_user":   belongsTo("User", "updated_by"),
+			"products":          hasMany("OrderProduct", "order_id", "order_id"),
+			"history":           hasMany("HistoricalRecord", "source_id", "order_id"),
+		},
+		"OrderProduct": {
+			"product": belongsTo("Product", "product_id"),
+		},
+		"Event": {
+			"account":         belongsTo("Account", "account_id"),
+			"mains":           through("User", "event_user_mains", "event_id", "main_id"),
+			"attendees":       through("User", "event_user_attendees", "event_id", "attendee_id"),
+			"contacts":        through("Contact", "event_contacts", "event_id", "contact_id"),
+			"created_by_user": belongsTo("User", "created_by"),
+			"updated_by_user": belongsTo("User", "updated_by"),
+			"history":         hasMany("HistoricalRecord", "source_id", "event_id"),
+		},
+		"Product": {
+			"history": hasMany("HistoricalRecord", "source_id", "product_id"),
+		},
+		"Campaign": {
+			"parent_campaign": belongsTo("Campaign", "parent_campaign_id"),
+			"salesperson":     belongsTo("User", "salesperson_id"),
+			"opportunities":   through("Opportunity", "opportunity_campaigns", "campaign_id", "opportunity_id"),
+			"history":         hasMany("HistoricalRecord", "source_id", "campaign_id"),
+		},
+		"HistoricalRecord": {
+			"modified_by_user": belongsTo("User", "modified_by"),
+		},
+	}
+}
+
+// mutations delegate to the managers, so they write the same history and run the same checks as the REST API.
+func (m *manager) mutations() map[string]graphql.Resolver {
+	return map[string]graphql.Resolver{
+		"create_account": mutation("Account", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &accountModel.Create{}
+			if err := decode(arguments["input"], input, func() { input.CreatedBy = s.input.UserID }); err != nil {
+				return code.FormatError, code.GetCodeMessage(code.FormatError, err.Error())
+			}
+
+			return m.AccountManager.Create(trx, input)
+		}),
+		"update_account": mutation("Account", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &accountModel.Update{}
+			if err := decode(arguments["input"], input, func() {
+				input.AccountID, input.UpdatedBy, input.IfMatch = s.update(arguments)
+			}); err != nil {
+				return code.FormatError, code.GetCodeMessage(code.FormatError, err.Error())
+			}
+
+			return m.AccountManager.Update(trx, input)
+		}),
+		"delete_account": mutation("", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &accountModel.Update{}
+			input.AccountID, input.UpdatedBy, _ = s.update(arguments)
+			return m.AccountManager.Delete(trx, input)
+		}),
+		"create_contact": mutation("Contact", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &contactModel.Create{}
+			if err := decode(arguments["input"], input, func() { input.CreatedBy = s.input.UserID }); err != nil {
+				return code.FormatError, code.GetCodeMessage(code.FormatError, err.Error())
+			}
+
+			return m.ContactManager.Create(trx, input)
+		}),
+		"update_contact": mutation("Contact", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &contactModel.Update{}
+			if err := decode(arguments["input"], input, func() {
+				input.ContactID, input.UpdatedBy, input.IfMatch = s.update(arguments)
+			}); err != nil {
+				return code.FormatError, code.GetCodeMessage(code.FormatError, err.Error())
+			}
+
+			return m.ContactManager.Update(trx, input)
+		}),
+		"delete_contact": mutation("", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &contactModel.Update{}
+			input.ContactID, input.UpdatedBy, _ = s.update(arguments)
+			return m.ContactManager.Delete(trx, input)
+		}),
+		"create_lead": mutation("Lead", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &leadModel.Create{}
+			if err := decode(arguments["input"], input, func() { input.CreatedBy = s.input.UserID }); err != nil {
+				return code.FormatError, code.GetCodeMessage(code.FormatError, err.Error())
+			}
+
+			return m.LeadManager.Create(trx, input)
+		}),
+		"update_lead": mutation("Lead", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &leadModel.Update{}
+			if err := decode(arguments["input"], input, func() {
+				input.LeadID, input.UpdatedBy, input.IfMatch = s.update(arguments)
+			}); err != nil {
+				return code.FormatError, code.GetCodeMessage(code.FormatError, err.Error())
+			}
+
+			return m.LeadManager.Update(trx, input)
+		}),
+		"delete_lead": mutation("", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &leadModel.Update{}
+			input.LeadID, input.UpdatedBy, _ = s.update(arguments)
+			return m.LeadManager.Delete(trx, input)
+		}),
+		"create_opportunity": mutation("Opportunity", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &opportunityModel.Create{}
+			if err := decode(arguments["input"], input, func() { input.CreatedBy = s.input.UserID }); err != nil {
+				return code.FormatError, code.GetCodeMessage(code.FormatError, err.Error())
+			}
+
+			return m.OpportunityManager.Create(trx, input)
+		}),
+		"update_opportunity": mutation("Opportunity", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &opportunityModel.Update{}
+			if err := decode(arguments["input"], input, func() {
+				input.OpportunityID, input.UpdatedBy, input.IfMatch = s.update(arguments)
+			}); err != nil {
+				return code.FormatError, code.GetCodeMessage(code.FormatError, err.Error())
+			}
+
+			return m.OpportunityManager.Update(trx, input)
+		}),
+		"delete_opportunity": mutation("", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &opportunityModel.Update{}
+			input.OpportunityID, input.UpdatedBy, _ = s.update(arguments)
+			return m.OpportunityManager.Delete(trx, input)
+		}),
+		"create_quote": mutation("Quote", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &quoteModel.Create{}
+			if err := decode(arguments["input"], input, func() { input.CreatedBy = s.input.UserID }); err != nil {
+				return code.FormatError, code.GetCodeMessage(code.FormatError, err.Error())
+			}
+
+			return m.QuoteManager.Create(trx, input)
+		}),
+		"update_quote": mutation("Quote", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &quoteModel.Update{}
+			if err := decode(arguments["input"], input, func() {
+				input.QuoteID, input.UpdatedBy, input.IfMatch = s.update(arguments)
+			}); err != nil {
+				return code.FormatError, code.GetCodeMessage(code.FormatError, err.Error())
+			}
+
+			return m.QuoteManager.Update(trx, input)
+		}),
+		"delete_quote": mutation("", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &quoteModel.Update{}
+			input.QuoteID, input.UpdatedBy, _ = s.update(arguments)
+			return m.QuoteManager.Delete(trx, input)
+		}),
+		"create_contract": mutation("Contract", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &contractModel.Create{}
+			if err := decode(arguments["input"], input, func() { input.CreatedBy = s.input.UserID }); err != nil {
+				return code.FormatError, code.GetCodeMessage(code.FormatError, err.Error())
+			}
+
+			return m.ContractManager.Create(trx, input)
+		}),
+		"update_contract": mutation("Contract", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &contractModel.Update{}
+			if err := decode(arguments["input"], input, func() {
+				input.ContractID, input.UpdatedBy, input.IfMatch = s.update(arguments)
+			}); err != nil {
+				return code.FormatError, code.GetCodeMessage(code.FormatError, err.Error())
+			}
+
+			return m.ContractManager.Update(trx, input)
+		}),
+		"delete_contract": mutation("", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &contractModel.Update{}
+			input.ContractID, input.UpdatedBy, _ = s.update(arguments)
+			return m.ContractManager.Delete(trx, input)
+		}),
+		"create_order": mutation("Order", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &orderModel.Create{}
+			if err := decode(arguments["input"], input, func() { input.CreatedBy = s.input.UserID }); err != nil {
+				return code.FormatError, code.GetCodeMessage(code.FormatError, err.Error())
+			}
+
+			return m.OrderManager.Create(trx, input)
+		}),
+		"update_order": mutation("Order", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &orderModel.Update{}
+			if err := decode(arguments["input"], input, func() {
+				input.OrderID, input.UpdatedBy, input.IfMatch = s.update(arguments)
+			}); err != nil {
+				return code.FormatError, code.GetCodeMessage(code.FormatError, err.Error())
+			}
+
+			return m.OrderManager.Update(trx, input)
+		}),
+		"delete_order": mutation("", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &orderModel.Update{}
+			input.OrderID, input.UpdatedBy, _ = s.update(arguments)
+			return m.OrderManager.Delete(trx, input)
+		}),
+		"create_event": mutation("Event", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &eventModel.Create{}
+			if err := decode(arguments["input"], input, func() { input.CreatedBy = s.input.UserID }); err != nil {
+				return code.FormatError, code.GetCodeMessage(code.FormatError, err.Error())
+			}
+
+			return m.EventManager.Create(trx, input)
+		}),
+		"update_event": mutation("Event", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &eventModel.Update{}
+			if err := decode(arguments["input"], input, func() {
+				input.EventID, input.UpdatedBy, input.IfMatch = s.update(arguments)
+			}); err != nil {
+				return code.FormatError, code.GetCodeMessage(code.FormatError, err.Error())
+			}
+
+			return m.EventManager.Update(trx, input)
+		}),
+		"delete_event": mutation("", func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+			input := &eventModel.Update{}
+			input.EventID, input.UpdatedBy, _ = s.update(arguments)
+			return m.EventManager.Delete(trx, input)
+		}),
+	}
+}
+
+// update returns the record ID, the updater and the version of an update or delete mutation.
+func (s *state) update(arguments map[string]any) (string, *string, string) {
+	id, _ := arguments["id"].(string)
+	ifMatch, _ := arguments["if_match"].(string)
+	return id, util.PointerString(s.input.UserID), ifMatch
+}
+
+// readable returns an error unless the role of the request may read typeName.
+func (s *state) readable(typeName string) error {
+	if _, guarded := graphqlModel.Reads[typeName]; guarded && !slices.Contains(s.input.Allowed, typeName) {
+		return failure(code.PermissionDenied, code.GetCodeMessage(code.PermissionDenied, "Sorry, you don't have permission."))
+	}
+
+	return nil
+}
+
+// load returns the records of table whose column holds one of the keys, grouped by the value of the column.
+// Users of other companies are never read.
+func (s *state) load(table, column string, keys []string) (map[string][]map[string]any, error) {
+	records, err := s.loader.GetByKeys(table, column, s.input.CompanyID, keys)
+	if err != nil {
+		log.Error(err)
+		return nil, failure(code.InternalServerError, nil)
+	}
+
+	return records, nil
+}
+
+// single resolves a record of typeName by the id argument.
+func single(typeName string) graphql.Resolver {
+	o := objects[typeName]
+	return func(request *graphql.Request, field *graphql.Field, parents []any) ([]any, error) {
+		s := request.Context.(*state)
+		if err := s.readable(typeName); err != nil {
+			return nil, err
+		}
+
+		id, _ := field.Arguments["id"].(string)
+		records, err := s.load(o.table, o.primaryKey, []string{id})
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]any, len(parents))
+		if found := records[id]; len(found) > 0 {
+			values[0] = found[0]
+		}
+
+		return values, nil
+	}
+}
+
+// list resolves a page of typeName from the GetByList of its manager, with the same filters, sort and pagination
+// as the REST API. The manager returns the IDs of the page, their records are then read in one query.
+// scope, when the list has custom fields, sets the company of the input like the presenters do.
+func list[T any](typeName string, getByList func(input *T) (int, any), scope func(input *T, companyID string)) graphql.Resolver {
+	o := objects[typeName]
+	return func(request *graphql.Request, field *graphql.Field, parents []any) ([]any, error) {
+		s := request.Context.(*state)
+		if err := s.readable(typeName); err != nil {
+			return nil, err
+		}
+
+		limit, _ := integer(field.Arguments["limit"])
+		if limit <= 0 || limit >= constant.DefaultLimit {
+			limit = constant.DefaultLimit
+		}
+
+		page, _ := integer(field.Arguments["page"])
+		input := new(T)
+		err := decode(map[string]any{
+			"filter": field.Arguments["filter"],
+			"page":   page,
+			"limit":  limit,
+			"cursor": field.Arguments["cursor"],
+			"count":  field.Arguments["count"],
+			"sort":   map[string]any{"by": field.Arguments["sort"]},
+			"fields": o.primaryKey,
+		}, input, func() {
+			if scope != nil {
+				scope(input, s.input.CompanyID)
+			}
+		})
+		if err != nil {
+			return nil, failure(code.FormatError, code.GetCodeMessage(code.FormatError, err.Error()))
+		}
+
+		httpCode, codeMessage := getByList(input)
+		message, ok := codeMessage.(*code.SuccessfulMessage)
+		if httpCode != code.Successful || !ok {
+			return nil, failure(httpCode, codeMessage)
+		}
+
+		marshal, err := json.Marshal(message.Body)
+		if err != nil {
+			log.Error(err)
+			return nil, failure(code.InternalServerError, nil)
+		}
+
+		body := struct {
+			Items      []map[string]any `json:"-"`
+			Total      int64            `json:"total"`
+			Pages      int64            `json:"pages"`
+			NextCursor string           `json:"next_cursor"`
+			PrevCursor string           `json:"prev_cursor"`
+		}{}
+		items := map[string]json.RawMessage{}
+		if err = json.Unmarshal(marshal, &body); err == nil {
+			if err = json.Unmarshal(marshal, &items); err == nil {
+				err = json.Unmarshal(items[o.table], &body.Items)
+			}
+		}
+
+		if err != nil {
+			log.Error(err)
+			return nil, failure(code.InternalServerError, nil)
+		}
+
+		ids := make([]string, len(body.Items))
+		for i, item := range body.Items {
+			ids[i] = fmt.Sprint(item[o.primaryKey])
+		}
+
+		records, err := s.load(o.table, o.primaryKey, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		// 依列表的排序回傳
+		output := make([]any, 0, len(ids))
+		for _, id := range ids {
+			if found := records[id]; len(found) > 0 {
+				output = append(output, found[0])
+			}
+		}
+
+		return []any{map[string]any{
+			"items":       output,
+			"total":       body.Total,
+			"pages":       body.Pages,
+			"next_cursor": nullable(body.NextCursor),
+			"prev_cursor": nullable(body.PrevCursor),
+		}}, nil
+	}
+}
+
+// belongsTo resolves the record of typeName referenced by column for every parent at once.
+func belongsTo(typeName, column string) graphql.Resolver {
+	o := objects[typeName]
+	return func(request *graphql.Request, field *graphql.Field, parents []any) ([]any, error) {
+		s := request.Context.(*state)
+		if err := s.readable(typeName); err != nil {
+			return nil, err
+		}
+
+		keys := make([]string, len(parents))
+		for i, parent := range parents {
+			keys[i] = value(parent, column)
+		}
+
+		records, err := s.load(o.table, o.primaryKey, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]any, len(parents))
+		for i, key := range keys {
+			if found := records[key]; len(found) > 0 {
+				values[i] = found[0]
+			}
+		}
+
+		return values, nil
+	}
+}
+
+// hasMany resolves the records of typeName whose column references the key of every parent at once.
+func hasMany(typeName, column, key string) graphql.Resolver {
+	o := objects[typeName]
+	return func(request *graphql.Request, field *graphql.Field, parents []any) ([]any, error) {
+		s := request.Context.(*state)
+		if err := s.readable(typeName); err != nil {
+			return nil, err
+		}
+
+		keys := make([]string, len(parents))
+		for i, parent := range parents {
+			keys[i] = value(parent, key)
+		}
+
+		records, err := s.load(o.table, column, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]any, len(parents))
+		for i, key := range keys {
+			values[i] = many(records[key])
+		}
+
+		return values, nil
+	}
+}
+
+// through resolves the records of typeName linked to every parent by a link table, whose column references
+// the primary key of the parent and whose target references the records.
+func through(typeName, link, column, target string) graphql.Resolver {
+	o := objects[typeName]
+	return func(request *graphql.Request, field *graphql.Field, parents []any) ([]any, error) {
+		s := request.Context.(*state)
+		if err := s.readable(typeName); err != nil {
+			return nil, err
+		}
+
+		keys := make([]string, len(parents))
+		for i, parent := range parents {
+			keys[i] = value(parent, column)
+		}
+
+		links, err := s.load(link, column, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		var ids []string
+		for _, records := range links {
+			for _, record := range records {
+				ids = append(ids, value(record, target))
+			}
+		}
+
+		records, err := s.load(o.table, o.primaryKey, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]any, len(parents))
+		for i, key := range keys {
+			var linked []map[string]any
+			for _, record := range links[key] {
+				if found := records[value(record, target)]; len(found) > 0 {
+					linked = append(linked, found[0])
+				}
+			}
+
+			values[i] = many(linked)
+		}
+
+		return values, nil
+	}
+}
+
+// mutation runs a manager method in a savepoint of the request, so a failed mutation is rolled back alone,
+// and resolves the record it wrote as typeName, or its ID when typeName is empty or the role may not read it.
+func mutation(typeName string, run func(trx *gorm.DB, s *state, arguments map[string]any) (int, any)) graphql.Resolver {
+	return func(request *graphql.Request, field *graphql.Field, parents []any) ([]any, error) {
+		s := request.Context.(*state)
+		if !slices.Contains(s.input.Allowed, field.Name) {
+			return nil, failure(code.PermissionDenied, code.GetCodeMessage(code.PermissionDenied, "Sorry, you don't have permission."))
+		}
+
+		id, _ := field.Arguments["id"].(string)
+		if ifMatch, _ := field.Arguments["if_match"].(string); id != "" && typeName != "" && ifMatch == "" && s.input.IfMatchRequired {
+			return nil, failure(code.PreconditionRequired, code.GetCodeMessage(code.PreconditionRequired, "if_match is required."))
+		}
+
+		var failed error
+		err := helpers.Savepoint(s.trx, func(trx *gorm.DB) error {
+			httpCode, codeMessage := run(trx, s, field.Arguments)
+			if httpCode != code.Successful {
+				failed = failure(httpCode, codeMessage)
+				return errFailed
+			}
+
+			// 新增時由結果取得資料ID
+			if message, ok := codeMessage.(*code.SuccessfulMessage); ok && id == "" {
+				switch body := message.Body.(type) {
+				case *string:
+					id = *body
+				case string:
+					id = body
+				}
+			}
+
+			return nil
+		})
+		if failed != nil {
+			return nil, failed
+		}
+
+		if err != nil {
+			log.Error(err)
+			return nil, failure(code.InternalServerError, nil)
+		}
+
+		if typeName == "" {
+			return []any{id}, nil
+		}
+
+		// 無法讀取的類型只回傳資料ID
+		o := objects[typeName]
+		if s.readable(typeName) != nil {
+			return []any{map[string]any{o.primaryKey: id}}, nil
+		}
+
+		records, err := s.load(o.table, o.primaryKey, []string{id})
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]any, len(parents))
+		if found := records[id]; len(found) > 0 {
+			values[0] = found[0]
+		}
+
+		return values, nil
+	}
+}
+
+// decode reads a JSON argument into input like a request body, fixed sets the fields the client may not choose,
+// and input is then validated by its binding tags.
+func decode(argument any, input any, fixed func()) error {
+	marshal, err := json.Marshal(argument)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(marshal, input); err != nil {
+		return err
+	}
+
+	if fixed != nil {
+		fixed()
+	}
+
+	return binding.Validator.ValidateStruct(input)
+}
+
+// failure turns the code message of a manager into the error of a field, the detail of a 500 is only logged.
+func failure(httpCode int, codeMessage any) error {
+	output := &gqlerror.Error{
+		Message:    http.StatusText(httpCode),
+		Extensions: map[string]any{"status": httpCode},
+	}
+
+	if message, ok := codeMessage.(*code.ErrorMessage); ok {
+		output.Message = message.Message
+		if httpCode != code.InternalServerError {
+			output.Extensions["detailed"] = message.Detailed
+		}
+	}
+
+	return output
+}
+
+// value returns the value of column of a record as text, empty when it is null.
+func value(record any, column string) string {
+	if record, ok := record.(map[string]any); ok && record[column] != nil {
+		return fmt.Sprint(record[column])
+	}
+
+	return ""
+}
+
+// many returns records as a list, empty rather than null when there is none.
+func many(records []map[string]any) []any {
+	output := make([]any, len(records))
+	for i, record := range records {
+		output[i] = record
+	}
+
+	return output
+}
+
+// integer returns an Int argument, given as a literal or by a variable.
+func integer(argument any) (int64, bool) {
+	switch argument := argument.(type) {
+	case int:
+		return int64(argument), true
+	case int64:
+		return argument, true
+	case float64:
+		return int64(argument), true
+	case json.Number:
+		n, err := argument.Int64()
+		return n, err == nil
+	}
+
+	return 0, false
+}
+
+func nullable(s string) any {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
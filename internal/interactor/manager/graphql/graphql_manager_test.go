@@ -0,0 +1,324 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+
+	constant "crm/internal/interactor/constants"
+	graphqlModel "crm/internal/interactor/models/graphql"
+	"crm/internal/interactor/pkg/graphql"
+	"crm/internal/interactor/pkg/util/code"
+	loaderService "crm/internal/interactor/service/loader"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// stubLoader reads the records from the transaction of the test and counts its queries.
+type stubLoader struct {
+	trx   *gorm.DB
+	calls *[]string
+}
+
+func (l stubLoader) WithTrx(tx *gorm.DB) loaderService.Service {
+	return stubLoader{trx: tx, calls: l.calls}
+}
+
+func (l stubLoader) GetByKeys(table, column, companyID string, keys []string) (map[string][]map[string]any, error) {
+	*l.calls = append(*l.calls, table+"."+column+"@"+companyID)
+	var records []map[string]any
+	if err := l.trx.Table(table).Where(column+" in ?", keys).Order("rowid").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	output := map[string][]map[string]any{}
+	for _, record := range records {
+		key := fmt.Sprint(record[column])
+		output[key] = append(output[key], record)
+	}
+
+	return output, nil
+}
+
+// listInput is the input of the stub GetByList.
+type listInput struct {
+	Page      int64  `json:"page"`
+	Limit     int64  `json:"limit"`
+	Fields    string `json:"fields"`
+	CompanyID string `json:"-"`
+}
+
+// testManager returns a manager over an in-memory database with two accounts, the first having two contacts.
+// The inputs of accounts are appended to lists and the queries of the loader to calls.
+func testManager(t *testing.T, lists *[]listInput, calls *[]string) (*manager, *gorm.DB) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 記憶體資料庫只存在於單一連線
+	connection, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	connection.SetMaxOpenConns(1)
+	for _, statement := range []string{
+		"create table accounts (account_id text primary key, name text not null)",
+		"create table contacts (contact_id text primary key, name text not null)",
+		"create table account_contacts (account_id text, contact_id text)",
+		"insert into accounts values ('a1', 'Acme'), ('a2', 'Globex')",
+		"insert into contacts values ('c1', 'Ann'), ('c2', 'Bob')",
+		"insert into account_contacts values ('a1', 'c2'), ('a1', 'c1')",
+	} {
+		if err = db.Exec(statement).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	trx := db.Begin()
+	t.Cleanup(func() { trx.Rollback() })
+
+	// 新增名稱為 fail 的帳戶會在寫入後失敗
+	create := func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+		input, _ := arguments["input"].(map[string]any)
+		name, _ := input["name"].(string)
+		id := "id-" + name
+		if err := trx.Exec("insert into accounts values (?, ?)", id, name).Error; err != nil {
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+
+		if name == "fail" {
+			return code.BadRequest, code.GetCodeMessage(code.BadRequest, "name is not allowed.")
+		}
+
+		return code.Successful, code.GetCodeMessage(code.Successful, &id)
+	}
+	update := func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+		id, _, _ := s.update(arguments)
+		input, _ := arguments["input"].(map[string]any)
+		if err := trx.Exec("update accounts set name = ? where account_id = ?", input["name"], id).Error; err != nil {
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+
+		return code.Successful, code.GetCodeMessage(code.Successful, "Update ok!")
+	}
+	remove := func(trx *gorm.DB, s *state, arguments map[string]any) (int, any) {
+		id, _, _ := s.update(arguments)
+		if err := trx.Exec("delete from accounts where account_id = ?", id).Error; err != nil {
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+
+		return code.Successful, code.GetCodeMessage(code.Successful, "Delete ok!")
+	}
+
+	// 列表依名稱倒序回傳ID
+	getByList := func(input *listInput) (int, any) {
+		*lists = append(*lists, *input)
+		return code.Successful, code.GetCodeMessage(code.Successful, map[string]any{
+			"accounts":    []map[string]any{{"account_id": "a2"}, {"account_id": "a1"}},
+			"total":       2,
+			"pages":       1,
+			"next_cursor": "next",
+		})
+	}
+
+	m := &manager{LoaderService: stubLoader{calls: calls}}
+	m.Schema = graphql.MustSchema(schema, graphql.Resolvers{
+		"Query": {
+			"account":  single("Account"),
+			"accounts": list("Account", getByList, func(input *listInput, companyID string) { input.CompanyID = companyID }),
+		},
+		"Mutation": {
+			"create_account": mutation("Account", create),
+			"update_account": mutation("Account", update),
+			"delete_account": mutation("", remove),
+		},
+		"Account": {
+			"contacts": through("Contact", "account_contacts", "account_id", "contact_id"),
+		},
+	})
+
+	return m, trx
+}
+
+// execute runs query with the permissions allowed and returns its data as JSON and the status of its errors by path.
+func execute(t *testing.T, m *manager, trx *gorm.DB, input *graphqlModel.Request) (string, map[string]any) {
+	t.Helper()
+	input.CompanyID = "company"
+	httpCode, output := m.Execute(trx, input)
+	if httpCode != code.Successful {
+		t.Fatalf("Execute() = %d", httpCode)
+	}
+
+	response := output.(*graphql.Response)
+	data, err := json.Marshal(response.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := map[string]any{}
+	for _, err := range response.Errors {
+		statuses[err.Path.String()] = err.Extensions["status"]
+	}
+
+	return string(data), statuses
+}
+
+// names returns the names of the accounts in trx.
+func names(t *testing.T, trx *gorm.DB) []string {
+	t.Helper()
+	var output []string
+	if err := trx.Table("accounts").Order("name").Pluck("name", &output).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return output
+}
+
+func TestMutations(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		allowed      []string
+		ifMatch      bool
+		wantData     string
+		wantStatuses map[string]any
+		wantNames    []string
+	}{
+		{"failed mutation rolls back only its savepoint",
+			`mutation { a: create_account(input: {name: "first"}) { account_id name } b: create_account(input: {name: "fail"}) { account_id } c: create_account(input: {name: "third"}) { name } }`,
+			[]string{"create_account", "Account"}, false,
+			`{"a":{"account_id":"id-first","name":"first"},"b":null,"c":{"name":"third"}}`,
+			map[string]any{"b": code.BadRequest}, []string{"Acme", "Globex", "first", "third"}},
+		{"mutation not allowed",
+			`mutation { create_account(input: {name: "first"}) { account_id } }`,
+			[]string{"Account"}, false,
+			`{"create_account":null}`, map[string]any{"create_account": code.PermissionDenied}, []string{"Acme", "Globex"}},
+		{"unreadable type resolves only the ID",
+			`mutation { create_account(input: {name: "first"}) { account_id } }`,
+			[]string{"create_account"}, false,
+			`{"create_account":{"account_id":"id-first"}}`, map[string]any{}, []string{"Acme", "Globex", "first"}},
+		{"update without if_match when it is required",
+			`mutation { update_account(id: "a1", input: {name: "Initech"}) { name } }`,
+			[]string{"update_account", "Account"}, true,
+			`{"update_account":null}`, map[string]any{"update_account": code.PreconditionRequired}, []string{"Acme", "Globex"}},
+		{"update with if_match",
+			`mutation { update_account(id: "a1", input: {name: "Initech"}, if_match: "etag") { account_id name } }`,
+			[]string{"update_account", "Account"}, true,
+			`{"update_account":{"account_id":"a1","name":"Initech"}}`, map[string]any{}, []string{"Globex", "Initech"}},
+		{"delete resolves the ID without if_match",
+			`mutation { delete_account(id: "a2") }`,
+			[]string{"delete_account"}, true,
+			`{"delete_account":"a2"}`, map[string]any{}, []string{"Acme"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, trx := testManager(t, &[]listInput{}, &[]string{})
+			data, statuses := execute(t, m, trx, &graphqlModel.Request{Query: tt.query, Allowed: tt.allowed, IfMatchRequired: tt.ifMatch})
+			if data != tt.wantData {
+				t.Errorf("Execute() data = %s, want %s", data, tt.wantData)
+			}
+
+			if !reflect.DeepEqual(statuses, tt.wantStatuses) {
+				t.Errorf("Execute() statuses = %v, want %v", statuses, tt.wantStatuses)
+			}
+
+			if got := names(t, trx); !slices.Equal(got, tt.wantNames) {
+				t.Errorf("Execute() left accounts %v, want %v", got, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestQueries(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		allowed      []string
+		wantData     string
+		wantStatuses map[string]any
+	}{
+		{"single",
+			`{ account(id: "a2") { account_id name } missing: account(id: "a9") { name } }`, []string{"Account"},
+			`{"account":{"account_id":"a2","name":"Globex"},"missing":null}`, map[string]any{}},
+		{"unreadable type",
+			`{ account(id: "a2") { name } }`, nil,
+			`{"account":null}`, map[string]any{"account": code.PermissionDenied}},
+		{"list keeps the order of the manager",
+			`{ accounts { items { account_id } total pages next_cursor prev_cursor } }`, []string{"Account"},
+			`{"accounts":{"items":[{"account_id":"a2"},{"account_id":"a1"}],"total":2,"pages":1,"next_cursor":"next","prev_cursor":null}}`,
+			map[string]any{}},
+		{"through",
+			`{ accounts { items { account_id contacts { name } } } }`, []string{"Account", "Contact"},
+			`{"accounts":{"items":[{"account_id":"a2","contacts":[]},{"account_id":"a1","contacts":[{"name":"Bob"},{"name":"Ann"}]}]}}`,
+			map[string]any{}},
+		{"unreadable type through a link table",
+			`{ account(id: "a1") { name contacts { name } } }`, []string{"Account"},
+			`{"account":null}`, map[string]any{"account.contacts": code.PermissionDenied}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, trx := testManager(t, &[]listInput{}, &[]string{})
+			data, statuses := execute(t, m, trx, &graphqlModel.Request{Query: tt.query, Allowed: tt.allowed})
+			if data != tt.wantData {
+				t.Errorf("Execute() data = %s, want %s", data, tt.wantData)
+			}
+
+			if !reflect.DeepEqual(statuses, tt.wantStatuses) {
+				t.Errorf("Execute() statuses = %v, want %v", statuses, tt.wantStatuses)
+			}
+		})
+	}
+}
+
+func TestListInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  listInput
+	}{
+		{"default limit", `{ accounts { total } }`,
+			listInput{Limit: constant.DefaultLimit, Fields: "account_id", CompanyID: "company"}},
+		{"page and limit", `{ accounts(page: 2, limit: 5) { total } }`,
+			listInput{Page: 2, Limit: 5, Fields: "account_id", CompanyID: "company"}},
+		{"limit above the maximum", fmt.Sprintf(`{ accounts(limit: %d) { total } }`, constant.DefaultLimit+1),
+			listInput{Limit: constant.DefaultLimit, Fields: "account_id", CompanyID: "company"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lists []listInput
+			m, trx := testManager(t, &lists, &[]string{})
+			if _, statuses := execute(t, m, trx, &graphqlModel.Request{Query: tt.query, Allowed: []string{"Account"}}); len(statuses) > 0 {
+				t.Fatalf("Execute() statuses = %v", statuses)
+			}
+
+			if want := []listInput{tt.want}; !reflect.DeepEqual(lists, want) {
+				t.Errorf("Execute() listed %+v, want %+v", lists, want)
+			}
+		})
+	}
+}
+
+func TestThroughBatches(t *testing.T) {
+	var calls []string
+	m, trx := testManager(t, &[]listInput{}, &calls)
+	query := `{ accounts { items { contacts { name } } } }`
+	if _, statuses := execute(t, m, trx, &graphqlModel.Request{Query: query, Allowed: []string{"Account", "Contact"}}); len(statuses) > 0 {
+		t.Fatalf("Execute() statuses = %v", statuses)
+	}
+
+	// 每張資料表只查詢一次,且限定在請求的公司
+	want := []string{"accounts.account_id@company", "account_contacts.account_id@company", "contacts.contact_id@company"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("Execute() loaded %v, want %v", calls, want)
+	}
+}
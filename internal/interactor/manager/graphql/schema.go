@@ -0,0 +1,417 @@
+package graphql
+
+// schema is the GraphQL schema of the CRM, its fields are named like the JSON fields of the REST API.
+// Times are RFC 3339 strings, JSON arguments take the same JSON as the request bodies of the REST API.
+const schema = `
+scalar JSON
+
+type Query {
+  account(id: ID!): Account
+  accounts(page: Int, limit: Int = 20, cursor: String, count: String, sort: String, filter: JSON): AccountList!
+  contact(id: ID!): Contact
+  contacts(page: Int, limit: Int = 20, cursor: String, count: String, sort: String, filter: JSON): ContactList!
+  lead(id: ID!): Lead
+  leads(page: Int, limit: Int = 20, cursor: String, count: String, sort: String, filter: JSON): LeadList!
+  opportunity(id: ID!): Opportunity
+  opportunities(page: Int, limit: Int = 20, cursor: String, count: String, sort: String, filter: JSON): OpportunityList!
+  quote(id: ID!): Quote
+  quotes(page: Int, limit: Int = 20, cursor: String, count: String, sort: String, filter: JSON): QuoteList!
+  contract(id: ID!): Contract
+  contracts(page: Int, limit: Int = 20, cursor: String, count: String, sort: String, filter: JSON): ContractList!
+  order(id: ID!): Order
+  orders(page: Int, limit: Int = 20, cursor: String, count: String, sort: String, filter: JSON): OrderList!
+  product(id: ID!): Product
+  products(page: Int, limit: Int = 20, cursor: String, count: String, sort: String, filter: JSON): ProductList!
+  campaign(id: ID!): Campaign
+  campaigns(page: Int, limit: Int = 20, cursor: String, count: String, sort: String, filter: JSON): CampaignList!
+  event(id: ID!): Event
+  industry(id: ID!): Industry
+  user(id: ID!): User
+}
+
+type Mutation {
+  create_account(input: JSON!): Account
+  update_account(id: ID!, input: JSON!, if_match: String): Account
+  delete_account(id: ID!): ID
+  create_contact(input: JSON!): Contact
+  update_contact(id: ID!, input: JSON!, if_match: String): Contact
+  delete_contact(id: ID!): ID
+  create_lead(input: JSON!): Lead
+  update_lead(id: ID!, input: JSON!, if_match: String): Lead
+  delete_lead(id: ID!): ID
+  create_opportunity(input: JSON!): Opportunity
+  update_opportunity(id: ID!, input: JSON!, if_match: String): Opportunity
+  delete_opportunity(id: ID!): ID
+  create_quote(input: JSON!): Quote
+  update_quote(id: ID!, input: JSON!, if_match: String): Quote
+  delete_quote(id: ID!): ID
+  create_contract(input: JSON!): Contract
+  update_contract(id: ID!, input: JSON!, if_match: String): Contract
+  delete_contract(id: ID!): ID
+  create_order(input: JSON!): Order
+  update_order(id: ID!, input: JSON!, if_match: String): Order
+  delete_order(id: ID!): ID
+  create_event(input: JSON!): Event
+  update_event(id: ID!, input: JSON!, if_match: String): Event
+  delete_event(id: ID!): ID
+}
+
+type Account {
+  account_id: ID!
+  name: String
+  phone_number: String
+  type: [String!]
+  industry_id: ID
+  parent_account_id: ID
+  salesperson_id: ID
+  custom_fields: JSON
+  created_at: String
+  created_by: ID
+  updated_at: String
+  updated_by: ID
+  industry: Industry
+  parent_account: Account
+  salesperson: User
+  created_by_user: User
+  updated_by_user: User
+  contacts: [Contact!]!
+  leads: [Lead!]!
+  opportunities: [Opportunity!]!
+  quotes: [Quote!]!
+  contracts: [Contract!]!
+  orders: [Order!]!
+  events: [Event!]!
+  history: [HistoricalRecord!]!
+}
+
+type Contact {
+  contact_id: ID!
+  name: String
+  title: String
+  phone_number: String
+  cell_phone: String
+  email: String
+  salutation: String
+  department: String
+  supervisor_id: ID
+  account_id: ID
+  salesperson_id: ID
+  custom_fields: JSON
+  created_at: String
+  created_by: ID
+  updated_at: String
+  updated_by: ID
+  account: Account
+  accounts: [Account!]!
+  supervisor: Contact
+  salesperson: User
+  created_by_user: User
+  updated_by_user: User
+  events: [Event!]!
+  history: [HistoricalRecord!]!
+}
+
+type Lead {
+  lead_id: ID!
+  status: String
+  description: String
+  source: String
+  account_id: ID
+  rating: String
+  salesperson_id: ID
+  custom_fields: JSON
+  created_at: String
+  created_by: ID
+  updated_at: String
+  updated_by: ID
+  account: Account
+  salesperson: User
+  created_by_user: User
+  updated_by_user: User
+  opportunities: [Opportunity!]!
+  history: [HistoricalRecord!]!
+}
+
+type Opportunity {
+  opportunity_id: ID!
+  name: String
+  stage: String
+  forecast_category: String
+  close_date: String
+  lead_id: ID
+  account_id: ID
+  amount: Float
+  salesperson_id: ID
+  custom_fields: JSON
+  created_at: String
+  created_by: ID
+  updated_at: String
+  updated_by: ID
+  lead: Lead
+  account: Account
+  salesperson: User
+  created_by_user: User
+  updated_by_user: User
+  quotes: [Quote!]!
+  contracts: [Contract!]!
+  campaigns: [Campaign!]!
+  history: [HistoricalRecord!]!
+}
+
+type Quote {
+  quote_id: ID!
+  code: String
+  name: String
+  status: String
+  is_syncing: Boolean
+  is_final: Boolean
+  opportunity_id: ID
+  account_id: ID
+  expiration_date: String
+  description: String
+  tax: Float
+  shipping_and_handling: Float
+  created_at: String
+  created_by: ID
+  updated_at: String
+  updated_by: ID
+  opportunity: Opportunity
+  account: Account
+  created_by_user: User
+  updated_by_user: User
+  products: [QuoteProduct!]!
+  history: [HistoricalRecord!]!
+}
+
+type QuoteProduct {
+  quote_product_id: ID!
+  quote_id: ID
+  product_id: ID
+  quantity: Int
+  unit_price: Float
+  sub_total: Float
+  total_price: Float
+  discount: String
+  description: String
+  product: Product
+}
+
+type Contract {
+  contract_id: ID!
+  code: String
+  status: String
+  start_date: String
+  term: Int
+  end_date: String
+  opportunity_id: ID
+  account_id: ID
+  description: String
+  salesperson_id: ID
+  created_at: String
+  created_by: ID
+  updated_at: String
+  updated_by: ID
+  opportunity: Opportunity
+  account: Account
+  salesperson: User
+  created_by_user: User
+  updated_by_user: User
+  orders: [Order!]!
+  history: [HistoricalRecord!]!
+}
+
+type Order {
+  order_id: ID!
+  code: String
+  status: String
+  start_date: String
+  account_id: ID
+  contract_id: ID
+  description: String
+  activated_at: String
+  activated_by: ID
+  created_at: String
+  created_by: ID
+  updated_at: String
+  updated_by: ID
+  account: Account
+  contract: Contract
+  activated_by_user: User
+  created_by_user: User
+  updated_by_user: User
+  products: [OrderProduct!]!
+  history: [HistoricalRecord!]!
+}
+
+type OrderProduct {
+  order_product_id: ID!
+  order_id: ID
+  product_id: ID
+  quantity: Int
+  unit_price: Float
+  quote_price: Float
+  sub_total: Float
+  description: String
+  product: Product
+}
+
+type Event {
+  event_id: ID!
+  subject: String
+  is_whole: Boolean
+  start_date: String
+  end_date: String
+  account_id: ID
+  type: String
+  location: String
+  description: String
+  created_at: String
+  created_by: ID
+  updated_at: String
+  updated_by: ID
+  account: Account
+  mains: [User!]!
+  attendees: [User!]!
+  contacts: [Contact!]!
+  created_by_user: User
+  updated_by_user: User
+  history: [HistoricalRecord!]!
+}
+
+type Product {
+  product_id: ID!
+  name: String
+  code: String
+  is_enable: Boolean
+  description: String
+  price: Float
+  created_at: String
+  created_by: ID
+  updated_at: String
+  updated_by: ID
+  history: [HistoricalRecord!]!
+}
+
+type Campaign {
+  campaign_id: ID!
+  name: String
+  status: String
+  is_enable: Boolean
+  type: String
+  parent_campaign_id: ID
+  start_date: String
+  end_date: String
+  description: String
+  sent: Int
+  budget_cost: Float
+  expected_responses: Float
+  actual_cost: Float
+  expected_income: Float
+  salesperson_id: ID
+  created_at: String
+  created_by: ID
+  updated_at: String
+  updated_by: ID
+  parent_campaign: Campaign
+  salesperson: User
+  opportunities: [Opportunity!]!
+  history: [HistoricalRecord!]!
+}
+
+type Industry {
+  industry_id: ID!
+  name: String
+}
+
+type User {
+  user_id: ID!
+  user_name: String
+  name: String
+  phone_number: String
+  email: String
+}
+
+type HistoricalRecord {
+  historical_record_id: ID!
+  source_id: ID
+  source_type: String
+  action: String
+  field: String
+  value: String
+  modified_at: String
+  modified_by: ID
+  modified_by_user: User
+}
+
+type AccountList {
+  items: [Account!]!
+  total: Int!
+  pages: Int!
+  next_cursor: String
+  prev_cursor: String
+}
+
+type ContactList {
+  items: [Contact!]!
+  total: Int!
+  pages: Int!
+  next_cursor: String
+  prev_cursor: String
+}
+
+type LeadList {
+  items: [Lead!]!
+  total: Int!
+  pages: Int!
+  next_cursor: String
+  prev_cursor: String
+}
+
+type OpportunityList {
+  items: [Opportunity!]!
+  total: Int!
+  pages: Int!
+  next_cursor: String
+  prev_cursor: String
+}
+
+type QuoteList {
+  items: [Quote!]!
+  total: Int!
+  pages: Int!
+  next_cursor: String
+  prev_cursor: String
+}
+
+type ContractList {
+  items: [Contract!]!
+  total: Int!
+  pages: Int!
+  next_cursor: String
+  prev_cursor: String
+}
+
+type OrderList {
+  items: [Order!]!
+  total: Int!
+  pages: Int!
+  next_cursor: String
+  prev_cursor: String
+}
+
+type ProductList {
+  items: [Product!]!
+  total: Int!
+  pages: Int!
+  next_cursor: String
+  prev_cursor: String
+}
+
+type CampaignList {
+  items: [Campaign!]!
+  total: Int!
+  pages: Int!
+  next_cursor: String
+  prev_cursor: String
+}
+`
@@ -3,9 +3,11 @@ package historical_record
 import (
 	"encoding/json"
 	"errors"
+	"strings"
 
 	"crm/internal/interactor/pkg/util"
 
+	"crm/internal/interactor/models/enums"
 	historicalRecordModel "crm/internal/interactor/models/historical_records"
 	historicalRecordService "crm/internal/interactor/service/historical_record"
 
@@ -31,6 +33,10 @@ func Init(db *gorm.DB) Manager {
 }
 
 func (m *manager) GetByList(input *historicalRecordModel.Fields) (int, any) {
+	enums.Normalize(enums.HistoricalRecordAction, input.Action)
+	enums.Normalize(enums.HistoricalRecordSourceType, input.SourceType)
+	enums.Normalize(enums.HistoricalRecordField, input.Field.Field)
+	language := enums.Language(input.Language)
 	output := &historicalRecordModel.List{}
 	output.Limit = input.Limit
 	output.Page = input.Page
@@ -54,7 +60,8 @@ func (m *manager) GetByList(input *historicalRecordModel.Fields) (int, any) {
 
 	for i, historicalRecords := range output.HistoricalRecords {
 		historicalRecords.ModifiedBy = *historicalRecordBase[i].ModifiedByUsers.Name
-		historicalRecords.Content = *historicalRecordBase[i].Action + *historicalRecordBase[i].SourceType + *historicalRecordBase[i].Field
+		historicalRecords.Content = content(*historicalRecordBase[i].Action, *historicalRecordBase[i].SourceType, *historicalRecordBase[i].Field, language)
+		historicalRecords.Value = enums.Label(historicalRecords.Value, language)
 	}
 
 	return code.Successful, code.GetCodeMessage(code.Successful, output)
@@ -79,7 +86,33 @@ func (m *manager) GetBySingle(input *historicalRecordModel.Field) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 	output.ModifiedBy = *historicalRecordBase.ModifiedByUsers.Name
-	output.Description = *historicalRecordBase.Action + *historicalRecordBase.SourceType + *historicalRecordBase.Field
+	output.Description = content(*historicalRecordBase.Action, *historicalRecordBase.SourceType, *historicalRecordBase.Field, enums.Language(input.Language))
 
 	return code.Successful, code.GetCodeMessage(code.Successful, output)
 }
+
+// content describes a record in language, such as 修改線索狀態為 or Updated lead status to, the value follows it.
+func content(action, sourceType, field, language string) string {
+	to := action == enums.ActionUpdated && field != ""
+	if language == enums.English {
+		words := make([]string, 0, 4)
+		for _, word := range []string{enums.Label(action, language), strings.ToLower(enums.Label(sourceType, language)), enums.Label(field, language)} {
+			if word != "" {
+				words = append(words, word)
+			}
+		}
+
+		if to {
+			words = append(words, "to")
+		}
+
+		return strings.Join(words, " ")
+	}
+
+	output := enums.Label(action, language) + enums.Label(sourceType, language) + enums.Label(field, language)
+	if to {
+		output += "為"
+	}
+
+	return output
+}
@@ -0,0 +1,98 @@
+package import_mapping
+
+import (
+	"encoding/json"
+	"errors"
+
+	importMappingModel "crm/internal/interactor/models/import_mappings"
+	"crm/internal/interactor/pkg/util"
+	importMappingService "crm/internal/interactor/service/import_mapping"
+
+	"gorm.io/gorm"
+
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+)
+
+type Manager interface {
+	Create(input *importMappingModel.Create) (int, any)
+	GetByList(input *importMappingModel.Field) (int, any)
+	Delete(input *importMappingModel.Field) (int, any)
+}
+
+type manager struct {
+	ImportMappingService importMappingService.Service
+}
+
+func Init(db *gorm.DB) Manager {
+	return &manager{
+		ImportMappingService: importMappingService.Init(db),
+	}
+}
+
+func (m *manager) Create(input *importMappingModel.Create) (int, any) {
+	_, err := m.ImportMappingService.GetBySingle(&importMappingModel.Field{
+		CompanyID: input.CompanyID,
+		Entity:    util.PointerString(input.Entity),
+		Name:      util.PointerString(input.Name),
+	})
+	if err == nil {
+		return code.Conflict, code.GetCodeMessage(code.Conflict, "mapping "+input.Name+" already exists")
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	importMappingBase, err := m.ImportMappingService.Create(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, importMappingBase.ImportMappingID)
+}
+
+func (m *manager) GetByList(input *importMappingModel.Field) (int, any) {
+	output := &importMappingModel.List{}
+	importMappingBase, err := m.ImportMappingService.GetByList(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	importMappingByte, err := json.Marshal(importMappingBase)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	err = json.Unmarshal(importMappingByte, &output.ImportMappings)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, output)
+}
+
+func (m *manager) Delete(input *importMappingModel.Field) (int, any) {
+	_, err := m.ImportMappingService.GetBySingle(input)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, err.Error())
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	err = m.ImportMappingService.Delete(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, "Delete ok!")
+}
@@ -0,0 +1,805 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"crm/config"
+	"crm/internal/interactor/manager/account"
+	"crm/internal/interactor/manager/contact"
+	"crm/internal/interactor/manager/lead"
+	accountModel "crm/internal/interactor/models/accounts"
+	bulkModel "crm/internal/interactor/models/bulk"
+	contactModel "crm/internal/interactor/models/contacts"
+	customFieldModel "crm/internal/interactor/models/custom_fields"
+	importMappingModel "crm/internal/interactor/models/import_mappings"
+	importModel "crm/internal/interactor/models/imports"
+	jobModel "crm/internal/interactor/models/jobs"
+	leadModel "crm/internal/interactor/models/leads"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/xlsx"
+	customFieldService "crm/internal/interactor/service/custom_field"
+	importMappingService "crm/internal/interactor/service/import_mapping"
+	jobService "crm/internal/interactor/service/job"
+	lookupService "crm/internal/interactor/service/lookup"
+
+	"gorm.io/gorm"
+
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+)
+
+type Manager interface {
+	Create(input *importModel.Create) (int, any)
+}
+
+type manager struct {
+	db                   *gorm.DB
+	LeadManager          lead.Manager
+	AccountManager       account.Manager
+	ContactManager       contact.Manager
+	ImportMappingService importMappingService.Service
+	JobService           jobService.Service
+	LookupService        lookupService.Service
+	CustomFieldService   customFieldService.Service
+}
+
+func Init(db *gorm.DB) Manager {
+	return &manager{
+		db:                   db,
+		LeadManager:          lead.Init(db),
+		AccountManager:       account.Init(db),
+		ContactManager:       contact.Init(db),
+		ImportMappingService: importMappingService.Init(db),
+		JobService:           jobService.Init(db),
+		LookupService:        lookupService.Init(db),
+		CustomFieldService:   customFieldService.Init(db),
+	}
+}
+
+// lookup resolves a column holding a name into the ID field of a model.
+type lookup struct {
+	// 查詢類型
+	kind string
+	// 模型欄位
+	field string
+}
+
+var lookups = map[string]lookup{
+	"industry":       {kind: "industry", field: "industry_id"},
+	"parent_account": {kind: "account", field: "parent_account_id"},
+	"account":        {kind: "account", field: "account_id"},
+	"salesperson":    {kind: "salesperson", field: "salesperson_id"},
+}
+
+// target is an entity that can be imported.
+type target struct {
+	// 主鍵欄位,帶入時更新該筆資料
+	primaryKey string
+	// 比對既有資料的欄位及查詢類型,未帶入主鍵且符合一筆時更新該筆資料
+	match, matchKind string
+	// 可用的名稱查詢
+	lookups []string
+	// 新增及更新的模型
+	create, update reflect.Type
+	// 以 best-effort 模式執行批次作業
+	bulk func(m *manager, trx *gorm.DB, userID string, creates, updates []any) (int, any)
+	// 公司的自訂欄位型別,以欄位鍵值為鍵
+	custom map[string]string
+}
+
+var targets = map[string]target{
+	"accounts": {
+		primaryKey: "account_id",
+		match:      "name",
+		matchKind:  "account",
+		lookups:    []string{"industry", "parent_account", "salesperson"},
+		create:     reflect.TypeFor[accountModel.Create](),
+		update:     reflect.TypeFor[accountModel.Update](),
+		bulk: func(m *manager, trx *gorm.DB, userID string, creates, updates []any) (int, any) {
+			return m.AccountManager.Bulk(trx, &accountModel.Bulk{
+				Mode:   bulkModel.Mode{Mode: bulkModel.BestEffort},
+				Create: items[accountModel.Create](creates),
+				Update: unversioned(updates, func(update *accountModel.Update) *accountModel.BulkUpdate {
+					return &accountModel.BulkUpdate{Update: *update}
+				}),
+				UserID: userID,
+			})
+		},
+	},
+	"contacts": {
+		primaryKey: "contact_id",
+		match:      "email",
+		matchKind:  "contact_email",
+		lookups:    []string{"account", "salesperson"},
+		create:     reflect.TypeFor[contactModel.Create](),
+		update:     reflect.TypeFor[contactModel.Update](),
+		bulk: func(m *manager, trx *gorm.DB, userID string, creates, updates []any) (int, any) {
+			return m.ContactManager.Bulk(trx, &contactModel.Bulk{
+				Mode:   bulkModel.Mode{Mode: bulkModel.BestEffort},
+				Create: items[contactModel.Create](creates),
+				Update: unversioned(updates, func(update *contactModel.Update) *contactModel.BulkUpdate {
+					return &contactModel.BulkUpdate{Update: *update}
+				}),
+				UserID: userID,
+			})
+		},
+	},
+	"leads": {
+		primaryKey: "lead_id",
+		lookups:    []string{"account", "salesperson"},
+		create:     reflect.TypeFor[leadModel.Create](),
+		update:     reflect.TypeFor[leadModel.Update](),
+		bulk: func(m *manager, trx *gorm.DB, userID string, creates, updates []any) (int, any) {
+			return m.LeadManager.Bulk(trx, &leadModel.Bulk{
+				Mode:   bulkModel.Mode{Mode: bulkModel.BestEffort},
+				Create: items[leadModel.Create](creates),
+				Update: unversioned(updates, func(update *leadModel.Update) *leadModel.BulkUpdate {
+					return &leadModel.BulkUpdate{Update: *update}
+				}),
+				UserID: userID,
+			})
+		},
+	},
+}
+
+// plan is the rows of a file mapped to bulk operations.
+type plan struct {
+	// 標題列
+	header []string
+	// 資料列
+	rows [][]string
+	// 新增及更新的模型,及其資料列號
+	creates, updates       []any
+	createRows, updateRows []int
+	// 拒絕的資料列
+	rejected map[int]any
+	// 有警告的資料列
+	warned map[int][]any
+	// 預覽
+	preview []*importModel.Row
+	// 新增及更新筆數
+	inserted, updated int
+}
+
+func (p *plan) reject(row int, err any) {
+	p.rejected[row] = err
+}
+
+func (m *manager) Create(input *importModel.Create) (int, any) {
+	t := targets[input.Entity]
+	// 公司的自訂欄位以 custom_fields.<key> 對應
+	customFieldBase, err := m.CustomFieldService.GetByCache(input.CompanyID, input.Entity)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	t.custom = map[string]string{}
+	for _, customField := range customFieldBase {
+		t.custom[*customField.Key] = *customField.Type
+	}
+
+	columns, httpCode, codeMessage := m.mapping(input, t)
+	if columns == nil {
+		return httpCode, codeMessage
+	}
+
+	records, err := read(input.FileName, input.File)
+	if err != nil {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+	}
+
+	if len(records) < 2 {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, "the file has no data rows")
+	}
+
+	if len(records)-1 > config.ImportMaxRows {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, fmt.Sprintf("the file has more than %d rows", config.ImportMaxRows))
+	}
+
+	p, err := m.prepare(t, columns, records, input.CompanyID)
+	if err != nil {
+		if errors.Is(err, errMapping) {
+			return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	if err = m.save(input, columns); err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 試算在交易中執行後一律復原
+	if input.DryRun {
+		trx := m.db.Begin()
+		err = m.execute(trx, t, p, input.CreatedBy)
+		trx.Rollback()
+		if err != nil {
+			log.Error(err)
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+
+		return code.Successful, code.GetCodeMessage(code.Successful, p.report())
+	}
+
+	jobBase, err := m.JobService.Create(&jobModel.Create{
+		CompanyID: input.CompanyID,
+		Kind:      jobModel.Import,
+		Entity:    input.Entity,
+		CreatedBy: input.CreatedBy,
+	})
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	go m.run(*jobBase.JobID, t, p, input)
+
+	return code.Sync, code.GetCodeMessage(code.Sync, jobBase.JobID)
+}
+
+// mapping returns the column mapping of the import and checks that the name it is saved as is free. Columns is nil on failure.
+func (m *manager) mapping(input *importModel.Create, t target) (columns map[string]string, httpCode int, codeMessage any) {
+	if input.MappingID != "" {
+		mappingBase, err := m.ImportMappingService.GetBySingle(&importMappingModel.Field{
+			ImportMappingID: input.MappingID,
+			CompanyID:       input.CompanyID,
+			Entity:          util.PointerString(input.Entity),
+		})
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, err.Error())
+			}
+
+			log.Error(err)
+			return nil, code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+
+		columns = mappingBase.Columns
+	} else if err := json.Unmarshal([]byte(input.Mapping), &columns); err != nil || len(columns) == 0 {
+		return nil, code.BadRequest, code.GetCodeMessage(code.BadRequest, "mapping or mapping_id is required")
+	}
+
+	if err := t.validate(columns); err != nil {
+		return nil, code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+	}
+
+	if input.SaveMapping != "" && input.MappingID == "" {
+		_, err := m.ImportMappingService.GetBySingle(&importMappingModel.Field{
+			CompanyID: input.CompanyID,
+			Entity:    util.PointerString(input.Entity),
+			Name:      util.PointerString(input.SaveMapping),
+		})
+		if err == nil {
+			return nil, code.Conflict, code.GetCodeMessage(code.Conflict, "mapping "+input.SaveMapping+" already exists")
+		}
+
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error(err)
+			return nil, code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+	}
+
+	return columns, code.Successful, nil
+}
+
+// save saves the column mapping of the import when asked, once the file has been validated against it.
+func (m *manager) save(input *importModel.Create, columns map[string]string) error {
+	if input.SaveMapping == "" || input.MappingID != "" {
+		return nil
+	}
+
+	_, err := m.ImportMappingService.Create(&importMappingModel.Create{
+		CompanyID: input.CompanyID,
+		Entity:    input.Entity,
+		Name:      input.SaveMapping,
+		Columns:   columns,
+		CreatedBy: input.CreatedBy,
+	})
+
+	return err
+}
+
+// errMapping is wrapped by the errors caused by a mapping that does not fit the file or the entity.
+var errMapping = errors.New("invalid mapping")
+
+// validate checks that every column is mapped to a field of the models, a custom field, a lookup or the primary key.
+func (t target) validate(columns map[string]string) error {
+	fields := t.fields()
+	for column, field := range columns {
+		if !fields[field] {
+			return fmt.Errorf("%w: column %s is mapped to unknown field %s", errMapping, column, field)
+		}
+	}
+
+	return nil
+}
+
+// fields returns the fields a column can be mapped to.
+func (t target) fields() map[string]bool {
+	output := map[string]bool{t.primaryKey: true}
+	for _, name := range t.lookups {
+		output[name] = true
+	}
+
+	for _, model := range []reflect.Type{t.create, t.update} {
+		for i := range model.NumField() {
+			name, _, _ := strings.Cut(model.Field(i).Tag.Get("json"), ",")
+			if name != "" && name != "-" && name != "created_by" && name != "updated_by" && name != "custom_fields" {
+				output[name] = true
+			}
+		}
+	}
+
+	for key := range t.custom {
+		output[customFieldModel.Prefix+key] = true
+	}
+
+	return output
+}
+
+var bom = []byte("\ufeff")
+
+// read returns the records of a CSV or XLSX file.
+func read(name string, data []byte) ([][]string, error) {
+	var records [][]string
+	var err error
+	switch strings.ToLower(path.Ext(name)) {
+	case ".csv":
+		reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, bom)))
+		reader.FieldsPerRecord = -1
+		records, err = reader.ReadAll()
+	case ".xlsx":
+		records, err = xlsx.Read(bytes.NewReader(data), int64(len(data)), config.ImportMaxUnzippedSize<<20)
+	default:
+		return nil, errors.New("only .csv and .xlsx files can be imported")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// 略過空白列
+	return slices.DeleteFunc(records, func(record []string) bool {
+		return !slices.ContainsFunc(record, func(cell string) bool {
+			return strings.TrimSpace(cell) != ""
+		})
+	}), nil
+}
+
+// prepare maps the rows to models, resolving the lookups and the records to update.
+func (m *manager) prepare(t target, columns map[string]string, records [][]string, companyID string) (*plan, error) {
+	p := &plan{header: records[0], rows: records[1:], rejected: map[int]any{}, warned: map[int][]any{}}
+	index := map[string]int{}
+	for i, name := range p.header {
+		index[strings.TrimSpace(name)] = i
+	}
+
+	for column := range columns {
+		if _, ok := index[column]; !ok {
+			return nil, fmt.Errorf("%w: column %s is not in the file", errMapping, column)
+		}
+	}
+
+	rows := make([]map[string]string, len(p.rows))
+	for i, record := range p.rows {
+		rows[i] = map[string]string{}
+		for column, field := range columns {
+			if j := index[column]; j < len(record) && strings.TrimSpace(record[j]) != "" {
+				rows[i][field] = strings.TrimSpace(record[j])
+			}
+		}
+	}
+
+	// 依名稱查詢,每種類型查詢一次
+	names := map[string][]string{}
+	for _, row := range rows {
+		for name, value := range row {
+			if l, ok := lookups[name]; ok && slices.Contains(t.lookups, name) {
+				names[l.kind] = append(names[l.kind], value)
+			}
+		}
+
+		if t.match != "" && row[t.primaryKey] == "" && row[t.match] != "" {
+			names[t.matchKind] = append(names[t.matchKind], row[t.match])
+		}
+	}
+
+	found := map[string]map[string][]string{}
+	for kind, values := range names {
+		slices.Sort(values)
+		ids, err := m.LookupService.GetByNames(kind, companyID, slices.Compact(values))
+		if err != nil {
+			return nil, err
+		}
+
+		found[kind] = ids
+	}
+
+	for i, row := range rows {
+		number := i + 2
+		values := map[string]any{}
+		id := row[t.primaryKey]
+		if id == "" && t.match != "" && row[t.match] != "" {
+			switch ids := found[t.matchKind][row[t.match]]; len(ids) {
+			case 0:
+			case 1:
+				id = ids[0]
+			default:
+				p.reject(number, fmt.Sprintf("%s %s matches %d records", t.match, row[t.match], len(ids)))
+				continue
+			}
+		}
+
+		model, action := t.create, bulkModel.Create
+		if id != "" {
+			model, action = t.update, bulkModel.Update
+			values[t.primaryKey] = id
+		}
+
+		var rowErrors []string
+		customFields := map[string]any{}
+		for name, value := range row {
+			if name == t.primaryKey {
+				continue
+			}
+
+			if key, ok := strings.CutPrefix(name, customFieldModel.Prefix); ok {
+				converted, err := convertCustom(t.custom[key], name, value)
+				if err != nil {
+					rowErrors = append(rowErrors, err.Error())
+				} else {
+					customFields[key] = converted
+				}
+
+				continue
+			}
+
+			if l, ok := lookups[name]; ok && slices.Contains(t.lookups, name) {
+				switch ids := found[l.kind][value]; len(ids) {
+				case 0:
+					rowErrors = append(rowErrors, fmt.Sprintf("%s %s not found", name, value))
+				case 1:
+					values[l.field] = ids[0]
+				default:
+					rowErrors = append(rowErrors, fmt.Sprintf("%s %s matches %d records", name, value, len(ids)))
+				}
+
+				continue
+			}
+
+			converted, ok, err := convert(model, name, value)
+			if err != nil {
+				rowErrors = append(rowErrors, err.Error())
+			} else if ok {
+				values[name] = converted
+			}
+		}
+
+		if len(customFields) > 0 {
+			values["custom_fields"] = customFields
+		}
+
+		if len(rowErrors) > 0 {
+			sort.Strings(rowErrors)
+			p.reject(number, strings.Join(rowErrors, "; "))
+			continue
+		}
+
+		marshal, err := json.Marshal(values)
+		if err != nil {
+			return nil, err
+		}
+
+		item := reflect.New(model).Interface()
+		if err = json.Unmarshal(marshal, item); err != nil {
+			p.reject(number, err.Error())
+			continue
+		}
+
+		if action == bulkModel.Create {
+			p.creates, p.createRows = append(p.creates, item), append(p.createRows, number)
+		} else {
+			p.updates, p.updateRows = append(p.updates, item), append(p.updateRows, number)
+		}
+
+		if len(p.preview) < 20 {
+			p.preview = append(p.preview, &importModel.Row{Row: number, Action: action, ID: id, Values: values})
+		}
+	}
+
+	return p, nil
+}
+
+// convert turns value into the type of the field of model tagged name, ok is false when model has no such field.
+func convert(model reflect.Type, name, value string) (output any, ok bool, err error) {
+	for i := range model.NumField() {
+		field := model.Field(i)
+		if tag, _, _ := strings.Cut(field.Tag.Get("json"), ","); tag != name {
+			continue
+		}
+
+		kind := field.Type
+		for kind.Kind() == reflect.Pointer {
+			kind = kind.Elem()
+		}
+
+		switch {
+		case kind == reflect.TypeFor[time.Time]():
+			for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02", "2006/01/02"} {
+				if parsed, err := time.Parse(layout, value); err == nil {
+					return parsed, true, nil
+				}
+			}
+
+			return nil, true, fmt.Errorf("%s %s is not a date", name, value)
+		case kind.Kind() == reflect.Slice && kind.Elem().Kind() == reflect.String:
+			parts := strings.FieldsFunc(value, func(r rune) bool {
+				return r == ',' || r == '、' || r == ';'
+			})
+			for j := range parts {
+				parts[j] = strings.TrimSpace(parts[j])
+			}
+
+			return parts, true, nil
+		case kind.Kind() == reflect.Bool:
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, true, fmt.Errorf("%s %s is not a boolean", name, value)
+			}
+
+			return parsed, true, nil
+		case kind.Kind() >= reflect.Int && kind.Kind() <= reflect.Float64:
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, true, fmt.Errorf("%s %s is not a number", name, value)
+			}
+
+			return parsed, true, nil
+		}
+
+		return value, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// convertCustom turns value into the type of a custom field of typ named name,
+// the options of a picklist are checked by the manager of the entity.
+func convertCustom(typ, name, value string) (any, error) {
+	switch typ {
+	case customFieldModel.Number:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s is not a number", name, value)
+		}
+
+		return parsed, nil
+	case customFieldModel.Boolean:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s is not a boolean", name, value)
+		}
+
+		return parsed, nil
+	case customFieldModel.Date:
+		for _, layout := range []string{time.DateOnly, "2006/01/02", time.RFC3339, "2006-01-02 15:04:05"} {
+			if parsed, err := time.Parse(layout, value); err == nil {
+				return parsed.Format(time.DateOnly), nil
+			}
+		}
+
+		return nil, fmt.Errorf("%s %s is not a date", name, value)
+	}
+
+	return value, nil
+}
+
+// execute runs the operations of p in trx and records their results.
+func (m *manager) execute(trx *gorm.DB, t target, p *plan, userID string) error {
+	if len(p.creates)+len(p.updates) == 0 {
+		return nil
+	}
+
+	httpCode, codeMessage := t.bulk(m, trx, userID, p.creates, p.updates)
+	message, ok := codeMessage.(*code.SuccessfulMessage)
+	if httpCode != code.Successful || !ok {
+		return fmt.Errorf("import: bulk operation failed: %v", codeMessage)
+	}
+
+	for _, result := range message.Body.(*bulkModel.List).Results {
+		rows := p.createRows
+		if result.Action == bulkModel.Update {
+			rows = p.updateRows
+		}
+
+		switch {
+		case result.Status != code.Successful:
+			p.reject(rows[result.Index], result.Error)
+		case result.Action == bulkModel.Create:
+			p.inserted++
+		default:
+			p.updated++
+		}
+
+		if result.Status == code.Successful && len(result.Warnings) > 0 {
+			p.warned[rows[result.Index]] = result.Warnings
+		}
+	}
+
+	return nil
+}
+
+// report returns the result of a dry run.
+func (p *plan) report() *importModel.Report {
+	output := &importModel.Report{
+		Inserted: p.inserted,
+		Updated:  p.updated,
+		Rejected: len(p.rejected),
+		Errors:   make([]*importModel.RowError, 0, len(p.rejected)),
+		Warnings: make([]*importModel.RowWarning, 0, len(p.warned)),
+		Preview:  p.preview,
+	}
+
+	for _, row := range slices.Sorted(maps.Keys(p.rejected)) {
+		output.Errors = append(output.Errors, &importModel.RowError{Row: row, Error: p.rejected[row]})
+	}
+
+	for _, row := range slices.Sorted(maps.Keys(p.warned)) {
+		output.Warnings = append(output.Warnings, &importModel.RowWarning{Row: row, Warnings: p.warned[row]})
+	}
+
+	return output
+}
+
+// run imports the rows of p in the background and reports the result on the job.
+func (m *manager) run(jobID string, t target, p *plan, input *importModel.Create) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error(r)
+			m.fail(jobID, fmt.Sprint(r))
+		}
+	}()
+
+	err := m.JobService.Update(&jobModel.Update{
+		JobID:  jobID,
+		Status: util.PointerString(jobModel.Running),
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	err = m.db.Transaction(func(trx *gorm.DB) error {
+		return m.execute(trx, t, p, input.CreatedBy)
+	})
+	if err != nil {
+		log.Error(err)
+		m.fail(jobID, err.Error())
+		return
+	}
+
+	update := &jobModel.Update{
+		JobID:      jobID,
+		Status:     util.PointerString(jobModel.Succeeded),
+		Inserted:   util.PointerInt(p.inserted),
+		Updated:    util.PointerInt(p.updated),
+		Rejected:   util.PointerInt(len(p.rejected)),
+		FinishedAt: util.PointerTime(util.NowToUTC()),
+	}
+
+	if len(p.rejected) > 0 {
+		file, err := p.errorFile(input.FileName)
+		if err != nil {
+			log.Error(err)
+			m.fail(jobID, err.Error())
+			return
+		}
+
+		update.FileName = util.PointerString(file.FileName)
+		update.ContentType = util.PointerString(file.ContentType)
+		update.File = file.Content
+	}
+
+	if err = m.JobService.Update(update); err != nil {
+		log.Error(err)
+	}
+}
+
+func (m *manager) fail(jobID string, message string) {
+	err := m.JobService.Update(&jobModel.Update{
+		JobID:      jobID,
+		Status:     util.PointerString(jobModel.Failed),
+		Error:      util.PointerString(message),
+		FinishedAt: util.PointerTime(util.NowToUTC()),
+	})
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+// errorFile returns the rejected rows with their errors, in the format of the imported file.
+func (p *plan) errorFile(name string) (*jobModel.File, error) {
+	records := [][]string{append(slices.Clone(p.header), "錯誤")}
+	for _, row := range slices.Sorted(maps.Keys(p.rejected)) {
+		message, ok := p.rejected[row].(string)
+		if !ok {
+			marshal, _ := json.Marshal(p.rejected[row])
+			message = string(marshal)
+		}
+
+		records = append(records, append(slices.Clone(p.rows[row-2]), message))
+	}
+
+	base := strings.TrimSuffix(path.Base(name), path.Ext(name))
+	var buffer bytes.Buffer
+	if strings.EqualFold(path.Ext(name), ".xlsx") {
+		writer, err := xlsx.NewWriter(&buffer)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range records {
+			if err = writer.WriteRow(record); err != nil {
+				return nil, err
+			}
+		}
+
+		if err = writer.Close(); err != nil {
+			return nil, err
+		}
+
+		return &jobModel.File{
+			FileName:    base + "_errors.xlsx",
+			ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			Content:     buffer.Bytes(),
+		}, nil
+	}
+
+	// BOM 讓 Excel 以 UTF-8 開啟
+	buffer.Write(bom)
+	writer := csv.NewWriter(&buffer)
+	if err := writer.WriteAll(records); err != nil {
+		return nil, err
+	}
+
+	return &jobModel.File{
+		FileName:    base + "_errors.csv",
+		ContentType: "text/csv; charset=utf-8",
+		Content:     buffer.Bytes(),
+	}, nil
+}
+
+// items returns the models of a bulk request.
+func items[T any](values []any) []*T {
+	output := make([]*T, len(values))
+	for i, value := range values {
+		output[i] = value.(*T)
+	}
+
+	return output
+}
+
+// unversioned wraps the updates of a file as bulk update items, a file carries no versions to check.
+func unversioned[T, B any](values []any, wrap func(*T) *B) []*B {
+	output := make([]*B, len(values))
+	for i, value := range values {
+		output[i] = wrap(value.(*T))
+	}
+
+	return output
+}
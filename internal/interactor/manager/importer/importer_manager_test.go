@@ -0,0 +1,121 @@
+package importer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	customFieldModel "crm/internal/interactor/models/custom_fields"
+)
+
+type model struct {
+	Name      *string    `json:"name,omitempty"`
+	Amount    *float64   `json:"amount,omitempty"`
+	Employees int64      `json:"employees"`
+	IsEnable  *bool      `json:"is_enable,omitempty"`
+	Tags      []string   `json:"tags,omitempty"`
+	CloseDate *time.Time `json:"close_date,omitempty"`
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		value   string
+		want    any
+		wantOK  bool
+		wantErr bool
+	}{
+		{"text", "name", "Acme", "Acme", true, false},
+		{"float", "amount", "12.5", 12.5, true, false},
+		{"int", "employees", "30", 30.0, true, false},
+		{"not a number", "amount", "twelve", nil, true, true},
+		{"bool", "is_enable", "true", true, true, false},
+		{"bool digit", "is_enable", "0", false, true, false},
+		{"not a bool", "is_enable", "yes", nil, true, true},
+		{"list", "tags", "a, b;c、d", []string{"a", "b", "c", "d"}, true, false},
+		{"date", "close_date", "2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), true, false},
+		{"date with slashes", "close_date", "2024/03/01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), true, false},
+		{"date time", "close_date", "2024-03-01 08:30:00", time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC), true, false},
+		{"rfc3339", "close_date", "2024-03-01T08:30:00Z", time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC), true, false},
+		{"not a date", "close_date", "01/03/2024", nil, true, true},
+		{"unknown field", "password", "secret", nil, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := convert(reflect.TypeFor[model](), tt.field, tt.value)
+			if (err != nil) != tt.wantErr || ok != tt.wantOK {
+				t.Fatalf("convert() ok = %v, error = %v, want ok %v, error %v", ok, err, tt.wantOK, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("convert() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertCustom(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     string
+		value   string
+		want    any
+		wantErr bool
+	}{
+		{"text", customFieldModel.Text, "north", "north", false},
+		{"picklist", customFieldModel.Picklist, "gold", "gold", false},
+		{"number", customFieldModel.Number, "3", 3.0, false},
+		{"not a number", customFieldModel.Number, "three", nil, true},
+		{"boolean", customFieldModel.Boolean, "false", false, false},
+		{"not a boolean", customFieldModel.Boolean, "no", nil, true},
+		{"date", customFieldModel.Date, "2024/03/01", "2024-03-01", false},
+		{"date time keeps the day", customFieldModel.Date, "2024-03-01T23:00:00Z", "2024-03-01", false},
+		{"not a date", customFieldModel.Date, "March 1st", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertCustom(tt.typ, "field", tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("convertCustom() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("convertCustom() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		data    string
+		want    [][]string
+		wantErr bool
+	}{
+		{"csv", "leads.csv", "name,email\nAcme,a@acme.com\n", [][]string{{"name", "email"}, {"Acme", "a@acme.com"}}, false},
+		{"csv with bom", "leads.CSV", "\ufeffname\nAcme\n", [][]string{{"name"}, {"Acme"}}, false},
+		{"blank rows are skipped", "leads.csv", "name,email\n , \nAcme,\n", [][]string{{"name", "email"}, {"Acme", ""}}, false},
+		{"ragged rows", "leads.csv", "name,email\nAcme\n", [][]string{{"name", "email"}, {"Acme"}}, false},
+		{"bad quote", "leads.csv", "name\n\"Acme\n", nil, true},
+		{"not an xlsx", "leads.xlsx", "name\n", nil, true},
+		{"other extension", "leads.txt", "name\n", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := read(tt.file, []byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("read() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("read() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
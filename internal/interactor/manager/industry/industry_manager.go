@@ -4,11 +4,15 @@ import (
 	"encoding/json"
 	"errors"
 
+	industryDB "crm/internal/entity/postgresql/db/industries"
 	industryModel "crm/internal/interactor/models/industries"
 	industryService "crm/internal/interactor/service/industry"
 
+	recycleBinService "crm/internal/interactor/service/recycle_bin"
 	"gorm.io/gorm"
 
+	"crm/internal/interactor/helpers"
+	"crm/internal/interactor/pkg/cache"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
 )
@@ -17,30 +21,29 @@ type Manager interface {
 	Create(trx *gorm.DB, input *industryModel.Create) (int, any)
 	GetByList(input *industryModel.Field) (int, any)
 	GetBySingle(input *industryModel.Field) (int, any)
-	Delete(input *industryModel.Field) (int, any)
+	Delete(trx *gorm.DB, input *industryModel.Update) (int, any)
 	Update(input *industryModel.Update) (int, any)
 }
 
 type manager struct {
-	IndustryService industryService.Service
+	IndustryService   industryService.Service
+	RecycleBinService recycleBinService.Service
 }
 
 func Init(db *gorm.DB) Manager {
 	return &manager{
-		IndustryService: industryService.Init(db),
+		IndustryService:   industryService.Init(db),
+		RecycleBinService: recycleBinService.Init(db),
 	}
 }
 
 func (m *manager) Create(trx *gorm.DB, input *industryModel.Create) (int, any) {
-	defer trx.Rollback()
-
 	industryBase, err := m.IndustryService.WithTrx(trx).Create(input)
 	if err != nil {
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, industryBase.IndustryID)
 }
 
@@ -66,7 +69,7 @@ func (m *manager) GetByList(input *industryModel.Field) (int, any) {
 }
 
 func (m *manager) GetBySingle(input *industryModel.Field) (int, any) {
-	industryBase, err := m.IndustryService.GetBySingle(input)
+	industryBase, err := m.getBySingle(input)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, err.Error())
@@ -87,7 +90,16 @@ func (m *manager) GetBySingle(input *industryModel.Field) (int, any) {
 	return code.Successful, code.GetCodeMessage(code.Successful, output)
 }
 
-func (m *manager) Delete(input *industryModel.Field) (int, any) {
+// getBySingle reads the industry through the cache when it is looked up by ID only.
+func (m *manager) getBySingle(input *industryModel.Field) (*industryDB.Base, error) {
+	if *input != (industryModel.Field{IndustryID: input.IndustryID}) {
+		return m.IndustryService.GetBySingle(input)
+	}
+
+	return m.IndustryService.GetByCache(input.IndustryID)
+}
+
+func (m *manager) Delete(trx *gorm.DB, input *industryModel.Update) (int, any) {
 	_, err := m.IndustryService.GetBySingle(&industryModel.Field{
 		IndustryID: input.IndustryID,
 	})
@@ -100,12 +112,25 @@ func (m *manager) Delete(input *industryModel.Field) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	err = m.IndustryService.Delete(input)
+	// 依刪除規則刪除並移至資源回收筒,有限制刪除的關聯資料時回傳受影響的資料
+	preview, _, err := m.RecycleBinService.WithTrx(trx).Delete("industries", input.IndustryID, *input.UpdatedBy)
 	if err != nil {
+		if errors.Is(err, recycleBinService.ErrRestricted) {
+			return code.Conflict, code.GetCodeMessage(code.Conflict, preview)
+		}
+
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	// 交易提交後才清除快取,以免同時的查詢在提交前又快取舊資料
+	helpers.AfterCommit(trx, func() {
+		err := cache.Default().Delete(cache.Key(cache.Industry, input.IndustryID))
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
 	return code.Successful, code.GetCodeMessage(code.Successful, "Delete ok!")
 }
 
@@ -128,5 +153,11 @@ func (m *manager) Update(input *industryModel.Update) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	// 清除快取
+	err = cache.Default().Delete(cache.Key(cache.Industry, input.IndustryID))
+	if err != nil {
+		log.Error(err)
+	}
+
 	return code.Successful, code.GetCodeMessage(code.Successful, industryBase.IndustryID)
 }
@@ -0,0 +1,76 @@
+package job
+
+import (
+	"encoding/json"
+	"errors"
+
+	jobModel "crm/internal/interactor/models/jobs"
+	jobService "crm/internal/interactor/service/job"
+
+	"gorm.io/gorm"
+
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+)
+
+type Manager interface {
+	GetBySingle(input *jobModel.Field) (int, any)
+	GetByFile(input *jobModel.Field) (int, any)
+}
+
+type manager struct {
+	JobService jobService.Service
+}
+
+func Init(db *gorm.DB) Manager {
+	return &manager{
+		JobService: jobService.Init(db),
+	}
+}
+
+func (m *manager) GetBySingle(input *jobModel.Field) (int, any) {
+	jobBase, err := m.JobService.GetBySingle(input)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, err.Error())
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 檔案內容另由 GetByFile 下載
+	jobBase.File = nil
+	output := &jobModel.Single{}
+	jobByte, _ := json.Marshal(jobBase)
+	err = json.Unmarshal(jobByte, &output)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, output)
+}
+
+// GetByFile returns the file produced by a job as a *jobModel.File.
+func (m *manager) GetByFile(input *jobModel.Field) (int, any) {
+	jobBase, err := m.JobService.GetBySingle(input)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, err.Error())
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	if jobBase.FileName == nil || jobBase.File == nil {
+		return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, "the job has no file")
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, &jobModel.File{
+		FileName:    *jobBase.FileName,
+		ContentType: *jobBase.ContentType,
+		Content:     jobBase.File,
+	})
+}
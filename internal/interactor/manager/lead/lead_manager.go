@@ -5,10 +5,15 @@ import (
 	"errors"
 
 	"crm/internal/interactor/helpers"
+	bulkModel "crm/internal/interactor/models/bulk"
+	"crm/internal/interactor/models/enums"
 
 	historicalRecordModel "crm/internal/interactor/models/historical_records"
-	userModel "crm/internal/interactor/models/users"
+	customFieldService "crm/internal/interactor/service/custom_field"
+	duplicateService "crm/internal/interactor/service/duplicate"
 	historicalRecordService "crm/internal/interactor/service/historical_record"
+	picklistService "crm/internal/interactor/service/picklist"
+	recycleBinService "crm/internal/interactor/service/recycle_bin"
 	userService "crm/internal/interactor/service/user"
 
 	"crm/internal/interactor/pkg/util"
@@ -18,6 +23,9 @@ import (
 
 	"gorm.io/gorm"
 
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/sort"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
 )
@@ -27,14 +35,19 @@ type Manager interface {
 	GetByList(input *leadModel.Fields) (int, any)
 	GetByListNoPagination(input *leadModel.FieldsNoPagination) (int, any)
 	GetBySingle(input *leadModel.Field) (int, any)
-	Delete(input *leadModel.Field) (int, any)
+	Delete(trx *gorm.DB, input *leadModel.Update) (int, any)
 	Update(trx *gorm.DB, input *leadModel.Update) (int, any)
+	Bulk(trx *gorm.DB, input *leadModel.Bulk) (int, any)
 }
 
 type manager struct {
 	LeadService             leadService.Service
 	HistoricalRecordService historicalRecordService.Service
 	UserService             userService.Service
+	DuplicateService        duplicateService.Service
+	RecycleBinService       recycleBinService.Service
+	PicklistService         picklistService.Service
+	CustomFieldService      customFieldService.Service
 }
 
 func Init(db *gorm.DB) Manager {
@@ -42,13 +55,32 @@ func Init(db *gorm.DB) Manager {
 		LeadService:             leadService.Init(db),
 		HistoricalRecordService: historicalRecordService.Init(db),
 		UserService:             userService.Init(db),
+		DuplicateService:        duplicateService.Init(db),
+		RecycleBinService:       recycleBinService.Init(db),
+		PicklistService:         picklistService.Init(db),
+		CustomFieldService:      customFieldService.Init(db),
 	}
 }
 
-const sourceType = "線索"
+const sourceType = enums.SourceTypeLead
 
 func (m *manager) Create(trx *gorm.DB, input *leadModel.Create) (int, any) {
-	defer trx.Rollback()
+	// 狀態名稱轉為代碼
+	enums.Normalize(enums.LeadStatus, &input.Status)
+
+	// 依公司的選項清單驗證,未帶入的欄位使用預設值
+	if httpCode, codeMessage := helpers.CheckPicklists(m.PicklistService, m.UserService, input.CreatedBy, "leads", map[string]any{
+		"status": &input.Status,
+		"source": &input.Source,
+		"rating": &input.Rating,
+	}, nil); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
+
+	// 依公司的自訂欄位驗證,未帶入的欄位使用預設值
+	if httpCode, codeMessage := helpers.CheckCustomFields(m.CustomFieldService, m.UserService, input.CreatedBy, "leads", &input.CustomFields, nil); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
 
 	leadBase, err := m.LeadService.WithTrx(trx).Create(input)
 	if err != nil {
@@ -59,7 +91,7 @@ func (m *manager) Create(trx *gorm.DB, input *leadModel.Create) (int, any) {
 	// 同步新增線索歷程記錄
 	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
 		SourceID:   *leadBase.LeadID,
-		Action:     "建立",
+		Action:     enums.ActionCreated,
 		SourceType: sourceType,
 		ModifiedBy: *leadBase.CreatedBy,
 	})
@@ -68,20 +100,41 @@ func (m *manager) Create(trx *gorm.DB, input *leadModel.Create) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	trx.Commit()
-	return code.Successful, code.GetCodeMessage(code.Successful, leadBase.LeadID)
+	return helpers.WarnDuplicates(trx, m.DuplicateService, m.UserService, input.CreatedBy, "leads", *leadBase.LeadID,
+		code.Successful, code.GetCodeMessage(code.Successful, leadBase.LeadID))
 }
 
 func (m *manager) GetByList(input *leadModel.Fields) (int, any) {
+	for i := range input.FilterStatus {
+		enums.Normalize(enums.LeadStatus, &input.FilterStatus[i])
+	}
+
 	output := &leadModel.List{}
+	if err := input.Projection.Validate(output, "leads", leadModel.Relations); err != nil {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+	}
+
 	output.Limit = input.Limit
 	output.Page = input.Page
-	quantity, leadBase, err := m.LeadService.GetByList(input)
+	// 公司的自訂欄位可用於搜尋及排序
+	customColumns, err := helpers.CustomColumns(m.CustomFieldService, input.CompanyID, "leads")
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	input.CustomColumns = customColumns
+	quantity, leadBase, cursors, err := m.LeadService.GetByList(input)
 	if err != nil {
+		if errors.Is(err, filter.ErrInvalid) || errors.Is(err, sort.ErrInvalid) || errors.Is(err, page.ErrInvalid) {
+			return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+		}
+
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 	output.Total.Total = quantity
+	output.Cursors = cursors
 	leadByte, err := json.Marshal(leadBase)
 	if err != nil {
 		log.Error(err)
@@ -101,10 +154,21 @@ func (m *manager) GetByList(input *leadModel.Fields) (int, any) {
 		leads.SalespersonName = *leadBase[i].Salespeople.Name
 	}
 
-	return code.Successful, code.GetCodeMessage(code.Successful, output)
+	// 篩選回傳欄位並嵌入展開的關聯
+	body, err := input.Projection.Shape(output, "leads", leadBase, leadModel.Relations)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, body)
 }
 
 func (m *manager) GetByListNoPagination(input *leadModel.FieldsNoPagination) (int, any) {
+	for i := range input.FilterStatus {
+		enums.Normalize(enums.LeadStatus, &input.FilterStatus[i])
+	}
+
 	output := &leadModel.ListNoPagination{}
 	leadBase, err := m.LeadService.GetByListNoPagination(input)
 	if err != nil {
@@ -152,7 +216,7 @@ func (m *manager) GetBySingle(input *leadModel.Field) (int, any) {
 	return code.Successful, code.GetCodeMessage(code.Successful, output)
 }
 
-func (m *manager) Delete(input *leadModel.Field) (int, any) {
+func (m *manager) Delete(trx *gorm.DB, input *leadModel.Update) (int, any) {
 	_, err := m.LeadService.GetBySingle(&leadModel.Field{
 		LeadID: input.LeadID,
 	})
@@ -165,7 +229,31 @@ func (m *manager) Delete(input *leadModel.Field) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	err = m.LeadService.Delete(input)
+	// 依刪除規則刪除並移至資源回收筒,有限制刪除的關聯資料時回傳受影響的資料
+	preview, cascaded, err := m.RecycleBinService.WithTrx(trx).Delete("leads", input.LeadID, *input.UpdatedBy)
+	if err != nil {
+		if errors.Is(err, recycleBinService.ErrRestricted) {
+			return code.Conflict, code.GetCodeMessage(code.Conflict, preview)
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 同步新增刪除歷程記錄
+	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
+		SourceID:   input.LeadID,
+		Action:     enums.ActionDeleted,
+		SourceType: sourceType,
+		ModifiedBy: *input.UpdatedBy,
+	})
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 同步新增一併刪除資料的刪除歷程記錄
+	err = helpers.RecordCascaded(m.HistoricalRecordService.WithTrx(trx), cascaded, enums.ActionDeleted, *input.UpdatedBy)
 	if err != nil {
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
@@ -175,7 +263,7 @@ func (m *manager) Delete(input *leadModel.Field) (int, any) {
 }
 
 func (m *manager) Update(trx *gorm.DB, input *leadModel.Update) (int, any) {
-	defer trx.Rollback()
+	enums.Normalize(enums.LeadStatus, input.Status)
 
 	leadBase, err := m.LeadService.GetBySingle(&leadModel.Field{
 		LeadID: input.LeadID,
@@ -189,6 +277,38 @@ func (m *manager) Update(trx *gorm.DB, input *leadModel.Update) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	// 樂觀鎖定,版本不符時回傳目前資料
+	if input.IfMatch != "" {
+		matched, err := helpers.CheckVersion(trx, "leads", "lead_id", input.LeadID, input.IfMatch)
+		if err != nil {
+			log.Error(err)
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+
+		if !matched {
+			return helpers.PreconditionFailed(m.GetBySingle(&leadModel.Field{
+				LeadID: input.LeadID,
+			}))
+		}
+	}
+
+	if httpCode, codeMessage := helpers.CheckPicklists(m.PicklistService, m.UserService, *input.UpdatedBy, "leads", map[string]any{
+		"status": input.Status,
+		"source": input.Source,
+		"rating": input.Rating,
+	}, map[string]any{
+		"status": leadBase.Status,
+		"source": leadBase.Source,
+		"rating": leadBase.Rating,
+	}); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
+
+	// 依公司的自訂欄位驗證,僅更新帶入的欄位
+	if httpCode, codeMessage := helpers.CheckCustomFields(m.CustomFieldService, m.UserService, *input.UpdatedBy, "leads", &input.CustomFields, leadBase.CustomFields); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
+
 	err = m.LeadService.WithTrx(trx).Update(input)
 	if err != nil {
 		log.Error(err)
@@ -199,42 +319,40 @@ func (m *manager) Update(trx *gorm.DB, input *leadModel.Update) (int, any) {
 	var records []historicalRecordModel.AddHistoricalRecord
 
 	if input.Status != nil && *input.Status != *leadBase.Status {
-		helpers.AddHistoricalRecord(&records, "修改", "狀態為", *input.Status)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldStatus, *input.Status)
 	}
 
 	if input.Description != nil && *input.Description != *leadBase.Description {
-		helpers.AddHistoricalRecord(&records, "修改", "描述為", *input.Description)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldDescription, *input.Description)
 	}
 
 	if input.Source != nil {
 		if *input.Source != *leadBase.Source {
 			if *input.Source == "" {
-				helpers.AddHistoricalRecord(&records, "移除", "來源", "")
+				helpers.AddHistoricalRecord(&records, enums.ActionRemoved, enums.FieldSource, "")
 			} else {
-				helpers.AddHistoricalRecord(&records, "修改", "來源為", *input.Source)
+				helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldSource, *input.Source)
 			}
 		}
 	} else if *leadBase.Source != "" {
-		helpers.AddHistoricalRecord(&records, "移除", "來源", "")
+		helpers.AddHistoricalRecord(&records, enums.ActionRemoved, enums.FieldSource, "")
 	}
 
 	if input.Rating != nil {
 		if *input.Rating != *leadBase.Rating {
 			if *input.Rating == "" {
-				helpers.AddHistoricalRecord(&records, "清除", "分級", "")
+				helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldRating, "")
 			} else {
-				helpers.AddHistoricalRecord(&records, "修改", "分級為", *input.Rating)
+				helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldRating, *input.Rating)
 			}
 		}
 	} else if *leadBase.Rating != "" {
-		helpers.AddHistoricalRecord(&records, "清除", "分級", "")
+		helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldRating, "")
 	}
 
 	if input.SalespersonID != nil && *input.SalespersonID != *leadBase.SalespersonID {
-		salespersonBase, _ := m.UserService.GetBySingle(&userModel.Field{
-			UserID: *input.SalespersonID,
-		})
-		helpers.AddHistoricalRecord(&records, "修改", "業務員為", *salespersonBase.Name)
+		salespersonBase, _ := m.UserService.GetByCache(*input.SalespersonID)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldSalesperson, *salespersonBase.Name)
 	}
 
 	for _, record := range records {
@@ -252,6 +370,72 @@ func (m *manager) Update(trx *gorm.DB, input *leadModel.Update) (int, any) {
 		}
 	}
 
-	trx.Commit()
-	return code.Successful, code.GetCodeMessage(code.Successful, leadBase.LeadID)
+	return helpers.WarnDuplicates(trx, m.DuplicateService, m.UserService, *input.UpdatedBy, "leads", *leadBase.LeadID,
+		code.Successful, code.GetCodeMessage(code.Successful, leadBase.LeadID))
+}
+
+// Bulk creates, updates and deletes leads at once, see helpers.Bulk for the modes.
+func (m *manager) Bulk(trx *gorm.DB, input *leadModel.Bulk) (int, any) {
+	history := m.HistoricalRecordService.Batch()
+	var operations []*helpers.Operation
+	for i, create := range input.Create {
+		create.CreatedBy = input.UserID
+		operations = append(operations, &helpers.Operation{
+			Action: bulkModel.Create,
+			Index:  i,
+			Input:  create,
+			Run: func(trx *gorm.DB) (int, any) {
+				return m.scoped(trx, history).Create(trx, create)
+			},
+		})
+	}
+
+	for i, item := range input.Update {
+		update := &item.Update
+		update.UpdatedBy = util.PointerString(input.UserID)
+		update.IfMatch = item.IfMatch
+		operations = append(operations, &helpers.Operation{
+			Action: bulkModel.Update,
+			Index:  i,
+			ID:     update.LeadID,
+			Input:  update,
+			// 公司要求 If-Match 時,未帶版本的項目不更新,版本不符的項目由 Update 回傳 412
+			Unversioned: input.IfMatchRequired && item.IfMatch == "",
+			Run: func(trx *gorm.DB) (int, any) {
+				return m.scoped(trx, history).Update(trx, update)
+			},
+		})
+	}
+
+	for i, leadID := range input.Delete {
+		update := &leadModel.Update{
+			LeadID:    leadID,
+			UpdatedBy: util.PointerString(input.UserID),
+		}
+		operations = append(operations, &helpers.Operation{
+			Action: bulkModel.Delete,
+			Index:  i,
+			ID:     leadID,
+			Input:  update,
+			Run: func(trx *gorm.DB) (int, any) {
+				return m.scoped(trx, history).Delete(trx, update)
+			},
+		})
+	}
+
+	return helpers.Bulk(trx, input.Mode.Mode, history, operations)
+}
+
+// scoped returns a copy of the manager whose services run in trx, every service is carried over.
+// Its historical records are held by history.
+func (m *manager) scoped(trx *gorm.DB, history historicalRecordService.Batch) *manager {
+	scoped := *m
+	scoped.LeadService = m.LeadService.WithTrx(trx)
+	scoped.UserService = m.UserService.WithTrx(trx)
+	scoped.DuplicateService = m.DuplicateService.WithTrx(trx)
+	scoped.RecycleBinService = m.RecycleBinService.WithTrx(trx)
+	scoped.PicklistService = m.PicklistService.WithTrx(trx)
+	scoped.CustomFieldService = m.CustomFieldService.WithTrx(trx)
+	scoped.HistoricalRecordService = history
+	return &scoped
 }
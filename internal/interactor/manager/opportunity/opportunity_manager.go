@@ -6,9 +6,13 @@ import (
 	"strconv"
 
 	"crm/internal/interactor/helpers"
+	bulkModel "crm/internal/interactor/models/bulk"
+	"crm/internal/interactor/models/enums"
 	historicalRecordModel "crm/internal/interactor/models/historical_records"
-	userModel "crm/internal/interactor/models/users"
+	customFieldService "crm/internal/interactor/service/custom_field"
 	historicalRecordService "crm/internal/interactor/service/historical_record"
+	picklistService "crm/internal/interactor/service/picklist"
+	recycleBinService "crm/internal/interactor/service/recycle_bin"
 	userService "crm/internal/interactor/service/user"
 
 	campaignModel "crm/internal/interactor/models/campaigns"
@@ -21,6 +25,9 @@ import (
 
 	"gorm.io/gorm"
 
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/sort"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
 )
@@ -33,6 +40,7 @@ type Manager interface {
 	GetBySingleCampaigns(input *opportunityModel.Field) (int, any)
 	Delete(trx *gorm.DB, input *opportunityModel.Update) (int, any)
 	Update(trx *gorm.DB, input *opportunityModel.Update) (int, any)
+	Bulk(trx *gorm.DB, input *opportunityModel.Bulk) (int, any)
 }
 
 type manager struct {
@@ -41,6 +49,9 @@ type manager struct {
 	LeadService             leadService.Service
 	HistoricalRecordService historicalRecordService.Service
 	UserService             userService.Service
+	RecycleBinService       recycleBinService.Service
+	PicklistService         picklistService.Service
+	CustomFieldService      customFieldService.Service
 }
 
 func Init(db *gorm.DB) Manager {
@@ -50,20 +61,34 @@ func Init(db *gorm.DB) Manager {
 		LeadService:             leadService.Init(db),
 		HistoricalRecordService: historicalRecordService.Init(db),
 		UserService:             userService.Init(db),
+		RecycleBinService:       recycleBinService.Init(db),
+		PicklistService:         picklistService.Init(db),
+		CustomFieldService:      customFieldService.Init(db),
 	}
 }
 
-const sourceType = "商機"
+const sourceType = enums.SourceTypeOpportunity
 
 func (m *manager) Create(trx *gorm.DB, input *opportunityModel.Create) (int, any) {
-	defer trx.Rollback()
+	// 依公司的選項清單驗證,未帶入的欄位使用預設值
+	if httpCode, codeMessage := helpers.CheckPicklists(m.PicklistService, m.UserService, input.CreatedBy, "opportunities", map[string]any{
+		"stage":             &input.Stage,
+		"forecast_category": &input.ForecastCategory,
+	}, nil); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
+
+	// 依公司的自訂欄位驗證,未帶入的欄位使用預設值
+	if httpCode, codeMessage := helpers.CheckCustomFields(m.CustomFieldService, m.UserService, input.CreatedBy, "opportunities", &input.CustomFields, nil); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
 
 	// 若由線索轉換
 	if input.LeadID != "" {
 		// 同步將線索狀態改為「已轉換」
 		err := m.LeadService.WithTrx(trx).Update(&leadModel.Update{
 			LeadID:    input.LeadID,
-			Status:    util.PointerString("已轉換"),
+			Status:    util.PointerString(enums.LeadStatusConverted),
 			UpdatedBy: util.PointerString(input.CreatedBy),
 		})
 		if err != nil {
@@ -74,10 +99,10 @@ func (m *manager) Create(trx *gorm.DB, input *opportunityModel.Create) (int, any
 		// 同步新增線索歷程記錄
 		_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
 			SourceID:   input.LeadID,
-			Action:     "修改",
-			SourceType: "線索",
-			Field:      "狀態為",
-			Value:      "已轉換",
+			Action:     enums.ActionUpdated,
+			SourceType: enums.SourceTypeLead,
+			Field:      enums.FieldStatus,
+			Value:      enums.LeadStatusConverted,
 			ModifiedBy: input.CreatedBy,
 		})
 		if err != nil {
@@ -101,7 +126,7 @@ func (m *manager) Create(trx *gorm.DB, input *opportunityModel.Create) (int, any
 	// 同步新增商機歷程記錄
 	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
 		SourceID:   *opportunityBase.OpportunityID,
-		Action:     "建立",
+		Action:     enums.ActionCreated,
 		SourceType: sourceType,
 		ModifiedBy: *opportunityBase.CreatedBy,
 	})
@@ -110,20 +135,36 @@ func (m *manager) Create(trx *gorm.DB, input *opportunityModel.Create) (int, any
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, opportunityBase.OpportunityID)
 }
 
 func (m *manager) GetByList(input *opportunityModel.Fields) (int, any) {
 	output := &opportunityModel.List{}
+	if err := input.Projection.Validate(output, "opportunities", opportunityModel.Relations); err != nil {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+	}
+
 	output.Limit = input.Limit
 	output.Page = input.Page
-	quantity, opportunityBase, err := m.OpportunityService.GetByList(input)
+	// 公司的自訂欄位可用於搜尋及排序
+	customColumns, err := helpers.CustomColumns(m.CustomFieldService, input.CompanyID, "opportunities")
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	input.CustomColumns = customColumns
+	quantity, opportunityBase, cursors, err := m.OpportunityService.GetByList(input)
 	if err != nil {
+		if errors.Is(err, filter.ErrInvalid) || errors.Is(err, sort.ErrInvalid) || errors.Is(err, page.ErrInvalid) {
+			return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+		}
+
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 	output.Total.Total = quantity
+	output.Cursors = cursors
 	opportunityByte, err := json.Marshal(opportunityBase)
 	if err != nil {
 		log.Error(err)
@@ -144,7 +185,14 @@ func (m *manager) GetByList(input *opportunityModel.Fields) (int, any) {
 		opportunities.LeadDescription = *opportunityBase[i].Leads.Description
 	}
 
-	return code.Successful, code.GetCodeMessage(code.Successful, output)
+	// 篩選回傳欄位並嵌入展開的關聯
+	body, err := input.Projection.Shape(output, "opportunities", opportunityBase, opportunityModel.Relations)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, body)
 }
 
 func (m *manager) GetByListNoPagination(input *opportunityModel.FieldsNoPagination) (int, any) {
@@ -231,8 +279,6 @@ func (m *manager) GetBySingleCampaigns(input *opportunityModel.Field) (int, any)
 }
 
 func (m *manager) Delete(trx *gorm.DB, input *opportunityModel.Update) (int, any) {
-	defer trx.Rollback()
-
 	opportunityBase, err := m.OpportunityService.GetBySingle(&opportunityModel.Field{
 		OpportunityID: input.OpportunityID,
 	})
@@ -249,7 +295,7 @@ func (m *manager) Delete(trx *gorm.DB, input *opportunityModel.Update) (int, any
 	if opportunityBase.LeadID != nil {
 		err = m.LeadService.WithTrx(trx).Update(&leadModel.Update{
 			LeadID:    *opportunityBase.LeadID,
-			Status:    util.PointerString("發展中"),
+			Status:    util.PointerString(enums.LeadStatusWorking),
 			UpdatedBy: input.UpdatedBy,
 		})
 		if err != nil {
@@ -260,10 +306,10 @@ func (m *manager) Delete(trx *gorm.DB, input *opportunityModel.Update) (int, any
 		// 同步新增線索歷程記錄
 		_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
 			SourceID:   *opportunityBase.LeadID,
-			Action:     "修改",
-			SourceType: "線索",
-			Field:      "狀態為",
-			Value:      "發展中",
+			Action:     enums.ActionUpdated,
+			SourceType: enums.SourceTypeLead,
+			Field:      enums.FieldStatus,
+			Value:      enums.LeadStatusWorking,
 			ModifiedBy: *input.UpdatedBy,
 		})
 		if err != nil {
@@ -272,19 +318,40 @@ func (m *manager) Delete(trx *gorm.DB, input *opportunityModel.Update) (int, any
 		}
 	}
 
-	err = m.OpportunityService.WithTrx(trx).Delete(input)
+	// 依刪除規則刪除並移至資源回收筒,有限制刪除的關聯資料時回傳受影響的資料
+	preview, cascaded, err := m.RecycleBinService.WithTrx(trx).Delete("opportunities", input.OpportunityID, *input.UpdatedBy)
+	if err != nil {
+		if errors.Is(err, recycleBinService.ErrRestricted) {
+			return code.Conflict, code.GetCodeMessage(code.Conflict, preview)
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 同步新增刪除歷程記錄
+	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
+		SourceID:   input.OpportunityID,
+		Action:     enums.ActionDeleted,
+		SourceType: sourceType,
+		ModifiedBy: *input.UpdatedBy,
+	})
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 同步新增一併刪除資料的刪除歷程記錄
+	err = helpers.RecordCascaded(m.HistoricalRecordService.WithTrx(trx), cascaded, enums.ActionDeleted, *input.UpdatedBy)
 	if err != nil {
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, "Delete ok!")
 }
 
 func (m *manager) Update(trx *gorm.DB, input *opportunityModel.Update) (int, any) {
-	defer trx.Rollback()
-
 	opportunityBase, err := m.OpportunityService.GetBySingle(&opportunityModel.Field{
 		OpportunityID: input.OpportunityID,
 	})
@@ -297,6 +364,36 @@ func (m *manager) Update(trx *gorm.DB, input *opportunityModel.Update) (int, any
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	// 樂觀鎖定,版本不符時回傳目前資料
+	if input.IfMatch != "" {
+		matched, err := helpers.CheckVersion(trx, "opportunities", "opportunity_id", input.OpportunityID, input.IfMatch)
+		if err != nil {
+			log.Error(err)
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+
+		if !matched {
+			return helpers.PreconditionFailed(m.GetBySingle(&opportunityModel.Field{
+				OpportunityID: input.OpportunityID,
+			}))
+		}
+	}
+
+	if httpCode, codeMessage := helpers.CheckPicklists(m.PicklistService, m.UserService, *input.UpdatedBy, "opportunities", map[string]any{
+		"stage":             input.Stage,
+		"forecast_category": input.ForecastCategory,
+	}, map[string]any{
+		"stage":             opportunityBase.Stage,
+		"forecast_category": opportunityBase.ForecastCategory,
+	}); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
+
+	// 依公司的自訂欄位驗證,僅更新帶入的欄位
+	if httpCode, codeMessage := helpers.CheckCustomFields(m.CustomFieldService, m.UserService, *input.UpdatedBy, "opportunities", &input.CustomFields, opportunityBase.CustomFields); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
+
 	err = m.OpportunityService.WithTrx(trx).Update(input)
 	if err != nil {
 		log.Error(err)
@@ -307,32 +404,30 @@ func (m *manager) Update(trx *gorm.DB, input *opportunityModel.Update) (int, any
 	var records []historicalRecordModel.AddHistoricalRecord
 
 	if input.Name != nil && *input.Name != *opportunityBase.Name {
-		helpers.AddHistoricalRecord(&records, "修改", "名稱為", *input.Name)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldName, *input.Name)
 	}
 
 	if input.Stage != nil && *input.Stage != *opportunityBase.Stage {
-		helpers.AddHistoricalRecord(&records, "修改", "階段為", *input.Stage)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldStage, *input.Stage)
 	}
 
 	if input.ForecastCategory != nil && *input.ForecastCategory != *opportunityBase.ForecastCategory {
-		helpers.AddHistoricalRecord(&records, "修改", "預測種類為", *input.ForecastCategory)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldForecastCategory, *input.ForecastCategory)
 	}
 
 	if input.CloseDate != nil && *input.CloseDate != *opportunityBase.CloseDate {
-		helpers.AddHistoricalRecord(&records, "修改", "結束日期為", input.CloseDate.UTC().Format("2006-01-02T15:04:05.999999Z"))
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldCloseDate, input.CloseDate.UTC().Format("2006-01-02T15:04:05.999999Z"))
 	}
 
 	if input.Amount != nil && *input.Amount != *opportunityBase.Amount {
-		helpers.AddHistoricalRecord(&records, "修改", "金額為", strconv.FormatFloat(*input.Amount, 'f', -1, 64))
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldAmount, strconv.FormatFloat(*input.Amount, 'f', -1, 64))
 	} else if *opportunityBase.Amount != 0 {
-		helpers.AddHistoricalRecord(&records, "清除", "金額", "")
+		helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldAmount, "")
 	}
 
 	if input.SalespersonID != nil && *input.SalespersonID != *opportunityBase.SalespersonID {
-		salespersonBase, _ := m.UserService.GetBySingle(&userModel.Field{
-			UserID: *input.SalespersonID,
-		})
-		helpers.AddHistoricalRecord(&records, "修改", "業務員為", *salespersonBase.Name)
+		salespersonBase, _ := m.UserService.GetByCache(*input.SalespersonID)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldSalesperson, *salespersonBase.Name)
 	}
 
 	for _, record := range records {
@@ -350,6 +445,72 @@ func (m *manager) Update(trx *gorm.DB, input *opportunityModel.Update) (int, any
 		}
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, opportunityBase.OpportunityID)
 }
+
+// Bulk creates, updates and deletes opportunities at once, see helpers.Bulk for the modes.
+func (m *manager) Bulk(trx *gorm.DB, input *opportunityModel.Bulk) (int, any) {
+	history := m.HistoricalRecordService.Batch()
+	var operations []*helpers.Operation
+	for i, create := range input.Create {
+		create.CreatedBy = input.UserID
+		operations = append(operations, &helpers.Operation{
+			Action: bulkModel.Create,
+			Index:  i,
+			Input:  create,
+			Run: func(trx *gorm.DB) (int, any) {
+				return m.scoped(trx, history).Create(trx, create)
+			},
+		})
+	}
+
+	for i, item := range input.Update {
+		update := &item.Update
+		update.UpdatedBy = util.PointerString(input.UserID)
+		update.IfMatch = item.IfMatch
+		operations = append(operations, &helpers.Operation{
+			Action: bulkModel.Update,
+			Index:  i,
+			ID:     update.OpportunityID,
+			Input:  update,
+			// 公司要求 If-Match 時,未帶版本的項目不更新,版本不符的項目由 Update 回傳 412
+			Unversioned: input.IfMatchRequired && item.IfMatch == "",
+			Run: func(trx *gorm.DB) (int, any) {
+				return m.scoped(trx, history).Update(trx, update)
+			},
+		})
+	}
+
+	for i, opportunityID := range input.Delete {
+		update := &opportunityModel.Update{
+			OpportunityID: opportunityID,
+			UpdatedBy:     util.PointerString(input.UserID),
+		}
+		operations = append(operations, &helpers.Operation{
+			Action: bulkModel.Delete,
+			Index:  i,
+			ID:     opportunityID,
+			Input:  update,
+			Run: func(trx *gorm.DB) (int, any) {
+				return m.scoped(trx, history).Delete(trx, update)
+			},
+		})
+	}
+
+	return helpers.Bulk(trx, input.Mode.Mode, history, operations)
+}
+
+// scoped returns a copy of the manager whose services run in trx, every service is carried over.
+// Its historical records are held by history.
+func (m *manager) scoped(trx *gorm.DB, history historicalRecordService.Batch) *manager {
+	scoped := *m
+	scoped.OpportunityService = m.OpportunityService.WithTrx(trx)
+	scoped.CampaignService = m.CampaignService.WithTrx(trx)
+	scoped.LeadService = m.LeadService.WithTrx(trx)
+	scoped.UserService = m.UserService.WithTrx(trx)
+	scoped.RecycleBinService = m.RecycleBinService.WithTrx(trx)
+	scoped.PicklistService = m.PicklistService.WithTrx(trx)
+	scoped.CustomFieldService = m.CustomFieldService.WithTrx(trx)
+	scoped.HistoricalRecordService = history
+	return &scoped
+}
@@ -34,15 +34,12 @@ func Init(db *gorm.DB) Manager {
 }
 
 func (m *manager) Create(trx *gorm.DB, input *opportunityCampaignModel.Create) (int, any) {
-	defer trx.Rollback()
-
 	opportunityCampaignBase, err := m.OpportunityCampaignService.WithTrx(trx).Create(input)
 	if err != nil {
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, opportunityCampaignBase.OpportunityCampaignID)
 }
 
@@ -7,9 +7,11 @@ import (
 	"crm/internal/interactor/helpers"
 
 	accountModel "crm/internal/interactor/models/accounts"
+	"crm/internal/interactor/models/enums"
 	historicalRecordModel "crm/internal/interactor/models/historical_records"
 	accountService "crm/internal/interactor/service/account"
 	historicalRecordService "crm/internal/interactor/service/historical_record"
+	recycleBinService "crm/internal/interactor/service/recycle_bin"
 
 	"crm/internal/interactor/pkg/util"
 
@@ -20,6 +22,9 @@ import (
 
 	"gorm.io/gorm"
 
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/sort"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
 )
@@ -29,7 +34,7 @@ type Manager interface {
 	GetByList(input *orderModel.Fields) (int, any)
 	GetBySingle(input *orderModel.Field) (int, any)
 	GetBySingleProducts(input *orderModel.Field) (int, any)
-	Delete(input *orderModel.Field) (int, any)
+	Delete(trx *gorm.DB, input *orderModel.Update) (int, any)
 	Update(trx *gorm.DB, input *orderModel.Update) (int, any)
 }
 
@@ -38,6 +43,7 @@ type manager struct {
 	ContractService         contractService.Service
 	HistoricalRecordService historicalRecordService.Service
 	AccountService          accountService.Service
+	RecycleBinService       recycleBinService.Service
 }
 
 func Init(db *gorm.DB) Manager {
@@ -46,13 +52,15 @@ func Init(db *gorm.DB) Manager {
 		ContractService:         contractService.Init(db),
 		HistoricalRecordService: historicalRecordService.Init(db),
 		AccountService:          accountService.Init(db),
+		RecycleBinService:       recycleBinService.Init(db),
 	}
 }
 
-const sourceType = "訂單"
+const sourceType = enums.SourceTypeOrder
 
 func (m *manager) Create(trx *gorm.DB, input *orderModel.Create) (int, any) {
-	defer trx.Rollback()
+	// 狀態名稱轉為代碼
+	enums.Normalize(enums.OrderStatus, &input.Status)
 
 	// 同步契約的account_id
 	contractBase, _ := m.ContractService.GetBySingle(&contractModel.Field{
@@ -69,7 +77,7 @@ func (m *manager) Create(trx *gorm.DB, input *orderModel.Create) (int, any) {
 	// 同步新增訂單歷程記錄
 	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
 		SourceID:   *orderBase.OrderID,
-		Action:     "建立",
+		Action:     enums.ActionCreated,
 		SourceType: sourceType,
 		ModifiedBy: *orderBase.CreatedBy,
 	})
@@ -78,20 +86,29 @@ func (m *manager) Create(trx *gorm.DB, input *orderModel.Create) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, orderBase.OrderID)
 }
 
 func (m *manager) GetByList(input *orderModel.Fields) (int, any) {
+	enums.Normalize(enums.OrderStatus, &input.FilterStatus)
 	output := &orderModel.List{}
+	if err := input.Projection.Validate(output, "orders", orderModel.Relations); err != nil {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+	}
+
 	output.Limit = input.Limit
 	output.Page = input.Page
-	quantity, orderBase, err := m.OrderService.GetByList(input)
+	quantity, orderBase, cursors, err := m.OrderService.GetByList(input)
 	if err != nil {
+		if errors.Is(err, filter.ErrInvalid) || errors.Is(err, sort.ErrInvalid) || errors.Is(err, page.ErrInvalid) {
+			return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+		}
+
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 	output.Total.Total = quantity
+	output.Cursors = cursors
 	orderByte, err := json.Marshal(orderBase)
 	if err != nil {
 		log.Error(err)
@@ -117,7 +134,14 @@ func (m *manager) GetByList(input *orderModel.Fields) (int, any) {
 		}
 	}
 
-	return code.Successful, code.GetCodeMessage(code.Successful, output)
+	// 篩選回傳欄位並嵌入展開的關聯
+	body, err := input.Projection.Shape(output, "orders", orderBase, orderModel.Relations)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, body)
 }
 
 func (m *manager) GetBySingle(input *orderModel.Field) (int, any) {
@@ -188,7 +212,7 @@ func (m *manager) GetBySingleProducts(input *orderModel.Field) (int, any) {
 	return code.Successful, code.GetCodeMessage(code.Successful, output)
 }
 
-func (m *manager) Delete(input *orderModel.Field) (int, any) {
+func (m *manager) Delete(trx *gorm.DB, input *orderModel.Update) (int, any) {
 	_, err := m.OrderService.GetBySingle(&orderModel.Field{
 		OrderID: input.OrderID,
 	})
@@ -201,7 +225,31 @@ func (m *manager) Delete(input *orderModel.Field) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	err = m.OrderService.Delete(input)
+	// 依刪除規則刪除並移至資源回收筒,有限制刪除的關聯資料時回傳受影響的資料
+	preview, cascaded, err := m.RecycleBinService.WithTrx(trx).Delete("orders", input.OrderID, *input.UpdatedBy)
+	if err != nil {
+		if errors.Is(err, recycleBinService.ErrRestricted) {
+			return code.Conflict, code.GetCodeMessage(code.Conflict, preview)
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 同步新增刪除歷程記錄
+	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
+		SourceID:   input.OrderID,
+		Action:     enums.ActionDeleted,
+		SourceType: sourceType,
+		ModifiedBy: *input.UpdatedBy,
+	})
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 同步新增一併刪除資料的刪除歷程記錄
+	err = helpers.RecordCascaded(m.HistoricalRecordService.WithTrx(trx), cascaded, enums.ActionDeleted, *input.UpdatedBy)
 	if err != nil {
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
@@ -211,7 +259,7 @@ func (m *manager) Delete(input *orderModel.Field) (int, any) {
 }
 
 func (m *manager) Update(trx *gorm.DB, input *orderModel.Update) (int, any) {
-	defer trx.Rollback()
+	enums.Normalize(enums.OrderStatus, input.Status)
 
 	orderBase, err := m.OrderService.GetBySingle(&orderModel.Field{
 		OrderID: input.OrderID,
@@ -225,9 +273,24 @@ func (m *manager) Update(trx *gorm.DB, input *orderModel.Update) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	// 樂觀鎖定,版本不符時回傳目前資料
+	if input.IfMatch != "" {
+		matched, err := helpers.CheckVersion(trx, "orders", "order_id", input.OrderID, input.IfMatch)
+		if err != nil {
+			log.Error(err)
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+
+		if !matched {
+			return helpers.PreconditionFailed(m.GetBySingle(&orderModel.Field{
+				OrderID: input.OrderID,
+			}))
+		}
+	}
+
 	// 判斷該訂單是否啟用
-	if *orderBase.Status != *input.Status {
-		if *input.Status == "啟動中" {
+	if input.Status != nil && *orderBase.Status != *input.Status {
+		if *input.Status == enums.OrderStatusActivated {
 			input.ActivatedBy = input.UpdatedBy
 		} else {
 			input.ActivatedBy = nil
@@ -252,37 +315,37 @@ func (m *manager) Update(trx *gorm.DB, input *orderModel.Update) (int, any) {
 	var records []historicalRecordModel.AddHistoricalRecord
 
 	if input.Status != nil && *input.Status != *orderBase.Status {
-		helpers.AddHistoricalRecord(&records, "修改", "狀態為", *input.Status)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldStatus, *input.Status)
 	}
 
 	if input.StartDate != nil && *input.StartDate != *orderBase.StartDate {
-		helpers.AddHistoricalRecord(&records, "修改", "開始日期為", input.StartDate.UTC().Format("2006-01-02T15:04:05.999999Z"))
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldStartDate, input.StartDate.UTC().Format("2006-01-02T15:04:05.999999Z"))
 	}
 
 	if input.ContractID != nil && *input.ContractID != *orderBase.ContractID {
 		contractBase, _ := m.ContractService.GetBySingle(&contractModel.Field{
 			ContractID: *input.ContractID,
 		})
-		helpers.AddHistoricalRecord(&records, "修改", "契約號碼為", *contractBase.Code)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldContractCode, *contractBase.Code)
 
 		if contractBase.AccountID != orderBase.AccountID {
 			accountBase, _ := m.AccountService.GetBySingle(&accountModel.Field{
 				AccountID: *contractBase.AccountID,
 			})
-			helpers.AddHistoricalRecord(&records, "修改", "帳戶為", *accountBase.Name)
+			helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldAccount, *accountBase.Name)
 		}
 	}
 
 	if input.Description != nil {
 		if *input.Description != *orderBase.Description {
 			if *input.Description == "" {
-				helpers.AddHistoricalRecord(&records, "清除", "描述", "")
+				helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldDescription, "")
 			} else {
-				helpers.AddHistoricalRecord(&records, "修改", "描述為", *input.Description)
+				helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldDescription, *input.Description)
 			}
 		}
 	} else if *orderBase.Description != "" {
-		helpers.AddHistoricalRecord(&records, "清除", "描述", "")
+		helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldDescription, "")
 	}
 
 	for _, record := range records {
@@ -300,6 +363,5 @@ func (m *manager) Update(trx *gorm.DB, input *orderModel.Update) (int, any) {
 		}
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, orderBase.OrderID)
 }
@@ -42,8 +42,6 @@ func Init(db *gorm.DB) Manager {
 }
 
 func (m *manager) Create(trx *gorm.DB, input *orderProductModel.CreateList) (int, any) {
-	defer trx.Rollback()
-
 	var output []*string
 	number := 0
 	for i, inputBody := range input.OrderProducts {
@@ -87,7 +85,6 @@ func (m *manager) Create(trx *gorm.DB, input *orderProductModel.CreateList) (int
 		number++
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, output)
 }
 
@@ -0,0 +1,310 @@
+package picklist
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+
+	picklistDB "crm/internal/entity/postgresql/db/picklists"
+	"crm/internal/interactor/helpers"
+	"crm/internal/interactor/models/enums"
+	picklistModel "crm/internal/interactor/models/picklists"
+	"crm/internal/interactor/pkg/cache"
+	"crm/internal/interactor/pkg/util"
+	picklistService "crm/internal/interactor/service/picklist"
+
+	"gorm.io/gorm"
+
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+)
+
+type Manager interface {
+	Create(trx *gorm.DB, input *picklistModel.Create) (int, any)
+	GetByList(input *picklistModel.Field) (int, any)
+	GetBySingle(input *picklistModel.Field) (int, any)
+	Update(trx *gorm.DB, input *picklistModel.Update) (int, any)
+	Delete(trx *gorm.DB, input *picklistModel.Field) (int, any)
+}
+
+type manager struct {
+	PicklistService picklistService.Service
+}
+
+func Init(db *gorm.DB) Manager {
+	return &manager{
+		PicklistService: picklistService.Init(db),
+	}
+}
+
+func (m *manager) Create(trx *gorm.DB, input *picklistModel.Create) (int, any) {
+	if !slices.Contains(picklistModel.Entities[input.Entity], input.Field) {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, "field "+input.Field+" of "+input.Entity+" cannot have a picklist")
+	}
+
+	_, err := m.PicklistService.WithTrx(trx).GetBySingle(&picklistModel.Field{
+		CompanyID: input.CompanyID,
+		Entity:    util.PointerString(input.Entity),
+		Field:     util.PointerString(input.Field),
+	})
+	if err == nil {
+		return code.Conflict, code.GetCodeMessage(code.Conflict, "picklist of "+input.Entity+"."+input.Field+" already exists")
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	input.Values, err = m.check(trx, input.CompanyID, input.Entity, input.Field, input.ControllingField, input.Values)
+	if err != nil {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+	}
+
+	picklistBase, err := m.PicklistService.WithTrx(trx).Create(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	m.invalidate(trx, input.CompanyID, input.Entity)
+	return code.Successful, code.GetCodeMessage(code.Successful, picklistBase.PicklistID)
+}
+
+func (m *manager) GetByList(input *picklistModel.Field) (int, any) {
+	picklistBase, err := m.PicklistService.GetByList(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	language := enums.Language(input.Language)
+	output := &picklistModel.List{Picklists: make([]*picklistModel.Single, 0, len(picklistBase))}
+	defined := map[string]bool{}
+	for _, picklist := range picklistBase {
+		output.Picklists = append(output.Picklists, single(picklist, language))
+		defined[*picklist.Entity+"."+*picklist.Field] = true
+	}
+
+	// 公司未定義時回傳系統選項,供畫面顯示
+	for _, entity := range slices.Sorted(maps.Keys(picklistModel.SystemValues)) {
+		for _, field := range slices.Sorted(maps.Keys(picklistModel.SystemValues[entity])) {
+			if defined[entity+"."+field] || input.Entity != nil && *input.Entity != entity || input.Field != nil && *input.Field != field {
+				continue
+			}
+
+			system := &picklistModel.Single{
+				Entity: entity,
+				Field:  field,
+			}
+			for i, value := range picklistModel.SystemValues[entity][field] {
+				system.Values = append(system.Values, &picklistModel.Value{
+					Value:     value,
+					Label:     enums.Label(value, language),
+					Sequence:  i + 1,
+					IsDefault: i == 0,
+					IsActive:  util.PointerBool(true),
+				})
+			}
+
+			output.Picklists = append(output.Picklists, system)
+		}
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, output)
+}
+
+func (m *manager) GetBySingle(input *picklistModel.Field) (int, any) {
+	picklistBase, err := m.PicklistService.GetBySingle(input)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, err.Error())
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, single(picklistBase, enums.Language(input.Language)))
+}
+
+func (m *manager) Update(trx *gorm.DB, input *picklistModel.Update) (int, any) {
+	picklistBase, err := m.PicklistService.WithTrx(trx).GetBySingle(&picklistModel.Field{
+		PicklistID: input.PicklistID,
+		CompanyID:  input.CompanyID,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, err.Error())
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	controllingField := ""
+	if picklistBase.ControllingField != nil {
+		controllingField = *picklistBase.ControllingField
+	}
+
+	if input.ControllingField != nil {
+		controllingField = *input.ControllingField
+	}
+
+	if input.Values == nil {
+		for _, value := range picklistBase.Values {
+			input.Values = append(input.Values, &picklistModel.Value{
+				Value:             value.Value,
+				Label:             value.Label,
+				Sequence:          value.Sequence,
+				IsDefault:         value.IsDefault,
+				IsActive:          util.PointerBool(value.IsActive),
+				ControllingValues: value.ControllingValues,
+			})
+		}
+	}
+
+	input.Values, err = m.check(trx, input.CompanyID, *picklistBase.Entity, *picklistBase.Field, controllingField, input.Values)
+	if err != nil {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+	}
+
+	err = m.PicklistService.WithTrx(trx).Update(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	m.invalidate(trx, input.CompanyID, *picklistBase.Entity)
+	return code.Successful, code.GetCodeMessage(code.Successful, picklistBase.PicklistID)
+}
+
+func (m *manager) Delete(trx *gorm.DB, input *picklistModel.Field) (int, any) {
+	picklistBase, err := m.PicklistService.WithTrx(trx).GetBySingle(input)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, err.Error())
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	err = m.PicklistService.WithTrx(trx).Delete(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	m.invalidate(trx, input.CompanyID, *picklistBase.Entity)
+	return code.Successful, code.GetCodeMessage(code.Successful, "Delete ok!")
+}
+
+// check validates the options of the picklist of entity.field and adds the system values it leaves out.
+// The options of a dependent picklist may only name the values of its controlling picklist, when it has one.
+func (m *manager) check(trx *gorm.DB, companyID, entity, field, controllingField string, values []*picklistModel.Value) ([]*picklistModel.Value, error) {
+	if controllingField != "" && picklistModel.Dependencies[entity][field] != controllingField {
+		return nil, errors.New("field " + field + " of " + entity + " cannot depend on " + controllingField)
+	}
+
+	var controlling []string
+	if controllingField != "" {
+		controllingBase, err := m.PicklistService.WithTrx(trx).GetBySingle(&picklistModel.Field{
+			CompanyID: companyID,
+			Entity:    util.PointerString(entity),
+			Field:     util.PointerString(controllingField),
+		})
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error(err)
+			return nil, err
+		}
+
+		if controllingBase != nil {
+			for _, value := range controllingBase.Values {
+				controlling = append(controlling, value.Value)
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	defaults := 0
+	for _, value := range values {
+		if seen[value.Value] {
+			return nil, errors.New("value " + value.Value + " is repeated")
+		}
+
+		seen[value.Value] = true
+		if value.IsDefault {
+			defaults++
+		}
+
+		if controllingField == "" {
+			value.ControllingValues = nil
+		}
+
+		for _, controllingValue := range value.ControllingValues {
+			if controlling != nil && !slices.Contains(controlling, controllingValue) {
+				return nil, errors.New("value " + controllingValue + " is not in the picklist of " + controllingField)
+			}
+		}
+	}
+
+	if defaults > 1 {
+		return nil, errors.New("a picklist has a single default value")
+	}
+
+	// 系統會寫入的值(如已轉換的線索狀態)不可移除或停用
+	for _, system := range picklistModel.SystemValues[entity][field] {
+		i := slices.IndexFunc(values, func(value *picklistModel.Value) bool {
+			return value.Value == system
+		})
+		if i < 0 {
+			values = append(values, &picklistModel.Value{
+				Value:    system,
+				Label:    enums.Label(system, enums.Chinese),
+				Sequence: len(values) + 1,
+			})
+			continue
+		}
+
+		if values[i].IsActive != nil && !*values[i].IsActive {
+			return nil, errors.New("value " + system + " is used by the system and cannot be deactivated")
+		}
+	}
+
+	return values, nil
+}
+
+// invalidate drops the cached picklists of entity checked by the managers of the records
+// once trx is committed.
+func (m *manager) invalidate(trx *gorm.DB, companyID, entity string) {
+	helpers.AfterCommit(trx, func() {
+		err := cache.Default().Delete(cache.Key(cache.Picklist, companyID+":"+entity))
+		if err != nil {
+			log.Error(err)
+		}
+	})
+}
+
+// single returns a picklist, the options holding codes with their default label are translated to language.
+func single(picklist *picklistDB.Base, language string) *picklistModel.Single {
+	output := &picklistModel.Single{}
+	picklistByte, err := json.Marshal(picklist)
+	if err != nil {
+		log.Error(err)
+	}
+
+	err = json.Unmarshal(picklistByte, output)
+	if err != nil {
+		log.Error(err)
+	}
+
+	for _, value := range output.Values {
+		if value.Label == enums.Label(value.Value, enums.Chinese) {
+			value.Label = enums.Label(value.Value, language)
+		}
+	}
+
+	return output
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 
+	"crm/internal/interactor/models/enums"
 	quoteModel "crm/internal/interactor/models/quotes"
 	quoteService "crm/internal/interactor/service/quote"
 
@@ -11,16 +12,27 @@ import (
 	orderModel "crm/internal/interactor/models/orders"
 	contractService "crm/internal/interactor/service/contract"
 
+	productDB "crm/internal/entity/postgresql/db/products"
 	quoteProductDB "crm/internal/entity/postgresql/db/quote_products"
+	userDB "crm/internal/entity/postgresql/db/users"
 	productModel "crm/internal/interactor/models/products"
 	quoteProductModel "crm/internal/interactor/models/quote_products"
 	"crm/internal/interactor/pkg/util"
 	orderService "crm/internal/interactor/service/order"
 	productService "crm/internal/interactor/service/product"
 	quoteProductService "crm/internal/interactor/service/quote_product"
+	userService "crm/internal/interactor/service/user"
 
+	historicalRecordModel "crm/internal/interactor/models/historical_records"
+	historicalRecordService "crm/internal/interactor/service/historical_record"
+	recycleBinService "crm/internal/interactor/service/recycle_bin"
 	"gorm.io/gorm"
 
+	"crm/internal/interactor/helpers"
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/sort"
+	"crm/internal/interactor/pkg/cache"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
 )
@@ -30,31 +42,35 @@ type Manager interface {
 	GetByList(input *productModel.Fields) (int, any)
 	GetByOrderIDList(input *productModel.Fields) (int, any)
 	GetBySingle(input *productModel.Field) (int, any)
-	Delete(input *productModel.Field) (int, any)
-	Update(input *productModel.Update) (int, any)
+	Delete(trx *gorm.DB, input *productModel.Update) (int, any)
+	Update(trx *gorm.DB, input *productModel.Update) (int, any)
 }
 
 type manager struct {
-	ProductService      productService.Service
-	QuoteProductService quoteProductService.Service
-	OrderService        orderService.Service
-	ContractService     contractService.Service
-	QuoteService        quoteService.Service
+	ProductService          productService.Service
+	QuoteProductService     quoteProductService.Service
+	OrderService            orderService.Service
+	ContractService         contractService.Service
+	QuoteService            quoteService.Service
+	UserService             userService.Service
+	RecycleBinService       recycleBinService.Service
+	HistoricalRecordService historicalRecordService.Service
 }
 
 func Init(db *gorm.DB) Manager {
 	return &manager{
-		ProductService:      productService.Init(db),
-		QuoteProductService: quoteProductService.Init(db),
-		OrderService:        orderService.Init(db),
-		ContractService:     contractService.Init(db),
-		QuoteService:        quoteService.Init(db),
+		ProductService:          productService.Init(db),
+		QuoteProductService:     quoteProductService.Init(db),
+		OrderService:            orderService.Init(db),
+		ContractService:         contractService.Init(db),
+		QuoteService:            quoteService.Init(db),
+		UserService:             userService.Init(db),
+		RecycleBinService:       recycleBinService.Init(db),
+		HistoricalRecordService: historicalRecordService.Init(db),
 	}
 }
 
 func (m *manager) Create(trx *gorm.DB, input *productModel.Create) (int, any) {
-	defer trx.Rollback()
-
 	// 判斷產品識別碼是否重複
 	quantity, _ := m.ProductService.GetByQuantity(&productModel.Field{
 		Code: util.PointerString(input.Code),
@@ -71,21 +87,29 @@ func (m *manager) Create(trx *gorm.DB, input *productModel.Create) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, productBase.ProductID)
 }
 
 func (m *manager) GetByList(input *productModel.Fields) (int, any) {
 	output := &productModel.List{}
+	if err := input.Projection.Validate(output, "products", productModel.Relations); err != nil {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+	}
+
 	output.Limit = input.Limit
 	output.Page = input.Page
-	quantity, productBase, err := m.ProductService.GetByList(input)
+	quantity, productBase, cursors, err := m.ProductService.GetByList(input)
 	if err != nil {
+		if errors.Is(err, filter.ErrInvalid) || errors.Is(err, sort.ErrInvalid) || errors.Is(err, page.ErrInvalid) {
+			return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+		}
+
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
 	output.Total.Total = quantity
+	output.Cursors = cursors
 	productByte, err := json.Marshal(productBase)
 	if err != nil {
 		log.Error(err)
@@ -104,20 +128,28 @@ func (m *manager) GetByList(input *productModel.Fields) (int, any) {
 		products.UpdatedBy = *productBase[i].UpdatedByUsers.Name
 	}
 
-	return code.Successful, code.GetCodeMessage(code.Successful, output)
+	// 篩選回傳欄位並嵌入展開的關聯
+	body, err := input.Projection.Shape(output, "products", productBase, productModel.Relations)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, body)
 }
 
 func (m *manager) GetByOrderIDList(input *productModel.Fields) (int, any) {
 	output := &productModel.List{}
 	output.Limit = input.Limit
 	output.Page = input.Page
-	quantity, productBase, err := m.ProductService.GetByList(input)
+	quantity, productBase, cursors, err := m.ProductService.GetByList(input)
 	if err != nil {
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
 	output.Total.Total = quantity
+	output.Cursors = cursors
 	productByte, err := json.Marshal(productBase)
 	if err != nil {
 		log.Error(err)
@@ -185,7 +217,7 @@ func (m *manager) GetByOrderIDList(input *productModel.Fields) (int, any) {
 }
 
 func (m *manager) GetBySingle(input *productModel.Field) (int, any) {
-	productBase, err := m.ProductService.GetBySingle(input)
+	productBase, err := m.getBySingle(input)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, err.Error())
@@ -203,13 +235,65 @@ func (m *manager) GetBySingle(input *productModel.Field) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	output.CreatedBy = *productBase.CreatedByUsers.Name
-	output.UpdatedBy = *productBase.UpdatedByUsers.Name
+	// 建立者或更新者已不存在時名稱為空
+	if productBase.CreatedByUsers.Name != nil {
+		output.CreatedBy = *productBase.CreatedByUsers.Name
+	}
+
+	if productBase.UpdatedByUsers.Name != nil {
+		output.UpdatedBy = *productBase.UpdatedByUsers.Name
+	}
 
 	return code.Successful, code.GetCodeMessage(code.Successful, output)
 }
 
-func (m *manager) Delete(input *productModel.Field) (int, any) {
+// getBySingle reads the product through the cache when it is looked up by ID only,
+// the names of the creating and updating users come from the user cache.
+func (m *manager) getBySingle(input *productModel.Field) (*productDB.Base, error) {
+	if *input != (productModel.Field{ProductID: input.ProductID}) {
+		return m.ProductService.GetBySingle(input)
+	}
+
+	productBase, err := m.ProductService.GetByCache(input.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	createdByBase, err := m.user(productBase.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedByBase, err := m.user(productBase.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	productBase.CreatedByUsers = *createdByBase
+	productBase.UpdatedByUsers = *updatedByBase
+
+	return productBase, nil
+}
+
+// user returns the user of userID from the user cache, a user that no longer exists is returned empty.
+func (m *manager) user(userID *string) (*userDB.Base, error) {
+	if userID == nil {
+		return &userDB.Base{}, nil
+	}
+
+	userBase, err := m.UserService.GetByCache(*userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &userDB.Base{}, nil
+		}
+
+		return nil, err
+	}
+
+	return userBase, nil
+}
+
+func (m *manager) Delete(trx *gorm.DB, input *productModel.Update) (int, any) {
 	_, err := m.ProductService.GetBySingle(&productModel.Field{
 		ProductID: input.ProductID,
 	})
@@ -222,16 +306,48 @@ func (m *manager) Delete(input *productModel.Field) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	err = m.ProductService.Delete(input)
+	// 依刪除規則刪除並移至資源回收筒,有限制刪除的關聯資料時回傳受影響的資料
+	preview, cascaded, err := m.RecycleBinService.WithTrx(trx).Delete("products", input.ProductID, *input.UpdatedBy)
+	if err != nil {
+		if errors.Is(err, recycleBinService.ErrRestricted) {
+			return code.Conflict, code.GetCodeMessage(code.Conflict, preview)
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 同步新增刪除歷程記錄
+	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
+		SourceID:   input.ProductID,
+		Action:     enums.ActionDeleted,
+		SourceType: enums.SourceTypeProduct,
+		ModifiedBy: *input.UpdatedBy,
+	})
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 同步新增一併刪除資料的刪除歷程記錄
+	err = helpers.RecordCascaded(m.HistoricalRecordService.WithTrx(trx), cascaded, enums.ActionDeleted, *input.UpdatedBy)
 	if err != nil {
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	// 交易提交後才清除快取,以免同時的查詢在提交前又快取舊資料
+	helpers.AfterCommit(trx, func() {
+		err := cache.Default().Delete(cache.Key(cache.Product, input.ProductID))
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
 	return code.Successful, code.GetCodeMessage(code.Successful, "Delete ok!")
 }
 
-func (m *manager) Update(input *productModel.Update) (int, any) {
+func (m *manager) Update(trx *gorm.DB, input *productModel.Update) (int, any) {
 	productBase, err := m.ProductService.GetBySingle(&productModel.Field{
 		ProductID: input.ProductID,
 	})
@@ -255,11 +371,19 @@ func (m *manager) Update(input *productModel.Update) (int, any) {
 		}
 	}
 
-	err = m.ProductService.Update(input)
+	err = m.ProductService.WithTrx(trx).Update(input)
 	if err != nil {
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	// 交易提交後才清除快取,以免同時的查詢在提交前又快取舊資料
+	helpers.AfterCommit(trx, func() {
+		err := cache.Default().Delete(cache.Key(cache.Product, input.ProductID))
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
 	return code.Successful, code.GetCodeMessage(code.Successful, productBase.ProductID)
 }
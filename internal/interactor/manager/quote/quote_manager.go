@@ -8,12 +8,14 @@ import (
 
 	"crm/internal/interactor/helpers"
 	accountModel "crm/internal/interactor/models/accounts"
+	"crm/internal/interactor/models/enums"
 	historicalRecordModel "crm/internal/interactor/models/historical_records"
 	opportunityModel "crm/internal/interactor/models/opportunities"
 	quoteModel "crm/internal/interactor/models/quotes"
 	accountService "crm/internal/interactor/service/account"
 	historicalRecordService "crm/internal/interactor/service/historical_record"
 	opportunityService "crm/internal/interactor/service/opportunity"
+	recycleBinService "crm/internal/interactor/service/recycle_bin"
 
 	"crm/internal/interactor/pkg/util"
 
@@ -21,6 +23,9 @@ import (
 
 	"gorm.io/gorm"
 
+	"crm/internal/interactor/models/filter"
+	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/sort"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
 )
@@ -30,7 +35,7 @@ type Manager interface {
 	GetByList(input *quoteModel.Fields) (int, any)
 	GetBySingle(input *quoteModel.Field) (int, any)
 	GetBySingleProducts(input *quoteModel.Field) (int, any)
-	Delete(input *quoteModel.Field) (int, any)
+	Delete(trx *gorm.DB, input *quoteModel.Update) (int, any)
 	Update(trx *gorm.DB, input *quoteModel.Update) (int, any)
 }
 
@@ -39,6 +44,7 @@ type manager struct {
 	HistoricalRecordService historicalRecordService.Service
 	OpportunityService      opportunityService.Service
 	AccountService          accountService.Service
+	RecycleBinService       recycleBinService.Service
 }
 
 func Init(db *gorm.DB) Manager {
@@ -47,13 +53,15 @@ func Init(db *gorm.DB) Manager {
 		HistoricalRecordService: historicalRecordService.Init(db),
 		OpportunityService:      opportunityService.Init(db),
 		AccountService:          accountService.Init(db),
+		RecycleBinService:       recycleBinService.Init(db),
 	}
 }
 
-const sourceType = "報價"
+const sourceType = enums.SourceTypeQuote
 
 func (m *manager) Create(trx *gorm.DB, input *quoteModel.Create) (int, any) {
-	defer trx.Rollback()
+	// 狀態名稱轉為代碼
+	enums.Normalize(enums.QuoteStatus, &input.Status)
 
 	// 同步商機的account_id
 	opportunityBase, _ := m.OpportunityService.GetBySingle(&opportunityModel.Field{
@@ -70,7 +78,7 @@ func (m *manager) Create(trx *gorm.DB, input *quoteModel.Create) (int, any) {
 	// 同步新增報價歷程記錄
 	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
 		SourceID:   *quoteBase.QuoteID,
-		Action:     "建立",
+		Action:     enums.ActionCreated,
 		SourceType: sourceType,
 		ModifiedBy: *quoteBase.CreatedBy,
 	})
@@ -79,20 +87,29 @@ func (m *manager) Create(trx *gorm.DB, input *quoteModel.Create) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, quoteBase.QuoteID)
 }
 
 func (m *manager) GetByList(input *quoteModel.Fields) (int, any) {
+	enums.Normalize(enums.QuoteStatus, &input.FilterStatus)
 	output := &quoteModel.List{}
+	if err := input.Projection.Validate(output, "quotes", quoteModel.Relations); err != nil {
+		return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+	}
+
 	output.Limit = input.Limit
 	output.Page = input.Page
-	quantity, quoteBase, err := m.QuoteService.GetByList(input)
+	quantity, quoteBase, cursors, err := m.QuoteService.GetByList(input)
 	if err != nil {
+		if errors.Is(err, filter.ErrInvalid) || errors.Is(err, sort.ErrInvalid) || errors.Is(err, page.ErrInvalid) {
+			return code.BadRequest, code.GetCodeMessage(code.BadRequest, err.Error())
+		}
+
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 	output.Total.Total = quantity
+	output.Cursors = cursors
 	quoteByte, err := json.Marshal(quoteBase)
 	if err != nil {
 		log.Error(err)
@@ -124,7 +141,14 @@ func (m *manager) GetByList(input *quoteModel.Fields) (int, any) {
 		quotes.GrandTotal = quotes.TotalPrice + *quoteBase[i].ShippingAndHandling + *quoteBase[i].Tax
 	}
 
-	return code.Successful, code.GetCodeMessage(code.Successful, output)
+	// 篩選回傳欄位並嵌入展開的關聯
+	body, err := input.Projection.Shape(output, "quotes", quoteBase, quoteModel.Relations)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, body)
 }
 
 func (m *manager) GetBySingle(input *quoteModel.Field) (int, any) {
@@ -201,7 +225,7 @@ func (m *manager) GetBySingleProducts(input *quoteModel.Field) (int, any) {
 	return code.Successful, code.GetCodeMessage(code.Successful, output)
 }
 
-func (m *manager) Delete(input *quoteModel.Field) (int, any) {
+func (m *manager) Delete(trx *gorm.DB, input *quoteModel.Update) (int, any) {
 	_, err := m.QuoteService.GetBySingle(&quoteModel.Field{
 		QuoteID: input.QuoteID,
 	})
@@ -214,7 +238,31 @@ func (m *manager) Delete(input *quoteModel.Field) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	err = m.QuoteService.Delete(input)
+	// 依刪除規則刪除並移至資源回收筒,有限制刪除的關聯資料時回傳受影響的資料
+	preview, cascaded, err := m.RecycleBinService.WithTrx(trx).Delete("quotes", input.QuoteID, *input.UpdatedBy)
+	if err != nil {
+		if errors.Is(err, recycleBinService.ErrRestricted) {
+			return code.Conflict, code.GetCodeMessage(code.Conflict, preview)
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 同步新增刪除歷程記錄
+	_, err = m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
+		SourceID:   input.QuoteID,
+		Action:     enums.ActionDeleted,
+		SourceType: sourceType,
+		ModifiedBy: *input.UpdatedBy,
+	})
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	// 同步新增一併刪除資料的刪除歷程記錄
+	err = helpers.RecordCascaded(m.HistoricalRecordService.WithTrx(trx), cascaded, enums.ActionDeleted, *input.UpdatedBy)
 	if err != nil {
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
@@ -224,7 +272,7 @@ func (m *manager) Delete(input *quoteModel.Field) (int, any) {
 }
 
 func (m *manager) Update(trx *gorm.DB, input *quoteModel.Update) (int, any) {
-	defer trx.Rollback()
+	enums.Normalize(enums.QuoteStatus, input.Status)
 
 	quoteBase, err := m.QuoteService.GetBySingle(&quoteModel.Field{
 		QuoteID: input.QuoteID,
@@ -238,6 +286,21 @@ func (m *manager) Update(trx *gorm.DB, input *quoteModel.Update) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	// 樂觀鎖定,版本不符時回傳目前資料
+	if input.IfMatch != "" {
+		matched, err := helpers.CheckVersion(trx, "quotes", "quote_id", input.QuoteID, input.IfMatch)
+		if err != nil {
+			log.Error(err)
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+
+		if !matched {
+			return helpers.PreconditionFailed(m.GetBySingle(&quoteModel.Field{
+				QuoteID: input.QuoteID,
+			}))
+		}
+	}
+
 	// 同步更新商機的account_id至該報價
 	if input.OpportunityID != nil && *input.OpportunityID != *quoteBase.OpportunityID {
 		opportunityBase, _ := m.OpportunityService.GetBySingle(&opportunityModel.Field{
@@ -256,26 +319,26 @@ func (m *manager) Update(trx *gorm.DB, input *quoteModel.Update) (int, any) {
 	var records []historicalRecordModel.AddHistoricalRecord
 
 	if input.Name != nil && *input.Name != *quoteBase.Name {
-		helpers.AddHistoricalRecord(&records, "修改", "名稱為", *input.Name)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldName, *input.Name)
 	}
 
 	if input.Status != nil && *input.Status != *quoteBase.Status {
-		helpers.AddHistoricalRecord(&records, "修改", "狀態為", *input.Status)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldStatus, *input.Status)
 	}
 
 	if input.IsSyncing != nil && *input.IsSyncing != *quoteBase.IsSyncing {
 		if *input.IsSyncing == true {
-			helpers.AddHistoricalRecord(&records, "確認", "同步化", "此報價至商機")
+			helpers.AddHistoricalRecord(&records, enums.ActionConfirmed, enums.FieldSync, enums.ValueQuoteSynced)
 		} else {
-			helpers.AddHistoricalRecord(&records, "取消", "同步化", "此報價至商機")
+			helpers.AddHistoricalRecord(&records, enums.ActionCancelled, enums.FieldSync, enums.ValueQuoteSynced)
 		}
 	}
 
 	if input.IsFinal != nil && *input.IsFinal != *quoteBase.IsFinal {
 		if *input.IsFinal == true {
-			helpers.AddHistoricalRecord(&records, "確認", "", "此報價為最終版")
+			helpers.AddHistoricalRecord(&records, enums.ActionConfirmed, "", enums.ValueQuoteFinal)
 		} else {
-			helpers.AddHistoricalRecord(&records, "取消", "", "此報價為最終版")
+			helpers.AddHistoricalRecord(&records, enums.ActionCancelled, "", enums.ValueQuoteFinal)
 		}
 	}
 
@@ -283,42 +346,42 @@ func (m *manager) Update(trx *gorm.DB, input *quoteModel.Update) (int, any) {
 		opportunityBase, _ := m.OpportunityService.GetBySingle(&opportunityModel.Field{
 			OpportunityID: *input.OpportunityID,
 		})
-		helpers.AddHistoricalRecord(&records, "修改", "商機為", *opportunityBase.Name)
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldOpportunity, *opportunityBase.Name)
 
 		if opportunityBase.AccountID != quoteBase.AccountID {
 			accountBase, _ := m.AccountService.GetBySingle(&accountModel.Field{
 				AccountID: *opportunityBase.AccountID,
 			})
-			helpers.AddHistoricalRecord(&records, "修改", "帳戶為", *accountBase.Name)
+			helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldAccount, *accountBase.Name)
 		}
 	}
 
 	if input.ExpirationDate != nil && *input.ExpirationDate != *quoteBase.ExpirationDate {
-		helpers.AddHistoricalRecord(&records, "修改", "到期日期為", input.ExpirationDate.UTC().Format("2006-01-02T15:04:05.999999Z"))
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldExpirationDate, input.ExpirationDate.UTC().Format("2006-01-02T15:04:05.999999Z"))
 	}
 
 	if input.Description != nil {
 		if *input.Description != *quoteBase.Description {
 			if *input.Description == "" {
-				helpers.AddHistoricalRecord(&records, "清除", "描述", "")
+				helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldDescription, "")
 			} else {
-				helpers.AddHistoricalRecord(&records, "修改", "描述為", *input.Description)
+				helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldDescription, *input.Description)
 			}
 		}
 	} else if *quoteBase.Description != "" {
-		helpers.AddHistoricalRecord(&records, "清除", "描述", "")
+		helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldDescription, "")
 	}
 
 	if input.Tax != nil && *input.Tax != *quoteBase.Tax {
-		helpers.AddHistoricalRecord(&records, "修改", "稅額為", strconv.FormatFloat(*input.Tax, 'f', -1, 64))
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldTax, strconv.FormatFloat(*input.Tax, 'f', -1, 64))
 	} else if *quoteBase.Tax != 0 {
-		helpers.AddHistoricalRecord(&records, "清除", "稅額", "")
+		helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldTax, "")
 	}
 
 	if input.ShippingAndHandling != nil && *input.ShippingAndHandling != *quoteBase.ShippingAndHandling {
-		helpers.AddHistoricalRecord(&records, "修改", "運費及其他費用為", strconv.FormatFloat(*input.ShippingAndHandling, 'f', -1, 64))
+		helpers.AddHistoricalRecord(&records, enums.ActionUpdated, enums.FieldShippingAndHandling, strconv.FormatFloat(*input.ShippingAndHandling, 'f', -1, 64))
 	} else if *quoteBase.ShippingAndHandling != 0 {
-		helpers.AddHistoricalRecord(&records, "清除", "運費及其他費用", "")
+		helpers.AddHistoricalRecord(&records, enums.ActionCleared, enums.FieldShippingAndHandling, "")
 	}
 
 	for _, record := range records {
@@ -336,6 +399,5 @@ func (m *manager) Update(trx *gorm.DB, input *quoteModel.Update) (int, any) {
 		}
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, quoteBase.QuoteID)
 }
@@ -41,8 +41,6 @@ func Init(db *gorm.DB) Manager {
 }
 
 func (m *manager) Create(trx *gorm.DB, input *quoteProductModel.CreateList) (int, any) {
-	defer trx.Rollback()
-
 	var output []*string
 	number := 0
 	for i, inputBody := range input.QuoteProducts {
@@ -86,7 +84,6 @@ func (m *manager) Create(trx *gorm.DB, input *quoteProductModel.CreateList) (int
 		number++
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, output)
 }
 
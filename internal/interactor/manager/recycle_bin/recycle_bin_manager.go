@@ -0,0 +1,155 @@
+package recycle_bin
+
+import (
+	"errors"
+	"strings"
+
+	"crm/internal/interactor/helpers"
+	policyModel "crm/internal/interactor/models/delete_policies"
+	"crm/internal/interactor/models/enums"
+	historicalRecordModel "crm/internal/interactor/models/historical_records"
+	recycleBinModel "crm/internal/interactor/models/recycle_bins"
+	"crm/internal/interactor/pkg/util"
+	historicalRecordService "crm/internal/interactor/service/historical_record"
+	recycleBinService "crm/internal/interactor/service/recycle_bin"
+
+	"gorm.io/gorm"
+
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+)
+
+type Manager interface {
+	GetByList(input *recycleBinModel.Fields) (int, any)
+	Restore(trx *gorm.DB, input *recycleBinModel.Records) (int, any)
+	Purge(trx *gorm.DB, input *recycleBinModel.Records) (int, any)
+	Preview(input *policyModel.Field) (int, any)
+}
+
+type manager struct {
+	RecycleBinService       recycleBinService.Service
+	HistoricalRecordService historicalRecordService.Service
+}
+
+func Init(db *gorm.DB) Manager {
+	return &manager{
+		RecycleBinService:       recycleBinService.Init(db),
+		HistoricalRecordService: historicalRecordService.Init(db),
+	}
+}
+
+func (m *manager) GetByList(input *recycleBinModel.Fields) (int, any) {
+	output := &recycleBinModel.List{}
+	output.Limit = input.Limit
+	output.Page = max(input.Page, 1)
+	input.Page = output.Page
+	quantity, recycleBinBase, err := m.RecycleBinService.GetByList(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	output.RecycleBins = recycleBinBase
+	output.Total.Total = quantity
+	output.Pages = util.Pagination(quantity, output.Limit)
+
+	return code.Successful, code.GetCodeMessage(code.Successful, output)
+}
+
+func (m *manager) Restore(trx *gorm.DB, input *recycleBinModel.Records) (int, any) {
+	if httpCode, codeMessage := m.check(trx, input); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
+
+	cascaded, err := m.RecycleBinService.WithTrx(trx).Restore(input)
+	if err != nil {
+		if errors.Is(err, recycleBinService.ErrParentDeleted) {
+			return code.Conflict, code.GetCodeMessage(code.Conflict, err.Error())
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return m.record(trx, input, cascaded, enums.ActionRestored)
+}
+
+func (m *manager) Purge(trx *gorm.DB, input *recycleBinModel.Records) (int, any) {
+	if httpCode, codeMessage := m.check(trx, input); httpCode != code.Successful {
+		return httpCode, codeMessage
+	}
+
+	cascaded, err := m.RecycleBinService.WithTrx(trx).Purge(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return m.record(trx, input, cascaded, enums.ActionPurged)
+}
+
+// check returns 404 unless every record of input is in the recycle bin.
+func (m *manager) check(trx *gorm.DB, input *recycleBinModel.Records) (int, any) {
+	records, err := m.RecycleBinService.WithTrx(trx).GetByRecords(input)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	deleted := map[string]bool{}
+	for _, record := range records {
+		deleted[record.ID] = true
+	}
+
+	var missing []string
+	for _, id := range input.IDs {
+		if !deleted[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) > 0 {
+		return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, "records "+strings.Join(missing, ", ")+" are not in the recycle bin")
+	}
+
+	return code.Successful, nil
+}
+
+// record adds a historical record of action to every record of input and to the records cascaded with them.
+func (m *manager) record(trx *gorm.DB, input *recycleBinModel.Records, cascaded map[string][]string, action string) (int, any) {
+	for _, id := range input.IDs {
+		_, err := m.HistoricalRecordService.WithTrx(trx).Create(&historicalRecordModel.Create{
+			SourceID:   id,
+			Action:     action,
+			SourceType: enums.SourceTypes[input.Entity],
+			ModifiedBy: input.ModifiedBy,
+		})
+		if err != nil {
+			log.Error(err)
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+	}
+
+	err := helpers.RecordCascaded(m.HistoricalRecordService.WithTrx(trx), cascaded, action, input.ModifiedBy)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, &recycleBinModel.Result{IDs: input.IDs})
+}
+
+// Preview returns what deleting the record of input would affect by the delete policies.
+func (m *manager) Preview(input *policyModel.Field) (int, any) {
+	preview, err := m.RecycleBinService.Preview(input)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return code.DoesNotExist, code.GetCodeMessage(code.DoesNotExist, err.Error())
+		}
+
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, preview)
+}
@@ -9,8 +9,11 @@ import (
 	roleModel "crm/internal/interactor/models/roles"
 	roleService "crm/internal/interactor/service/role"
 
+	recycleBinService "crm/internal/interactor/service/recycle_bin"
 	"gorm.io/gorm"
 
+	"crm/internal/interactor/helpers"
+	"crm/internal/interactor/pkg/cache"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
 )
@@ -19,23 +22,23 @@ type Manager interface {
 	Create(trx *gorm.DB, input *roleModel.Create) (int, any)
 	GetByList(input *roleModel.Fields) (int, any)
 	GetBySingle(input *roleModel.Field) (int, any)
-	Delete(input *roleModel.Update) (int, any)
+	Delete(trx *gorm.DB, input *roleModel.Update) (int, any)
 	Update(input *roleModel.Update) (int, any)
 }
 
 type manager struct {
-	RoleService roleService.Service
+	RoleService       roleService.Service
+	RecycleBinService recycleBinService.Service
 }
 
 func Init(db *gorm.DB) Manager {
 	return &manager{
-		RoleService: roleService.Init(db),
+		RoleService:       roleService.Init(db),
+		RecycleBinService: recycleBinService.Init(db),
 	}
 }
 
 func (m *manager) Create(trx *gorm.DB, input *roleModel.Create) (int, any) {
-	defer trx.Rollback()
-
 	// 判斷角色是否重複
 	quantity, _ := m.RoleService.GetByQuantity(&roleModel.Field{
 		Name:      util.PointerString(input.Name),
@@ -53,7 +56,6 @@ func (m *manager) Create(trx *gorm.DB, input *roleModel.Create) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	trx.Commit()
 	return code.Successful, code.GetCodeMessage(code.Successful, roleBase.RoleID)
 }
 
@@ -104,7 +106,7 @@ func (m *manager) GetBySingle(input *roleModel.Field) (int, any) {
 	return code.Successful, code.GetCodeMessage(code.Successful, output)
 }
 
-func (m *manager) Delete(input *roleModel.Update) (int, any) {
+func (m *manager) Delete(trx *gorm.DB, input *roleModel.Update) (int, any) {
 	_, err := m.RoleService.GetBySingle(&roleModel.Field{
 		RoleID: input.RoleID,
 	})
@@ -117,12 +119,25 @@ func (m *manager) Delete(input *roleModel.Update) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	err = m.RoleService.Delete(input)
+	// 依刪除規則刪除,有限制刪除的關聯資料時回傳受影響的資料
+	preview, _, err := m.RecycleBinService.WithTrx(trx).Delete("roles", input.RoleID, *input.UpdatedBy)
 	if err != nil {
+		if errors.Is(err, recycleBinService.ErrRestricted) {
+			return code.Conflict, code.GetCodeMessage(code.Conflict, preview)
+		}
+
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	// 交易提交後才清除快取,以免同時的查詢在提交前又快取舊資料
+	helpers.AfterCommit(trx, func() {
+		err := cache.Default().Delete(cache.Key(cache.Role, input.RoleID))
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
 	return code.Successful, code.GetCodeMessage(code.Successful, "Delete ok!")
 }
 
@@ -145,5 +160,11 @@ func (m *manager) Update(input *roleModel.Update) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	// 清除快取
+	err = cache.Default().Delete(cache.Key(cache.Role, input.RoleID))
+	if err != nil {
+		log.Error(err)
+	}
+
 	return code.Successful, code.GetCodeMessage(code.Successful, roleBase.RoleID)
 }
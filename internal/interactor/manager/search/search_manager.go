@@ -0,0 +1,129 @@
+package search
+
+import (
+	"encoding/json"
+	"html"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	searchModel "crm/internal/interactor/models/searches"
+	searchService "crm/internal/interactor/service/search"
+
+	"gorm.io/gorm"
+
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+)
+
+type Manager interface {
+	GetByList(input *searchModel.Field) (int, any)
+}
+
+type manager struct {
+	SearchService searchService.Service
+}
+
+func Init(db *gorm.DB) Manager {
+	return &manager{
+		SearchService: searchService.Init(db),
+	}
+}
+
+func (m *manager) GetByList(input *searchModel.Field) (int, any) {
+	output := &searchModel.List{Results: []*searchModel.Result{}}
+	types := strings.Split(input.Types, ",")
+	if strings.TrimSpace(input.Types) == "" {
+		types = make([]string, 0, len(searchModel.Types))
+		for name := range searchModel.Types {
+			types = append(types, name)
+		}
+
+		sort.Strings(types)
+	}
+
+	searched := make([]string, 0, len(types))
+	for _, name := range types {
+		name = strings.TrimSpace(name)
+		if _, ok := searchModel.Types[name]; !ok {
+			return code.BadRequest, code.GetCodeMessage(code.BadRequest, "unknown type "+name)
+		}
+
+		if slices.Contains(input.Visible, name) && !slices.Contains(searched, name) {
+			searched = append(searched, name)
+		}
+	}
+
+	if len(searched) == 0 {
+		return code.Successful, code.GetCodeMessage(code.Successful, output)
+	}
+
+	searchBase, err := m.SearchService.GetByList(input, searched)
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	pattern := highlighter(input.Q)
+	for _, base := range searchBase {
+		result := &searchModel.Result{
+			Type:       *base.Type,
+			ID:         *base.ID,
+			Title:      *base.Title,
+			Highlights: map[string]string{},
+		}
+		if base.Rank != nil {
+			result.Rank = *base.Rank
+		}
+
+		fields := map[string]*string{}
+		if base.Fields != nil {
+			if err = json.Unmarshal([]byte(*base.Fields), &fields); err != nil {
+				log.Error(err)
+				return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+			}
+		}
+
+		for name, value := range fields {
+			if value != nil && pattern.MatchString(*value) {
+				result.Highlights[name] = highlight(pattern, *value)
+			}
+		}
+
+		output.Results = append(output.Results, result)
+	}
+
+	return code.Successful, code.GetCodeMessage(code.Successful, output)
+}
+
+// highlighter matches the whole search string or any of its words, longest first.
+func highlighter(q string) *regexp.Regexp {
+	words := append([]string{strings.TrimSpace(q)}, strings.Fields(q)...)
+	sort.SliceStable(words, func(i, j int) bool {
+		return len(words[i]) > len(words[j])
+	})
+
+	quoted := make([]string, 0, len(words))
+	for _, word := range words {
+		if word != "" && !slices.Contains(quoted, regexp.QuoteMeta(word)) {
+			quoted = append(quoted, regexp.QuoteMeta(word))
+		}
+	}
+
+	return regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+}
+
+// highlight escapes value and wraps the matches of pattern in <em></em>.
+func highlight(pattern *regexp.Regexp, value string) string {
+	var builder strings.Builder
+	last := 0
+	for _, match := range pattern.FindAllStringIndex(value, -1) {
+		builder.WriteString(html.EscapeString(value[last:match[0]]))
+		builder.WriteString("<em>" + html.EscapeString(value[match[0]:match[1]]) + "</em>")
+		last = match[1]
+	}
+
+	builder.WriteString(html.EscapeString(value[last:]))
+	return builder.String()
+}
@@ -6,11 +6,16 @@ import (
 
 	"crm/internal/interactor/pkg/util"
 
+	"crm/internal/interactor/models/page"
 	userModel "crm/internal/interactor/models/users"
+	duplicateRuleService "crm/internal/interactor/service/duplicate_rule"
 	userService "crm/internal/interactor/service/user"
 
+	recycleBinService "crm/internal/interactor/service/recycle_bin"
 	"gorm.io/gorm"
 
+	"crm/internal/interactor/helpers"
+	"crm/internal/interactor/pkg/cache"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
 )
@@ -20,23 +25,25 @@ type Manager interface {
 	GetByList(input *userModel.Fields) (int, any)
 	GetByListNoPagination(input *userModel.Field) (int, any)
 	GetBySingle(input *userModel.Field) (int, any)
-	Delete(input *userModel.Update) (int, any)
+	Delete(trx *gorm.DB, input *userModel.Update) (int, any)
 	Update(input *userModel.Update) (int, any)
 }
 
 type manager struct {
-	UserService userService.Service
+	UserService          userService.Service
+	RecycleBinService    recycleBinService.Service
+	DuplicateRuleService duplicateRuleService.Service
 }
 
 func Init(db *gorm.DB) Manager {
 	return &manager{
-		UserService: userService.Init(db),
+		UserService:          userService.Init(db),
+		RecycleBinService:    recycleBinService.Init(db),
+		DuplicateRuleService: duplicateRuleService.Init(db),
 	}
 }
 
 func (m *manager) Create(trx *gorm.DB, input *userModel.Create) (int, any) {
-	defer trx.Rollback()
-
 	// 判斷使用者名稱是否重複
 	quantity, _ := m.UserService.GetByQuantity(&userModel.Field{
 		UserName:  util.PointerString(input.UserName),
@@ -54,7 +61,24 @@ func (m *manager) Create(trx *gorm.DB, input *userModel.Create) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	trx.Commit()
+	// 公司的第一個使用者,建立公司預設的重複比對規則
+	quantity, _, err = m.UserService.WithTrx(trx).GetByList(&userModel.Fields{
+		Field:      userModel.Field{CompanyID: util.PointerString(input.CompanyID)},
+		Pagination: page.Pagination{Page: 1, Limit: 1},
+	})
+	if err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	if quantity == 1 {
+		err = m.DuplicateRuleService.WithTrx(trx).Seed(input.CompanyID, *userBase.UserID)
+		if err != nil {
+			log.Error(err)
+			return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+		}
+	}
+
 	return code.Successful, code.GetCodeMessage(code.Successful, userBase.UserID)
 }
 
@@ -126,7 +150,7 @@ func (m *manager) GetBySingle(input *userModel.Field) (int, any) {
 	return code.Successful, code.GetCodeMessage(code.Successful, output)
 }
 
-func (m *manager) Delete(input *userModel.Update) (int, any) {
+func (m *manager) Delete(trx *gorm.DB, input *userModel.Update) (int, any) {
 	_, err := m.UserService.GetBySingle(&userModel.Field{
 		UserID: input.UserID,
 	})
@@ -139,12 +163,25 @@ func (m *manager) Delete(input *userModel.Update) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
-	err = m.UserService.Delete(input)
+	// 依刪除規則刪除,有限制刪除的關聯資料時回傳受影響的資料
+	preview, _, err := m.RecycleBinService.WithTrx(trx).Delete("users", input.UserID, *input.UpdatedBy)
 	if err != nil {
+		if errors.Is(err, recycleBinService.ErrRestricted) {
+			return code.Conflict, code.GetCodeMessage(code.Conflict, preview)
+		}
+
 		log.Error(err)
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	// 交易提交後才清除快取,以免同時的查詢在提交前又快取舊資料
+	helpers.AfterCommit(trx, func() {
+		err := cache.Default().Delete(cache.Key(cache.User, input.UserID))
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
 	return code.Successful, code.GetCodeMessage(code.Successful, "Delete ok!")
 }
 
@@ -167,5 +204,11 @@ func (m *manager) Update(input *userModel.Update) (int, any) {
 		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
 	}
 
+	// 清除快取
+	err = cache.Default().Delete(cache.Key(cache.User, input.UserID))
+	if err != nil {
+		log.Error(err)
+	}
+
 	return code.Successful, code.GetCodeMessage(code.Successful, userBase.UserID)
 }
@@ -2,11 +2,39 @@ package accounts
 
 import (
 	"crm/internal/interactor/models/account_contacts"
+	"crm/internal/interactor/models/bulk"
+	"crm/internal/interactor/models/exports"
+	"crm/internal/interactor/models/filter"
 	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/projection"
 	"crm/internal/interactor/models/section"
 	"crm/internal/interactor/models/sort"
 )
 
+// Relations are the relations of an account that can be expanded in a list.
+var Relations = projection.Relations{
+	"industry":        {Association: "Industries", Display: "name"},
+	"salesperson":     {Association: "Salespeople", Display: "name"},
+	"created_by_user": {Association: "CreatedByUsers", Display: "name"},
+	"updated_by_user": {Association: "UpdatedByUsers", Display: "name"},
+	"contacts":        {Association: "AccountContacts"},
+}
+
+// Columns are the columns of an exported account list, relations are exported by their display names.
+var Columns = exports.Columns{
+	{Key: "account_id", Chinese: "帳戶ID", English: "Account ID"},
+	{Key: "name", Chinese: "帳戶名稱", English: "Name"},
+	{Key: "phone_number", Chinese: "帳戶電話", English: "Phone Number"},
+	{Key: "type", Chinese: "帳戶類型", English: "Type"},
+	{Key: "industry_name", Chinese: "行業", English: "Industry"},
+	{Key: "parent_account_name", Chinese: "父系帳戶", English: "Parent Account"},
+	{Key: "salesperson_name", Chinese: "業務員", English: "Salesperson"},
+	{Key: "created_by", Chinese: "創建者", English: "Created By"},
+	{Key: "updated_by", Chinese: "更新者", English: "Updated By"},
+	{Key: "created_at", Chinese: "創建時間", English: "Created At"},
+	{Key: "updated_at", Chinese: "更新時間", English: "Updated At"},
+}
+
 // Create struct is used to create achieves
 type Create struct {
 	// 帳戶名稱
@@ -19,6 +47,10 @@ type Create struct {
 	IndustryID string `json:"industry_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4"`
 	// 父系帳戶ID
 	ParentAccountID string `json:"parent_account_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4"`
+	// 業務員ID,不帶入時為創建者
+	SalespersonID string `json:"salesperson_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4"`
+	// 自訂欄位,以欄位鍵值為鍵,未帶入的欄位使用預設值
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 	// 創建者
 	CreatedBy string `json:"created_by,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
 }
@@ -49,8 +81,16 @@ type Fields struct {
 	Filter `json:"filter"`
 	// 分頁搜尋結構檔
 	page.Pagination
+	// 游標分頁結構檔
+	page.Cursor
+	// 回傳欄位與展開關聯
+	projection.Projection
 	// 排序欄位
 	sort.Sort `json:"sort"`
+	// 公司ID,用於自訂欄位的搜尋及排序
+	CompanyID string `json:"-" swaggerignore:"true"`
+	// 自訂欄位的搜尋及排序欄位
+	CustomColumns filter.Fields `json:"-" swaggerignore:"true"`
 }
 
 // FieldsNoPagination is the searched structure file (including filter)
@@ -71,6 +111,8 @@ type Filter struct {
 	FilterType []string `json:"type,omitempty"`
 	// 業務員名稱
 	FilterSalespersonName string `json:"salesperson_name,omitempty"`
+	// 組合搜尋條件,可使用 and/or 群組,自訂欄位為 custom_fields.<key>
+	Where *filter.Expression `json:"where,omitempty"`
 }
 
 // FilterNoPagination struct is used to store the search field no pagination
@@ -105,6 +147,8 @@ type List struct {
 		SalespersonID string `json:"salesperson_id,omitempty"`
 		// 業務員名稱
 		SalespersonName string `json:"salesperson_name,omitempty"`
+		// 自訂欄位
+		CustomFields map[string]any `json:"custom_fields,omitempty"`
 		// 創建者
 		CreatedBy string `json:"created_by,omitempty"`
 		// 更新者
@@ -114,6 +158,8 @@ type List struct {
 	} `json:"accounts"`
 	// 分頁返回結構檔
 	page.Total
+	// 游標返回結構檔
+	page.Cursors
 }
 
 // ListNoPagination is multiple return structure files without pagination
@@ -149,6 +195,8 @@ type Single struct {
 	SalespersonID string `json:"salesperson_id,omitempty"`
 	// 業務員名稱
 	SalespersonName string `json:"salesperson_name,omitempty"`
+	// 自訂欄位
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 	// 創建者
 	CreatedBy string `json:"created_by,omitempty"`
 	// 更新者
@@ -179,6 +227,8 @@ type SingleContacts struct {
 	SalespersonID string `json:"salesperson_id,omitempty"`
 	// 業務員名稱
 	SalespersonName string `json:"salesperson_name,omitempty"`
+	// 自訂欄位
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 	// 創建者
 	CreatedBy string `json:"created_by,omitempty"`
 	// 更新者
@@ -193,6 +243,8 @@ type SingleContacts struct {
 type Update struct {
 	// 帳戶ID
 	AccountID string `json:"account_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4" swaggerignore:"true"`
+	// 版本(If-Match)
+	IfMatch string `json:"-" swaggerignore:"true"`
 	// 帳戶名稱
 	Name *string `json:"name,omitempty"`
 	// 帳戶電話
@@ -205,6 +257,30 @@ type Update struct {
 	ParentAccountID *string `json:"parent_account_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4"`
 	// 業務員ID
 	SalespersonID *string `json:"salesperson_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4"`
+	// 自訂欄位,以欄位鍵值為鍵,僅更新帶入的欄位,帶入 null 時清除
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 	// 更新者
 	UpdatedBy *string `json:"updated_by,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
 }
+
+// Bulk struct is used to create, update and delete accounts at once
+type Bulk struct {
+	bulk.Mode
+	// 新增的帳戶(最多500筆)
+	Create []*Create `json:"create,omitempty" binding:"max=500"`
+	// 更新的帳戶,須帶入account_id(最多500筆)
+	Update []*BulkUpdate `json:"update,omitempty" binding:"max=500"`
+	// 刪除的帳戶ID(最多500筆)
+	Delete []string `json:"delete,omitempty" binding:"max=500"`
+	// 操作者
+	UserID string `json:"-" swaggerignore:"true"`
+	// 公司是否要求 If-Match,要求時更新項目須帶入 if_match
+	IfMatchRequired bool `json:"-" swaggerignore:"true"`
+}
+
+// BulkUpdate is an update of a bulk request with the version of the account it expects
+type BulkUpdate struct {
+	Update
+	// 版本(ETag),與單筆更新的 If-Match 相同
+	IfMatch string `json:"if_match,omitempty"`
+}
@@ -0,0 +1,61 @@
+package api_keys
+
+import (
+	"time"
+)
+
+// Create struct is used to issue an API key
+type Create struct {
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+	// 名稱
+	Name string `json:"name,omitempty" binding:"required,max=100" validate:"required,max=100"`
+	// 使用者ID,以此使用者及其角色呼叫,不帶入時為創建者
+	UserID string `json:"user_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4"`
+	// 到期時間,不帶入時不會到期
+	ExpiredAt *time.Time `json:"expired_at,omitempty"`
+	// 創建者
+	CreatedBy string `json:"created_by,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+}
+
+// Field is structure file for search
+type Field struct {
+	// API key ID
+	APIKeyID string `json:"api_key_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4" swaggerignore:"true"`
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" swaggerignore:"true"`
+	// API key
+	Key string `json:"-" swaggerignore:"true"`
+}
+
+// List is multiple return structure files
+type List struct {
+	// 多筆
+	APIKeys []*Single `json:"api_keys"`
+}
+
+// Single return structure file
+type Single struct {
+	// API key ID
+	APIKeyID string `json:"api_key_id,omitempty"`
+	// 名稱
+	Name string `json:"name,omitempty"`
+	// 使用者ID
+	UserID string `json:"user_id,omitempty"`
+	// 前綴(供辨識)
+	Prefix string `json:"prefix,omitempty"`
+	// 到期時間
+	ExpiredAt *time.Time `json:"expired_at,omitempty"`
+	// 創建時間
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// 創建者
+	CreatedBy string `json:"created_by,omitempty"`
+}
+
+// Issued is returned once when an API key is created, the key cannot be read again
+type Issued struct {
+	// API key ID
+	APIKeyID string `json:"api_key_id,omitempty"`
+	// API key(僅於新增時回傳)
+	Key string `json:"key,omitempty"`
+}
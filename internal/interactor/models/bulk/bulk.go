@@ -0,0 +1,50 @@
+package bulk
+
+// Modes of a bulk request.
+const (
+	// AllOrNothing keeps the operations only when every one of them succeeds
+	AllOrNothing = "all_or_nothing"
+	// BestEffort keeps the operations that succeed and rolls back the failed ones
+	BestEffort = "best_effort"
+)
+
+// Actions of a bulk operation.
+const (
+	Create = "create"
+	Update = "update"
+	Delete = "delete"
+)
+
+// Mode struct is embedded by the bulk request of each entity
+type Mode struct {
+	// 模式 all_or_nothing(任一失敗則全部不執行,預設) 或 best_effort(保留成功的項目)
+	Mode string `json:"mode,omitempty" binding:"omitempty,oneof=all_or_nothing best_effort"`
+}
+
+// List is the return structure of a bulk request
+type List struct {
+	// 模式
+	Mode string `json:"mode"`
+	// 成功筆數
+	Succeeded int `json:"succeeded"`
+	// 失敗筆數
+	Failed int `json:"failed"`
+	// 各項目結果,依新增、更新、刪除及項目順序排列
+	Results []*Result `json:"results"`
+}
+
+// Result is the result of an operation of a bulk request
+type Result struct {
+	// 動作(create, update, delete)
+	Action string `json:"action"`
+	// 項目在該動作陣列中的序號
+	Index int `json:"index"`
+	// 資料ID
+	ID string `json:"id,omitempty"`
+	// 狀態碼
+	Status int `json:"status"`
+	// 錯誤內容
+	Error any `json:"error,omitempty"`
+	// 警告(如疑似重複的資料)
+	Warnings []any `json:"warnings,omitempty"`
+}
@@ -1,6 +1,8 @@
 package campaigns
 
 import (
+	"crm/internal/interactor/models/exports"
+	"crm/internal/interactor/models/filter"
 	"time"
 
 	"crm/internal/interactor/models/sort"
@@ -8,9 +10,41 @@ import (
 	"crm/internal/interactor/models/opportunity_campaigns"
 
 	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/projection"
 	"crm/internal/interactor/models/section"
 )
 
+// Relations are the relations of a campaign that can be expanded in a list.
+var Relations = projection.Relations{
+	"salesperson":     {Association: "Salespeople", Display: "name"},
+	"created_by_user": {Association: "CreatedByUsers", Display: "name"},
+	"updated_by_user": {Association: "UpdatedByUsers", Display: "name"},
+	"opportunities":   {Association: "OpportunityCampaigns"},
+}
+
+// Columns are the columns of an exported campaign list, relations are exported by their display names.
+var Columns = exports.Columns{
+	{Key: "campaign_id", Chinese: "行銷活動ID", English: "Campaign ID"},
+	{Key: "name", Chinese: "行銷活動名稱", English: "Name"},
+	{Key: "status", Chinese: "行銷活動狀態", English: "Status"},
+	{Key: "is_enable", Chinese: "是否啟用", English: "Enabled"},
+	{Key: "type", Chinese: "行銷活動類型", English: "Type"},
+	{Key: "parent_campaign_name", Chinese: "父系行銷活動", English: "Parent Campaign"},
+	{Key: "start_date", Chinese: "開始日期", English: "Start Date"},
+	{Key: "end_date", Chinese: "結束日期", English: "End Date"},
+	{Key: "description", Chinese: "行銷活動描述", English: "Description"},
+	{Key: "sent", Chinese: "已傳送數量", English: "Sent"},
+	{Key: "budget_cost", Chinese: "預算成本", English: "Budgeted Cost"},
+	{Key: "expected_responses", Chinese: "預期回應(%)", English: "Expected Response (%)"},
+	{Key: "actual_cost", Chinese: "實際成本", English: "Actual Cost"},
+	{Key: "expected_income", Chinese: "預期收入", English: "Expected Revenue"},
+	{Key: "salesperson_name", Chinese: "業務員", English: "Salesperson"},
+	{Key: "created_by", Chinese: "創建者", English: "Created By"},
+	{Key: "updated_by", Chinese: "更新者", English: "Updated By"},
+	{Key: "created_at", Chinese: "創建時間", English: "Created At"},
+	{Key: "updated_at", Chinese: "更新時間", English: "Updated At"},
+}
+
 // Create struct is used to create achieves
 type Create struct {
 	// 行銷活動名稱
@@ -85,6 +119,10 @@ type Fields struct {
 	Filter `json:"filter"`
 	// 分頁搜尋結構檔
 	page.Pagination
+	// 游標分頁結構檔
+	page.Cursor
+	// 回傳欄位與展開關聯
+	projection.Projection
 	// 排序欄位
 	sort.Sort `json:"sort"`
 }
@@ -101,6 +139,8 @@ type Filter struct {
 	FilterStatus string `json:"status,omitempty"`
 	// 業務員名稱
 	FilterSalespersonName string `json:"salesperson_name,omitempty"`
+	// 組合搜尋條件,可使用 and/or 群組
+	Where *filter.Expression `json:"where,omitempty"`
 }
 
 // List is multiple return structure files
@@ -150,6 +190,8 @@ type List struct {
 	} `json:"campaigns"`
 	// 分頁返回結構檔
 	page.Total
+	// 游標返回結構檔
+	page.Cursors
 }
 
 // ListNoPagination is multiple return structure files without pagination
@@ -0,0 +1,31 @@
+package company_settings
+
+import (
+	"time"
+)
+
+// Field is structure file for search
+type Field struct {
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+}
+
+// Single return structure file
+type Single struct {
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty"`
+	// 更新資料時是否必須帶入 If-Match
+	IfMatchRequired bool `json:"if_match_required"`
+	// 更新時間
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// Update struct is used to update the settings of a company
+type Update struct {
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+	// 更新資料時是否必須帶入 If-Match
+	IfMatchRequired *bool `json:"if_match_required,omitempty"`
+	// 更新者
+	UpdatedBy *string `json:"updated_by,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+}
@@ -1,11 +1,42 @@
 package contacts
 
 import (
+	"crm/internal/interactor/models/bulk"
+	"crm/internal/interactor/models/exports"
+	"crm/internal/interactor/models/filter"
 	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/projection"
 	"crm/internal/interactor/models/section"
 	"crm/internal/interactor/models/sort"
 )
 
+// Relations are the relations of a contact that can be expanded in a list.
+var Relations = projection.Relations{
+	"account":         {Association: "Accounts", Display: "name"},
+	"salesperson":     {Association: "Salespeople", Display: "name"},
+	"created_by_user": {Association: "CreatedByUsers", Display: "name"},
+	"updated_by_user": {Association: "UpdatedByUsers", Display: "name"},
+}
+
+// Columns are the columns of an exported contact list, relations are exported by their display names.
+var Columns = exports.Columns{
+	{Key: "contact_id", Chinese: "聯絡人ID", English: "Contact ID"},
+	{Key: "name", Chinese: "聯絡人名稱", English: "Name"},
+	{Key: "title", Chinese: "聯絡人職稱", English: "Title"},
+	{Key: "phone_number", Chinese: "聯絡人電話", English: "Phone Number"},
+	{Key: "cell_phone", Chinese: "聯絡人行動電話", English: "Mobile"},
+	{Key: "email", Chinese: "聯絡人電子郵件", English: "Email"},
+	{Key: "salutation", Chinese: "聯絡人稱謂", English: "Salutation"},
+	{Key: "department", Chinese: "聯絡人部門", English: "Department"},
+	{Key: "supervisor_name", Chinese: "直屬上司", English: "Reports To"},
+	{Key: "account_name", Chinese: "帳戶", English: "Account"},
+	{Key: "salesperson_name", Chinese: "業務員", English: "Salesperson"},
+	{Key: "created_by", Chinese: "創建者", English: "Created By"},
+	{Key: "updated_by", Chinese: "更新者", English: "Updated By"},
+	{Key: "created_at", Chinese: "創建時間", English: "Created At"},
+	{Key: "updated_at", Chinese: "更新時間", English: "Updated At"},
+}
+
 // Create struct is used to create achieves
 type Create struct {
 	// 聯絡人名稱
@@ -26,6 +57,10 @@ type Create struct {
 	SupervisorID string `json:"supervisor_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4"`
 	// 帳戶ID
 	AccountID string `json:"account_id,omitempty" binding:"required,uuid4" validate:"required,uuid4"`
+	// 業務員ID,不帶入時為創建者
+	SalespersonID string `json:"salesperson_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4"`
+	// 自訂欄位,以欄位鍵值為鍵,未帶入的欄位使用預設值
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 	// 創建者
 	CreatedBy string `json:"created_by,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
 }
@@ -64,8 +99,16 @@ type Fields struct {
 	Filter `json:"filter"`
 	// 分頁搜尋結構檔
 	page.Pagination
+	// 游標分頁結構檔
+	page.Cursor
+	// 回傳欄位與展開關聯
+	projection.Projection
 	// 排序欄位
 	sort.Sort `json:"sort"`
+	// 公司ID,用於自訂欄位的搜尋及排序
+	CompanyID string `json:"-" swaggerignore:"true"`
+	// 自訂欄位的搜尋及排序欄位
+	CustomColumns filter.Fields `json:"-" swaggerignore:"true"`
 }
 
 // Filter struct is used to store the search field
@@ -80,6 +123,8 @@ type Filter struct {
 	FilterEmail string `json:"email,omitempty"`
 	// 業務員名稱
 	FilterSalespersonName string `json:"salesperson_name,omitempty"`
+	// 組合搜尋條件,可使用 and/or 群組,自訂欄位為 custom_fields.<key>
+	Where *filter.Expression `json:"where,omitempty"`
 }
 
 // List is multiple return structure files
@@ -114,6 +159,8 @@ type List struct {
 		SalespersonID string `json:"salesperson_id,omitempty"`
 		// 業務員名稱
 		SalespersonName string `json:"salesperson_name,omitempty"`
+		// 自訂欄位
+		CustomFields map[string]any `json:"custom_fields,omitempty"`
 		// 創建者
 		CreatedBy string `json:"created_by,omitempty"`
 		// 更新者
@@ -123,6 +170,8 @@ type List struct {
 	} `json:"contacts"`
 	// 分頁返回結構檔
 	page.Total
+	// 游標返回結構檔
+	page.Cursors
 }
 
 // ListNoPagination is multiple return structure files without pagination
@@ -166,6 +215,8 @@ type Single struct {
 	SalespersonID string `json:"salesperson_id,omitempty"`
 	// 業務員名稱
 	SalespersonName string `json:"salesperson_name,omitempty"`
+	// 自訂欄位
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 	// 創建者
 	CreatedBy string `json:"created_by,omitempty"`
 	// 更新者
@@ -178,6 +229,8 @@ type Single struct {
 type Update struct {
 	// 聯絡人ID
 	ContactID string `json:"contact_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4" swaggerignore:"true"`
+	// 版本(If-Match)
+	IfMatch string `json:"-" swaggerignore:"true"`
 	// 聯絡人名稱
 	Name *string `json:"name,omitempty"`
 	// 聯絡人職稱
@@ -198,6 +251,30 @@ type Update struct {
 	AccountID *string `json:"account_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4"`
 	// 業務員ID
 	SalespersonID *string `json:"salesperson_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4"`
+	// 自訂欄位,以欄位鍵值為鍵,僅更新帶入的欄位,帶入 null 時清除
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 	// 更新者
 	UpdatedBy *string `json:"updated_by,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
 }
+
+// Bulk struct is used to create, update and delete contacts at once
+type Bulk struct {
+	bulk.Mode
+	// 新增的聯絡人(最多500筆)
+	Create []*Create `json:"create,omitempty" binding:"max=500"`
+	// 更新的聯絡人,須帶入contact_id(最多500筆)
+	Update []*BulkUpdate `json:"update,omitempty" binding:"max=500"`
+	// 刪除的聯絡人ID(最多500筆)
+	Delete []string `json:"delete,omitempty" binding:"max=500"`
+	// 操作者
+	UserID string `json:"-" swaggerignore:"true"`
+	// 公司是否要求 If-Match,要求時更新項目須帶入 if_match
+	IfMatchRequired bool `json:"-" swaggerignore:"true"`
+}
+
+// BulkUpdate is an update of a bulk request with the version of the contact it expects
+type BulkUpdate struct {
+	Update
+	// 版本(ETag),與單筆更新的 If-Match 相同
+	IfMatch string `json:"if_match,omitempty"`
+}
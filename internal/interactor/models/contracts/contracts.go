@@ -1,18 +1,48 @@
 package contracts
 
 import (
+	"crm/internal/interactor/models/exports"
+	"crm/internal/interactor/models/filter"
 	"time"
 
 	"crm/internal/interactor/models/sort"
 
 	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/projection"
 	"crm/internal/interactor/models/section"
 )
 
+// Relations are the relations of a contract that can be expanded in a list.
+var Relations = projection.Relations{
+	"opportunity":     {Association: "Opportunities", Display: "name"},
+	"account":         {Association: "Accounts", Display: "name"},
+	"salesperson":     {Association: "Salespeople", Display: "name"},
+	"created_by_user": {Association: "CreatedByUsers", Display: "name"},
+	"updated_by_user": {Association: "UpdatedByUsers", Display: "name"},
+}
+
+// Columns are the columns of an exported contract list, relations are exported by their display names.
+var Columns = exports.Columns{
+	{Key: "contract_id", Chinese: "契約ID", English: "Contract ID"},
+	{Key: "code", Chinese: "契約號碼", English: "Contract Number"},
+	{Key: "status", Chinese: "契約狀態", English: "Status"},
+	{Key: "opportunity_name", Chinese: "商機", English: "Opportunity"},
+	{Key: "account_name", Chinese: "帳戶", English: "Account"},
+	{Key: "start_date", Chinese: "契約開始日期", English: "Start Date"},
+	{Key: "term", Chinese: "契約有效期限(月)", English: "Term (Months)"},
+	{Key: "end_date", Chinese: "契約結束日期", English: "End Date"},
+	{Key: "description", Chinese: "契約描述", English: "Description"},
+	{Key: "salesperson_name", Chinese: "業務員", English: "Salesperson"},
+	{Key: "created_by", Chinese: "創建者", English: "Created By"},
+	{Key: "updated_by", Chinese: "更新者", English: "Updated By"},
+	{Key: "created_at", Chinese: "創建時間", English: "Created At"},
+	{Key: "updated_at", Chinese: "更新時間", English: "Updated At"},
+}
+
 // Create struct is used to create achieves
 type Create struct {
 	// 契約狀態
-	Status string `json:"status,omitempty" binding:"required" validate:"required"`
+	Status string `json:"status,omitempty" binding:"required,enum=contract.status" validate:"required" example:"contract.status.draft"`
 	// 契約開始日期
 	StartDate time.Time `json:"start_date,omitempty" binding:"required" validate:"required"`
 	// 契約有效期限(月)
@@ -61,6 +91,10 @@ type Fields struct {
 	Filter `json:"filter"`
 	// 分頁搜尋結構檔
 	page.Pagination
+	// 游標分頁結構檔
+	page.Cursor
+	// 回傳欄位與展開關聯
+	projection.Projection
 	// 排序欄位
 	sort.Sort `json:"sort"`
 }
@@ -81,6 +115,8 @@ type Filter struct {
 	FilterAccountName string `json:"account_name,omitempty"`
 	// 契約狀態
 	FilterStatus []string `json:"status,omitempty"`
+	// 組合搜尋條件,可使用 and/or 群組
+	Where *filter.Expression `json:"where,omitempty"`
 }
 
 // FilterNoPagination struct is used to store the search field no pagination
@@ -130,6 +166,8 @@ type List struct {
 	} `json:"contracts"`
 	// 分頁返回結構檔
 	page.Total
+	// 游標返回結構檔
+	page.Cursors
 }
 
 // ListNoPagination is multiple return structure files without pagination
@@ -185,8 +223,10 @@ type Single struct {
 type Update struct {
 	// 契約ID
 	ContractID string `json:"contract_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4" swaggerignore:"true"`
+	// 版本(If-Match)
+	IfMatch string `json:"-" swaggerignore:"true"`
 	// 契約狀態
-	Status *string `json:"status,omitempty"`
+	Status *string `json:"status,omitempty" binding:"omitempty,enum=contract.status"`
 	// 契約開始日期
 	StartDate *time.Time `json:"start_date,omitempty"`
 	// 契約有效期限(月)
@@ -0,0 +1,173 @@
+package custom_fields
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Entities are the data types that can have custom fields, their values are stored in the custom_fields column.
+var Entities = []string{"accounts", "contacts", "leads", "opportunities"}
+
+// Types of a custom field.
+const (
+	Text     = "text"
+	Number   = "number"
+	Boolean  = "boolean"
+	Date     = "date"
+	Picklist = "picklist"
+)
+
+// Prefix names a custom field in filters, sort, exports and import mappings, as in custom_fields.tax_id.
+const Prefix = "custom_fields."
+
+// 文字欄位長度上限
+const maxText = 1000
+
+// keyPattern is the form of a key, it is used in SQL as it is and must never hold quotes.
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// ValidKey reports whether key can name a custom field.
+func ValidKey(key string) bool {
+	return keyPattern.MatchString(key)
+}
+
+// Check returns value in the form stored for a custom field of typ, or an error telling what is expected.
+// Numbers are JSON numbers, dates are strings in the format 2006-01-02 and picklist values must be one of options.
+func Check(typ string, options []string, value any) (any, error) {
+	switch typ {
+	case Text:
+		if text, ok := value.(string); ok && utf8.RuneCountInString(text) <= maxText {
+			return text, nil
+		}
+
+		return nil, errors.New("must be a text of at most 1000 characters.")
+	case Number:
+		switch number := value.(type) {
+		case float64:
+			return number, nil
+		case int:
+			return float64(number), nil
+		case json.Number:
+			if parsed, err := number.Float64(); err == nil {
+				return parsed, nil
+			}
+		}
+
+		return nil, errors.New("must be a number.")
+	case Boolean:
+		if boolean, ok := value.(bool); ok {
+			return boolean, nil
+		}
+
+		return nil, errors.New("must be a boolean.")
+	case Date:
+		if date, ok := value.(string); ok {
+			if _, err := time.Parse(time.DateOnly, date); err == nil {
+				return date, nil
+			}
+		}
+
+		return nil, errors.New("must be a date in the format 2006-01-02.")
+	case Picklist:
+		if option, ok := value.(string); ok && slices.Contains(options, option) {
+			return option, nil
+		}
+
+		return nil, errors.New("must be one of " + strings.Join(options, ", ") + ".")
+	}
+
+	return nil, errors.New("has an unknown type " + typ + ".")
+}
+
+// Create struct is used to create a custom field
+type Create struct {
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+	// 資料類型(accounts, contacts, leads, opportunities)
+	Entity string `json:"entity,omitempty" binding:"required,oneof=accounts contacts leads opportunities" validate:"required,oneof=accounts contacts leads opportunities"`
+	// 欄位鍵值,小寫英文字母開頭,僅可使用小寫英文字母、數字及底線,建立後不可修改
+	Key string `json:"key,omitempty" binding:"required,max=50" validate:"required,max=50" example:"tax_id"`
+	// 顯示名稱
+	Label string `json:"label,omitempty" binding:"required,max=100" validate:"required,max=100" example:"統一編號"`
+	// 欄位型別(text, number, boolean, date, picklist),建立後不可修改
+	Type string `json:"type,omitempty" binding:"required,oneof=text number boolean date picklist" validate:"required,oneof=text number boolean date picklist"`
+	// 是否必填,新增資料未帶入且無預設值時回傳錯誤
+	Required bool `json:"required,omitempty"`
+	// 選項,picklist 型別必填
+	Options []string `json:"options,omitempty" binding:"omitempty,dive,required,max=100" validate:"omitempty,dive,required,max=100"`
+	// 預設值,新增資料未帶入時使用
+	DefaultValue any `json:"default_value,omitempty" swaggertype:"string" example:"12345678"`
+	// 排序,由小到大
+	Sequence int `json:"sequence,omitempty"`
+	// 創建者
+	CreatedBy string `json:"created_by,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+}
+
+// Field is structure file for search
+type Field struct {
+	// 自訂欄位ID
+	CustomFieldID string `json:"custom_field_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4" swaggerignore:"true"`
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" swaggerignore:"true"`
+	// 資料類型(accounts, contacts, leads, opportunities)
+	Entity *string `json:"entity,omitempty" form:"entity" binding:"omitempty,oneof=accounts contacts leads opportunities" validate:"omitempty,oneof=accounts contacts leads opportunities"`
+	// 欄位鍵值
+	Key *string `json:"key,omitempty" form:"key"`
+}
+
+// List is multiple return structure files
+type List struct {
+	// 多筆
+	CustomFields []*Single `json:"custom_fields"`
+}
+
+// Single return structure file
+type Single struct {
+	// 自訂欄位ID
+	CustomFieldID string `json:"custom_field_id,omitempty"`
+	// 資料類型
+	Entity string `json:"entity,omitempty"`
+	// 欄位鍵值
+	Key string `json:"key,omitempty"`
+	// 顯示名稱
+	Label string `json:"label,omitempty"`
+	// 欄位型別
+	Type string `json:"type,omitempty"`
+	// 是否必填
+	Required bool `json:"required"`
+	// 選項
+	Options []string `json:"options,omitempty"`
+	// 預設值
+	DefaultValue any `json:"default_value,omitempty" swaggertype:"string"`
+	// 排序
+	Sequence int `json:"sequence"`
+	// 創建時間
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// 更新時間
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// Update struct is used to update a custom field
+type Update struct {
+	// 自訂欄位ID
+	CustomFieldID string `json:"custom_field_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4" swaggerignore:"true"`
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" swaggerignore:"true"`
+	// 顯示名稱
+	Label *string `json:"label,omitempty" binding:"omitempty,max=100" validate:"omitempty,max=100"`
+	// 是否必填
+	Required *bool `json:"required,omitempty"`
+	// 選項,取代全部選項,已使用的選項保留於資料中
+	Options *[]string `json:"options,omitempty" binding:"omitempty,dive,required,max=100" validate:"omitempty,dive,required,max=100"`
+	// 預設值,帶入 null 時移除預設值
+	DefaultValue json.RawMessage `json:"default_value,omitempty" swaggertype:"string"`
+	// 排序
+	Sequence *int `json:"sequence,omitempty"`
+	// 更新者
+	UpdatedBy *string `json:"updated_by,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+}
@@ -0,0 +1,126 @@
+package delete_policies
+
+// Policies applied to the records referencing a deleted record.
+const (
+	// Restrict 有關聯資料時不可刪除
+	Restrict = "restrict"
+	// Cascade 關聯資料一併刪除
+	Cascade = "cascade"
+	// Nullify 清除關聯資料的關聯欄位
+	Nullify = "nullify"
+)
+
+// Reference is a column referencing the records of an entity.
+type Reference struct {
+	// 關聯資料類型,關聯表為空
+	Entity string
+	// 資料表
+	Table string
+	// 主鍵
+	PrimaryKey string
+	// 關聯欄位
+	Column string
+	// 刪除規則
+	Policy string
+}
+
+// Policies are the references of each entity, with the policy applied to them when a record of the entity is deleted.
+// A cascaded record applies the policies of its own entity in turn.
+// Audit columns such as created_by are not references, a soft deleted user still names the records it created.
+var Policies = map[string][]Reference{
+	"accounts": {
+		{Entity: "accounts", Table: "accounts", PrimaryKey: "account_id", Column: "parent_account_id", Policy: Nullify},
+		{Entity: "contacts", Table: "contacts", PrimaryKey: "contact_id", Column: "account_id", Policy: Cascade},
+		{Entity: "leads", Table: "leads", PrimaryKey: "lead_id", Column: "account_id", Policy: Cascade},
+		{Entity: "opportunities", Table: "opportunities", PrimaryKey: "opportunity_id", Column: "account_id", Policy: Cascade},
+		{Entity: "quotes", Table: "quotes", PrimaryKey: "quote_id", Column: "account_id", Policy: Cascade},
+		{Entity: "contracts", Table: "contracts", PrimaryKey: "contract_id", Column: "account_id", Policy: Restrict},
+		{Entity: "orders", Table: "orders", PrimaryKey: "order_id", Column: "account_id", Policy: Restrict},
+		{Entity: "events", Table: "events", PrimaryKey: "event_id", Column: "account_id", Policy: Nullify},
+		{Table: "account_contacts", PrimaryKey: "account_contact_id", Column: "account_id", Policy: Cascade},
+	},
+	"contacts": {
+		{Entity: "contacts", Table: "contacts", PrimaryKey: "contact_id", Column: "supervisor_id", Policy: Nullify},
+		{Table: "account_contacts", PrimaryKey: "account_contact_id", Column: "contact_id", Policy: Cascade},
+		{Table: "event_contacts", PrimaryKey: "event_contact_id", Column: "contact_id", Policy: Cascade},
+	},
+	"leads": {
+		{Entity: "opportunities", Table: "opportunities", PrimaryKey: "opportunity_id", Column: "lead_id", Policy: Nullify},
+	},
+	"opportunities": {
+		{Entity: "quotes", Table: "quotes", PrimaryKey: "quote_id", Column: "opportunity_id", Policy: Cascade},
+		{Entity: "contracts", Table: "contracts", PrimaryKey: "contract_id", Column: "opportunity_id", Policy: Restrict},
+		{Table: "opportunity_campaigns", PrimaryKey: "opportunity_campaign_id", Column: "opportunity_id", Policy: Cascade},
+	},
+	"contracts": {
+		{Entity: "orders", Table: "orders", PrimaryKey: "order_id", Column: "contract_id", Policy: Restrict},
+	},
+	"orders": {
+		{Table: "order_products", PrimaryKey: "order_product_id", Column: "order_id", Policy: Cascade},
+	},
+	"quotes": {
+		{Table: "quote_products", PrimaryKey: "quote_product_id", Column: "quote_id", Policy: Cascade},
+	},
+	"products": {
+		{Table: "order_products", PrimaryKey: "order_product_id", Column: "product_id", Policy: Restrict},
+		{Table: "quote_products", PrimaryKey: "quote_product_id", Column: "product_id", Policy: Restrict},
+	},
+	"campaigns": {
+		{Entity: "campaigns", Table: "campaigns", PrimaryKey: "campaign_id", Column: "parent_campaign_id", Policy: Nullify},
+		{Table: "opportunity_campaigns", PrimaryKey: "opportunity_campaign_id", Column: "campaign_id", Policy: Cascade},
+	},
+	"events": {
+		{Table: "event_contacts", PrimaryKey: "event_contact_id", Column: "event_id", Policy: Cascade},
+		{Table: "event_user_mains", PrimaryKey: "event_user_main_id", Column: "event_id", Policy: Cascade},
+		{Table: "event_user_attendees", PrimaryKey: "event_user_attendee_id", Column: "event_id", Policy: Cascade},
+	},
+	"industries": {
+		{Entity: "accounts", Table: "accounts", PrimaryKey: "account_id", Column: "industry_id", Policy: Nullify},
+	},
+	"roles": {
+		{Entity: "users", Table: "users", PrimaryKey: "user_id", Column: "role_id", Policy: Restrict},
+	},
+	"users": {
+		{Entity: "accounts", Table: "accounts", PrimaryKey: "account_id", Column: "salesperson_id", Policy: Restrict},
+		{Entity: "contacts", Table: "contacts", PrimaryKey: "contact_id", Column: "salesperson_id", Policy: Restrict},
+		{Entity: "leads", Table: "leads", PrimaryKey: "lead_id", Column: "salesperson_id", Policy: Restrict},
+		{Entity: "opportunities", Table: "opportunities", PrimaryKey: "opportunity_id", Column: "salesperson_id", Policy: Restrict},
+		{Entity: "contracts", Table: "contracts", PrimaryKey: "contract_id", Column: "salesperson_id", Policy: Restrict},
+		{Entity: "campaigns", Table: "campaigns", PrimaryKey: "campaign_id", Column: "salesperson_id", Policy: Restrict},
+		{Table: "event_user_attendees", PrimaryKey: "event_user_attendee_id", Column: "attendee_id", Policy: Cascade},
+	},
+}
+
+// Preview is what deleting a record would affect
+type Preview struct {
+	// 資料類型
+	Entity string `json:"entity"`
+	// 資料ID
+	ID string `json:"id"`
+	// 是否可刪除,有限制刪除的關聯資料時不可刪除
+	Deletable bool `json:"deletable"`
+	// 受影響的關聯資料
+	Affected []*Affected `json:"affected"`
+}
+
+// Affected are the records of a reference affected by a delete
+type Affected struct {
+	// 資料表
+	Table string `json:"table"`
+	// 關聯欄位
+	Column string `json:"column"`
+	// 刪除規則(restrict, cascade, nullify)
+	Policy string `json:"policy"`
+	// 筆數
+	Count int `json:"count"`
+	// 資料ID(最多20筆)
+	IDs []string `json:"ids"`
+}
+
+// Field is structure file for search
+type Field struct {
+	// 資料類型
+	Entity string `json:"-" swaggerignore:"true"`
+	// 資料ID
+	ID string `json:"id,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+}
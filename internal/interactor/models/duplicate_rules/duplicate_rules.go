@@ -0,0 +1,84 @@
+package duplicate_rules
+
+import (
+	"time"
+)
+
+// Defaults are the rules every company starts with.
+var Defaults = []Create{
+	{Entity: "accounts", Field: "name", Method: "normalized"},
+	{Entity: "accounts", Field: "name", Method: "trigram", Threshold: 0.6},
+	{Entity: "accounts", Field: "phone_number", Method: "normalized"},
+	{Entity: "contacts", Field: "email", Method: "normalized"},
+	{Entity: "contacts", Field: "cell_phone", Method: "normalized"},
+	{Entity: "contacts", Field: "name", Method: "trigram", Threshold: 0.8},
+	{Entity: "leads", Field: "description", Method: "trigram", Threshold: 0.8},
+}
+
+// Create struct is used to create a duplicate matching rule
+type Create struct {
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+	// 資料類型(accounts, contacts, leads)
+	Entity string `json:"entity,omitempty" binding:"required,oneof=accounts contacts leads" validate:"required,oneof=accounts contacts leads"`
+	// 比對欄位(accounts: name, phone_number; contacts: name, email, phone_number, cell_phone; leads: description)
+	Field string `json:"field,omitempty" binding:"required" validate:"required"`
+	// 比對方式(exact 完全相同, normalized 正規化後相同, trigram 三字元相似度)
+	Method string `json:"method,omitempty" binding:"required,oneof=exact normalized trigram" validate:"required,oneof=exact normalized trigram"`
+	// 相似度門檻(trigram 使用,0.3-1)
+	Threshold float64 `json:"threshold,omitempty" binding:"omitempty,gte=0.3,lte=1" validate:"omitempty,gte=0.3,lte=1"`
+	// 創建者
+	CreatedBy string `json:"created_by,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+}
+
+// Field is structure file for search
+type Field struct {
+	// 重複規則ID
+	DuplicateRuleID string `json:"duplicate_rule_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4" swaggerignore:"true"`
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" swaggerignore:"true"`
+	// 資料類型(accounts, contacts, leads)
+	Entity *string `json:"entity,omitempty" form:"entity" binding:"omitempty,oneof=accounts contacts leads" validate:"omitempty,oneof=accounts contacts leads"`
+	// 是否啟用
+	IsEnable *bool `json:"is_enable,omitempty" form:"is_enable"`
+}
+
+// List is multiple return structure files
+type List struct {
+	// 多筆
+	DuplicateRules []*Single `json:"duplicate_rules"`
+}
+
+// Single return structure file
+type Single struct {
+	// 重複規則ID
+	DuplicateRuleID string `json:"duplicate_rule_id,omitempty"`
+	// 資料類型
+	Entity string `json:"entity,omitempty"`
+	// 比對欄位
+	Field string `json:"field,omitempty"`
+	// 比對方式(exact, normalized, trigram)
+	Method string `json:"method,omitempty"`
+	// 相似度門檻
+	Threshold float64 `json:"threshold"`
+	// 是否啟用
+	IsEnable bool `json:"is_enable"`
+	// 創建時間
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// 更新時間
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// Update struct is used to update a duplicate matching rule
+type Update struct {
+	// 重複規則ID
+	DuplicateRuleID string `json:"duplicate_rule_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4" swaggerignore:"true"`
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" swaggerignore:"true"`
+	// 相似度門檻(trigram 使用,0.3-1)
+	Threshold *float64 `json:"threshold,omitempty" binding:"omitempty,gte=0.3,lte=1" validate:"omitempty,gte=0.3,lte=1"`
+	// 是否啟用
+	IsEnable *bool `json:"is_enable,omitempty"`
+	// 更新者
+	UpdatedBy *string `json:"updated_by,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+}
@@ -0,0 +1,91 @@
+package duplicates
+
+// Methods of a duplicate matching rule.
+const (
+	Exact      = "exact"
+	Normalized = "normalized"
+	Trigram    = "trigram"
+)
+
+// Normalizations of a matched field.
+const (
+	Name  = "name"
+	Email = "email"
+	Phone = "phone"
+)
+
+// Fields are the fields that can be matched by entity, with the normalization used by the normalized method.
+var Fields = map[string]map[string]string{
+	"accounts": {"name": Name, "phone_number": Phone},
+	"contacts": {"name": Name, "email": Email, "phone_number": Phone, "cell_phone": Phone},
+	"leads":    {"description": Name},
+}
+
+// MergeFields are the fields whose surviving value can be picked when records are merged.
+var MergeFields = map[string][]string{
+	"accounts": {"name", "phone_number", "industry_id", "type", "parent_account_id", "salesperson_id"},
+	"contacts": {"name", "title", "phone_number", "cell_phone", "email", "salutation", "department", "supervisor_id", "account_id", "salesperson_id"},
+	"leads":    {"status", "description", "source", "account_id", "rating", "salesperson_id"},
+}
+
+// Field is structure file for search
+type Field struct {
+	// 資料類型(accounts, contacts, leads)
+	Entity string `json:"entity,omitempty" swaggerignore:"true"`
+	// 資料ID
+	ID string `json:"id,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+	// 公司ID,使用公司的比對規則
+	CompanyID string `json:"company_id,omitempty" swaggerignore:"true"`
+}
+
+// List is multiple return structure files
+type List struct {
+	// 疑似重複的資料
+	Candidates []*Candidate `json:"candidates"`
+}
+
+// Candidate is a record that looks like a duplicate
+type Candidate struct {
+	// 資料ID
+	ID string `json:"id,omitempty"`
+	// 標題
+	Title string `json:"title,omitempty"`
+	// 相似度(0-1)
+	Score float64 `json:"score"`
+	// 符合的規則(欄位:比對方式)
+	Rules []string `json:"rules"`
+}
+
+// Warning is returned with a created or updated record that looks like existing records
+type Warning struct {
+	// 警告類型
+	Type string `json:"type,omitempty"`
+	// 警告訊息
+	Message string `json:"message,omitempty"`
+	// 疑似重複的資料
+	Candidates []*Candidate `json:"candidates,omitempty"`
+}
+
+// Merge struct is used to merge records into a surviving record
+type Merge struct {
+	// 資料類型
+	Entity string `json:"-" swaggerignore:"true"`
+	// 保留的資料ID
+	SurvivorID string `json:"survivor_id,omitempty" binding:"required,uuid4" validate:"required,uuid4"`
+	// 併入後刪除的資料ID(最多10筆)
+	MergedIDs []string `json:"merged_ids,omitempty" binding:"required,min=1,max=10,dive,uuid4" validate:"required,min=1,max=10,dive,uuid4"`
+	// 欄位保留值的來源資料ID(欄位:資料ID),未指定的欄位保留原值,原值為空時取第一筆有值的併入資料
+	Fields map[string]string `json:"fields,omitempty"`
+	// 更新者
+	UpdatedBy string `json:"-" swaggerignore:"true"`
+}
+
+// Merged is returned by a merge
+type Merged struct {
+	// 保留的資料ID
+	SurvivorID string `json:"survivor_id"`
+	// 併入後刪除的資料ID
+	MergedIDs []string `json:"merged_ids"`
+	// 各關聯移轉的筆數(資料表.欄位:筆數)
+	Reparented map[string]int64 `json:"reparented"`
+}
@@ -0,0 +1,331 @@
+package enums
+
+import (
+	"strings"
+)
+
+// Languages of the translations.
+const (
+	Chinese = "zh-TW"
+	English = "en"
+)
+
+// Types of the enumerations, a code is its type followed by a dot and a name.
+const (
+	LeadStatus                 = "lead.status"
+	OrderStatus                = "order.status"
+	ContractStatus             = "contract.status"
+	QuoteStatus                = "quote.status"
+	HistoricalRecordAction     = "historical_record.action"
+	HistoricalRecordSourceType = "historical_record.source_type"
+	HistoricalRecordField      = "historical_record.field"
+	HistoricalRecordValue      = "historical_record.value"
+)
+
+// Codes of the lead statuses.
+const (
+	LeadStatusNew         = "lead.status.new"
+	LeadStatusWorking     = "lead.status.working"
+	LeadStatusUnqualified = "lead.status.unqualified"
+	LeadStatusConverted   = "lead.status.converted"
+)
+
+// Codes of the order statuses.
+const (
+	OrderStatusDraft     = "order.status.draft"
+	OrderStatusActivated = "order.status.activated"
+)
+
+// Codes of the contract statuses.
+const (
+	ContractStatusDraft      = "contract.status.draft"
+	ContractStatusInApproval = "contract.status.in_approval"
+	ContractStatusSigned     = "contract.status.signed"
+)
+
+// Codes of the quote statuses.
+const (
+	QuoteStatusDraft    = "quote.status.draft"
+	QuoteStatusInReview = "quote.status.in_review"
+	QuoteStatusAccepted = "quote.status.accepted"
+	QuoteStatusRejected = "quote.status.rejected"
+)
+
+// Codes of the actions of the historical records.
+const (
+	ActionCreated   = "historical_record.action.created"
+	ActionUpdated   = "historical_record.action.updated"
+	ActionDeleted   = "historical_record.action.deleted"
+	ActionCleared   = "historical_record.action.cleared"
+	ActionRemoved   = "historical_record.action.removed"
+	ActionConfirmed = "historical_record.action.confirmed"
+	ActionCancelled = "historical_record.action.cancelled"
+	ActionMerged    = "historical_record.action.merged"
+	ActionRestored  = "historical_record.action.restored"
+	ActionPurged    = "historical_record.action.purged"
+)
+
+// Codes of the source types of the historical records.
+const (
+	SourceTypeAccount     = "historical_record.source_type.account"
+	SourceTypeContact     = "historical_record.source_type.contact"
+	SourceTypeLead        = "historical_record.source_type.lead"
+	SourceTypeOpportunity = "historical_record.source_type.opportunity"
+	SourceTypeContract    = "historical_record.source_type.contract"
+	SourceTypeOrder       = "historical_record.source_type.order"
+	SourceTypeQuote       = "historical_record.source_type.quote"
+	SourceTypeProduct     = "historical_record.source_type.product"
+	SourceTypeCampaign    = "historical_record.source_type.campaign"
+	SourceTypeEvent       = "historical_record.source_type.event"
+)
+
+// SourceTypes are the source types of the historical records by entity.
+var SourceTypes = map[string]string{
+	"accounts":      SourceTypeAccount,
+	"contacts":      SourceTypeContact,
+	"leads":         SourceTypeLead,
+	"contracts":     SourceTypeContract,
+	"opportunities": SourceTypeOpportunity,
+	"orders":        SourceTypeOrder,
+	"quotes":        SourceTypeQuote,
+	"products":      SourceTypeProduct,
+	"campaigns":     SourceTypeCampaign,
+	"events":        SourceTypeEvent,
+}
+
+// Codes of the fields of the historical records.
+const (
+	FieldName                = "historical_record.field.name"
+	FieldStatus              = "historical_record.field.status"
+	FieldDescription         = "historical_record.field.description"
+	FieldSalesperson         = "historical_record.field.salesperson"
+	FieldAccount             = "historical_record.field.account"
+	FieldSource              = "historical_record.field.source"
+	FieldPhoneNumber         = "historical_record.field.phone_number"
+	FieldPhone               = "historical_record.field.phone"
+	FieldCellPhone           = "historical_record.field.cell_phone"
+	FieldEmail               = "historical_record.field.email"
+	FieldDepartment          = "historical_record.field.department"
+	FieldTitle               = "historical_record.field.title"
+	FieldSalutation          = "historical_record.field.salutation"
+	FieldRating              = "historical_record.field.rating"
+	FieldStartDate           = "historical_record.field.start_date"
+	FieldCloseDate           = "historical_record.field.close_date"
+	FieldExpirationDate      = "historical_record.field.expiration_date"
+	FieldOpportunity         = "historical_record.field.opportunity"
+	FieldIndustry            = "historical_record.field.industry"
+	FieldSupervisor          = "historical_record.field.supervisor"
+	FieldParentAccount       = "historical_record.field.parent_account"
+	FieldSync                = "historical_record.field.sync"
+	FieldAmount              = "historical_record.field.amount"
+	FieldShippingAndHandling = "historical_record.field.shipping_and_handling"
+	FieldTax                 = "historical_record.field.tax"
+	FieldType                = "historical_record.field.type"
+	FieldForecastCategory    = "historical_record.field.forecast_category"
+	FieldStage               = "historical_record.field.stage"
+	FieldTerm                = "historical_record.field.term"
+	FieldContractCode        = "historical_record.field.contract_code"
+	FieldData                = "historical_record.field.data"
+)
+
+// Codes of the fixed values of the historical records.
+const (
+	ValueQuoteSynced = "historical_record.value.quote_synced"
+	ValueQuoteFinal  = "historical_record.value.quote_final"
+)
+
+// Enum is a code stored in the database with its translations.
+type Enum struct {
+	// 代碼
+	Code string
+	// 中文
+	Chinese string
+	// 英文
+	English string
+}
+
+// Label returns the translation of the enum in language, Chinese unless English is asked for.
+func (e Enum) Label(language string) string {
+	if language == English {
+		return e.English
+	}
+
+	return e.Chinese
+}
+
+// Type returns the type of the enum.
+func (e Enum) Type() string {
+	return Type(e.Code)
+}
+
+// Enums are every enum in the order of their types.
+var Enums = []Enum{
+	{LeadStatusNew, "新線索", "New"},
+	{LeadStatusWorking, "發展中", "Working"},
+	{LeadStatusUnqualified, "不合格", "Unqualified"},
+	{LeadStatusConverted, "已轉換", "Converted"},
+	{OrderStatusDraft, "草稿", "Draft"},
+	{OrderStatusActivated, "啟動中", "Activated"},
+	{ContractStatusDraft, "草稿", "Draft"},
+	{ContractStatusInApproval, "審核中", "In Approval"},
+	{ContractStatusSigned, "已簽署", "Signed"},
+	{QuoteStatusDraft, "草稿", "Draft"},
+	{QuoteStatusInReview, "審核中", "In Review"},
+	{QuoteStatusAccepted, "已接受", "Accepted"},
+	{QuoteStatusRejected, "已拒絕", "Rejected"},
+	{ActionCreated, "建立", "Created"},
+	{ActionUpdated, "修改", "Updated"},
+	{ActionDeleted, "刪除", "Deleted"},
+	{ActionCleared, "清除", "Cleared"},
+	{ActionRemoved, "移除", "Removed"},
+	{ActionConfirmed, "確認", "Confirmed"},
+	{ActionCancelled, "取消", "Cancelled"},
+	{ActionMerged, "合併", "Merged"},
+	{ActionRestored, "還原", "Restored"},
+	{ActionPurged, "永久刪除", "Purged"},
+	{SourceTypeAccount, "帳戶", "Account"},
+	{SourceTypeContact, "聯絡人", "Contact"},
+	{SourceTypeLead, "線索", "Lead"},
+	{SourceTypeOpportunity, "商機", "Opportunity"},
+	{SourceTypeContract, "契約", "Contract"},
+	{SourceTypeOrder, "訂單", "Order"},
+	{SourceTypeQuote, "報價", "Quote"},
+	{SourceTypeProduct, "產品", "Product"},
+	{SourceTypeCampaign, "行銷活動", "Campaign"},
+	{SourceTypeEvent, "事件", "Event"},
+	{FieldName, "名稱", "name"},
+	{FieldStatus, "狀態", "status"},
+	{FieldDescription, "描述", "description"},
+	{FieldSalesperson, "業務員", "salesperson"},
+	{FieldAccount, "帳戶", "account"},
+	{FieldSource, "來源", "source"},
+	{FieldPhoneNumber, "電話號碼", "phone number"},
+	{FieldPhone, "電話", "phone"},
+	{FieldCellPhone, "行動電話", "cell phone"},
+	{FieldEmail, "電子郵件", "email"},
+	{FieldDepartment, "部門", "department"},
+	{FieldTitle, "職稱", "title"},
+	{FieldSalutation, "稱謂", "salutation"},
+	{FieldRating, "分級", "rating"},
+	{FieldStartDate, "開始日期", "start date"},
+	{FieldCloseDate, "結束日期", "close date"},
+	{FieldExpirationDate, "到期日期", "expiration date"},
+	{FieldOpportunity, "商機", "opportunity"},
+	{FieldIndustry, "行業", "industry"},
+	{FieldSupervisor, "直屬上司", "supervisor"},
+	{FieldParentAccount, "父系帳戶", "parent account"},
+	{FieldSync, "同步化", "sync"},
+	{FieldAmount, "金額", "amount"},
+	{FieldShippingAndHandling, "運費及其他費用", "shipping and handling"},
+	{FieldTax, "稅額", "tax"},
+	{FieldType, "類型", "type"},
+	{FieldForecastCategory, "預測種類", "forecast category"},
+	{FieldStage, "階段", "stage"},
+	{FieldTerm, "有效期限(月)", "term (months)"},
+	{FieldContractCode, "契約號碼", "contract number"},
+	{FieldData, "資料", "data"},
+	{ValueQuoteSynced, "此報價至商機", "this quote to the opportunity"},
+	{ValueQuoteFinal, "此報價為最終版", "this quote as the final version"},
+}
+
+var byCode = func() map[string]Enum {
+	output := make(map[string]Enum, len(Enums))
+	for _, enum := range Enums {
+		output[enum.Code] = enum
+	}
+
+	return output
+}()
+
+// Type returns the type of a code, such as lead.status for lead.status.converted.
+func Type(code string) string {
+	if i := strings.LastIndex(code, "."); i > 0 {
+		return code[:i]
+	}
+
+	return ""
+}
+
+// Label returns the translation of a code in language, a value that is not a code is returned as it is.
+func Label(code, language string) string {
+	enum, ok := byCode[code]
+	if !ok {
+		return code
+	}
+
+	return enum.Label(language)
+}
+
+// Code returns the code of value in the enumeration typ, value is a code or one of its translations
+// so that the clients sending the labels stored before the codes keep working.
+func Code(typ, value string) (string, bool) {
+	if enum, ok := byCode[value]; ok {
+		return value, enum.Type() == typ
+	}
+
+	for _, enum := range Enums {
+		if enum.Type() == typ && (value == enum.Chinese || strings.EqualFold(value, enum.English)) {
+			return enum.Code, true
+		}
+	}
+
+	return value, false
+}
+
+// Normalize replaces the translation held by value with its code, value is left as it is when it is nil or unknown.
+func Normalize(typ string, value *string) {
+	if value != nil {
+		*value, _ = Code(typ, *value)
+	}
+}
+
+// Codes returns the codes of the enumeration typ in order.
+func Codes(typ string) []string {
+	var output []string
+	for _, enum := range Enums {
+		if enum.Type() == typ {
+			output = append(output, enum.Code)
+		}
+	}
+
+	return output
+}
+
+// Language returns the language named first by an Accept-Language header, Chinese by default.
+func Language(acceptLanguage string) string {
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		switch tag = strings.ToLower(strings.TrimSpace(tag)); {
+		case strings.HasPrefix(tag, "zh"):
+			return Chinese
+		case strings.HasPrefix(tag, "en"):
+			return English
+		}
+	}
+
+	return Chinese
+}
+
+// Field is structure file for search
+type Field struct {
+	// 類型,如 lead.status,不帶入時回傳全部
+	Type string `json:"type,omitempty" form:"type"`
+	// 語系(Accept-Language)
+	Language string `json:"-" swaggerignore:"true"`
+}
+
+// List is multiple return structure files
+type List struct {
+	// 多筆
+	Enums []*Single `json:"enums"`
+}
+
+// Single return structure file
+type Single struct {
+	// 類型
+	Type string `json:"type,omitempty" example:"lead.status"`
+	// 代碼
+	Code string `json:"code,omitempty" example:"lead.status.converted"`
+	// 翻譯
+	Label string `json:"label,omitempty" example:"已轉換"`
+}
@@ -1,6 +1,8 @@
 package events
 
 import (
+	"crm/internal/interactor/models/bulk"
+	"crm/internal/interactor/models/filter"
 	"time"
 
 	"crm/internal/interactor/models/event_contacts"
@@ -82,6 +84,8 @@ type Filter struct {
 	FilterStartDate string `json:"start_date,omitempty"`
 	// 事件結束日期
 	FilterEndDate time.Time `json:"end_date,omitempty" swaggerignore:"true"`
+	// 組合搜尋條件,可使用 and/or 群組
+	Where *filter.Expression `json:"where,omitempty"`
 }
 
 // List is multiple return structure files
@@ -163,6 +167,8 @@ type Single struct {
 type Update struct {
 	// 事件ID
 	EventID string `json:"event_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4" swaggerignore:"true"`
+	// 版本(If-Match)
+	IfMatch string `json:"-" swaggerignore:"true"`
 	// 事件主題
 	Subject *string `json:"subject,omitempty"`
 	// 主要人員IDs
@@ -212,3 +218,25 @@ type Contacts struct {
 	// 聯絡人名稱
 	ContactName string `json:"contact_name,omitempty"`
 }
+
+// Bulk struct is used to create, update and delete events at once
+type Bulk struct {
+	bulk.Mode
+	// 新增的事件(最多500筆)
+	Create []*Create `json:"create,omitempty" binding:"max=500"`
+	// 更新的事件,須帶入event_id(最多500筆)
+	Update []*BulkUpdate `json:"update,omitempty" binding:"max=500"`
+	// 刪除的事件ID(最多500筆)
+	Delete []string `json:"delete,omitempty" binding:"max=500"`
+	// 操作者
+	UserID string `json:"-" swaggerignore:"true"`
+	// 公司是否要求 If-Match,要求時更新項目須帶入 if_match
+	IfMatchRequired bool `json:"-" swaggerignore:"true"`
+}
+
+// BulkUpdate is an update of a bulk request with the version of the event it expects
+type BulkUpdate struct {
+	Update
+	// 版本(ETag),與單筆更新的 If-Match 相同
+	IfMatch string `json:"if_match,omitempty"`
+}
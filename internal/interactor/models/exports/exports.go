@@ -0,0 +1,71 @@
+package exports
+
+import (
+	"io"
+)
+
+// Formats of an export.
+const (
+	CSV  = "csv"
+	XLSX = "xlsx"
+)
+
+// Languages of the headers of an export.
+const (
+	Chinese = "zh-TW"
+	English = "en"
+)
+
+// Format is the export format of a list, the list is returned as JSON when it is left out.
+type Format struct {
+	// 匯出格式(csv, xlsx),不帶入時回傳JSON
+	Format string `json:"-" form:"format" binding:"omitempty,oneof=csv xlsx" swaggerignore:"true"`
+}
+
+// Column is a column of an export, keyed by the json key of the list item.
+type Column struct {
+	// 欄位
+	Key string
+	// 中文標題
+	Chinese string
+	// 英文標題
+	English string
+}
+
+// Columns are the columns of an export in their default order.
+type Columns []Column
+
+// Header returns the header of the column in language, Chinese unless English is asked for.
+func (c Column) Header(language string) string {
+	if language == English {
+		return c.English
+	}
+
+	return c.Chinese
+}
+
+// Create struct is used to export a list
+type Create struct {
+	// 資料類型
+	Entity string `json:"entity,omitempty" binding:"required" validate:"required"`
+	// 匯出格式(csv, xlsx)
+	Format string `json:"format,omitempty" binding:"required,oneof=csv xlsx" validate:"required,oneof=csv xlsx"`
+	// 匯出欄位,以逗號分隔,不帶入時匯出全部欄位
+	Fields string `json:"fields,omitempty"`
+	// 標題語系(Accept-Language)
+	Language string `json:"language,omitempty"`
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" binding:"required,uuid4" validate:"required,uuid4"`
+	// 創建者
+	CreatedBy string `json:"created_by,omitempty" binding:"required,uuid4" validate:"required,uuid4"`
+}
+
+// Stream is an export small enough to be written to the response directly
+type Stream struct {
+	// 檔案名稱
+	FileName string
+	// 檔案類型
+	ContentType string
+	// 寫入檔案內容
+	Write func(w io.Writer) error
+}
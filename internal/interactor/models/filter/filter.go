@@ -0,0 +1,328 @@
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalid is wrapped by every error caused by the filter given by the client.
+var ErrInvalid = errors.New("invalid filter")
+
+const (
+	// 巢狀群組的最大深度
+	maxDepth = 5
+	// 條件數上限
+	maxConditions = 50
+)
+
+// Column types.
+const (
+	Text   = "text"
+	Number = "number"
+	Time   = "time"
+	Bool   = "bool"
+	UUID   = "uuid"
+)
+
+// operators allowed on every column type.
+var operators = map[string][]string{
+	Text:   {"eq", "ne", "in", "contains", "starts_with", "is_null"},
+	Number: {"eq", "ne", "gt", "gte", "lt", "lte", "between", "in", "is_null"},
+	Time:   {"eq", "ne", "gt", "gte", "lt", "lte", "between", "is_null"},
+	Bool:   {"eq", "ne", "is_null"},
+	UUID:   {"eq", "ne", "in", "is_null"},
+}
+
+// Column is a filterable column of an entity.
+type Column struct {
+	// SQL 欄位,關聯表需帶上 join 名稱如 "Accounts".name
+	Name string
+	// 欄位型別
+	Type string
+	// JSONB 欄位及鍵值,自訂欄位的等於比對使用 GIN 索引
+	Document, Key string
+}
+
+// Fields is the allow-list of an entity, keyed by the field name used in the filter.
+type Fields map[string]Column
+
+// Columns returns the SQL column of every field, list endpoints sort on the same fields they filter on.
+func (f Fields) Columns() map[string]string {
+	columns := make(map[string]string, len(f))
+	for field, column := range f {
+		columns[field] = column.Name
+	}
+
+	return columns
+}
+
+// With returns the fields along with extra, such as the custom fields of a company.
+func (f Fields) With(extra Fields) Fields {
+	if len(extra) == 0 {
+		return f
+	}
+
+	fields := make(Fields, len(f)+len(extra))
+	for field, column := range f {
+		fields[field] = column
+	}
+
+	for field, column := range extra {
+		fields[field] = column
+	}
+
+	return fields
+}
+
+// Custom returns the column of key in the JSONB column document, such as accounts.custom_fields.
+// Key is used in SQL as it is and must be checked by the caller. A value of another type reads as null,
+// the records of other companies may hold the same key with another type.
+func Custom(document, key, typ string) Column {
+	value := document + "->>'" + key + "'"
+	name := "(" + value + ")"
+	switch typ {
+	case Number:
+		name = "(case when jsonb_typeof(" + document + "->'" + key + "') = 'number' then (" + value + ")::numeric end)"
+	case Bool:
+		name = "(case when jsonb_typeof(" + document + "->'" + key + "') = 'boolean' then (" + value + ")::boolean end)"
+	case Time:
+		name = "(case when " + value + ` ~ '^\d{4}-\d{2}-\d{2}$' then (` + value + ")::date end)"
+	}
+
+	return Column{Name: name, Type: typ, Document: document, Key: key}
+}
+
+var customPattern = regexp.MustCompile(`([a-z_.]+)->>?'([a-z0-9_]+)'`)
+
+// CustomKey returns the JSONB column, the key and the type read by a column made by Custom, ok is false for other columns.
+func CustomKey(name string) (document, key, typ string, ok bool) {
+	match := customPattern.FindStringSubmatch(name)
+	if match == nil || !strings.HasPrefix(name, "(") {
+		return "", "", "", false
+	}
+
+	switch {
+	case strings.HasSuffix(name, ")::numeric end)"):
+		typ = Number
+	case strings.HasSuffix(name, ")::boolean end)"):
+		typ = Bool
+	case strings.HasSuffix(name, ")::date end)"):
+		typ = Time
+	default:
+		typ = Text
+	}
+
+	return match[1], match[2], typ, true
+}
+
+// Expression is either an and/or group or a single condition.
+type Expression struct {
+	// 且群組
+	And []*Expression `json:"and,omitempty"`
+	// 或群組
+	Or []*Expression `json:"or,omitempty"`
+	// 欄位名稱
+	Field string `json:"field,omitempty"`
+	// 運算子(eq, ne, gt, gte, lt, lte, between, in, contains, starts_with, is_null)
+	Operator string `json:"op,omitempty"`
+	// 比對值,between 為兩個值的陣列,in 為陣列,is_null 為布林(預設 true)
+	Value any `json:"value,omitempty" swaggertype:"object"`
+}
+
+// Clause validates the expression against the allow-list and turns it into a where condition.
+func (e *Expression) Clause(fields Fields) (clause.Expression, error) {
+	count := 0
+	return e.clause(fields, 1, &count)
+}
+
+func (e *Expression) clause(fields Fields, depth int, count *int) (clause.Expression, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("%w: groups are nested deeper than %d", ErrInvalid, maxDepth)
+	}
+
+	switch {
+	case len(e.And) > 0 && len(e.Or) == 0 && e.Field == "":
+		expressions, err := group(e.And, fields, depth, count)
+		if err != nil {
+			return nil, err
+		}
+
+		return clause.And(expressions...), nil
+	case len(e.Or) > 0 && len(e.And) == 0 && e.Field == "":
+		expressions, err := group(e.Or, fields, depth, count)
+		if err != nil {
+			return nil, err
+		}
+
+		return clause.Or(expressions...), nil
+	case e.Field != "" && len(e.And) == 0 && len(e.Or) == 0:
+		*count++
+		if *count > maxConditions {
+			return nil, fmt.Errorf("%w: more than %d conditions", ErrInvalid, maxConditions)
+		}
+
+		return e.condition(fields)
+	}
+
+	return nil, fmt.Errorf("%w: a node needs exactly one of and, or, field", ErrInvalid)
+}
+
+func group(children []*Expression, fields Fields, depth int, count *int) ([]clause.Expression, error) {
+	expressions := make([]clause.Expression, 0, len(children))
+	for _, child := range children {
+		if child == nil {
+			return nil, fmt.Errorf("%w: empty node", ErrInvalid)
+		}
+
+		expression, err := child.clause(fields, depth+1, count)
+		if err != nil {
+			return nil, err
+		}
+
+		expressions = append(expressions, expression)
+	}
+
+	return expressions, nil
+}
+
+func (e *Expression) condition(fields Fields) (clause.Expression, error) {
+	column, ok := fields[e.Field]
+	if !ok {
+		return nil, fmt.Errorf("%w: field %q is not filterable", ErrInvalid, e.Field)
+	}
+
+	allowed := false
+	for _, operator := range operators[column.Type] {
+		allowed = allowed || operator == e.Operator
+	}
+
+	if !allowed {
+		return nil, fmt.Errorf("%w: operator %q is not allowed on %s field %q", ErrInvalid, e.Operator, column.Type, e.Field)
+	}
+
+	switch e.Operator {
+	case "is_null":
+		if isNull, ok := e.Value.(bool); ok && !isNull {
+			return clause.Expr{SQL: column.Name + " is not null"}, nil
+		}
+
+		if e.Value != nil && e.Value != true {
+			return nil, fmt.Errorf("%w: is_null on %q expects a boolean", ErrInvalid, e.Field)
+		}
+
+		return clause.Expr{SQL: column.Name + " is null"}, nil
+	case "between":
+		values, err := list(e.Value, column, e.Field)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(values) != 2 {
+			return nil, fmt.Errorf("%w: between on %q expects two values", ErrInvalid, e.Field)
+		}
+
+		return clause.Expr{SQL: column.Name + " between ? and ?", Vars: values}, nil
+	case "in":
+		values, err := list(e.Value, column, e.Field)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(values) == 0 {
+			return nil, fmt.Errorf("%w: in on %q expects at least one value", ErrInvalid, e.Field)
+		}
+
+		return clause.Expr{SQL: column.Name + " in ?", Vars: []any{values}}, nil
+	}
+
+	value, err := scalar(e.Value, column, e.Field)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.Operator {
+	case "contains":
+		return clause.Expr{SQL: column.Name + " ilike ?", Vars: []any{"%" + escape(value.(string)) + "%"}}, nil
+	case "starts_with":
+		return clause.Expr{SQL: column.Name + " ilike ?", Vars: []any{escape(value.(string)) + "%"}}, nil
+	}
+
+	// 自訂欄位以包含比對使用 GIN 索引
+	if e.Operator == "eq" && column.Document != "" && column.Type != Time {
+		document, err := json.Marshal(map[string]any{column.Key: value})
+		if err != nil {
+			return nil, err
+		}
+
+		return clause.Expr{SQL: column.Document + " @> ?", Vars: []any{string(document)}}, nil
+	}
+
+	sql := map[string]string{"eq": " = ?", "ne": " <> ?", "gt": " > ?", "gte": " >= ?", "lt": " < ?", "lte": " <= ?"}
+	return clause.Expr{SQL: column.Name + sql[e.Operator], Vars: []any{value}}, nil
+}
+
+// list checks every element of an array value.
+func list(value any, column Column, field string) ([]any, error) {
+	values, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q expects an array", ErrInvalid, field)
+	}
+
+	output := make([]any, len(values))
+	for i, v := range values {
+		checked, err := scalar(v, column, field)
+		if err != nil {
+			return nil, err
+		}
+
+		output[i] = checked
+	}
+
+	return output, nil
+}
+
+// scalar checks that a value decoded from JSON matches the column type.
+func scalar(value any, column Column, field string) (any, error) {
+	switch column.Type {
+	case Text:
+		if v, ok := value.(string); ok {
+			return v, nil
+		}
+	case Number:
+		if v, ok := value.(float64); ok {
+			return v, nil
+		}
+	case Bool:
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+	case UUID:
+		if v, ok := value.(string); ok {
+			if _, err := uuid.Parse(v); err == nil {
+				return v, nil
+			}
+		}
+	case Time:
+		if v, ok := value.(string); ok {
+			for _, layout := range []string{time.RFC3339Nano, time.DateOnly} {
+				if t, err := time.Parse(layout, v); err == nil {
+					return t, nil
+				}
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %q expects a %s value", ErrInvalid, field, column.Type)
+}
+
+// escape makes the wildcards of a like pattern literal.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
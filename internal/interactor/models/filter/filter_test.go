@@ -0,0 +1,147 @@
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+var fields = Fields{
+	"name":       {Name: "accounts.name", Type: Text},
+	"amount":     {Name: "opportunities.amount", Type: Number},
+	"close_date": {Name: "opportunities.close_date", Type: Time},
+	"is_enable":  {Name: "products.is_enable", Type: Bool},
+	"account_id": {Name: "contacts.account_id", Type: UUID},
+	"region":     Custom("accounts.custom_fields", "region", Text),
+	"budget":     Custom("accounts.custom_fields", "budget", Number),
+}
+
+// parse decodes the filter like the list endpoints do, so numbers are float64 and arrays []any.
+func parse(t *testing.T, filter string) *Expression {
+	t.Helper()
+	expression := &Expression{}
+	if err := json.Unmarshal([]byte(filter), expression); err != nil {
+		t.Fatalf("decode %s: %v", filter, err)
+	}
+
+	return expression
+}
+
+func TestClauseOperators(t *testing.T) {
+	closeDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		filter string
+		want   clause.Expression
+	}{
+		{"eq", `{"field":"name","op":"eq","value":"Acme"}`, clause.Expr{SQL: "accounts.name = ?", Vars: []any{"Acme"}}},
+		{"ne", `{"field":"amount","op":"ne","value":5}`, clause.Expr{SQL: "opportunities.amount <> ?", Vars: []any{5.0}}},
+		{"gt", `{"field":"amount","op":"gt","value":1}`, clause.Expr{SQL: "opportunities.amount > ?", Vars: []any{1.0}}},
+		{"gte", `{"field":"amount","op":"gte","value":1}`, clause.Expr{SQL: "opportunities.amount >= ?", Vars: []any{1.0}}},
+		{"lt", `{"field":"amount","op":"lt","value":1}`, clause.Expr{SQL: "opportunities.amount < ?", Vars: []any{1.0}}},
+		{"lte", `{"field":"close_date","op":"lte","value":"2024-03-01"}`, clause.Expr{SQL: "opportunities.close_date <= ?", Vars: []any{closeDate}}},
+		{"between", `{"field":"amount","op":"between","value":[1,10]}`, clause.Expr{SQL: "opportunities.amount between ? and ?", Vars: []any{1.0, 10.0}}},
+		{"in", `{"field":"name","op":"in","value":["a","b"]}`, clause.Expr{SQL: "accounts.name in ?", Vars: []any{[]any{"a", "b"}}}},
+		{"contains escapes wildcards", `{"field":"name","op":"contains","value":"50%_off"}`, clause.Expr{SQL: "accounts.name ilike ?", Vars: []any{`%50\%\_off%`}}},
+		{"starts_with", `{"field":"name","op":"starts_with","value":"Ac"}`, clause.Expr{SQL: "accounts.name ilike ?", Vars: []any{"Ac%"}}},
+		{"is_null", `{"field":"account_id","op":"is_null"}`, clause.Expr{SQL: "contacts.account_id is null"}},
+		{"is_null false", `{"field":"account_id","op":"is_null","value":false}`, clause.Expr{SQL: "contacts.account_id is not null"}},
+		{"bool", `{"field":"is_enable","op":"eq","value":true}`, clause.Expr{SQL: "products.is_enable = ?", Vars: []any{true}}},
+		{"uuid", `{"field":"account_id","op":"eq","value":"6f1c2a52-3c4b-4d6e-9f10-2a3b4c5d6e7f"}`, clause.Expr{SQL: "contacts.account_id = ?", Vars: []any{"6f1c2a52-3c4b-4d6e-9f10-2a3b4c5d6e7f"}}},
+		{"custom eq uses containment", `{"field":"region","op":"eq","value":"north"}`, clause.Expr{SQL: "accounts.custom_fields @> ?", Vars: []any{`{"region":"north"}`}}},
+		{"custom gt reads the typed column", `{"field":"budget","op":"gt","value":100}`, clause.Expr{SQL: fields["budget"].Name + " > ?", Vars: []any{100.0}}},
+		{"and", `{"and":[{"field":"name","op":"eq","value":"a"},{"field":"amount","op":"gt","value":1}]}`, clause.And(
+			clause.Expr{SQL: "accounts.name = ?", Vars: []any{"a"}},
+			clause.Expr{SQL: "opportunities.amount > ?", Vars: []any{1.0}},
+		)},
+		{"nested or", `{"or":[{"field":"name","op":"eq","value":"a"},{"and":[{"field":"amount","op":"gt","value":1},{"field":"amount","op":"lt","value":9}]}]}`, clause.Or(
+			clause.Expr{SQL: "accounts.name = ?", Vars: []any{"a"}},
+			clause.And(
+				clause.Expr{SQL: "opportunities.amount > ?", Vars: []any{1.0}},
+				clause.Expr{SQL: "opportunities.amount < ?", Vars: []any{9.0}},
+			),
+		)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parse(t, tt.filter).Clause(fields)
+			if err != nil {
+				t.Fatalf("Clause() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Clause() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClauseInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+	}{
+		{"empty node", `{}`},
+		{"field and group", `{"field":"name","op":"eq","value":"a","and":[{"field":"name","op":"eq","value":"b"}]}`},
+		{"and and or", `{"and":[{"field":"name","op":"eq","value":"a"}],"or":[{"field":"name","op":"eq","value":"b"}]}`},
+		{"null child", `{"and":[null]}`},
+		{"unknown field", `{"field":"password","op":"eq","value":"a"}`},
+		{"operator not allowed on type", `{"field":"name","op":"gt","value":"a"}`},
+		{"unknown operator", `{"field":"amount","op":"like","value":1}`},
+		{"text expects string", `{"field":"name","op":"eq","value":1}`},
+		{"number expects number", `{"field":"amount","op":"eq","value":"1"}`},
+		{"bad uuid", `{"field":"account_id","op":"eq","value":"not-a-uuid"}`},
+		{"bad time", `{"field":"close_date","op":"eq","value":"01/03/2024"}`},
+		{"between one value", `{"field":"amount","op":"between","value":[1]}`},
+		{"between not an array", `{"field":"amount","op":"between","value":1}`},
+		{"in empty", `{"field":"name","op":"in","value":[]}`},
+		{"in mixed types", `{"field":"name","op":"in","value":["a",1]}`},
+		{"is_null not a boolean", `{"field":"name","op":"is_null","value":"yes"}`},
+		{"too deep", `{"and":[{"and":[{"and":[{"and":[{"and":[{"field":"name","op":"eq","value":"a"}]}]}]}]}]}`},
+		{"too many conditions", `{"or":[` + strings.Repeat(`{"field":"name","op":"eq","value":"a"},`, maxConditions) + `{"field":"name","op":"eq","value":"a"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse(t, tt.filter).Clause(fields)
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("Clause() error = %v, want ErrInvalid", err)
+			}
+		})
+	}
+}
+
+func TestCustomKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		column  string
+		wantKey string
+		wantTyp string
+		wantOK  bool
+	}{
+		{"text", Custom("leads.custom_fields", "source_code", Text).Name, "source_code", Text, true},
+		{"number", Custom("leads.custom_fields", "score", Number).Name, "score", Number, true},
+		{"bool", Custom("leads.custom_fields", "vip", Bool).Name, "vip", Bool, true},
+		{"time", Custom("leads.custom_fields", "renewal", Time).Name, "renewal", Time, true},
+		{"plain column", "leads.status", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document, key, typ, ok := CustomKey(tt.column)
+			if ok != tt.wantOK || key != tt.wantKey || typ != tt.wantTyp {
+				t.Fatalf("CustomKey() = %q, %q, %v, want %q, %q, %v", key, typ, ok, tt.wantKey, tt.wantTyp, tt.wantOK)
+			}
+
+			if ok && document != "leads.custom_fields" {
+				t.Errorf("CustomKey() document = %q", document)
+			}
+		})
+	}
+}
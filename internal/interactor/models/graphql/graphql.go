@@ -0,0 +1,94 @@
+package graphql
+
+import "net/http"
+
+// Permission is a REST route, a role allowed on it is allowed on the GraphQL type or mutation it guards.
+type Permission struct {
+	// 路徑
+	Path string
+	// 方法
+	Method string
+}
+
+// Reads are the routes listing the records of each type, a type is readable by the roles allowed to list it.
+// Historical records are readable by every role as in the REST API.
+var Reads = map[string]Permission{
+	"Account":      {Path: "/crm/v1.0/accounts/list", Method: http.MethodPost},
+	"Contact":      {Path: "/crm/v1.0/contacts/list", Method: http.MethodPost},
+	"Lead":         {Path: "/crm/v1.0/leads/list", Method: http.MethodPost},
+	"Opportunity":  {Path: "/crm/v1.0/opportunities/list", Method: http.MethodPost},
+	"Quote":        {Path: "/crm/v1.0/quotes/list", Method: http.MethodPost},
+	"QuoteProduct": {Path: "/crm/v1.0/quotes-products", Method: http.MethodGet},
+	"Contract":     {Path: "/crm/v1.0/contracts/list", Method: http.MethodPost},
+	"Order":        {Path: "/crm/v1.0/orders/list", Method: http.MethodPost},
+	"OrderProduct": {Path: "/crm/v1.0/orders-products", Method: http.MethodGet},
+	"Event":        {Path: "/crm/v1.0/events/list", Method: http.MethodPost},
+	"Product":      {Path: "/crm/v1.0/products/list", Method: http.MethodPost},
+	"Campaign":     {Path: "/crm/v1.0/campaigns/list", Method: http.MethodPost},
+	"Industry":     {Path: "/crm/v1.0/industries", Method: http.MethodGet},
+	"User":         {Path: "/crm/v1.0/users/list", Method: http.MethodPost},
+}
+
+// Writes are the routes of the managers each mutation calls.
+var Writes = map[string]Permission{
+	"create_account":     {Path: "/crm/v1.0/accounts", Method: http.MethodPost},
+	"update_account":     {Path: "/crm/v1.0/accounts/:accountID", Method: http.MethodPatch},
+	"delete_account":     {Path: "/crm/v1.0/accounts/:accountID", Method: http.MethodDelete},
+	"create_contact":     {Path: "/crm/v1.0/contacts", Method: http.MethodPost},
+	"update_contact":     {Path: "/crm/v1.0/contacts/:contactID", Method: http.MethodPatch},
+	"delete_contact":     {Path: "/crm/v1.0/contacts/:contactID", Method: http.MethodDelete},
+	"create_lead":        {Path: "/crm/v1.0/leads", Method: http.MethodPost},
+	"update_lead":        {Path: "/crm/v1.0/leads/:leadID", Method: http.MethodPatch},
+	"delete_lead":        {Path: "/crm/v1.0/leads/:leadID", Method: http.MethodDelete},
+	"create_opportunity": {Path: "/crm/v1.0/opportunities", Method: http.MethodPost},
+	"update_opportunity": {Path: "/crm/v1.0/opportunities/:opportunityID", Method: http.MethodPatch},
+	"delete_opportunity": {Path: "/crm/v1.0/opportunities/:opportunityID", Method: http.MethodDelete},
+	"create_quote":       {Path: "/crm/v1.0/quotes", Method: http.MethodPost},
+	"update_quote":       {Path: "/crm/v1.0/quotes/:quoteID", Method: http.MethodPatch},
+	"delete_quote":       {Path: "/crm/v1.0/quotes/:quoteID", Method: http.MethodDelete},
+	"create_contract":    {Path: "/crm/v1.0/contracts", Method: http.MethodPost},
+	"update_contract":    {Path: "/crm/v1.0/contracts/:contractID", Method: http.MethodPatch},
+	"delete_contract":    {Path: "/crm/v1.0/contracts/:contractID", Method: http.MethodDelete},
+	"create_order":       {Path: "/crm/v1.0/orders", Method: http.MethodPost},
+	"update_order":       {Path: "/crm/v1.0/orders/:orderID", Method: http.MethodPatch},
+	"delete_order":       {Path: "/crm/v1.0/orders/:orderID", Method: http.MethodDelete},
+	"create_event":       {Path: "/crm/v1.0/events", Method: http.MethodPost},
+	"update_event":       {Path: "/crm/v1.0/events/:eventID", Method: http.MethodPatch},
+	"delete_event":       {Path: "/crm/v1.0/events/:eventID", Method: http.MethodDelete},
+}
+
+// Request struct is used to run a GraphQL query or mutation
+type Request struct {
+	// 查詢
+	Query string `json:"query,omitempty" binding:"required" validate:"required"`
+	// 執行的操作名稱,查詢有多個操作時帶入
+	OperationName string `json:"operationName,omitempty"`
+	// 變數
+	Variables map[string]any `json:"variables,omitempty"`
+	// 公司ID
+	CompanyID string `json:"-" swaggerignore:"true"`
+	// 使用者ID
+	UserID string `json:"-" swaggerignore:"true"`
+	// 角色可讀取的類型及可執行的異動
+	Allowed []string `json:"-" swaggerignore:"true"`
+	// 更新是否必須帶入 if_match
+	IfMatchRequired bool `json:"-" swaggerignore:"true"`
+}
+
+// Response is the result of a GraphQL request
+type Response struct {
+	// 資料
+	Data any `json:"data"`
+	// 錯誤
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+// Error is an error of a GraphQL request
+type Error struct {
+	// 錯誤訊息
+	Message string `json:"message"`
+	// 錯誤的欄位位置
+	Path []any `json:"path,omitempty"`
+	// 錯誤的狀態碼(status)及詳細內容(detailed)
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
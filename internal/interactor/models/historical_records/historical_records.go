@@ -36,6 +36,8 @@ type Field struct {
 	Action *string `json:"action,omitempty" form:"action"`
 	// 來源ID
 	SourceID *string `json:"source_id,omitempty" form:"source_id"`
+	// 內容語系(Accept-Language)
+	Language string `json:"-" swaggerignore:"true"`
 }
 
 // Fields is the searched structure file (including pagination)
@@ -0,0 +1,41 @@
+package idempotency_keys
+
+import (
+	"time"
+)
+
+// Create struct is used to create achieves
+type Create struct {
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" binding:"required,uuid4" validate:"required,uuid4"`
+	// 使用者ID
+	UserID string `json:"user_id,omitempty" binding:"required,uuid4" validate:"required,uuid4"`
+	// 冪等鍵
+	Key string `json:"key,omitempty" binding:"required,max=255" validate:"required,max=255"`
+	// 請求內容雜湊值
+	RequestHash string `json:"request_hash,omitempty" binding:"required" validate:"required"`
+}
+
+// Field is structure file for search
+type Field struct {
+	// 冪等鍵ID
+	IdempotencyKeyID string `json:"idempotency_key_id,omitempty"`
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty"`
+	// 使用者ID
+	UserID string `json:"user_id,omitempty"`
+	// 冪等鍵
+	Key string `json:"key,omitempty"`
+	// 建立時間早於此時間者視為過期
+	ExpiredBefore *time.Time `json:"expired_before,omitempty"`
+}
+
+// Update struct is used to save the response of the first request
+type Update struct {
+	// 冪等鍵ID
+	IdempotencyKeyID string `json:"idempotency_key_id,omitempty"`
+	// 回應狀態碼
+	StatusCode int `json:"status_code,omitempty"`
+	// 回應內容,空白的回應也需保存
+	ResponseBody string `json:"response_body"`
+}
@@ -0,0 +1,53 @@
+package import_mappings
+
+import (
+	"time"
+)
+
+// Create struct is used to save the column mapping of an import
+type Create struct {
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+	// 匯入資料類型(leads, contacts, accounts)
+	Entity string `json:"entity,omitempty" binding:"required,oneof=leads contacts accounts" validate:"required,oneof=leads contacts accounts"`
+	// 匯入對應名稱
+	Name string `json:"name,omitempty" binding:"required,max=100" validate:"required,max=100"`
+	// 欄位對應(檔案欄位名稱:資料欄位),資料欄位可用模型欄位及 industry, parent_account, account, salesperson 等名稱查詢
+	Columns map[string]string `json:"columns,omitempty" binding:"required,min=1" validate:"required,min=1"`
+	// 創建者
+	CreatedBy string `json:"created_by,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+}
+
+// Field is structure file for search
+type Field struct {
+	// 匯入對應ID
+	ImportMappingID string `json:"import_mapping_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4" swaggerignore:"true"`
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" swaggerignore:"true"`
+	// 匯入資料類型(leads, contacts, accounts)
+	Entity *string `json:"entity,omitempty" form:"entity" binding:"omitempty,oneof=leads contacts accounts" validate:"omitempty,oneof=leads contacts accounts"`
+	// 匯入對應名稱
+	Name *string `json:"name,omitempty" swaggerignore:"true"`
+}
+
+// List is multiple return structure files
+type List struct {
+	// 多筆
+	ImportMappings []*Single `json:"import_mappings"`
+}
+
+// Single return structure file
+type Single struct {
+	// 匯入對應ID
+	ImportMappingID string `json:"import_mapping_id,omitempty"`
+	// 匯入資料類型
+	Entity string `json:"entity,omitempty"`
+	// 匯入對應名稱
+	Name string `json:"name,omitempty"`
+	// 欄位對應(檔案欄位名稱:資料欄位)
+	Columns map[string]string `json:"columns,omitempty"`
+	// 創建時間
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// 創建者
+	CreatedBy string `json:"created_by,omitempty"`
+}
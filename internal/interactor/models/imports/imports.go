@@ -0,0 +1,67 @@
+package imports
+
+// Create struct is used to import a CSV or XLSX file
+type Create struct {
+	// 匯入資料類型(leads, contacts, accounts)
+	Entity string `json:"entity,omitempty" form:"entity" binding:"required,oneof=leads contacts accounts" validate:"required,oneof=leads contacts accounts"`
+	// 欄位對應 JSON(檔案欄位名稱:資料欄位),與 mapping_id 擇一帶入
+	Mapping string `json:"mapping,omitempty" form:"mapping"`
+	// 已儲存的匯入對應ID
+	MappingID string `json:"mapping_id,omitempty" form:"mapping_id" binding:"omitempty,uuid4" validate:"omitempty,uuid4"`
+	// 以此名稱儲存帶入的欄位對應
+	SaveMapping string `json:"save_mapping,omitempty" form:"save_mapping" binding:"omitempty,max=100" validate:"omitempty,max=100"`
+	// 試算,只驗證並預覽結果,不寫入資料
+	DryRun bool `json:"dry_run,omitempty" form:"dry_run"`
+	// 檔案名稱
+	FileName string `json:"-" swaggerignore:"true"`
+	// 檔案內容
+	File []byte `json:"-" swaggerignore:"true"`
+	// 公司ID
+	CompanyID string `json:"-" swaggerignore:"true"`
+	// 匯入者
+	CreatedBy string `json:"-" swaggerignore:"true"`
+}
+
+// Report is the result of a dry run
+type Report struct {
+	// 將新增筆數
+	Inserted int `json:"inserted"`
+	// 將更新筆數
+	Updated int `json:"updated"`
+	// 拒絕筆數
+	Rejected int `json:"rejected"`
+	// 拒絕的資料列
+	Errors []*RowError `json:"errors"`
+	// 有警告的資料列(如疑似重複的資料)
+	Warnings []*RowWarning `json:"warnings"`
+	// 前20筆資料列的對應結果
+	Preview []*Row `json:"preview"`
+}
+
+// RowError is a rejected row
+type RowError struct {
+	// 資料列(檔案中的列號,標題為第1列)
+	Row int `json:"row"`
+	// 錯誤內容
+	Error any `json:"error"`
+}
+
+// RowWarning is a row written with warnings
+type RowWarning struct {
+	// 資料列(檔案中的列號,標題為第1列)
+	Row int `json:"row"`
+	// 警告內容
+	Warnings []any `json:"warnings"`
+}
+
+// Row is a mapped row
+type Row struct {
+	// 資料列(檔案中的列號,標題為第1列)
+	Row int `json:"row"`
+	// 動作(create, update)
+	Action string `json:"action"`
+	// 資料ID(更新時)
+	ID string `json:"id,omitempty"`
+	// 對應後的欄位
+	Values map[string]any `json:"values"`
+}
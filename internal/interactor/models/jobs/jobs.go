@@ -0,0 +1,99 @@
+package jobs
+
+import (
+	"time"
+)
+
+// Kinds of a job.
+const (
+	Import = "import"
+	Export = "export"
+)
+
+// Statuses of a job.
+const (
+	Pending   = "pending"
+	Running   = "running"
+	Succeeded = "succeeded"
+	Failed    = "failed"
+)
+
+// Create struct is used to create a background job
+type Create struct {
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" binding:"required,uuid4" validate:"required,uuid4"`
+	// 工作種類(import, export)
+	Kind string `json:"kind,omitempty" binding:"required,oneof=import export" validate:"required,oneof=import export"`
+	// 資料類型
+	Entity string `json:"entity,omitempty" binding:"required" validate:"required"`
+	// 創建者
+	CreatedBy string `json:"created_by,omitempty" binding:"required,uuid4" validate:"required,uuid4"`
+}
+
+// Field is structure file for search
+type Field struct {
+	// 工作ID
+	JobID string `json:"job_id,omitempty" form:"-" binding:"omitempty,uuid4" validate:"omitempty,uuid4" swaggerignore:"true"`
+	// 創建者
+	CreatedBy string `json:"created_by,omitempty" form:"-" swaggerignore:"true"`
+}
+
+// Single return structure file
+type Single struct {
+	// 工作ID
+	JobID string `json:"job_id,omitempty"`
+	// 工作種類(import, export)
+	Kind string `json:"kind,omitempty"`
+	// 資料類型
+	Entity string `json:"entity,omitempty"`
+	// 狀態(pending, running, succeeded, failed)
+	Status string `json:"status,omitempty"`
+	// 新增筆數
+	Inserted int `json:"inserted"`
+	// 更新筆數
+	Updated int `json:"updated"`
+	// 拒絕筆數
+	Rejected int `json:"rejected"`
+	// 檔案名稱,完成後可下載(匯入為錯誤檔,匯出為匯出檔)
+	FileName string `json:"file_name,omitempty"`
+	// 錯誤訊息
+	Error string `json:"error,omitempty"`
+	// 創建時間
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// 完成時間
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// Update struct is used to report the progress of a job
+type Update struct {
+	// 工作ID
+	JobID string `json:"job_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4"`
+	// 狀態(pending, running, succeeded, failed)
+	Status *string `json:"status,omitempty"`
+	// 新增筆數
+	Inserted *int `json:"inserted,omitempty"`
+	// 更新筆數
+	Updated *int `json:"updated,omitempty"`
+	// 拒絕筆數
+	Rejected *int `json:"rejected,omitempty"`
+	// 檔案名稱
+	FileName *string `json:"file_name,omitempty"`
+	// 檔案類型
+	ContentType *string `json:"content_type,omitempty"`
+	// 檔案內容
+	File []byte `json:"file,omitempty"`
+	// 錯誤訊息
+	Error *string `json:"error,omitempty"`
+	// 完成時間
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// File is a file produced by a job
+type File struct {
+	// 檔案名稱
+	FileName string
+	// 檔案類型
+	ContentType string
+	// 檔案內容
+	Content []byte
+}
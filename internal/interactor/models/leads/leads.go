@@ -1,15 +1,42 @@
 package leads
 
 import (
+	"crm/internal/interactor/models/bulk"
+	"crm/internal/interactor/models/exports"
+	"crm/internal/interactor/models/filter"
 	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/projection"
 	"crm/internal/interactor/models/section"
 	"crm/internal/interactor/models/sort"
 )
 
+// Relations are the relations of a lead that can be expanded in a list.
+var Relations = projection.Relations{
+	"account":         {Association: "Accounts", Display: "name"},
+	"salesperson":     {Association: "Salespeople", Display: "name"},
+	"created_by_user": {Association: "CreatedByUsers", Display: "name"},
+	"updated_by_user": {Association: "UpdatedByUsers", Display: "name"},
+}
+
+// Columns are the columns of an exported lead list, relations are exported by their display names.
+var Columns = exports.Columns{
+	{Key: "lead_id", Chinese: "線索ID", English: "Lead ID"},
+	{Key: "status", Chinese: "線索狀態", English: "Status"},
+	{Key: "description", Chinese: "線索描述", English: "Description"},
+	{Key: "source", Chinese: "線索來源", English: "Source"},
+	{Key: "account_name", Chinese: "帳戶", English: "Account"},
+	{Key: "rating", Chinese: "線索分級", English: "Rating"},
+	{Key: "salesperson_name", Chinese: "業務員", English: "Salesperson"},
+	{Key: "created_by", Chinese: "創建者", English: "Created By"},
+	{Key: "updated_by", Chinese: "更新者", English: "Updated By"},
+	{Key: "created_at", Chinese: "創建時間", English: "Created At"},
+	{Key: "updated_at", Chinese: "更新時間", English: "Updated At"},
+}
+
 // Create struct is used to create achieves
 type Create struct {
-	// 線索狀態
-	Status string `json:"status,omitempty" binding:"required" validate:"required"`
+	// 線索狀態,依公司的選項清單,未設定時為 lead.status 代碼
+	Status string `json:"status,omitempty" binding:"required" validate:"required" example:"lead.status.new"`
 	// 線索描述
 	Description string `json:"description,omitempty" binding:"required" validate:"required"`
 	// 線索來源
@@ -18,6 +45,10 @@ type Create struct {
 	AccountID string `json:"account_id,omitempty" binding:"required,uuid4" validate:"required,uuid4"`
 	// 線索分級
 	Rating string `json:"rating,omitempty"`
+	// 業務員ID,不帶入時為創建者
+	SalespersonID string `json:"salesperson_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4"`
+	// 自訂欄位,以欄位鍵值為鍵,未帶入的欄位使用預設值
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 	// 創建者
 	CreatedBy string `json:"created_by,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
 }
@@ -48,8 +79,16 @@ type Fields struct {
 	Filter `json:"filter"`
 	// 分頁搜尋結構檔
 	page.Pagination
+	// 游標分頁結構檔
+	page.Cursor
+	// 回傳欄位與展開關聯
+	projection.Projection
 	// 排序欄位
 	sort.Sort `json:"sort"`
+	// 公司ID,用於自訂欄位的搜尋及排序
+	CompanyID string `json:"-" swaggerignore:"true"`
+	// 自訂欄位的搜尋及排序欄位
+	CustomColumns filter.Fields `json:"-" swaggerignore:"true"`
 }
 
 // FieldsNoPagination is the searched structure file (including filter)
@@ -74,6 +113,8 @@ type Filter struct {
 	FilterStatus []string `json:"status,omitempty"`
 	// 業務員名稱
 	FilterSalespersonName string `json:"salesperson_name,omitempty"`
+	// 組合搜尋條件,可使用 and/or 群組,自訂欄位為 custom_fields.<key>
+	Where *filter.Expression `json:"where,omitempty"`
 }
 
 // FilterNoPagination struct is used to store the search field no pagination
@@ -110,6 +151,8 @@ type List struct {
 		SalespersonID string `json:"salesperson_id,omitempty"`
 		// 業務員名稱
 		SalespersonName string `json:"salesperson_name,omitempty"`
+		// 自訂欄位
+		CustomFields map[string]any `json:"custom_fields,omitempty"`
 		// 創建者
 		CreatedBy string `json:"created_by,omitempty"`
 		// 更新者
@@ -119,6 +162,8 @@ type List struct {
 	} `json:"leads"`
 	// 分頁返回結構檔
 	page.Total
+	// 游標返回結構檔
+	page.Cursors
 }
 
 // ListNoPagination is multiple return structure files without pagination
@@ -152,6 +197,8 @@ type Single struct {
 	SalespersonID string `json:"salesperson_id,omitempty"`
 	// 業務員名稱
 	SalespersonName string `json:"salesperson_name,omitempty"`
+	// 自訂欄位
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 	// 創建者
 	CreatedBy string `json:"created_by,omitempty"`
 	// 更新者
@@ -164,7 +211,9 @@ type Single struct {
 type Update struct {
 	// 線索ID
 	LeadID string `json:"lead_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4" swaggerignore:"true"`
-	// 線索狀態
+	// 版本(If-Match)
+	IfMatch string `json:"-" swaggerignore:"true"`
+	// 線索狀態,依公司的選項清單,未設定時為 lead.status 代碼
 	Status *string `json:"status,omitempty"`
 	// 線索描述
 	Description *string `json:"description,omitempty"`
@@ -174,6 +223,30 @@ type Update struct {
 	Rating *string `json:"rating,omitempty"`
 	// 業務員ID
 	SalespersonID *string `json:"salesperson_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4"`
+	// 自訂欄位,以欄位鍵值為鍵,僅更新帶入的欄位,帶入 null 時清除
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 	// 更新者
 	UpdatedBy *string `json:"updated_by,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
 }
+
+// Bulk struct is used to create, update and delete leads at once
+type Bulk struct {
+	bulk.Mode
+	// 新增的線索(最多500筆)
+	Create []*Create `json:"create,omitempty" binding:"max=500"`
+	// 更新的線索,須帶入lead_id(最多500筆)
+	Update []*BulkUpdate `json:"update,omitempty" binding:"max=500"`
+	// 刪除的線索ID(最多500筆)
+	Delete []string `json:"delete,omitempty" binding:"max=500"`
+	// 操作者
+	UserID string `json:"-" swaggerignore:"true"`
+	// 公司是否要求 If-Match,要求時更新項目須帶入 if_match
+	IfMatchRequired bool `json:"-" swaggerignore:"true"`
+}
+
+// BulkUpdate is an update of a bulk request with the version of the lead it expects
+type BulkUpdate struct {
+	Update
+	// 版本(ETag),與單筆更新的 If-Match 相同
+	IfMatch string `json:"if_match,omitempty"`
+}
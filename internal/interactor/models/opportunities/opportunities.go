@@ -1,6 +1,9 @@
 package opportunities
 
 import (
+	"crm/internal/interactor/models/bulk"
+	"crm/internal/interactor/models/exports"
+	"crm/internal/interactor/models/filter"
 	"time"
 
 	"crm/internal/interactor/models/sort"
@@ -8,9 +11,37 @@ import (
 	"crm/internal/interactor/models/opportunity_campaigns"
 
 	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/projection"
 	"crm/internal/interactor/models/section"
 )
 
+// Relations are the relations of an opportunity that can be expanded in a list.
+var Relations = projection.Relations{
+	"lead":            {Association: "Leads", Display: "description"},
+	"account":         {Association: "Accounts", Display: "name"},
+	"salesperson":     {Association: "Salespeople", Display: "name"},
+	"created_by_user": {Association: "CreatedByUsers", Display: "name"},
+	"updated_by_user": {Association: "UpdatedByUsers", Display: "name"},
+	"campaigns":       {Association: "OpportunityCampaigns"},
+}
+
+// Columns are the columns of an exported opportunity list, relations are exported by their display names.
+var Columns = exports.Columns{
+	{Key: "opportunity_id", Chinese: "商機ID", English: "Opportunity ID"},
+	{Key: "name", Chinese: "商機名稱", English: "Name"},
+	{Key: "stage", Chinese: "商機階段", English: "Stage"},
+	{Key: "forecast_category", Chinese: "商機預測種類", English: "Forecast Category"},
+	{Key: "close_date", Chinese: "商機結束日期", English: "Close Date"},
+	{Key: "lead_description", Chinese: "線索", English: "Lead"},
+	{Key: "account_name", Chinese: "帳戶", English: "Account"},
+	{Key: "amount", Chinese: "預期收入金額", English: "Amount"},
+	{Key: "salesperson_name", Chinese: "業務員", English: "Salesperson"},
+	{Key: "created_by", Chinese: "創建者", English: "Created By"},
+	{Key: "updated_by", Chinese: "更新者", English: "Updated By"},
+	{Key: "created_at", Chinese: "創建時間", English: "Created At"},
+	{Key: "updated_at", Chinese: "更新時間", English: "Updated At"},
+}
+
 // Create struct is used to create achieves
 type Create struct {
 	// 商機名稱
@@ -27,6 +58,8 @@ type Create struct {
 	AccountID string `json:"account_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4"`
 	// 預期收入金額
 	Amount float64 `json:"amount,omitempty" binding:"omitempty,gte=0" validate:"omitempty,gte=0"`
+	// 自訂欄位,以欄位鍵值為鍵,未帶入的欄位使用預設值
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 	// 創建者
 	CreatedBy string `json:"created_by,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
 }
@@ -61,8 +94,16 @@ type Fields struct {
 	Filter `json:"filter"`
 	// 分頁搜尋結構檔
 	page.Pagination
+	// 游標分頁結構檔
+	page.Cursor
+	// 回傳欄位與展開關聯
+	projection.Projection
 	// 排序欄位
 	sort.Sort `json:"sort"`
+	// 公司ID,用於自訂欄位的搜尋及排序
+	CompanyID string `json:"-" swaggerignore:"true"`
+	// 自訂欄位的搜尋及排序欄位
+	CustomColumns filter.Fields `json:"-" swaggerignore:"true"`
 }
 
 // FieldsNoPagination is the searched structure file (including filter)
@@ -83,6 +124,8 @@ type Filter struct {
 	FilterStage []string `json:"stage,omitempty"`
 	// 業務員名稱
 	FilterSalespersonName string `json:"salesperson_name,omitempty"`
+	// 組合搜尋條件,可使用 and/or 群組,自訂欄位為 custom_fields.<key>
+	Where *filter.Expression `json:"where,omitempty"`
 }
 
 // FilterNoPagination struct is used to store the search field no pagination
@@ -121,6 +164,8 @@ type List struct {
 		SalespersonID string `json:"salesperson_id,omitempty"`
 		// 業務員名稱
 		SalespersonName string `json:"salesperson_name,omitempty"`
+		// 自訂欄位
+		CustomFields map[string]any `json:"custom_fields,omitempty"`
 		// 創建者
 		CreatedBy string `json:"created_by,omitempty"`
 		// 更新者
@@ -130,6 +175,8 @@ type List struct {
 	} `json:"opportunities"`
 	// 分頁返回結構檔
 	page.Total
+	// 游標返回結構檔
+	page.Cursors
 }
 
 // ListNoPagination is multiple return structure files without pagination
@@ -169,6 +216,8 @@ type Single struct {
 	SalespersonID string `json:"salesperson_id,omitempty"`
 	// 業務員名稱
 	SalespersonName string `json:"salesperson_name,omitempty"`
+	// 自訂欄位
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 	// 創建者
 	CreatedBy string `json:"created_by,omitempty"`
 	// 更新者
@@ -203,6 +252,8 @@ type SingleCampaigns struct {
 	SalespersonID string `json:"salesperson_id,omitempty"`
 	// 業務員名稱
 	SalespersonName string `json:"salesperson_name,omitempty"`
+	// 自訂欄位
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 	// 創建者
 	CreatedBy string `json:"created_by,omitempty"`
 	// 更新者
@@ -217,6 +268,8 @@ type SingleCampaigns struct {
 type Update struct {
 	// 商機ID
 	OpportunityID string `json:"opportunity_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4" swaggerignore:"true"`
+	// 版本(If-Match)
+	IfMatch string `json:"-" swaggerignore:"true"`
 	// 商機名稱
 	Name *string `json:"name,omitempty"`
 	// 商機階段
@@ -229,6 +282,30 @@ type Update struct {
 	Amount *float64 `json:"amount,omitempty" binding:"omitempty,gte=0" validate:"omitempty,gte=0"`
 	// 業務員ID
 	SalespersonID *string `json:"salesperson_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4"`
+	// 自訂欄位,以欄位鍵值為鍵,僅更新帶入的欄位,帶入 null 時清除
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 	// 更新者
 	UpdatedBy *string `json:"updated_by,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
 }
+
+// Bulk struct is used to create, update and delete opportunities at once
+type Bulk struct {
+	bulk.Mode
+	// 新增的商機(最多500筆)
+	Create []*Create `json:"create,omitempty" binding:"max=500"`
+	// 更新的商機,須帶入opportunity_id(最多500筆)
+	Update []*BulkUpdate `json:"update,omitempty" binding:"max=500"`
+	// 刪除的商機ID(最多500筆)
+	Delete []string `json:"delete,omitempty" binding:"max=500"`
+	// 操作者
+	UserID string `json:"-" swaggerignore:"true"`
+	// 公司是否要求 If-Match,要求時更新項目須帶入 if_match
+	IfMatchRequired bool `json:"-" swaggerignore:"true"`
+}
+
+// BulkUpdate is an update of a bulk request with the version of the opportunity it expects
+type BulkUpdate struct {
+	Update
+	// 版本(ETag),與單筆更新的 If-Match 相同
+	IfMatch string `json:"if_match,omitempty"`
+}
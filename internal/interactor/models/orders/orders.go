@@ -1,6 +1,8 @@
 package orders
 
 import (
+	"crm/internal/interactor/models/exports"
+	"crm/internal/interactor/models/filter"
 	"time"
 
 	"crm/internal/interactor/models/sort"
@@ -8,13 +10,42 @@ import (
 	"crm/internal/interactor/models/order_products"
 
 	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/projection"
 	"crm/internal/interactor/models/section"
 )
 
+// Relations are the relations of an order that can be expanded in a list.
+var Relations = projection.Relations{
+	"account":           {Association: "Accounts", Display: "name"},
+	"contract":          {Association: "Contracts", Display: "code"},
+	"created_by_user":   {Association: "CreatedByUsers", Display: "name"},
+	"updated_by_user":   {Association: "UpdatedByUsers", Display: "name"},
+	"activated_by_user": {Association: "ActivatedByUsers", Display: "name"},
+	"products":          {Association: "OrderProducts", Preload: true},
+}
+
+// Columns are the columns of an exported order list, relations are exported by their display names.
+var Columns = exports.Columns{
+	{Key: "order_id", Chinese: "訂單ID", English: "Order ID"},
+	{Key: "code", Chinese: "訂單號碼", English: "Order Number"},
+	{Key: "status", Chinese: "訂單狀態", English: "Status"},
+	{Key: "start_date", Chinese: "訂單開始日期", English: "Start Date"},
+	{Key: "account_name", Chinese: "帳戶", English: "Account"},
+	{Key: "contract_code", Chinese: "契約號碼", English: "Contract Number"},
+	{Key: "description", Chinese: "訂單描述", English: "Description"},
+	{Key: "grand_total", Chinese: "訂單總計", English: "Grand Total"},
+	{Key: "activated_by", Chinese: "啟用者", English: "Activated By"},
+	{Key: "activated_at", Chinese: "啟用時間", English: "Activated At"},
+	{Key: "created_by", Chinese: "創建者", English: "Created By"},
+	{Key: "updated_by", Chinese: "更新者", English: "Updated By"},
+	{Key: "created_at", Chinese: "創建時間", English: "Created At"},
+	{Key: "updated_at", Chinese: "更新時間", English: "Updated At"},
+}
+
 // Create struct is used to create achieves
 type Create struct {
 	// 訂單狀態
-	Status string `json:"status,omitempty" binding:"required" validate:"required"`
+	Status string `json:"status,omitempty" binding:"required,enum=order.status" validate:"required" example:"order.status.draft"`
 	// 訂單開始日期
 	StartDate time.Time `json:"start_date,omitempty" binding:"required" validate:"required"`
 	// 帳戶ID
@@ -53,6 +84,10 @@ type Fields struct {
 	Filter `json:"filter"`
 	// 分頁搜尋結構檔
 	page.Pagination
+	// 游標分頁結構檔
+	page.Cursor
+	// 回傳欄位與展開關聯
+	projection.Projection
 	// 排序欄位
 	sort.Sort `json:"sort"`
 }
@@ -67,6 +102,8 @@ type Filter struct {
 	FilterContractCode string `json:"contract_code,omitempty"`
 	// 訂單狀態
 	FilterStatus string `json:"status,omitempty"`
+	// 組合搜尋條件,可使用 and/or 群組
+	Where *filter.Expression `json:"where,omitempty"`
 }
 
 // List is multiple return structure files
@@ -106,6 +143,8 @@ type List struct {
 	} `json:"orders"`
 	// 分頁返回結構檔
 	page.Total
+	// 游標返回結構檔
+	page.Cursors
 }
 
 // Single return structure file
@@ -180,8 +219,10 @@ type SingleProducts struct {
 type Update struct {
 	// 訂單ID
 	OrderID string `json:"order_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4" swaggerignore:"true"`
+	// 版本(If-Match)
+	IfMatch string `json:"-" swaggerignore:"true"`
 	// 訂單狀態
-	Status *string `json:"status,omitempty"`
+	Status *string `json:"status,omitempty" binding:"omitempty,enum=order.status"`
 	// 訂單開始日期
 	StartDate *time.Time `json:"start_date,omitempty"`
 	// 帳戶ID
@@ -1,8 +1,10 @@
 package page
 
+import "errors"
+
 type Pagination struct {
-	// 頁數(請從1開始帶入)
-	Page int64 `json:"page" binding:"required,gt=0" validate:"required,gt=0" form:"page"`
+	// 頁數(請從1開始帶入,不帶入時使用游標分頁)
+	Page int64 `json:"page" binding:"omitempty,gt=0" validate:"omitempty,gt=0" form:"page"`
 	// 筆數(請從1開始帶入,最高上限20)
 	Limit int64 `json:"limit" binding:"required,gt=0" validate:"required,gt=0" form:"limit"`
 }
@@ -10,8 +12,27 @@ type Pagination struct {
 type Total struct {
 	// 頁數結構
 	Pagination
-	// 總筆數
+	// 總筆數(未計算時為-1)
 	Total int64 `json:"total"`
 	// 總頁數
 	Pages int64 `json:"pages"`
 }
+
+// Cursor is the keyset pagination, used when Page is left out.
+type Cursor struct {
+	// 游標(帶入回傳的 next_cursor 或 prev_cursor,第一頁不帶入)
+	Cursor string `json:"cursor,omitempty" form:"cursor" swaggerignore:"true"`
+	// 總筆數計算方式(exact 精確, approximate 估計, none 不計算),游標分頁預設為 none
+	Count string `json:"count,omitempty" form:"count" swaggerignore:"true"`
+}
+
+// Cursors are returned by a keyset paginated list.
+type Cursors struct {
+	// 下一頁游標
+	NextCursor string `json:"next_cursor,omitempty"`
+	// 上一頁游標
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// ErrInvalid is wrapped by every error caused by the cursor or count given by the client.
+var ErrInvalid = errors.New("invalid pagination")
@@ -0,0 +1,111 @@
+package picklists
+
+import (
+	"time"
+
+	"crm/internal/interactor/models/enums"
+)
+
+// Entities lists the fields of each data type that can have a picklist.
+var Entities = map[string][]string{
+	"opportunities": {"stage", "forecast_category"},
+	"leads":         {"status", "source", "rating"},
+	"campaigns":     {"type", "status"},
+	"events":        {"type"},
+	"accounts":      {"type"},
+}
+
+// Dependencies lists the field that controls the values of a dependent picklist.
+var Dependencies = map[string]map[string]string{
+	"opportunities": {"forecast_category": "stage"},
+}
+
+// SystemValues are the values the system sets by itself, they stay valid whatever the picklist of a company holds.
+// A field without a picklist of the company only accepts its system values.
+var SystemValues = map[string]map[string][]string{
+	"leads": {"status": enums.Codes(enums.LeadStatus)},
+}
+
+// Create struct is used to create the picklist of a field
+type Create struct {
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+	// 資料類型(opportunities, leads, campaigns, events, accounts)
+	Entity string `json:"entity,omitempty" binding:"required,oneof=opportunities leads campaigns events accounts" validate:"required,oneof=opportunities leads campaigns events accounts"`
+	// 欄位(opportunities: stage, forecast_category; leads: status, source, rating; campaigns: type, status; events: type; accounts: type)
+	Field string `json:"field,omitempty" binding:"required" validate:"required"`
+	// 控制欄位(opportunities 的 forecast_category 可依 stage),不帶入時選項不相依
+	ControllingField string `json:"controlling_field,omitempty"`
+	// 選項
+	Values []*Value `json:"values,omitempty" binding:"required,min=1,dive" validate:"required,min=1,dive"`
+	// 創建者
+	CreatedBy string `json:"created_by,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+}
+
+// Value is an option of a picklist
+type Value struct {
+	// 選項值,儲存於資料中
+	Value string `json:"value" binding:"required,max=100" validate:"required,max=100" example:"prospecting"`
+	// 顯示名稱,不帶入時同選項值
+	Label string `json:"label" binding:"max=100" validate:"max=100" example:"潛在客戶"`
+	// 排序,由小到大
+	Sequence int `json:"sequence"`
+	// 是否為預設值,新增資料未帶入時使用
+	IsDefault bool `json:"is_default"`
+	// 是否啟用,停用的選項不可再選用,不帶入時為啟用
+	IsActive *bool `json:"is_active,omitempty"`
+	// 可用的控制欄位值,空白表示不限
+	ControllingValues []string `json:"controlling_values,omitempty"`
+}
+
+// Field is structure file for search
+type Field struct {
+	// 選項清單ID
+	PicklistID string `json:"picklist_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4" swaggerignore:"true"`
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" swaggerignore:"true"`
+	// 資料類型(opportunities, leads, campaigns, events, accounts)
+	Entity *string `json:"entity,omitempty" form:"entity" binding:"omitempty,oneof=opportunities leads campaigns events accounts" validate:"omitempty,oneof=opportunities leads campaigns events accounts"`
+	// 欄位
+	Field *string `json:"field,omitempty" form:"field"`
+	// 語系(Accept-Language)
+	Language string `json:"-" swaggerignore:"true"`
+}
+
+// List is multiple return structure files
+type List struct {
+	// 多筆
+	Picklists []*Single `json:"picklists"`
+}
+
+// Single return structure file
+type Single struct {
+	// 選項清單ID,系統選項清單為空白
+	PicklistID string `json:"picklist_id,omitempty"`
+	// 資料類型
+	Entity string `json:"entity,omitempty"`
+	// 欄位
+	Field string `json:"field,omitempty"`
+	// 控制欄位
+	ControllingField string `json:"controlling_field,omitempty"`
+	// 選項
+	Values []*Value `json:"values"`
+	// 創建時間
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// 更新時間
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// Update struct is used to update the picklist of a field
+type Update struct {
+	// 選項清單ID
+	PicklistID string `json:"picklist_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4" swaggerignore:"true"`
+	// 公司ID
+	CompanyID string `json:"company_id,omitempty" swaggerignore:"true"`
+	// 控制欄位,空白表示取消相依
+	ControllingField *string `json:"controlling_field,omitempty"`
+	// 選項,取代全部選項
+	Values []*Value `json:"values,omitempty" binding:"omitempty,min=1,dive" validate:"omitempty,min=1,dive"`
+	// 更新者
+	UpdatedBy *string `json:"updated_by,omitempty" binding:"required,uuid4" validate:"required,uuid4" swaggerignore:"true"`
+}
@@ -1,11 +1,35 @@
 package products
 
 import (
+	"crm/internal/interactor/models/exports"
+	"crm/internal/interactor/models/filter"
 	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/projection"
 	"crm/internal/interactor/models/section"
 	"crm/internal/interactor/models/sort"
 )
 
+// Relations are the relations of a product that can be expanded in a list.
+var Relations = projection.Relations{
+	"created_by_user": {Association: "CreatedByUsers", Display: "name"},
+	"updated_by_user": {Association: "UpdatedByUsers", Display: "name"},
+}
+
+// Columns are the columns of an exported product list, relations are exported by their display names.
+var Columns = exports.Columns{
+	{Key: "product_id", Chinese: "產品ID", English: "Product ID"},
+	{Key: "name", Chinese: "產品名稱", English: "Name"},
+	{Key: "code", Chinese: "產品識別碼", English: "Product Code"},
+	{Key: "is_enable", Chinese: "是否啟用", English: "Enabled"},
+	{Key: "description", Chinese: "產品描述", English: "Description"},
+	{Key: "price", Chinese: "產品價格", English: "Price"},
+	{Key: "quote_price", Chinese: "產品報價金額", English: "Quoted Price"},
+	{Key: "created_by", Chinese: "創建者", English: "Created By"},
+	{Key: "updated_by", Chinese: "更新者", English: "Updated By"},
+	{Key: "created_at", Chinese: "創建時間", English: "Created At"},
+	{Key: "updated_at", Chinese: "更新時間", English: "Updated At"},
+}
+
 // Create struct is used to create achieves
 type Create struct {
 	// 產品名稱
@@ -48,6 +72,10 @@ type Fields struct {
 	Filter `json:"filter"`
 	// 分頁搜尋結構檔
 	page.Pagination
+	// 游標分頁結構檔
+	page.Cursor
+	// 回傳欄位與展開關聯
+	projection.Projection
 	// 排序欄位
 	sort.Sort `json:"sort"`
 }
@@ -60,6 +88,8 @@ type Filter struct {
 	FilterCode string `json:"code,omitempty"`
 	// 產品描述
 	FilterDescription string `json:"description,omitempty"`
+	// 組合搜尋條件,可使用 and/or 群組
+	Where *filter.Expression `json:"where,omitempty"`
 }
 
 // List is multiple return structure files
@@ -89,6 +119,8 @@ type List struct {
 	} `json:"products"`
 	// 分頁返回結構檔
 	page.Total
+	// 游標返回結構檔
+	page.Cursors
 }
 
 // Single return structure file
@@ -0,0 +1,234 @@
+package projection
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ErrInvalid is wrapped by every error caused by the fields or expand given by the client.
+var ErrInvalid = errors.New("invalid projection")
+
+// Projection struct is used to choose the fields of a response and the relations embedded in it
+type Projection struct {
+	// 回傳欄位,以逗號分隔,不帶入時回傳全部欄位
+	Fields string `json:"fields,omitempty" swaggerignore:"true"`
+	// 展開的關聯,以逗號分隔
+	Expand string `json:"expand,omitempty" swaggerignore:"true"`
+}
+
+// Relation is a relation of a record, it is joined for its display column unless it is expanded.
+type Relation struct {
+	// 關聯名稱(資料表結構檔欄位)
+	Association string
+	// 顯示欄位,一對多關聯不帶入
+	Display string
+	// 一對多關聯未展開時仍須讀取(如計算總計)
+	Preload bool
+}
+
+// Relations are the relations that can be expanded, keyed by the name used in the request.
+type Relations map[string]Relation
+
+// Columns returns the requested fields, nil when every field is requested.
+func (p *Projection) Columns() []string {
+	return split(p.Fields)
+}
+
+// Expanded returns the requested relations after checking them against relations.
+func (p *Projection) Expanded(relations Relations) ([]string, error) {
+	expanded := split(p.Expand)
+	for _, name := range expanded {
+		if _, ok := relations[name]; !ok {
+			return nil, fmt.Errorf("%w: relation %s cannot be expanded", ErrInvalid, name)
+		}
+	}
+
+	return expanded, nil
+}
+
+// Validate checks the requested fields against the items of output under key, and the requested relations against relations.
+func (p *Projection) Validate(output any, key string, relations Relations) error {
+	if _, err := p.Expanded(relations); err != nil {
+		return err
+	}
+
+	columns := p.Columns()
+	if len(columns) == 0 {
+		return nil
+	}
+
+	items, ok := field(reflect.TypeOf(output), key)
+	if !ok {
+		return fmt.Errorf("projection: %s is not a field of %T", key, output)
+	}
+
+	known := keys(items)
+	for _, column := range columns {
+		if !known[column] {
+			return fmt.Errorf("%w: unknown field %s", ErrInvalid, column)
+		}
+	}
+
+	return nil
+}
+
+// Shape keeps the requested fields of the items of output under key and embeds the expanded relations,
+// read from bases at the same index. Output is returned as is when nothing is requested.
+func (p *Projection) Shape(output any, key string, bases any, relations Relations) (any, error) {
+	columns := p.Columns()
+	expanded, err := p.Expanded(relations)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(columns) == 0 && len(expanded) == 0 {
+		return output, nil
+	}
+
+	marshal, err := json.Marshal(output)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]json.RawMessage{}
+	if err = json.Unmarshal(marshal, &body); err != nil {
+		return nil, err
+	}
+
+	var items []map[string]json.RawMessage
+	if err = json.Unmarshal(body[key], &items); err != nil {
+		return nil, err
+	}
+
+	values := reflect.ValueOf(bases)
+	for i, item := range items {
+		if len(columns) > 0 {
+			for name := range item {
+				if !slices.Contains(columns, name) {
+					delete(item, name)
+				}
+			}
+		}
+
+		base := reflect.Indirect(values.Index(i))
+		for _, name := range expanded {
+			item[name], err = embed(base.FieldByName(relations[name].Association))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	body[key], err = json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// embed marshals an expanded relation with its own columns only, dropping the nested relations and search structures.
+func embed(relation reflect.Value) (json.RawMessage, error) {
+	marshal, err := json.Marshal(relation.Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	if relation.Kind() == reflect.Slice {
+		var records []map[string]json.RawMessage
+		if err = json.Unmarshal(marshal, &records); err != nil {
+			return nil, err
+		}
+
+		for _, record := range records {
+			flatten(record)
+		}
+
+		return json.Marshal(records)
+	}
+
+	record := map[string]json.RawMessage{}
+	if err = json.Unmarshal(marshal, &record); err != nil {
+		return nil, err
+	}
+
+	flatten(record)
+	if len(record) == 0 {
+		return json.RawMessage("null"), nil
+	}
+
+	return json.Marshal(record)
+}
+
+// flatten drops the nested objects and the pagination of a record.
+func flatten(record map[string]json.RawMessage) {
+	delete(record, "page")
+	delete(record, "limit")
+	for name, value := range record {
+		if len(value) > 0 && (value[0] == '{' || value[0] == '[') {
+			delete(record, name)
+		}
+	}
+}
+
+// field returns the element type of the slice field of t tagged key.
+func field(t reflect.Type, key string) (reflect.Type, bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name == key {
+			elem := f.Type
+			for elem.Kind() == reflect.Pointer || elem.Kind() == reflect.Slice {
+				elem = elem.Elem()
+			}
+
+			return elem, true
+		}
+	}
+
+	return nil, false
+}
+
+// keys returns the json keys of a struct, including the ones of its embedded structs.
+func keys(t reflect.Type) map[string]bool {
+	output := map[string]bool{}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for key := range keys(f.Type) {
+				output[key] = true
+			}
+
+			continue
+		}
+
+		if name != "" && name != "-" {
+			output[name] = true
+		}
+	}
+
+	return output
+}
+
+func split(s string) []string {
+	var output []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" && !slices.Contains(output, part) {
+			output = append(output, part)
+		}
+	}
+
+	return output
+}
@@ -1,6 +1,8 @@
 package quotes
 
 import (
+	"crm/internal/interactor/models/exports"
+	"crm/internal/interactor/models/filter"
 	"time"
 
 	"crm/internal/interactor/models/sort"
@@ -8,15 +10,47 @@ import (
 	"crm/internal/interactor/models/quote_products"
 
 	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/projection"
 	"crm/internal/interactor/models/section"
 )
 
+// Relations are the relations of a quote that can be expanded in a list.
+var Relations = projection.Relations{
+	"opportunity":     {Association: "Opportunities", Display: "name"},
+	"created_by_user": {Association: "CreatedByUsers", Display: "name"},
+	"updated_by_user": {Association: "UpdatedByUsers", Display: "name"},
+	"products":        {Association: "QuoteProducts", Preload: true},
+}
+
+// Columns are the columns of an exported quote list, relations are exported by their display names.
+var Columns = exports.Columns{
+	{Key: "quote_id", Chinese: "報價ID", English: "Quote ID"},
+	{Key: "code", Chinese: "報價號碼", English: "Quote Number"},
+	{Key: "name", Chinese: "報價名稱", English: "Name"},
+	{Key: "status", Chinese: "報價狀態", English: "Status"},
+	{Key: "is_syncing", Chinese: "與商機同步", English: "Syncing"},
+	{Key: "is_final", Chinese: "最終版", English: "Final"},
+	{Key: "opportunity_name", Chinese: "商機", English: "Opportunity"},
+	{Key: "expiration_date", Chinese: "報價到期日期", English: "Expiration Date"},
+	{Key: "description", Chinese: "報價描述", English: "Description"},
+	{Key: "tax", Chinese: "報價稅額", English: "Tax"},
+	{Key: "shipping_and_handling", Chinese: "運費及其他費用", English: "Shipping and Handling"},
+	{Key: "sub_total", Chinese: "報價小計", English: "Subtotal"},
+	{Key: "total_price", Chinese: "報價總價", English: "Total Price"},
+	{Key: "discount", Chinese: "報價折扣", English: "Discount"},
+	{Key: "grand_total", Chinese: "報價總計", English: "Grand Total"},
+	{Key: "created_by", Chinese: "創建者", English: "Created By"},
+	{Key: "updated_by", Chinese: "更新者", English: "Updated By"},
+	{Key: "created_at", Chinese: "創建時間", English: "Created At"},
+	{Key: "updated_at", Chinese: "更新時間", English: "Updated At"},
+}
+
 // Create struct is used to create achieves
 type Create struct {
 	// 報價名稱
 	Name string `json:"name,omitempty" binding:"required" validate:"required"`
 	// 報價狀態
-	Status string `json:"status,omitempty" binding:"required" validate:"required"`
+	Status string `json:"status,omitempty" binding:"required,enum=quote.status" validate:"required" example:"quote.status.draft"`
 	// 報價與商機是否同步化
 	IsSyncing bool `json:"is_syncing,omitempty"`
 	// 商機ID
@@ -71,6 +105,10 @@ type Fields struct {
 	Filter `json:"filter"`
 	// 分頁搜尋結構檔
 	page.Pagination
+	// 游標分頁結構檔
+	page.Cursor
+	// 回傳欄位與展開關聯
+	projection.Projection
 	// 排序欄位
 	sort.Sort `json:"sort"`
 }
@@ -83,6 +121,8 @@ type Filter struct {
 	FilterOpportunityName string `json:"opportunity_name,omitempty"`
 	// 報價狀態
 	FilterStatus string `json:"status,omitempty"`
+	// 組合搜尋條件,可使用 and/or 群組
+	Where *filter.Expression `json:"where,omitempty"`
 }
 
 // List is multiple return structure files
@@ -132,6 +172,8 @@ type List struct {
 	} `json:"quotes"`
 	// 分頁返回結構檔
 	page.Total
+	// 游標返回結構檔
+	page.Cursors
 }
 
 // Single return structure file
@@ -228,10 +270,12 @@ type SingleProducts struct {
 type Update struct {
 	// 產品ID
 	QuoteID string `json:"quote_id,omitempty" binding:"omitempty,uuid4" validate:"omitempty,uuid4" swaggerignore:"true"`
+	// 版本(If-Match)
+	IfMatch string `json:"-" swaggerignore:"true"`
 	// 報價名稱
 	Name *string `json:"name,omitempty"`
 	// 報價狀態
-	Status *string `json:"status,omitempty"`
+	Status *string `json:"status,omitempty" binding:"omitempty,enum=quote.status"`
 	// 報價與商機是否同步化
 	IsSyncing *bool `json:"is_syncing,omitempty"`
 	// 報價是否為最終版
@@ -0,0 +1,56 @@
+package recycle_bins
+
+import (
+	"time"
+
+	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/section"
+)
+
+// Fields is structure file for search
+type Fields struct {
+	// 資料類型
+	Entity string `json:"entity,omitempty" binding:"required,oneof=accounts contacts leads contracts opportunities orders quotes products campaigns events" validate:"required,oneof=accounts contacts leads contracts opportunities orders quotes products campaigns events" swaggerignore:"true"`
+	// 刪除時間區間
+	section.ManagementExclusive
+	// 分頁
+	page.Pagination
+}
+
+// List is multiple return structure files
+type List struct {
+	// 多筆
+	RecycleBins []*Single `json:"recycle_bins"`
+	// 分頁返回結構檔
+	page.Total
+}
+
+// Single return structure file
+type Single struct {
+	// 資料ID
+	ID string `json:"id"`
+	// 標題
+	Title string `json:"title"`
+	// 刪除時間
+	DeletedAt time.Time `json:"deleted_at"`
+	// 刪除者
+	DeletedBy string `json:"deleted_by"`
+	// 刪除者名稱
+	DeletedByName string `json:"deleted_by_name"`
+}
+
+// Records struct is used to restore or purge deleted records
+type Records struct {
+	// 資料類型
+	Entity string `json:"-" binding:"required,oneof=accounts contacts leads contracts opportunities orders quotes products campaigns events" validate:"required,oneof=accounts contacts leads contracts opportunities orders quotes products campaigns events" swaggerignore:"true"`
+	// 資料ID(最多100筆)
+	IDs []string `json:"ids,omitempty" binding:"required,min=1,max=100,dive,uuid4" validate:"required,min=1,max=100,dive,uuid4"`
+	// 異動者
+	ModifiedBy string `json:"-" swaggerignore:"true"`
+}
+
+// Result is returned by a restore or a purge
+type Result struct {
+	// 已處理的資料ID
+	IDs []string `json:"ids"`
+}
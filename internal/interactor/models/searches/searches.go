@@ -0,0 +1,45 @@
+package searches
+
+// Types are the searchable record types, a type is visible to the roles allowed to list it.
+var Types = map[string]string{
+	"account":     "/crm/v1.0/accounts/list",
+	"contact":     "/crm/v1.0/contacts/list",
+	"lead":        "/crm/v1.0/leads/list",
+	"opportunity": "/crm/v1.0/opportunities/list",
+	"quote":       "/crm/v1.0/quotes/list",
+	"contract":    "/crm/v1.0/contracts/list",
+	"order":       "/crm/v1.0/orders/list",
+	"product":     "/crm/v1.0/products/list",
+}
+
+// Field is structure file for search
+type Field struct {
+	// 搜尋字串(公司名稱、電話、契約編號等)
+	Q string `json:"q,omitempty" form:"q" binding:"required,min=2,max=100" validate:"required,min=2,max=100"`
+	// 搜尋類型,以逗號分隔,不帶入時搜尋全部類型
+	Types string `json:"types,omitempty" form:"types"`
+	// 筆數(最高上限20)
+	Limit int64 `json:"limit,omitempty" form:"limit"`
+	// 角色可讀取的類型
+	Visible []string `json:"-" swaggerignore:"true"`
+}
+
+// List is multiple return structure files
+type List struct {
+	// 多筆
+	Results []*Result `json:"results"`
+}
+
+// Result is a record matching the search
+type Result struct {
+	// 資料類型(account, contact, lead, opportunity, quote, contract, order, product)
+	Type string `json:"type"`
+	// 資料ID
+	ID string `json:"id"`
+	// 標題
+	Title string `json:"title"`
+	// 相關度
+	Rank float64 `json:"rank"`
+	// 符合的欄位,符合的文字以 <em></em> 標示
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
@@ -30,3 +30,8 @@ type ManagementExclusive struct {
 	// 刪除的結束時間
 	DelEndAt *time.Time `json:"del_end_at,omitempty" form:"del_end_at"`
 }
+
+// Version returns the version of the record, it is exposed as the ETag.
+func (t TimeAt) Version() *time.Time {
+	return t.UpdatedAt
+}
@@ -1,9 +1,80 @@
 package sort
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalid is wrapped by every error caused by the sort given by the client.
+var ErrInvalid = errors.New("invalid sort")
+
+// 排序欄位數上限
+const maxColumns = 5
+
 // Sort struct is used to sort
 type Sort struct {
 	// 排序欄位
 	Field string `json:"field"`
 	// 排序方式
 	Direction string `json:"direction"`
+	// 多欄排序,格式為 field:direction 並以逗號分隔,如 stage:asc,amount:desc
+	By string `json:"by,omitempty"`
+}
+
+// Clause validates the requested order against the allow-list columns, keyed by the field name used in the request.
+// Without a requested order the newest records come first by createdAt, the primary key always breaks ties.
+func (s *Sort) Clause(columns map[string]string, createdAt, primaryKey string) (clause.OrderBy, error) {
+	spec := s.By
+	if spec == "" && s.Field != "" {
+		spec = s.Field + ":" + s.Direction
+	}
+
+	orderBy := clause.OrderBy{}
+	seen := map[string]bool{}
+	for _, pair := range strings.Split(spec, ",") {
+		field, direction, _ := strings.Cut(strings.TrimSpace(pair), ":")
+		if field == "" {
+			continue
+		}
+
+		column, ok := columns[field]
+		if !ok {
+			return orderBy, fmt.Errorf("%w: field %q is not sortable", ErrInvalid, field)
+		}
+
+		if seen[field] {
+			return orderBy, fmt.Errorf("%w: field %q is given twice", ErrInvalid, field)
+		}
+
+		direction = strings.ToLower(strings.TrimSpace(direction))
+		if direction != "" && direction != "asc" && direction != "desc" {
+			return orderBy, fmt.Errorf("%w: direction of %q must be asc or desc", ErrInvalid, field)
+		}
+
+		seen[field] = true
+		orderBy.Columns = append(orderBy.Columns, clause.OrderByColumn{
+			Column: clause.Column{Name: column, Raw: true},
+			Desc:   direction == "desc",
+		})
+	}
+
+	if len(orderBy.Columns) > maxColumns {
+		return orderBy, fmt.Errorf("%w: at most %d fields", ErrInvalid, maxColumns)
+	}
+
+	if len(orderBy.Columns) == 0 {
+		orderBy.Columns = append(orderBy.Columns, clause.OrderByColumn{
+			Column: clause.Column{Name: createdAt, Raw: true},
+			Desc:   true,
+		})
+	}
+
+	orderBy.Columns = append(orderBy.Columns, clause.OrderByColumn{
+		Column: clause.Column{Name: primaryKey, Raw: true},
+	})
+
+	return orderBy, nil
 }
@@ -0,0 +1,85 @@
+package sort
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"gorm.io/gorm/clause"
+)
+
+var columns = map[string]string{
+	"name":       "opportunities.name",
+	"stage":      "opportunities.stage",
+	"amount":     "opportunities.amount",
+	"close_date": "opportunities.close_date",
+	"created_at": "opportunities.created_at",
+	"updated_at": "opportunities.updated_at",
+}
+
+// order renders the columns as "name" or "name desc".
+func order(orderBy clause.OrderBy) []string {
+	var output []string
+	for _, column := range orderBy.Columns {
+		item := column.Column.Name
+		if column.Desc {
+			item += " desc"
+		}
+
+		output = append(output, item)
+	}
+
+	return output
+}
+
+func TestClause(t *testing.T) {
+	tests := []struct {
+		name string
+		sort Sort
+		want []string
+	}{
+		{"default newest first", Sort{}, []string{"opportunities.created_at desc", "opportunities.opportunity_id"}},
+		{"single field", Sort{Field: "name", Direction: "asc"}, []string{"opportunities.name", "opportunities.opportunity_id"}},
+		{"single field desc", Sort{Field: "amount", Direction: "desc"}, []string{"opportunities.amount desc", "opportunities.opportunity_id"}},
+		{"direction is optional", Sort{By: "stage"}, []string{"opportunities.stage", "opportunities.opportunity_id"}},
+		{"direction is case insensitive", Sort{By: "stage:DESC"}, []string{"opportunities.stage desc", "opportunities.opportunity_id"}},
+		{"several fields", Sort{By: "stage:asc, amount:desc"}, []string{"opportunities.stage", "opportunities.amount desc", "opportunities.opportunity_id"}},
+		{"by wins over field", Sort{Field: "name", Direction: "asc", By: "amount:desc"}, []string{"opportunities.amount desc", "opportunities.opportunity_id"}},
+		{"empty pairs are skipped", Sort{By: "stage:asc,,"}, []string{"opportunities.stage", "opportunities.opportunity_id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.sort.Clause(columns, "opportunities.created_at", "opportunities.opportunity_id")
+			if err != nil {
+				t.Fatalf("Clause() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(order(got), tt.want) {
+				t.Errorf("Clause() = %v, want %v", order(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestClauseInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		sort Sort
+	}{
+		{"unknown field", Sort{By: "password:asc"}},
+		{"raw sql", Sort{By: "name; drop table users"}},
+		{"bad direction", Sort{By: "name:up"}},
+		{"field twice", Sort{By: "name:asc,name:desc"}},
+		{"too many fields", Sort{By: "name,stage,amount,close_date,created_at,updated_at"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.sort.Clause(columns, "opportunities.created_at", "opportunities.opportunity_id")
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("Clause() error = %v, want ErrInvalid", err)
+			}
+		})
+	}
+}
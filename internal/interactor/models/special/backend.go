@@ -6,6 +6,7 @@ import (
 	"gorm.io/gorm"
 
 	"crm/internal/interactor/models/page"
+	"crm/internal/interactor/models/projection"
 	"crm/internal/interactor/models/section"
 )
 
@@ -33,6 +34,10 @@ type Base struct {
 	section.TimeAt
 	// 引入page
 	page.Pagination
+	// 游標分頁
+	page.Cursor
+	// 回傳欄位與展開關聯
+	projection.Projection
 	// 開始結束時間
 	section.StartEnd
 	// 開始結束時間
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"crm/config"
+	"crm/internal/interactor/pkg/connect"
+)
+
+// Kinds of cached records.
+const (
+	Role           = "role"
+	User           = "user"
+	Industry       = "industry"
+	Product        = "product"
+	Picklist       = "picklist"
+	CustomField    = "custom_field"
+	CompanySetting = "company_setting"
+)
+
+// Store keeps JSON encoded values for a limited time.
+type Store interface {
+	// Get decodes the value of key into output and reports whether it was found.
+	Get(key string, output any) (found bool, err error)
+	// Set stores input under key for ttl.
+	Set(key string, input any, ttl time.Duration) (err error)
+	// Delete removes keys.
+	Delete(keys ...string) (err error)
+}
+
+var (
+	defaultStore Store
+	defaultOnce  sync.Once
+)
+
+// Default uses redis when it is configured so that every instance sees the same invalidations,
+// otherwise an in-process LRU.
+func Default() Store {
+	defaultOnce.Do(func() {
+		redisDB, err := connect.Redis()
+		if err != nil || redisDB == nil {
+			defaultStore = NewMemory(config.CacheSize)
+			return
+		}
+
+		defaultStore = NewRedis(redisDB)
+	})
+
+	return defaultStore
+}
+
+// Key returns the key of a record of kind.
+func Key(kind, id string) string {
+	return kind + ":" + id
+}
+
+// TTL is how long a record is kept.
+func TTL() time.Duration {
+	return config.CacheTTL * time.Second
+}
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+type memory struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// NewMemory returns an in-process store that evicts the least recently used entry beyond size entries.
+func NewMemory(size int) Store {
+	return &memory{
+		size:    size,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+func (m *memory) Get(key string, output any) (found bool, err error) {
+	m.mu.Lock()
+	element, ok := m.entries[key]
+	if !ok {
+		m.mu.Unlock()
+		return false, nil
+	}
+
+	e := element.Value.(*entry)
+	if time.Now().After(e.expires) {
+		m.remove(element)
+		m.mu.Unlock()
+		return false, nil
+	}
+
+	m.order.MoveToFront(element)
+	value := e.value
+	m.mu.Unlock()
+
+	if err = json.Unmarshal(value, output); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (m *memory) Set(key string, input any, ttl time.Duration) (err error) {
+	value, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		element.Value = &entry{key: key, value: value, expires: time.Now().Add(ttl)}
+		m.order.MoveToFront(element)
+		return nil
+	}
+
+	m.entries[key] = m.order.PushFront(&entry{key: key, value: value, expires: time.Now().Add(ttl)})
+	for m.order.Len() > m.size {
+		m.remove(m.order.Back())
+	}
+
+	return nil
+}
+
+func (m *memory) Delete(keys ...string) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		if element, ok := m.entries[key]; ok {
+			m.remove(element)
+		}
+	}
+
+	return nil
+}
+
+func (m *memory) remove(element *list.Element) {
+	m.order.Remove(element)
+	delete(m.entries, element.Value.(*entry).key)
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"crm/internal/interactor/pkg/redis"
+)
+
+type redisStore struct {
+	db     redis.DB
+	prefix string
+}
+
+// NewRedis returns a store shared by every instance through redis.
+func NewRedis(db redis.DB) Store {
+	return &redisStore{
+		db:     db,
+		prefix: "cache:",
+	}
+}
+
+func (r *redisStore) Get(key string, output any) (found bool, err error) {
+	value, err := r.db.First(redis.String, r.prefix+key)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if err = json.Unmarshal(value, output); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *redisStore) Set(key string, input any, ttl time.Duration) (err error) {
+	value, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	return r.db.Create(redis.String, r.prefix+key, value, ttl)
+}
+
+func (r *redisStore) Delete(keys ...string) (err error) {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = r.prefix + key
+	}
+
+	return r.db.Delete(prefixed...)
+}
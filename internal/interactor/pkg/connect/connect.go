@@ -2,11 +2,13 @@ package connect
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"crm/config"
 
 	dbConfig "crm/internal/interactor/pkg/connect/postgres"
+	"crm/internal/interactor/pkg/redis"
 	"crm/internal/interactor/pkg/util"
 	"crm/internal/interactor/pkg/util/log"
 
@@ -35,3 +37,23 @@ func PostgresSQL() (db *gorm.DB, err error) {
 
 	return db, nil
 }
+
+// Redis connects to the configured redis, it returns nil when no redis address is configured.
+func Redis() (db redis.DB, err error) {
+	if config.RedisAddress == "" {
+		return nil, nil
+	}
+
+	redisConfig := redis.Config{}
+	redisConfig.Address = util.PointerString(config.RedisAddress)
+	redisConfig.Port = util.PointerString(strconv.Itoa(config.RedisPort))
+	redisConfig.Password = util.PointerString(config.RedisPassword)
+	redisConfig.DB = util.PointerInt64(config.RedisDB)
+	db, err = redisConfig.Connect()
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -0,0 +1,518 @@
+// Package graphql executes GraphQL requests against a schema whose fields are resolved in batches.
+// A field is resolved once for every object of a level, so a relation is read with one query
+// whatever the number of objects it is read for.
+package graphql
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// maxDepth is the deepest level of objects a request may select.
+const maxDepth = 10
+
+// Resolver resolves a field for all the parents of a level at once, it returns one value per parent.
+type Resolver func(request *Request, field *Field, parents []any) ([]any, error)
+
+// Resolvers are the resolvers of a schema by type and field name. A field without a resolver
+// reads the value of its name from parents of type map[string]any.
+type Resolvers map[string]map[string]Resolver
+
+// Schema is a GraphQL schema with its resolvers.
+type Schema struct {
+	schema    *ast.Schema
+	sdl       string
+	resolvers Resolvers
+}
+
+// Request is a GraphQL request.
+type Request struct {
+	// 查詢
+	Query string
+	// 執行的操作名稱
+	OperationName string
+	// 變數
+	Variables map[string]any
+	// 傳給 resolver 的內容
+	Context any
+}
+
+// Field is a field being resolved.
+type Field struct {
+	// 欄位名稱
+	Name string
+	// 參數
+	Arguments map[string]any
+	// 第一個上層物件中的位置
+	Path ast.Path
+}
+
+// Response is the result of a GraphQL request.
+type Response struct {
+	// 資料
+	Data any `json:"data"`
+	// 錯誤
+	Errors gqlerror.List `json:"errors,omitempty"`
+}
+
+// NewSchema parses sdl and checks that every resolver names a field of the schema.
+func NewSchema(sdl string, resolvers Resolvers) (*Schema, error) {
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: sdl})
+	if err != nil {
+		return nil, err
+	}
+
+	for name, fields := range resolvers {
+		definition := schema.Types[name]
+		if definition == nil {
+			return nil, fmt.Errorf("graphql: unknown type %s", name)
+		}
+
+		for field := range fields {
+			if definition.Fields.ForName(field) == nil {
+				return nil, fmt.Errorf("graphql: unknown field %s.%s", name, field)
+			}
+		}
+	}
+
+	return &Schema{schema: schema, sdl: sdl, resolvers: resolvers}, nil
+}
+
+// MustSchema is like NewSchema but panics on error.
+func MustSchema(sdl string, resolvers Resolvers) *Schema {
+	schema, err := NewSchema(sdl, resolvers)
+	if err != nil {
+		panic(err)
+	}
+
+	return schema
+}
+
+// SDL returns the schema definition.
+func (s *Schema) SDL() string {
+	return s.sdl
+}
+
+// Execute validates and runs the operation of request. The fields of a mutation run one after another,
+// with everything they select, in the order of the request. A field failing or resolving null where its type
+// is non-null nulls the closest nullable field above it.
+func (s *Schema) Execute(request *Request) (response *Response) {
+	document, errs := gqlparser.LoadQueryWithRules(s.schema, request.Query, nil)
+	if len(errs) > 0 {
+		return &Response{Errors: errs}
+	}
+
+	operation := document.Operations.ForName(request.OperationName)
+	if operation == nil {
+		return &Response{Errors: gqlerror.List{gqlerror.Errorf("operation %q not found", request.OperationName)}}
+	}
+
+	variables, err := validator.VariableValues(s.schema, operation, request.Variables)
+	if err != nil {
+		return &Response{Errors: gqlerror.List{gqlerror.WrapIfUnwrapped(err)}}
+	}
+
+	root := s.schema.Query
+	switch operation.Operation {
+	case ast.Mutation:
+		root = s.schema.Mutation
+	case ast.Subscription:
+		return &Response{Errors: gqlerror.List{gqlerror.Errorf("subscriptions are not supported")}}
+	}
+
+	e := &execution{schema: s, request: request, document: document, variables: variables}
+	defer func() {
+		if r := recover(); r != nil {
+			response = &Response{Errors: append(e.errors, gqlerror.Errorf("internal error: %v", r))}
+		}
+	}()
+
+	objects := e.selections(root, operation.SelectionSet, []any{nil}, []ast.Path{nil}, 1)
+
+	return &Response{Data: propagate(objects[0], ast.NamedType(root.Name, nil)), Errors: e.errors}
+}
+
+// execution is the state of a running request.
+type execution struct {
+	schema    *Schema
+	request   *Request
+	document  *ast.QueryDocument
+	variables map[string]any
+	errors    gqlerror.List
+}
+
+// object is a resolved object, it keeps its fields in the order of the request.
+type object struct {
+	keys   []string
+	types  []*ast.Type
+	values []any
+}
+
+func (o *object) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		name, _ := json.Marshal(key)
+		value, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(name)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// group is the fields of a selection set sharing a response key.
+type group struct {
+	key    string
+	fields []*ast.Field
+}
+
+// pending is the objects of a level waiting for their own selection set.
+type pending struct {
+	objects []any
+	paths   []ast.Path
+	slots   []*any
+}
+
+// selections resolves set on every parent of type definition, one field at a time for all of them.
+func (e *execution) selections(definition *ast.Definition, set ast.SelectionSet, parents []any, paths []ast.Path, depth int) []*object {
+	groups := e.collect(definition, set, nil, map[string]bool{})
+	keys := make([]string, len(groups))
+	types := make([]*ast.Type, len(groups))
+	for i, g := range groups {
+		keys[i] = g.key
+		types[i] = g.fields[0].Definition.Type
+	}
+
+	objects := make([]*object, len(parents))
+	for i := range objects {
+		objects[i] = &object{keys: keys, types: types, values: make([]any, len(groups))}
+	}
+
+	for j, g := range groups {
+		field := g.fields[0]
+		fieldPaths := make([]ast.Path, len(parents))
+		slots := make([]*any, len(parents))
+		for i := range parents {
+			fieldPaths[i] = extend(paths[i], ast.PathName(g.key))
+			slots[i] = &objects[i].values[j]
+		}
+
+		values, err := e.resolve(definition, field, parents, fieldPaths)
+		if err != nil {
+			e.fail(field, fieldPaths[0], err)
+			continue
+		}
+
+		var children ast.SelectionSet
+		for _, f := range g.fields {
+			children = append(children, f.SelectionSet...)
+		}
+
+		p := &pending{}
+		for i, value := range values {
+			e.complete(field, field.Definition.Type, value, fieldPaths[i], slots[i], p)
+		}
+
+		if len(p.objects) == 0 {
+			continue
+		}
+
+		if depth >= maxDepth {
+			e.fail(field, p.paths[0], fmt.Errorf("the query is deeper than %d levels", maxDepth))
+			continue
+		}
+
+		results := e.selections(e.schema.schema.Types[field.Definition.Type.Name()], children, p.objects, p.paths, depth+1)
+		for i, slot := range p.slots {
+			*slot = results[i]
+		}
+	}
+
+	return objects
+}
+
+// collect groups the fields of set that apply to definition by response key, in the order of the request.
+func (e *execution) collect(definition *ast.Definition, set ast.SelectionSet, groups []*group, visited map[string]bool) []*group {
+	for _, selection := range set {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if !e.included(selection.Directives) {
+				continue
+			}
+
+			key := cmp.Or(selection.Alias, selection.Name)
+			index := slices.IndexFunc(groups, func(g *group) bool { return g.key == key })
+			if index < 0 {
+				groups = append(groups, &group{key: key})
+				index = len(groups) - 1
+			}
+
+			groups[index].fields = append(groups[index].fields, selection)
+		case *ast.FragmentSpread:
+			if !e.included(selection.Directives) || visited[selection.Name] {
+				continue
+			}
+
+			visited[selection.Name] = true
+			fragment := e.document.Fragments.ForName(selection.Name)
+			if fragment != nil && e.applies(definition, fragment.TypeCondition) {
+				groups = e.collect(definition, fragment.SelectionSet, groups, visited)
+			}
+		case *ast.InlineFragment:
+			if e.included(selection.Directives) && (selection.TypeCondition == "" || e.applies(definition, selection.TypeCondition)) {
+				groups = e.collect(definition, selection.SelectionSet, groups, visited)
+			}
+		}
+	}
+
+	return groups
+}
+
+// included evaluates the @skip and @include directives of a selection.
+func (e *execution) included(directives ast.DirectiveList) bool {
+	if skip := directives.ForName("skip"); skip != nil && skip.ArgumentMap(e.variables)["if"] == true {
+		return false
+	}
+
+	if include := directives.ForName("include"); include != nil && include.ArgumentMap(e.variables)["if"] == false {
+		return false
+	}
+
+	return true
+}
+
+// applies reports whether a fragment on condition applies to objects of type definition.
+func (e *execution) applies(definition *ast.Definition, condition string) bool {
+	if condition == definition.Name {
+		return true
+	}
+
+	if t := e.schema.schema.Types[condition]; t != nil {
+		for _, possible := range e.schema.schema.GetPossibleTypes(t) {
+			if possible.Name == definition.Name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// resolve returns the values of field for every parent.
+func (e *execution) resolve(definition *ast.Definition, field *ast.Field, parents []any, paths []ast.Path) ([]any, error) {
+	values := make([]any, len(parents))
+	switch {
+	case field.Name == "__typename":
+		for i := range values {
+			values[i] = definition.Name
+		}
+
+		return values, nil
+	case strings.HasPrefix(field.Name, "__"):
+		return nil, errors.New("introspection is not supported, the schema is served by GET /crm/v1.0/graphql/schema")
+	}
+
+	resolver := e.schema.resolvers[definition.Name][field.Name]
+	if resolver == nil {
+		for i, parent := range parents {
+			if record, ok := parent.(map[string]any); ok {
+				values[i] = record[field.Name]
+			}
+		}
+
+		return values, nil
+	}
+
+	values, err := resolver(e.request, &Field{
+		Name:      field.Name,
+		Arguments: field.ArgumentMap(e.variables),
+		Path:      paths[0],
+	}, parents)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) != len(parents) {
+		return nil, fmt.Errorf("graphql: %s.%s resolved %d values for %d objects", definition.Name, field.Name, len(values), len(parents))
+	}
+
+	return values, nil
+}
+
+// complete writes value of type t into slot, objects are left to p to be resolved with the other objects of their level.
+func (e *execution) complete(field *ast.Field, t *ast.Type, value any, path ast.Path, slot *any, p *pending) {
+	if isNil(value) {
+		if t.NonNull {
+			e.fail(field, path, errors.New("cannot return null for a non-null field"))
+		}
+
+		*slot = nil
+		return
+	}
+
+	if t.Elem != nil {
+		items := reflect.ValueOf(value)
+		if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+			e.fail(field, path, fmt.Errorf("expected a list, got %T", value))
+			return
+		}
+
+		list := make([]any, items.Len())
+		*slot = list
+		for i := range list {
+			e.complete(field, t.Elem, items.Index(i).Interface(), extend(path, ast.PathIndex(i)), &list[i], p)
+		}
+
+		return
+	}
+
+	definition := e.schema.schema.Types[t.NamedType]
+	switch definition.Kind {
+	case ast.Object, ast.Interface, ast.Union:
+		p.objects = append(p.objects, value)
+		p.paths = append(p.paths, path)
+		p.slots = append(p.slots, slot)
+	default:
+		output, err := serialize(t.NamedType, value)
+		if err != nil {
+			e.fail(field, path, err)
+			return
+		}
+
+		*slot = output
+	}
+}
+
+// fail records the error of a field, the message and extensions of a *gqlerror.Error are kept.
+func (e *execution) fail(field *ast.Field, path ast.Path, err error) {
+	output := &gqlerror.Error{Message: err.Error()}
+	var gqlErr *gqlerror.Error
+	if errors.As(err, &gqlErr) {
+		output.Message = gqlErr.Message
+		output.Extensions = gqlErr.Extensions
+	}
+
+	output.Path = path
+	if field.Position != nil {
+		output.Locations = []gqlerror.Location{{Line: field.Position.Line, Column: field.Position.Column}}
+	}
+
+	e.errors = append(e.errors, output)
+}
+
+// propagate returns value of type t once every null of its non-null fields and list items is propagated
+// to the closest nullable field or list item above it, the error of the null is already recorded.
+// It returns nil when value itself becomes null.
+func propagate(value any, t *ast.Type) any {
+	if value == nil {
+		return nil
+	}
+
+	if t.Elem != nil {
+		list, _ := value.([]any)
+		for i := range list {
+			list[i] = propagate(list[i], t.Elem)
+			if list[i] == nil && t.Elem.NonNull {
+				return nil
+			}
+		}
+
+		return value
+	}
+
+	if o, ok := value.(*object); ok {
+		for i := range o.values {
+			o.values[i] = propagate(o.values[i], o.types[i])
+			if o.values[i] == nil && o.types[i].NonNull {
+				return nil
+			}
+		}
+	}
+
+	return value
+}
+
+// serialize converts a leaf value to the built-in scalar name, enums and custom scalars are returned as is.
+func serialize(name string, value any) (any, error) {
+	switch name {
+	case "Int":
+		switch value := value.(type) {
+		case json.Number:
+			if n, err := value.Int64(); err == nil {
+				return n, nil
+			}
+
+			f, err := value.Float64()
+			return int64(f), err
+		case float64:
+			return int64(value), nil
+		case int, int32, int64:
+			return value, nil
+		}
+	case "Float":
+		switch value := value.(type) {
+		case json.Number:
+			return value.Float64()
+		case float64, int, int32, int64:
+			return value, nil
+		}
+	case "String", "ID":
+		switch value := value.(type) {
+		case string:
+			return value, nil
+		case json.Number:
+			return value.String(), nil
+		default:
+			return fmt.Sprint(value), nil
+		}
+	case "Boolean":
+		if value, ok := value.(bool); ok {
+			return value, nil
+		}
+	default:
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("cannot use %T as %s", value, name)
+}
+
+// isNil reports whether value is nil or a nil pointer, map or slice.
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	}
+
+	return false
+}
+
+// extend returns a copy of path with element appended.
+func extend(path ast.Path, element ast.PathElement) ast.Path {
+	return append(slices.Clip(path), element)
+}
@@ -0,0 +1,254 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sdl = `
+type Query {
+  author(id: ID!): Author
+  authors(missing: Boolean): [Author!]!
+  broken: [Author]
+  failing: String
+  node: Node
+}
+
+type Mutation {
+  add(n: Int!): Int
+}
+
+type Author {
+  id: ID!
+  name: String!
+  nickname: String
+  books: [Book!]!
+}
+
+type Book {
+  title: String!
+  pages: Int
+}
+
+type Node {
+  value: Int
+  child: Node
+}
+`
+
+var authors = map[string]map[string]any{
+	"1": {"id": "1", "name": "Ann", "nickname": "A"},
+	"2": {"id": "2", "name": "Bob"},
+	"3": {"id": "3", "name": nil},
+}
+
+var books = map[string][]any{
+	"1": {map[string]any{"title": "Go"}, map[string]any{"title": "SQL", "pages": 300}},
+	"2": {},
+}
+
+// calls counts the calls of each resolver of a request.
+type calls map[string]int
+
+// testSchema returns a schema whose resolvers count their calls in calls and append the added numbers to added.
+func testSchema(calls calls, added *[]int64) *Schema {
+	return MustSchema(sdl, Resolvers{
+		"Query": {
+			"author": func(request *Request, field *Field, parents []any) ([]any, error) {
+				calls["author"]++
+				id, _ := field.Arguments["id"].(string)
+				if author, ok := authors[id]; ok {
+					return []any{author}, nil
+				}
+
+				return []any{nil}, nil
+			},
+			"authors": func(request *Request, field *Field, parents []any) ([]any, error) {
+				calls["authors"]++
+				list := []any{authors["1"], authors["2"]}
+				if field.Arguments["missing"] == true {
+					list = append(list, authors["3"])
+				}
+
+				return []any{list}, nil
+			},
+			"broken": func(request *Request, field *Field, parents []any) ([]any, error) {
+				return []any{}, nil
+			},
+			"failing": func(request *Request, field *Field, parents []any) ([]any, error) {
+				return nil, errors.New("failed")
+			},
+			"node": func(request *Request, field *Field, parents []any) ([]any, error) {
+				return []any{map[string]any{"value": 1}}, nil
+			},
+		},
+		"Mutation": {
+			"add": func(request *Request, field *Field, parents []any) ([]any, error) {
+				n, _ := field.Arguments["n"].(int64)
+				*added = append(*added, n)
+				return []any{n}, nil
+			},
+		},
+		"Author": {
+			"books": func(request *Request, field *Field, parents []any) ([]any, error) {
+				calls["books"]++
+				values := make([]any, len(parents))
+				for i, parent := range parents {
+					values[i] = books[parent.(map[string]any)["id"].(string)]
+				}
+
+				return values, nil
+			},
+		},
+		"Node": {
+			"child": func(request *Request, field *Field, parents []any) ([]any, error) {
+				values := make([]any, len(parents))
+				for i, parent := range parents {
+					values[i] = map[string]any{"value": parent.(map[string]any)["value"].(int) + 1}
+				}
+
+				return values, nil
+			},
+		},
+	})
+}
+
+// messages returns the errors of response as path: message.
+func messages(response *Response) []string {
+	var output []string
+	for _, err := range response.Errors {
+		output = append(output, err.Path.String()+": "+err.Message)
+	}
+
+	return output
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		variables  map[string]any
+		wantData   string
+		wantErrors []string
+	}{
+		{"aliases and merged fields",
+			`{ a: author(id: "1") { id name } b: author(id: "2") { name } author(id: "1") { id } author(id: "1") { nickname } }`, nil,
+			`{"a":{"id":"1","name":"Ann"},"b":{"name":"Bob"},"author":{"id":"1","nickname":"A"}}`, nil},
+		{"fragments",
+			`query { author(id: "1") { ...names ... on Author { books { title } } } } fragment names on Author { name nickname }`, nil,
+			`{"author":{"name":"Ann","nickname":"A","books":[{"title":"Go"},{"title":"SQL"}]}}`, nil},
+		{"skip and include by variables",
+			`query($skip: Boolean!, $include: Boolean!) { author(id: "1") { id name @skip(if: $skip) nickname @include(if: $include) ...books @skip(if: true) } } fragment books on Author { books { title } }`,
+			map[string]any{"skip": true, "include": false},
+			`{"author":{"id":"1"}}`, nil},
+		{"skip and include not applied",
+			`query($skip: Boolean!, $include: Boolean!) { author(id: "1") { id name @skip(if: $skip) nickname @include(if: $include) } }`,
+			map[string]any{"skip": false, "include": true},
+			`{"author":{"id":"1","name":"Ann","nickname":"A"}}`, nil},
+		{"typename",
+			`{ author(id: "2") { __typename id } }`, nil,
+			`{"author":{"__typename":"Author","id":"2"}}`, nil},
+		{"nullable fields stay null",
+			`{ author(id: "2") { nickname } missing: author(id: "9") { id } }`, nil,
+			`{"author":{"nickname":null},"missing":null}`, nil},
+		{"null in a non-null field nulls the nullable parent",
+			`{ author(id: "3") { id name } other: author(id: "1") { id } }`, nil,
+			`{"author":null,"other":{"id":"1"}}`, []string{"author.name: cannot return null for a non-null field"}},
+		{"null propagates through non-null list items and fields to the data",
+			`{ authors(missing: true) { name } }`, nil,
+			`null`, []string{"authors[2].name: cannot return null for a non-null field"}},
+		{"failed field is null and keeps the other fields",
+			`{ failing author(id: "2") { id } }`, nil,
+			`{"failing":null,"author":{"id":"2"}}`, []string{"failing: failed"}},
+		{"wrong number of values",
+			`{ broken { id } }`, nil,
+			`{"broken":null}`, []string{"broken: graphql: Query.broken resolved 0 values for 1 objects"}},
+		{"deepest level allowed",
+			`{ node {` + strings.Repeat(" child {", 8) + " value" + strings.Repeat(" }", 8) + ` } }`, nil,
+			`{"node":{"child":{"child":{"child":{"child":{"child":{"child":{"child":{"child":{"value":9}}}}}}}}}}`, nil},
+		{"deeper than maxDepth",
+			`{ node {` + strings.Repeat(" child {", 9) + " value" + strings.Repeat(" }", 9) + ` } }`, nil,
+			`{"node":{"child":{"child":{"child":{"child":{"child":{"child":{"child":{"child":{"child":null}}}}}}}}}}`,
+			[]string{"node.child.child.child.child.child.child.child.child.child: the query is deeper than 10 levels"}},
+		{"invalid query",
+			`{ author(id: "1") { unknown } }`, nil,
+			`null`, []string{`: Cannot query field "unknown" on type "Author".`}},
+		{"introspection",
+			`{ __schema { queryType { name } } }`, nil,
+			`null`, []string{"__schema: introspection is not supported, the schema is served by GET /crm/v1.0/graphql/schema"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := testSchema(calls{}, nil).Execute(&Request{Query: tt.query, Variables: tt.variables})
+			data, err := json.Marshal(response.Data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(data) != tt.wantData {
+				t.Errorf("Execute() data = %s, want %s", data, tt.wantData)
+			}
+
+			if got := messages(response); !reflect.DeepEqual(got, tt.wantErrors) {
+				t.Errorf("Execute() errors = %q, want %q", got, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestExecuteBatches(t *testing.T) {
+	calls := calls{}
+	response := testSchema(calls, nil).Execute(&Request{Query: `{ authors { name books { title } } more: authors { books { pages } } }`})
+	if len(response.Errors) > 0 {
+		t.Fatalf("Execute() errors = %v", response.Errors)
+	}
+
+	// 同一層的物件只解析一次,別名各自解析
+	if want := (map[string]int{"authors": 2, "books": 2}); !reflect.DeepEqual(map[string]int(calls), want) {
+		t.Errorf("Execute() calls = %v, want %v", calls, want)
+	}
+}
+
+func TestExecuteMutations(t *testing.T) {
+	var added []int64
+	response := testSchema(calls{}, &added).Execute(&Request{
+		Query:         `query Read { failing } mutation Write { c: add(n: 3) a: add(n: 1) b: add(n: 2) }`,
+		OperationName: "Write",
+	})
+	if len(response.Errors) > 0 {
+		t.Fatalf("Execute() errors = %v", response.Errors)
+	}
+
+	if want := []int64{3, 1, 2}; !reflect.DeepEqual(added, want) {
+		t.Errorf("Execute() ran the mutations in order %v, want %v", added, want)
+	}
+
+	data, _ := json.Marshal(response.Data)
+	if want := `{"c":3,"a":1,"b":2}`; string(data) != want {
+		t.Errorf("Execute() data = %s, want %s", data, want)
+	}
+}
+
+func TestNewSchema(t *testing.T) {
+	tests := []struct {
+		name      string
+		resolvers Resolvers
+		wantErr   bool
+	}{
+		{"known fields", Resolvers{"Author": {"books": nil}}, false},
+		{"unknown type", Resolvers{"Publisher": {"name": nil}}, true},
+		{"unknown field", Resolvers{"Author": {"publisher": nil}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSchema(sdl, tt.resolvers); (err != nil) != tt.wantErr {
+				t.Errorf("NewSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package limiter
+
+import (
+	"math"
+	"time"
+)
+
+// Rule is a token bucket that allows Limit requests per Period.
+type Rule struct {
+	// 期間內可用的請求數
+	Limit int
+	// 期間
+	Period time.Duration
+}
+
+// Result is the outcome of taking one token from a bucket.
+type Result struct {
+	// 是否允許
+	Allowed bool
+	// 請求上限
+	Limit int
+	// 剩餘請求數
+	Remaining int
+	// 桶子補滿所需時間
+	Reset time.Duration
+	// 可重試的等待時間
+	RetryAfter time.Duration
+}
+
+// Bucket is the rule applied to the requests of a key.
+type Bucket struct {
+	// 桶子的鍵值
+	Key string
+	Rule
+}
+
+// Store keeps the state of the buckets.
+type Store interface {
+	// Take removes one token from every bucket when each of them has one left, otherwise it takes none.
+	// The results are in the order of buckets, Allowed telling whether the bucket had a token left.
+	Take(buckets []Bucket, now time.Time) (output []*Result, err error)
+}
+
+// rate returns the number of tokens added per second.
+func (r Rule) rate() float64 {
+	return float64(r.Limit) / r.Period.Seconds()
+}
+
+// result describes the bucket after a take or peek, tokens being what is left in it.
+func result(rule Rule, tokens float64, allowed bool) *Result {
+	output := &Result{
+		Allowed:   allowed,
+		Limit:     rule.Limit,
+		Remaining: int(tokens),
+		Reset:     seconds((float64(rule.Limit) - tokens) / rule.rate()),
+	}
+	if !allowed {
+		output.RetryAfter = seconds((1 - tokens) / rule.rate())
+	}
+
+	return output
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s)) * time.Second
+}
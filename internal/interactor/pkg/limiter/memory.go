@@ -0,0 +1,78 @@
+package limiter
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	period time.Duration
+}
+
+type memory struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+// NewMemory returns a store that keeps the buckets in process memory.
+func NewMemory() Store {
+	return &memory{
+		buckets: map[string]*bucket{},
+	}
+}
+
+// Take refills the buckets and takes a token from each of them under one lock,
+// so that a concurrent request cannot drain a bucket between the check and the take.
+func (m *memory) Take(buckets []Bucket, now time.Time) (output []*Result, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sweep(now)
+	states := make([]*bucket, len(buckets))
+	allowed := true
+	for i, item := range buckets {
+		b, ok := m.buckets[item.Key]
+		if !ok {
+			b = &bucket{
+				tokens: float64(item.Limit),
+				last:   now,
+				period: item.Period,
+			}
+			m.buckets[item.Key] = b
+		}
+
+		b.tokens = math.Min(float64(item.Limit), b.tokens+now.Sub(b.last).Seconds()*item.rate())
+		b.last = now
+		allowed = allowed && b.tokens >= 1
+		states[i] = b
+	}
+
+	output = make([]*Result, len(buckets))
+	for i, b := range states {
+		if allowed {
+			b.tokens--
+		}
+
+		output[i] = result(buckets[i].Rule, b.tokens, allowed || b.tokens >= 1)
+	}
+
+	return output, nil
+}
+
+// sweep removes the buckets that are full again, at most once a minute.
+func (m *memory) sweep(now time.Time) {
+	if now.Sub(m.swept) < time.Minute {
+		return
+	}
+
+	m.swept = now
+	for key, b := range m.buckets {
+		if now.Sub(b.last) > b.period {
+			delete(m.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package limiter
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemory(t *testing.T) {
+	rule := Rule{Limit: 3, Period: 3 * time.Second}
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	type step struct {
+		after time.Duration
+		want  Result
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"takes until empty then denies", []step{
+			{after: 0, want: Result{Allowed: true, Limit: 3, Remaining: 2, Reset: time.Second}},
+			{after: 0, want: Result{Allowed: true, Limit: 3, Remaining: 1, Reset: 2 * time.Second}},
+			{after: 0, want: Result{Allowed: true, Limit: 3, Remaining: 0, Reset: 3 * time.Second}},
+			{after: 0, want: Result{Allowed: false, Limit: 3, Remaining: 0, Reset: 3 * time.Second, RetryAfter: time.Second}},
+		}},
+		{"refills over time", []step{
+			{after: 0, want: Result{Allowed: true, Limit: 3, Remaining: 2, Reset: time.Second}},
+			{after: 0, want: Result{Allowed: true, Limit: 3, Remaining: 1, Reset: 2 * time.Second}},
+			{after: 0, want: Result{Allowed: true, Limit: 3, Remaining: 0, Reset: 3 * time.Second}},
+			{after: 500 * time.Millisecond, want: Result{Allowed: false, Limit: 3, Remaining: 0, Reset: 3 * time.Second, RetryAfter: time.Second}},
+			{after: time.Second, want: Result{Allowed: true, Limit: 3, Remaining: 0, Reset: 3 * time.Second}},
+			{after: time.Hour, want: Result{Allowed: true, Limit: 3, Remaining: 2, Reset: time.Second}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemory()
+			now := start
+			for i, s := range tt.steps {
+				now = now.Add(s.after)
+				got, err := store.Take([]Bucket{{Key: "key", Rule: rule}}, now)
+				if err != nil {
+					t.Fatalf("step %d: error = %v", i, err)
+				}
+
+				if *got[0] != s.want {
+					t.Errorf("step %d: got %+v, want %+v", i, *got[0], s.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryBuckets(t *testing.T) {
+	wide := Bucket{Key: "company", Rule: Rule{Limit: 3, Period: time.Minute}}
+	narrow := Bucket{Key: "user", Rule: Rule{Limit: 1, Period: time.Minute}}
+	other := Bucket{Key: "other", Rule: Rule{Limit: 1, Period: time.Minute}}
+	now := time.Now()
+	tests := []struct {
+		name    string
+		buckets []Bucket
+		want    []bool
+	}{
+		{"every bucket has a token", []Bucket{wide, narrow}, []bool{true, true}},
+		{"one empty bucket denies", []Bucket{wide, narrow}, []bool{true, false}},
+		{"denial took nothing from the other bucket", []Bucket{wide, other}, []bool{true, true}},
+		{"other bucket is empty now", []Bucket{wide, other}, []bool{true, false}},
+		{"wide bucket still has its last token", []Bucket{wide}, []bool{true}},
+		{"wide bucket is empty", []Bucket{wide}, []bool{false}},
+	}
+
+	store := NewMemory()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.Take(tt.buckets, now)
+			if err != nil {
+				t.Fatalf("Take() error = %v", err)
+			}
+
+			for i, result := range got {
+				if result.Allowed != tt.want[i] {
+					t.Errorf("Take() bucket %s allowed = %v, want %v", tt.buckets[i].Key, result.Allowed, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryConcurrent(t *testing.T) {
+	store := NewMemory()
+	buckets := []Bucket{
+		{Key: "company", Rule: Rule{Limit: 100, Period: time.Hour}},
+		{Key: "user", Rule: Rule{Limit: 10, Period: time.Hour}},
+	}
+	now := time.Now()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := store.Take(buckets, now)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if got[0].Allowed && got[1].Allowed {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != 10 {
+		t.Errorf("allowed %d requests, want 10", allowed)
+	}
+
+	got, _ := store.Take(buckets[:1], now)
+	if got[0].Remaining != 89 {
+		t.Errorf("company bucket remaining = %d, want 89 after the denied requests took nothing", got[0].Remaining)
+	}
+}
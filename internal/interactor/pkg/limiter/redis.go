@@ -0,0 +1,82 @@
+package limiter
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript refills the buckets of KEYS and takes one token from each of them atomically,
+// or none when one of them is empty. ARGV holds the time (ms) and the limit and period (ms) of every key.
+// A bucket is stored as a hash of tokens and last refill time (ms).
+var takeScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local tokens = {}
+local allowed = 1
+for i, key in ipairs(KEYS) do
+	local limit = tonumber(ARGV[i * 2])
+	local period = tonumber(ARGV[i * 2 + 1])
+	local state = redis.call("HMGET", key, "tokens", "last")
+	local last = tonumber(state[2]) or now
+	tokens[i] = math.min(limit, (tonumber(state[1]) or limit) + math.max(0, now - last) * limit / period)
+	if tokens[i] < 1 then
+		allowed = 0
+	end
+end
+
+local output = {allowed}
+for i, key in ipairs(KEYS) do
+	local period = tonumber(ARGV[i * 2 + 1])
+	if allowed == 1 then
+		tokens[i] = tokens[i] - 1
+	end
+
+	redis.call("HSET", key, "tokens", tostring(tokens[i]), "last", now)
+	redis.call("PEXPIRE", key, period)
+	output[i + 1] = tostring(tokens[i])
+end
+
+return output
+`)
+
+type redisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedis returns a store shared by every instance connected to the same redis.
+func NewRedis(client *redis.Client) Store {
+	return &redisStore{
+		client: client,
+		prefix: "rate_limit:",
+	}
+}
+
+func (r *redisStore) Take(buckets []Bucket, now time.Time) (output []*Result, err error) {
+	keys := make([]string, len(buckets))
+	args := []any{now.UnixMilli()}
+	for i, bucket := range buckets {
+		keys[i] = r.prefix + bucket.Key
+		args = append(args, bucket.Limit, bucket.Period.Milliseconds())
+	}
+
+	values, err := takeScript.Run(context.Background(), r.client, keys, args...).Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := values[0].(int64) == 1
+	output = make([]*Result, len(buckets))
+	for i, bucket := range buckets {
+		tokens, err := strconv.ParseFloat(values[i+1].(string), 64)
+		if err != nil {
+			return nil, err
+		}
+
+		output[i] = result(bucket.Rule, tokens, allowed || tokens >= 1)
+	}
+
+	return output, nil
+}
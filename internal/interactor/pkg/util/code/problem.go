@@ -0,0 +1,145 @@
+package code
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProblemJSON is the media type of problem details (RFC 7807), clients asking for it in Accept receive errors as Problem.
+const ProblemJSON = "application/problem+json"
+
+// Error codes of the problem details, they are stable and meant to be compared by clients.
+const (
+	ErrBadRequest           = "bad_request"
+	ErrMalformedRequest     = "malformed_request"
+	ErrValidationFailed     = "validation_failed"
+	ErrUnauthorized         = "unauthorized"
+	ErrPermissionDenied     = "permission_denied"
+	ErrNotFound             = "not_found"
+	ErrMethodNotAllowed     = "method_not_allowed"
+	ErrConflict             = "conflict"
+	ErrPreconditionFailed   = "precondition_failed"
+	ErrUnsupportedMedia     = "unsupported_media_type"
+	ErrUnprocessableEntity  = "unprocessable_entity"
+	ErrPreconditionRequired = "precondition_required"
+	ErrRateLimited          = "rate_limited"
+	ErrInternal             = "internal_error"
+	ErrServiceUnavailable   = "service_unavailable"
+)
+
+// problemType prefixes the error code to form the type URI of a problem.
+const problemType = "urn:crm:problem:"
+
+var (
+	errorCodes = map[int]string{
+		400: ErrBadRequest,
+		401: ErrUnauthorized,
+		403: ErrPermissionDenied,
+		404: ErrNotFound,
+		405: ErrMethodNotAllowed,
+		409: ErrConflict,
+		412: ErrPreconditionFailed,
+		415: ErrUnsupportedMedia,
+		422: ErrUnprocessableEntity,
+		428: ErrPreconditionRequired,
+		429: ErrRateLimited,
+		500: ErrInternal,
+		503: ErrServiceUnavailable,
+	}
+)
+
+type Problem struct {
+	// 問題類型
+	Type string `json:"type" example:"urn:crm:problem:validation_failed"`
+	// 標題
+	Title string `json:"title"`
+	// HTTP狀態碼
+	Status int `json:"status"`
+	// 說明
+	Detail string `json:"detail,omitempty"`
+	// 發生問題的請求路徑
+	Instance string `json:"instance,omitempty"`
+	// 錯誤代碼
+	Code string `json:"code" example:"validation_failed"`
+	// 請求ID
+	RequestID string `json:"request_id,omitempty"`
+	// 錯誤時間
+	Timestamp string `json:"timestamp" example:"2021-07-29T07:23:47Z"`
+	// 欄位驗證錯誤
+	Errors []*FieldError `json:"errors,omitempty"`
+	// 詳細錯誤內容(如版本不符時的目前資料)
+	Detailed any `json:"detailed,omitempty"`
+}
+
+type FieldError struct {
+	// 欄位路徑
+	Field string `json:"field" example:"contacts[0].email"`
+	// 驗證規則
+	Code string `json:"code" example:"required"`
+	// 錯誤訊息
+	Message string `json:"message" example:"is required."`
+}
+
+// GetProblem returns the problem of an HTTP status and the detail of its error message.
+// A detail that is not a string is kept as detailed, the detail of a 500 is never returned since it may carry SQL.
+func GetProblem(status int, errorCode string, detailed any) *Problem {
+	if errorCode == "" {
+		errorCode = errorCodes[status]
+	}
+
+	if errorCode == "" {
+		errorCode = ErrBadRequest
+		if status >= 500 {
+			errorCode = ErrInternal
+		}
+	}
+
+	title, ok := message[status]
+	if !ok {
+		title = "HTTP " + strconv.Itoa(status)
+	}
+
+	problem := &Problem{
+		Type:      problemType + errorCode,
+		Title:     title,
+		Status:    status,
+		Code:      errorCode,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	if status >= 500 {
+		return problem
+	}
+
+	switch detail := detailed.(type) {
+	case nil:
+	case string:
+		problem.Detail = detail
+	default:
+		problem.Detailed = detail
+	}
+
+	return problem
+}
+
+// AcceptsProblem reports whether the Accept header asks for problem details.
+func AcceptsProblem(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(mediaType), ProblemJSON) {
+			continue
+		}
+
+		for _, param := range strings.Split(params, ";") {
+			if q, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if value, err := strconv.ParseFloat(q, 64); err == nil && value <= 0 {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
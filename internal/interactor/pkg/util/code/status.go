@@ -3,15 +3,20 @@ package code
 import "time"
 
 const (
-	Successful          = 200
-	Sync                = 202
-	BadRequest          = 400
-	JWTRejected         = 401
-	PermissionDenied    = 403
-	DoesNotExist        = 404
-	FormatError         = 415
-	InternalServerError = 500
-	ServerDown          = 503
+	Successful           = 200
+	Sync                 = 202
+	BadRequest           = 400
+	JWTRejected          = 401
+	PermissionDenied     = 403
+	DoesNotExist         = 404
+	Conflict             = 409
+	PreconditionFailed   = 412
+	FormatError          = 415
+	UnprocessableEntity  = 422
+	PreconditionRequired = 428
+	TooManyRequests      = 429
+	InternalServerError  = 500
+	ServerDown           = 503
 )
 
 var (
@@ -22,7 +27,12 @@ var (
 		401: "JWT rejected.",
 		403: "Permission denied.",
 		404: "Item does not exist.",
+		409: "Conflict.",
+		412: "Precondition failed.",
 		415: "Data format error.",
+		422: "Unprocessable entity.",
+		428: "Precondition required.",
+		429: "Too many requests.",
 		500: "Unexpected server error.",
 		503: "Server down.",
 	}
@@ -39,6 +49,8 @@ type SuccessfulMessage struct {
 	codeTime
 	// 正確回傳內容
 	Body any `json:"body"`
+	// 警告(如疑似重複的資料)
+	Warnings []any `json:"warnings,omitempty"`
 }
 
 type ErrorMessage struct {
@@ -69,6 +81,7 @@ func setSuccessfulMessage(code int, body any) *SuccessfulMessage {
 			time.Now().Format(time.RFC3339),
 		},
 		body,
+		nil,
 	}
 }
 
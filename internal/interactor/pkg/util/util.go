@@ -8,7 +8,9 @@ import (
 )
 
 func PointerString(s string) *string     { return &s }
+func PointerInt(i int) *int              { return &i }
 func PointerInt64(i int64) *int64        { return &i }
+func PointerFloat64(f float64) *float64  { return &f }
 func PointerBool(b bool) *bool           { return &b }
 func PointerTime(t time.Time) *time.Time { return &t }
 
@@ -0,0 +1,463 @@
+// Package xlsx reads the first worksheet of an Office Open XML workbook and streams new single sheet workbooks.
+// Cells are read as the text they hold, numbers are returned as stored and dates as 2006-01-02 or 2006-01-02 15:04:05.
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalid is returned for a file that is not a workbook.
+var ErrInvalid = errors.New("xlsx: invalid workbook")
+
+// ErrTooLarge is returned when the uncompressed parts of a workbook exceed the limit given to Read.
+var ErrTooLarge = errors.New("xlsx: workbook too large")
+
+// workbook is an archive being read, limit is the number of uncompressed bytes left to read.
+type workbook struct {
+	files map[string]*zip.File
+	limit int64
+}
+
+// Read returns the rows of the first worksheet, each padded to the widest row.
+// It stops with ErrTooLarge once the uncompressed parts it reads exceed limit bytes.
+func Read(r io.ReaderAt, size, limit int64) ([][]string, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	w := &workbook{files: map[string]*zip.File{}, limit: limit}
+	for _, file := range archive.File {
+		w.files[file.Name] = file
+	}
+
+	sheet, date1904, err := w.firstSheet()
+	if err != nil {
+		return nil, err
+	}
+
+	var shared []string
+	if file, ok := w.files["xl/sharedStrings.xml"]; ok {
+		if shared, err = w.sharedStrings(file); err != nil {
+			return nil, err
+		}
+	}
+
+	var dates []bool
+	if file, ok := w.files["xl/styles.xml"]; ok {
+		if dates, err = w.dateStyles(file); err != nil {
+			return nil, err
+		}
+	}
+
+	return w.worksheet(sheet, shared, dates, date1904)
+}
+
+// firstSheet returns the first worksheet and whether the dates of the workbook count from 1904.
+func (w *workbook) firstSheet() (*zip.File, bool, error) {
+	var workbook struct {
+		Properties struct {
+			Date1904 string `xml:"date1904,attr"`
+		} `xml:"workbookPr"`
+		Sheets []struct {
+			ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := w.decode(w.files["xl/workbook.xml"], &workbook); err != nil {
+		return nil, false, err
+	}
+
+	var relationships struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := w.decode(w.files["xl/_rels/workbook.xml.rels"], &relationships); err != nil {
+		return nil, false, err
+	}
+
+	if len(workbook.Sheets) == 0 {
+		return nil, false, fmt.Errorf("%w: no worksheet", ErrInvalid)
+	}
+
+	date1904 := workbook.Properties.Date1904 == "1" || workbook.Properties.Date1904 == "true"
+	for _, relationship := range relationships.Relationships {
+		if relationship.ID != workbook.Sheets[0].ID {
+			continue
+		}
+
+		name := strings.TrimPrefix(relationship.Target, "/")
+		if !strings.HasPrefix(name, "xl/") {
+			name = path.Join("xl", name)
+		}
+
+		if file, ok := w.files[name]; ok {
+			return file, date1904, nil
+		}
+	}
+
+	return nil, false, fmt.Errorf("%w: worksheet not found", ErrInvalid)
+}
+
+func (w *workbook) sharedStrings(file *zip.File) ([]string, error) {
+	var table struct {
+		Items []text `xml:"si"`
+	}
+	if err := w.decode(file, &table); err != nil {
+		return nil, err
+	}
+
+	output := make([]string, len(table.Items))
+	for i, item := range table.Items {
+		output[i] = item.String()
+	}
+
+	return output, nil
+}
+
+// dateStyles returns whether each cell style, indexed by the s attribute of a cell, formats a number as a date.
+func (w *workbook) dateStyles(file *zip.File) ([]bool, error) {
+	var styles struct {
+		Formats []struct {
+			ID   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		Cells []struct {
+			Format int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if err := w.decode(file, &styles); err != nil {
+		return nil, err
+	}
+
+	custom := map[int]bool{}
+	for _, format := range styles.Formats {
+		custom[format.ID] = DateFormat(format.Code)
+	}
+
+	output := make([]bool, len(styles.Cells))
+	for i, cell := range styles.Cells {
+		if date, ok := custom[cell.Format]; ok {
+			output[i] = date
+		} else {
+			output[i] = builtinDate(cell.Format)
+		}
+	}
+
+	return output, nil
+}
+
+// builtinDate reports whether a built-in number format is a date or time, including the East Asian date formats.
+func builtinDate(id int) bool {
+	return (id >= 14 && id <= 22) || (id >= 27 && id <= 36) || (id >= 45 && id <= 47) || (id >= 50 && id <= 58)
+}
+
+// DateFormat reports whether a custom number format code formats a number as a date or time.
+// Quoted text, escaped characters and bracketed sections such as colors are ignored.
+func DateFormat(code string) bool {
+	// 只看正數的格式
+	code, _, _ = strings.Cut(code, ";")
+	quoted, bracket := false, false
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+		switch {
+		case quoted:
+			quoted = c != '"'
+		case bracket:
+			// [h]:mm 等經過時間也是時間
+			if c == 'h' || c == 'm' || c == 's' || c == 'H' || c == 'M' || c == 'S' {
+				return true
+			}
+
+			bracket = c != ']'
+		case c == '"':
+			quoted = true
+		case c == '[':
+			bracket = true
+		case c == '\\' || c == '_' || c == '*':
+			i++
+		case strings.IndexByte("yYmMdDhHsS", c) >= 0:
+			return true
+		}
+	}
+
+	return false
+}
+
+// Date returns the text of a date serial, counted from 1899-12-30 or from 1904-01-01.
+// A serial with a fraction of a day holds a time.
+func Date(serial float64, date1904 bool) string {
+	epoch := time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+	if date1904 {
+		epoch = time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	days := math.Floor(serial)
+	seconds := math.Round((serial - days) * 86400)
+	date := epoch.AddDate(0, 0, int(days)).Add(time.Duration(seconds) * time.Second)
+	if seconds == 0 {
+		return date.Format(time.DateOnly)
+	}
+
+	return date.Format(time.DateTime)
+}
+
+// text is a string item, either plain or made of rich text runs.
+type text struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t text) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+
+	var builder strings.Builder
+	for _, run := range t.Runs {
+		builder.WriteString(run.T)
+	}
+
+	return builder.String()
+}
+
+func (w *workbook) worksheet(file *zip.File, shared []string, dates []bool, date1904 bool) ([][]string, error) {
+	var sheet struct {
+		Rows []struct {
+			R     int `xml:"r,attr"`
+			Cells []struct {
+				R      string `xml:"r,attr"`
+				S      int    `xml:"s,attr"`
+				T      string `xml:"t,attr"`
+				V      string `xml:"v"`
+				Inline text   `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := w.decode(file, &sheet); err != nil {
+		return nil, err
+	}
+
+	var output [][]string
+	width := 0
+	for i, row := range sheet.Rows {
+		index := i
+		if row.R > 0 {
+			index = row.R - 1
+		}
+
+		for len(output) <= index {
+			output = append(output, nil)
+		}
+
+		for j, cell := range row.Cells {
+			column := j
+			if cell.R != "" {
+				column = columnIndex(cell.R)
+			}
+
+			value := cell.V
+			switch cell.T {
+			case "s":
+				n, err := strconv.Atoi(cell.V)
+				if err != nil || n < 0 || n >= len(shared) {
+					return nil, fmt.Errorf("%w: shared string %s", ErrInvalid, cell.V)
+				}
+
+				value = shared[n]
+			case "inlineStr":
+				value = cell.Inline.String()
+			case "b":
+				value = map[string]string{"1": "TRUE", "0": "FALSE"}[cell.V]
+			case "", "n":
+				// 日期格式的數字轉為日期
+				if cell.S >= 0 && cell.S < len(dates) && dates[cell.S] {
+					if serial, err := strconv.ParseFloat(cell.V, 64); err == nil && serial >= 0 {
+						value = Date(serial, date1904)
+					}
+				}
+			}
+
+			for len(output[index]) <= column {
+				output[index] = append(output[index], "")
+			}
+
+			output[index][column] = value
+			width = max(width, len(output[index]))
+		}
+	}
+
+	for i := range output {
+		for len(output[i]) < width {
+			output[i] = append(output[i], "")
+		}
+	}
+
+	return output, nil
+}
+
+// columnIndex returns the zero based column of a cell reference such as AB12.
+func columnIndex(reference string) int {
+	index := 0
+	for _, r := range reference {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+
+		index = index*26 + int(r-'A') + 1
+	}
+
+	return index - 1
+}
+
+// decode reads a part, counting its uncompressed bytes against the limit of the workbook.
+func (w *workbook) decode(file *zip.File, v any) error {
+	if file == nil {
+		return fmt.Errorf("%w: missing part", ErrInvalid)
+	}
+
+	// 標頭記載的大小可能不實,實際讀取的位元組另外計算
+	if file.UncompressedSize64 > uint64(max(w.limit, 0)) {
+		return ErrTooLarge
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	defer reader.Close()
+
+	if err = xml.NewDecoder(&limited{reader: reader, workbook: w}).Decode(v); err != nil {
+		if errors.Is(err, ErrTooLarge) {
+			return ErrTooLarge
+		}
+
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	return nil
+}
+
+// limited reads a part until the workbook has no bytes left to read.
+type limited struct {
+	reader   io.Reader
+	workbook *workbook
+}
+
+func (l *limited) Read(p []byte) (int, error) {
+	if l.workbook.limit <= 0 {
+		// 剛好讀完上限時仍需確認是否還有資料
+		var probe [1]byte
+		n, err := l.reader.Read(probe[:])
+		if n > 0 {
+			return 0, ErrTooLarge
+		}
+
+		return 0, err
+	}
+
+	if int64(len(p)) > l.workbook.limit {
+		p = p[:l.workbook.limit]
+	}
+
+	n, err := l.reader.Read(p)
+	l.workbook.limit -= int64(n)
+	return n, err
+}
+
+// Writer streams the rows of a single sheet workbook, cells are written as inline strings.
+type Writer struct {
+	archive *zip.Writer
+	sheet   io.Writer
+	row     int
+}
+
+var parts = []struct{ name, content string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// NewWriter starts a workbook on w, Close must be called to complete it.
+func NewWriter(w io.Writer) (*Writer, error) {
+	archive := zip.NewWriter(w)
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = io.WriteString(file, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = io.WriteString(sheet, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`+
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{archive: archive, sheet: sheet}, nil
+}
+
+// WriteRow appends a row to the sheet.
+func (w *Writer) WriteRow(cells []string) error {
+	w.row++
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, `<row r="%d">`, w.row)
+	for _, cell := range cells {
+		buffer.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		if err := xml.EscapeText(&buffer, []byte(cell)); err != nil {
+			return err
+		}
+
+		buffer.WriteString(`</t></is></c>`)
+	}
+
+	buffer.WriteString(`</row>`)
+	_, err := w.sheet.Write(buffer.Bytes())
+	return err
+}
+
+// Close completes the sheet and the workbook, it does not close the underlying writer.
+func (w *Writer) Close() error {
+	if _, err := io.WriteString(w.sheet, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+
+	return w.archive.Close()
+}
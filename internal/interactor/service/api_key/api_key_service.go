@@ -0,0 +1,154 @@
+package api_key
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+
+	store "crm/internal/entity/postgresql/api_key"
+	db "crm/internal/entity/postgresql/db/api_keys"
+	model "crm/internal/interactor/models/api_keys"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/hash"
+	"crm/internal/interactor/pkg/util/log"
+	"crm/internal/interactor/pkg/util/uuid"
+
+	"gorm.io/gorm"
+)
+
+// keyPrefix marks the API keys of the CRM so that leaked keys are easy to find.
+const keyPrefix = "crm_"
+
+type Service interface {
+	WithTrx(tx *gorm.DB) Service
+	Create(input *model.Create) (output *db.Base, key string, err error)
+	GetByList(input *model.Field) (output []*db.Base, err error)
+	GetBySingle(input *model.Field) (output *db.Base, err error)
+	Delete(input *model.Field) (err error)
+}
+
+type service struct {
+	Repository store.Entity
+}
+
+func Init(db *gorm.DB) Service {
+	return &service{
+		Repository: store.Init(db),
+	}
+}
+
+func (s *service) WithTrx(tx *gorm.DB) Service {
+	return &service{
+		Repository: s.Repository.WithTrx(tx),
+	}
+}
+
+// Create issues a random key, only its hash is saved.
+func (s *service) Create(input *model.Create) (output *db.Base, key string, err error) {
+	random := make([]byte, 32)
+	if _, err = rand.Read(random); err != nil {
+		log.Error(err)
+		return nil, "", err
+	}
+
+	key = keyPrefix + hex.EncodeToString(random)
+	base := &db.Base{}
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return nil, "", err
+	}
+
+	err = json.Unmarshal(marshal, &base)
+	if err != nil {
+		log.Error(err)
+		return nil, "", err
+	}
+
+	if input.UserID == "" {
+		base.UserID = util.PointerString(input.CreatedBy)
+	}
+
+	base.APIKeyID = util.PointerString(uuid.CreatedUUIDString())
+	base.Prefix = util.PointerString(key[:len(keyPrefix)+8])
+	base.KeyHash = util.PointerString(hash.Sha256(key))
+	base.CreatedAt = util.PointerTime(util.NowToUTC())
+	err = s.Repository.Create(base)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return base, key, nil
+}
+
+func (s *service) GetByList(input *model.Field) (output []*db.Base, err error) {
+	keys, err := s.Repository.GetByList(&db.Base{
+		CompanyID: util.PointerString(input.CompanyID),
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	marshal, err := json.Marshal(keys)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &output)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// GetBySingle finds a key by its ID or by the key itself.
+func (s *service) GetBySingle(input *model.Field) (output *db.Base, err error) {
+	field := &db.Base{}
+	if input.APIKeyID != "" {
+		field.APIKeyID = util.PointerString(input.APIKeyID)
+	}
+
+	if input.CompanyID != "" {
+		field.CompanyID = util.PointerString(input.CompanyID)
+	}
+
+	if input.Key != "" {
+		field.KeyHash = util.PointerString(hash.Sha256(input.Key))
+	}
+
+	single, err := s.Repository.GetBySingle(field)
+	if err != nil {
+		return nil, err
+	}
+
+	marshal, err := json.Marshal(single)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &output)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *service) Delete(input *model.Field) (err error) {
+	err = s.Repository.Delete(&db.Base{
+		APIKeyID:  util.PointerString(input.APIKeyID),
+		CompanyID: util.PointerString(input.CompanyID),
+	})
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package company_setting
+
+import (
+	"encoding/json"
+	"errors"
+
+	store "crm/internal/entity/postgresql/company_setting"
+	db "crm/internal/entity/postgresql/db/company_settings"
+	model "crm/internal/interactor/models/company_settings"
+	"crm/internal/interactor/pkg/cache"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	WithTrx(tx *gorm.DB) Service
+	GetBySingle(input *model.Field) (output *db.Base, err error)
+	GetByCache(companyID string) (output *db.Base, err error)
+	Update(input *model.Update) (err error)
+}
+
+type service struct {
+	Repository store.Entity
+}
+
+func Init(db *gorm.DB) Service {
+	return &service{
+		Repository: store.Init(db),
+	}
+}
+
+func (s *service) WithTrx(tx *gorm.DB) Service {
+	return &service{
+		Repository: s.Repository.WithTrx(tx),
+	}
+}
+
+// GetBySingle returns the settings of the company, a company that never saved its settings gets the defaults.
+func (s *service) GetBySingle(input *model.Field) (output *db.Base, err error) {
+	single, err := s.Repository.GetBySingle(&db.Base{
+		CompanyID: util.PointerString(input.CompanyID),
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &db.Base{
+				CompanyID:       util.PointerString(input.CompanyID),
+				IfMatchRequired: util.PointerBool(false),
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	marshal, err := json.Marshal(single)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &output)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// GetByCache returns the settings of the company through the cache, they are read on every request.
+func (s *service) GetByCache(companyID string) (output *db.Base, err error) {
+	key := cache.Key(cache.CompanySetting, companyID)
+	output = &db.Base{}
+	found, err := cache.Default().Get(key, output)
+	if err != nil {
+		log.Error(err)
+	}
+
+	if found {
+		return output, nil
+	}
+
+	output, err = s.GetBySingle(&model.Field{
+		CompanyID: companyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = cache.Default().Set(key, output, cache.TTL())
+	if err != nil {
+		log.Error(err)
+	}
+
+	return output, nil
+}
+
+// Update saves the settings of the company, they are created on the first update.
+func (s *service) Update(input *model.Update) (err error) {
+	field := &db.Base{}
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	err = json.Unmarshal(marshal, &field)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	field.CreatedAt = util.PointerTime(util.NowToUTC())
+	field.CreatedBy = input.UpdatedBy
+	field.UpdatedAt = util.PointerTime(util.NowToUTC())
+	err = s.Repository.Save(field)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -1,11 +1,13 @@
 package contact
 
 import (
+	"cmp"
 	"encoding/json"
 
 	store "crm/internal/entity/postgresql/contact"
 	db "crm/internal/entity/postgresql/db/contacts"
 	model "crm/internal/interactor/models/contacts"
+	"crm/internal/interactor/models/page"
 	"crm/internal/interactor/pkg/util"
 	"crm/internal/interactor/pkg/util/log"
 	"crm/internal/interactor/pkg/util/uuid"
@@ -16,7 +18,7 @@ import (
 type Service interface {
 	WithTrx(tx *gorm.DB) Service
 	Create(input *model.Create) (output *db.Base, err error)
-	GetByList(input *model.Fields) (quantity int64, output []*db.Base, err error)
+	GetByList(input *model.Fields) (quantity int64, output []*db.Base, cursors page.Cursors, err error)
 	GetByListNoPagination(input *model.Field) (output []*db.Base, err error)
 	GetBySingle(input *model.Field) (output *db.Base, err error)
 	GetByQuantity(input *model.Field) (quantity int64, err error)
@@ -58,7 +60,7 @@ func (s *service) Create(input *model.Create) (output *db.Base, err error) {
 	base.CreatedAt = util.PointerTime(util.NowToUTC())
 	base.UpdatedAt = util.PointerTime(util.NowToUTC())
 	base.UpdatedBy = util.PointerString(input.CreatedBy)
-	base.SalespersonID = util.PointerString(input.CreatedBy)
+	base.SalespersonID = util.PointerString(cmp.Or(input.SalespersonID, input.CreatedBy))
 	err = s.Repository.Create(base)
 	if err != nil {
 		log.Error(err)
@@ -82,39 +84,40 @@ func (s *service) Create(input *model.Create) (output *db.Base, err error) {
 	return output, nil
 }
 
-func (s *service) GetByList(input *model.Fields) (quantity int64, output []*db.Base, err error) {
+func (s *service) GetByList(input *model.Fields) (quantity int64, output []*db.Base, cursors page.Cursors, err error) {
 	field := &db.Base{}
 	marshal, err := json.Marshal(input)
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
 	err = json.Unmarshal(marshal, &field)
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
-	quantity, fields, err := s.Repository.GetByList(field)
+	field.CustomColumns = input.CustomColumns
+	quantity, fields, cursors, err := s.Repository.GetByList(field)
 	if err != nil {
 		log.Error(err)
-		return 0, output, err
+		return 0, nil, cursors, err
 	}
 
 	marshal, err = json.Marshal(fields)
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
 	err = json.Unmarshal(marshal, &output)
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
-	return quantity, output, nil
+	return quantity, output, cursors, nil
 }
 
 func (s *service) GetByListNoPagination(input *model.Field) (output []*db.Base, err error) {
@@ -224,6 +227,8 @@ func (s *service) Update(input *model.Update) (err error) {
 		return err
 	}
 
+	// 清除全部自訂欄位時寫入空物件
+	field.CustomFields = input.CustomFields
 	err = s.Repository.Update(field)
 	if err != nil {
 		log.Error(err)
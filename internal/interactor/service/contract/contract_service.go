@@ -6,6 +6,7 @@ import (
 	store "crm/internal/entity/postgresql/contract"
 	db "crm/internal/entity/postgresql/db/contracts"
 	model "crm/internal/interactor/models/contracts"
+	"crm/internal/interactor/models/page"
 	"crm/internal/interactor/pkg/util"
 	"crm/internal/interactor/pkg/util/log"
 	"crm/internal/interactor/pkg/util/uuid"
@@ -16,7 +17,7 @@ import (
 type Service interface {
 	WithTrx(tx *gorm.DB) Service
 	Create(input *model.Create) (output *db.Base, err error)
-	GetByList(input *model.Fields) (quantity int64, output []*db.Base, err error)
+	GetByList(input *model.Fields) (quantity int64, output []*db.Base, cursors page.Cursors, err error)
 	GetByListNoPagination(input *model.FieldsNoPagination) (output []*db.Base, err error)
 	GetBySingle(input *model.Field) (output *db.Base, err error)
 	GetByQuantity(input *model.Field) (quantity int64, err error)
@@ -82,39 +83,39 @@ func (s *service) Create(input *model.Create) (output *db.Base, err error) {
 	return output, nil
 }
 
-func (s *service) GetByList(input *model.Fields) (quantity int64, output []*db.Base, err error) {
+func (s *service) GetByList(input *model.Fields) (quantity int64, output []*db.Base, cursors page.Cursors, err error) {
 	field := &db.Base{}
 	marshal, err := json.Marshal(input)
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
 	err = json.Unmarshal(marshal, &field)
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
-	quantity, fields, err := s.Repository.GetByList(field)
+	quantity, fields, cursors, err := s.Repository.GetByList(field)
 	if err != nil {
 		log.Error(err)
-		return 0, output, err
+		return 0, nil, cursors, err
 	}
 
 	marshal, err = json.Marshal(fields)
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
 	err = json.Unmarshal(marshal, &output)
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
-	return quantity, output, nil
+	return quantity, output, cursors, nil
 }
 
 func (s *service) GetByListNoPagination(input *model.FieldsNoPagination) (output []*db.Base, err error) {
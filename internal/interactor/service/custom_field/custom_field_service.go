@@ -0,0 +1,209 @@
+package custom_field
+
+import (
+	"encoding/json"
+
+	store "crm/internal/entity/postgresql/custom_field"
+	db "crm/internal/entity/postgresql/db/custom_fields"
+	model "crm/internal/interactor/models/custom_fields"
+	"crm/internal/interactor/pkg/cache"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/log"
+	"crm/internal/interactor/pkg/util/uuid"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	WithTrx(tx *gorm.DB) Service
+	Create(input *model.Create) (output *db.Base, err error)
+	GetByList(input *model.Field) (output []*db.Base, err error)
+	GetBySingle(input *model.Field) (output *db.Base, err error)
+	GetByCache(companyID, entity string) (output []*db.Base, err error)
+	Update(input *model.Update) (err error)
+	Delete(input *model.Field) (err error)
+	Purge(input *db.Base) (err error)
+}
+
+type service struct {
+	Repository store.Entity
+}
+
+func Init(db *gorm.DB) Service {
+	return &service{
+		Repository: store.Init(db),
+	}
+}
+
+func (s *service) WithTrx(tx *gorm.DB) Service {
+	return &service{
+		Repository: s.Repository.WithTrx(tx),
+	}
+}
+
+func (s *service) Create(input *model.Create) (output *db.Base, err error) {
+	base := &db.Base{}
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &base)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if base.Options == nil {
+		base.Options = &[]string{}
+	}
+
+	base.CustomFieldID = util.PointerString(uuid.CreatedUUIDString())
+	base.CreatedAt = util.PointerTime(util.NowToUTC())
+	base.UpdatedAt = util.PointerTime(util.NowToUTC())
+	base.UpdatedBy = util.PointerString(input.CreatedBy)
+	err = s.Repository.Create(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return base, nil
+}
+
+func (s *service) GetByList(input *model.Field) (output []*db.Base, err error) {
+	fields, err := s.Repository.GetByList(&db.Base{
+		CompanyID: util.PointerString(input.CompanyID),
+		Entity:    input.Entity,
+		Key:       input.Key,
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	marshal, err := json.Marshal(fields)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &output)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *service) GetBySingle(input *model.Field) (output *db.Base, err error) {
+	field := &db.Base{
+		CompanyID: util.PointerString(input.CompanyID),
+		Entity:    input.Entity,
+		Key:       input.Key,
+	}
+	if input.CustomFieldID != "" {
+		field.CustomFieldID = util.PointerString(input.CustomFieldID)
+	}
+
+	single, err := s.Repository.GetBySingle(field)
+	if err != nil {
+		return nil, err
+	}
+
+	marshal, err := json.Marshal(single)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &output)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// GetByCache returns the custom fields of entity defined by a company through the cache.
+func (s *service) GetByCache(companyID, entity string) (output []*db.Base, err error) {
+	key := cache.Key(cache.CustomField, companyID+":"+entity)
+	found, err := cache.Default().Get(key, &output)
+	if err != nil {
+		log.Error(err)
+	}
+
+	if found {
+		return output, nil
+	}
+
+	output, err = s.GetByList(&model.Field{
+		CompanyID: companyID,
+		Entity:    util.PointerString(entity),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = cache.Default().Set(key, output, cache.TTL())
+	if err != nil {
+		log.Error(err)
+	}
+
+	return output, nil
+}
+
+func (s *service) Update(input *model.Update) (err error) {
+	field := &db.Base{}
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	err = json.Unmarshal(marshal, &field)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	// null 移除預設值
+	if input.DefaultValue != nil {
+		field.DefaultValue = input.DefaultValue
+	}
+
+	field.UpdatedAt = util.PointerTime(util.NowToUTC())
+	err = s.Repository.Update(field)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) Delete(input *model.Field) (err error) {
+	err = s.Repository.Delete(&db.Base{
+		CustomFieldID: util.PointerString(input.CustomFieldID),
+		CompanyID:     util.PointerString(input.CompanyID),
+	})
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Purge removes the values of a custom field from the records of its company.
+func (s *service) Purge(input *db.Base) (err error) {
+	err = s.Repository.Purge(input)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
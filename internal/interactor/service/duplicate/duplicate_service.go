@@ -0,0 +1,127 @@
+package duplicate
+
+import (
+	"cmp"
+	"slices"
+
+	ruleDB "crm/internal/entity/postgresql/db/duplicate_rules"
+	db "crm/internal/entity/postgresql/db/duplicates"
+	store "crm/internal/entity/postgresql/duplicate"
+	ruleStore "crm/internal/entity/postgresql/duplicate_rule"
+	model "crm/internal/interactor/models/duplicates"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	WithTrx(tx *gorm.DB) Service
+	GetByList(input *model.Field) (output []*model.Candidate, err error)
+	GetByRecords(entity string, ids []string, fields []string) (output map[string]map[string]any, err error)
+	Merge(input *model.Merge, sources map[string]string) (reparented map[string]int64, err error)
+}
+
+type service struct {
+	Repository     store.Entity
+	RuleRepository ruleStore.Entity
+}
+
+func Init(db *gorm.DB) Service {
+	return &service{
+		Repository:     store.Init(db),
+		RuleRepository: ruleStore.Init(db),
+	}
+}
+
+func (s *service) WithTrx(tx *gorm.DB) Service {
+	return &service{
+		Repository:     s.Repository.WithTrx(tx),
+		RuleRepository: s.RuleRepository.WithTrx(tx),
+	}
+}
+
+// GetByList returns the records matching the record of input by any enabled rule of its company, best first.
+func (s *service) GetByList(input *model.Field) (output []*model.Candidate, err error) {
+	rules, err := s.RuleRepository.GetByList(&ruleDB.Base{
+		CompanyID: util.PointerString(input.CompanyID),
+		Entity:    util.PointerString(input.Entity),
+		IsEnable:  util.PointerBool(true),
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	candidates := map[string]*model.Candidate{}
+	for _, rule := range rules {
+		matches, err := s.Repository.GetByList(&db.Base{
+			Entity:    util.PointerString(input.Entity),
+			ID:        util.PointerString(input.ID),
+			Field:     util.PointerString(rule.Field),
+			Method:    util.PointerString(rule.Method),
+			Threshold: util.PointerFloat64(rule.Threshold),
+		})
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+
+		for _, match := range matches {
+			candidate, ok := candidates[match.ID]
+			if !ok {
+				candidate = &model.Candidate{ID: match.ID, Title: match.Title}
+				candidates[match.ID] = candidate
+				output = append(output, candidate)
+			}
+
+			candidate.Score = max(candidate.Score, match.Score)
+			candidate.Rules = append(candidate.Rules, rule.Field+":"+rule.Method)
+		}
+	}
+
+	slices.SortStableFunc(output, func(a, b *model.Candidate) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+
+	return output, nil
+}
+
+// GetByRecords returns the fields of the records as text keyed by ID, the records are locked until the transaction ends.
+func (s *service) GetByRecords(entity string, ids []string, fields []string) (output map[string]map[string]any, err error) {
+	records, err := s.Repository.GetByRecords(&db.Base{
+		Entity: util.PointerString(entity),
+		IDs:    ids,
+	}, fields)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	output = map[string]map[string]any{}
+	for _, record := range records {
+		if id, ok := record["id"].(string); ok {
+			output[id] = record
+		}
+	}
+
+	return output, nil
+}
+
+// Merge merges the records of input, sources names the record whose value each field of the surviving record takes.
+func (s *service) Merge(input *model.Merge, sources map[string]string) (reparented map[string]int64, err error) {
+	reparented, err = s.Repository.Merge(&db.Merge{
+		Entity:     input.Entity,
+		SurvivorID: input.SurvivorID,
+		MergedIDs:  input.MergedIDs,
+		Sources:    sources,
+		UpdatedAt:  util.NowToUTC(),
+		UpdatedBy:  input.UpdatedBy,
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return reparented, nil
+}
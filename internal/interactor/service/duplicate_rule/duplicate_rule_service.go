@@ -0,0 +1,174 @@
+package duplicate_rule
+
+import (
+	"encoding/json"
+
+	db "crm/internal/entity/postgresql/db/duplicate_rules"
+	store "crm/internal/entity/postgresql/duplicate_rule"
+	model "crm/internal/interactor/models/duplicate_rules"
+	duplicateModel "crm/internal/interactor/models/duplicates"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/log"
+	"crm/internal/interactor/pkg/util/uuid"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	WithTrx(tx *gorm.DB) Service
+	Create(input *model.Create) (output *db.Base, err error)
+	GetByList(input *model.Field) (output []*db.Base, err error)
+	GetBySingle(input *model.Field) (output *db.Base, err error)
+	Update(input *model.Update) (err error)
+	Delete(input *model.Field) (err error)
+	Seed(companyID, createdBy string) (err error)
+}
+
+type service struct {
+	Repository store.Entity
+}
+
+func Init(db *gorm.DB) Service {
+	return &service{
+		Repository: store.Init(db),
+	}
+}
+
+func (s *service) WithTrx(tx *gorm.DB) Service {
+	return &service{
+		Repository: s.Repository.WithTrx(tx),
+	}
+}
+
+func (s *service) Create(input *model.Create) (output *db.Base, err error) {
+	base := &db.Base{}
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &base)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	// 只有 trigram 使用門檻,其餘比對方式視為完全符合
+	if input.Method != duplicateModel.Trigram || input.Threshold == 0 {
+		base.Threshold = util.PointerFloat64(1)
+	}
+
+	base.DuplicateRuleID = util.PointerString(uuid.CreatedUUIDString())
+	base.IsEnable = util.PointerBool(true)
+	base.CreatedAt = util.PointerTime(util.NowToUTC())
+	base.UpdatedAt = util.PointerTime(util.NowToUTC())
+	base.UpdatedBy = util.PointerString(input.CreatedBy)
+	err = s.Repository.Create(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return base, nil
+}
+
+func (s *service) GetByList(input *model.Field) (output []*db.Base, err error) {
+	fields, err := s.Repository.GetByList(&db.Base{
+		CompanyID: util.PointerString(input.CompanyID),
+		Entity:    input.Entity,
+		IsEnable:  input.IsEnable,
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	marshal, err := json.Marshal(fields)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &output)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *service) GetBySingle(input *model.Field) (output *db.Base, err error) {
+	single, err := s.Repository.GetBySingle(&db.Base{
+		DuplicateRuleID: util.PointerString(input.DuplicateRuleID),
+		CompanyID:       util.PointerString(input.CompanyID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	marshal, err := json.Marshal(single)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &output)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *service) Update(input *model.Update) (err error) {
+	field := &db.Base{}
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	err = json.Unmarshal(marshal, &field)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	field.UpdatedAt = util.PointerTime(util.NowToUTC())
+	err = s.Repository.Update(field)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) Delete(input *model.Field) (err error) {
+	err = s.Repository.Delete(&db.Base{
+		DuplicateRuleID: util.PointerString(input.DuplicateRuleID),
+	})
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Seed creates the default rules of a new company.
+func (s *service) Seed(companyID, createdBy string) (err error) {
+	for _, rule := range model.Defaults {
+		rule.CompanyID = companyID
+		rule.CreatedBy = createdBy
+		_, err = s.Create(&rule)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -19,6 +19,18 @@ type Service interface {
 	GetByList(input *model.Fields) (quantity int64, output []*db.Base, err error)
 	GetBySingle(input *model.Field) (output *db.Base, err error)
 	GetByQuantity(input *model.Field) (quantity int64, err error)
+	Batch() Batch
+}
+
+// Batch is a Service holding the records it creates until Flush writes them in batches.
+type Batch interface {
+	Service
+	// Len returns the number of held records.
+	Len() int
+	// Truncate drops the records held after the first n, they belong to a rolled back operation.
+	Truncate(n int)
+	// Flush writes the held records with trx.
+	Flush(trx *gorm.DB) error
 }
 
 type service struct {
@@ -38,28 +50,18 @@ func (s *service) WithTrx(tx *gorm.DB) Service {
 }
 
 func (s *service) Create(input *model.Create) (output *db.Base, err error) {
-	base := &db.Base{}
-	marshal, err := json.Marshal(input)
-	if err != nil {
-		log.Error(err)
-		return nil, err
-	}
-
-	err = json.Unmarshal(marshal, &base)
+	base, err := newBase(input)
 	if err != nil {
-		log.Error(err)
 		return nil, err
 	}
 
-	base.HistoricalRecordID = util.PointerString(uuid.CreatedUUIDString())
-	base.ModifiedAt = util.PointerTime(util.NowToUTC())
 	err = s.Repository.Create(base)
 	if err != nil {
 		log.Error(err)
 		return nil, err
 	}
 
-	marshal, err = json.Marshal(base)
+	marshal, err := json.Marshal(base)
 	if err != nil {
 		log.Error(err)
 
@@ -168,3 +170,69 @@ func (s *service) GetByQuantity(input *model.Field) (quantity int64, err error)
 
 	return quantity, nil
 }
+
+func (s *service) Batch() Batch {
+	return &batch{service: s}
+}
+
+type batch struct {
+	*service
+	records []*db.Base
+}
+
+// WithTrx keeps the held records, the transaction is given to Flush.
+func (b *batch) WithTrx(tx *gorm.DB) Service {
+	return b
+}
+
+func (b *batch) Create(input *model.Create) (output *db.Base, err error) {
+	base, err := newBase(input)
+	if err != nil {
+		return nil, err
+	}
+
+	b.records = append(b.records, base)
+	return base, nil
+}
+
+func (b *batch) Len() int {
+	return len(b.records)
+}
+
+func (b *batch) Truncate(n int) {
+	b.records = b.records[:n]
+}
+
+func (b *batch) Flush(trx *gorm.DB) error {
+	if len(b.records) == 0 {
+		return nil
+	}
+
+	err := b.Repository.WithTrx(trx).CreateAll(b.records)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	b.records = nil
+	return nil
+}
+
+func newBase(input *model.Create) (*db.Base, error) {
+	base := &db.Base{}
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &base)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	base.HistoricalRecordID = util.PointerString(uuid.CreatedUUIDString())
+	base.ModifiedAt = util.PointerTime(util.NowToUTC())
+	return base, nil
+}
@@ -0,0 +1,132 @@
+package import_mapping
+
+import (
+	"encoding/json"
+
+	db "crm/internal/entity/postgresql/db/import_mappings"
+	store "crm/internal/entity/postgresql/import_mapping"
+	model "crm/internal/interactor/models/import_mappings"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/log"
+	"crm/internal/interactor/pkg/util/uuid"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	WithTrx(tx *gorm.DB) Service
+	Create(input *model.Create) (output *db.Base, err error)
+	GetByList(input *model.Field) (output []*db.Base, err error)
+	GetBySingle(input *model.Field) (output *db.Base, err error)
+	Delete(input *model.Field) (err error)
+}
+
+type service struct {
+	Repository store.Entity
+}
+
+func Init(db *gorm.DB) Service {
+	return &service{
+		Repository: store.Init(db),
+	}
+}
+
+func (s *service) WithTrx(tx *gorm.DB) Service {
+	return &service{
+		Repository: s.Repository.WithTrx(tx),
+	}
+}
+
+func (s *service) Create(input *model.Create) (output *db.Base, err error) {
+	base := &db.Base{}
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &base)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	base.ImportMappingID = util.PointerString(uuid.CreatedUUIDString())
+	base.CreatedAt = util.PointerTime(util.NowToUTC())
+	base.UpdatedAt = util.PointerTime(util.NowToUTC())
+	base.UpdatedBy = util.PointerString(input.CreatedBy)
+	err = s.Repository.Create(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return base, nil
+}
+
+func (s *service) GetByList(input *model.Field) (output []*db.Base, err error) {
+	fields, err := s.Repository.GetByList(&db.Base{
+		CompanyID: util.PointerString(input.CompanyID),
+		Entity:    input.Entity,
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	marshal, err := json.Marshal(fields)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &output)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *service) GetBySingle(input *model.Field) (output *db.Base, err error) {
+	field := &db.Base{
+		CompanyID: util.PointerString(input.CompanyID),
+		Entity:    input.Entity,
+		Name:      input.Name,
+	}
+	if input.ImportMappingID != "" {
+		field.ImportMappingID = util.PointerString(input.ImportMappingID)
+	}
+
+	single, err := s.Repository.GetBySingle(field)
+	if err != nil {
+		return nil, err
+	}
+
+	marshal, err := json.Marshal(single)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &output)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *service) Delete(input *model.Field) (err error) {
+	err = s.Repository.Delete(&db.Base{
+		ImportMappingID: util.PointerString(input.ImportMappingID),
+		CompanyID:       util.PointerString(input.CompanyID),
+	})
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	db "crm/internal/entity/postgresql/db/industries"
 	store "crm/internal/entity/postgresql/industry"
 	model "crm/internal/interactor/models/industries"
+	"crm/internal/interactor/pkg/cache"
 	"crm/internal/interactor/pkg/util"
 	"crm/internal/interactor/pkg/util/log"
 	"crm/internal/interactor/pkg/util/uuid"
@@ -18,6 +19,7 @@ type Service interface {
 	Create(input *model.Create) (output *db.Base, err error)
 	GetByList(input *model.Field) (output []*db.Base, err error)
 	GetBySingle(input *model.Field) (output *db.Base, err error)
+	GetByCache(industryID string) (output *db.Base, err error)
 	GetByQuantity(input *model.Field) (quantity int64, err error)
 	Update(input *model.Update) (err error)
 	Delete(input *model.Field) (err error)
@@ -147,6 +149,35 @@ func (s *service) GetBySingle(input *model.Field) (output *db.Base, err error) {
 	return output, nil
 }
 
+// GetByCache returns the industry by ID through the cache.
+func (s *service) GetByCache(industryID string) (output *db.Base, err error) {
+	key := cache.Key(cache.Industry, industryID)
+	output = &db.Base{}
+	found, err := cache.Default().Get(key, output)
+	if err != nil {
+		log.Error(err)
+	}
+
+	if found {
+		return output, nil
+	}
+
+	base, err := s.GetBySingle(&model.Field{
+		IndustryID: industryID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output = base
+	err = cache.Default().Set(key, output, cache.TTL())
+	if err != nil {
+		log.Error(err)
+	}
+
+	return output, nil
+}
+
 func (s *service) Delete(input *model.Field) (err error) {
 	field := &db.Base{}
 	marshal, err := json.Marshal(input)
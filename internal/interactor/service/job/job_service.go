@@ -0,0 +1,109 @@
+package job
+
+import (
+	"encoding/json"
+
+	db "crm/internal/entity/postgresql/db/jobs"
+	store "crm/internal/entity/postgresql/job"
+	model "crm/internal/interactor/models/jobs"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/log"
+	"crm/internal/interactor/pkg/util/uuid"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	WithTrx(tx *gorm.DB) Service
+	Create(input *model.Create) (output *db.Base, err error)
+	GetBySingle(input *model.Field) (output *db.Base, err error)
+	Update(input *model.Update) (err error)
+}
+
+type service struct {
+	Repository store.Entity
+}
+
+func Init(db *gorm.DB) Service {
+	return &service{
+		Repository: store.Init(db),
+	}
+}
+
+func (s *service) WithTrx(tx *gorm.DB) Service {
+	return &service{
+		Repository: s.Repository.WithTrx(tx),
+	}
+}
+
+func (s *service) Create(input *model.Create) (output *db.Base, err error) {
+	base := &db.Base{}
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &base)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	base.JobID = util.PointerString(uuid.CreatedUUIDString())
+	base.Status = util.PointerString(model.Pending)
+	base.CreatedAt = util.PointerTime(util.NowToUTC())
+	err = s.Repository.Create(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return base, nil
+}
+
+func (s *service) GetBySingle(input *model.Field) (output *db.Base, err error) {
+	single, err := s.Repository.GetBySingle(&db.Base{
+		JobID:     util.PointerString(input.JobID),
+		CreatedBy: util.PointerString(input.CreatedBy),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	marshal, err := json.Marshal(single)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &output)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *service) Update(input *model.Update) (err error) {
+	base := &db.Base{}
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	err = json.Unmarshal(marshal, &base)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	err = s.Repository.Update(base)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
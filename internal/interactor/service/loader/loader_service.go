@@ -0,0 +1,63 @@
+package loader
+
+import (
+	"fmt"
+	"slices"
+
+	db "crm/internal/entity/postgresql/db/loaders"
+	store "crm/internal/entity/postgresql/loader"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	WithTrx(tx *gorm.DB) Service
+	GetByKeys(table, column, companyID string, keys []string) (output map[string][]map[string]any, err error)
+}
+
+type service struct {
+	Repository store.Entity
+}
+
+func Init(db *gorm.DB) Service {
+	return &service{
+		Repository: store.Init(db),
+	}
+}
+
+func (s *service) WithTrx(tx *gorm.DB) Service {
+	return &service{
+		Repository: s.Repository.WithTrx(tx),
+	}
+}
+
+// GetByKeys returns the records of table whose column holds one of the keys, grouped by the value of the column.
+// The keys are read with one query per thousand keys whatever the number of records they came from.
+func (s *service) GetByKeys(table, column, companyID string, keys []string) (output map[string][]map[string]any, err error) {
+	keys = slices.DeleteFunc(slices.Clone(keys), func(key string) bool { return key == "" })
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+
+	output = map[string][]map[string]any{}
+	for start := 0; start < len(keys); start += 1000 {
+		records, err := s.Repository.GetByList(&db.Base{
+			Table:     util.PointerString(table),
+			Column:    util.PointerString(column),
+			Keys:      keys[start:min(start+1000, len(keys))],
+			CompanyID: util.PointerString(companyID),
+		})
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+
+		for _, record := range records {
+			key := fmt.Sprint(record[column])
+			output[key] = append(output[key], record)
+		}
+	}
+
+	return output, nil
+}
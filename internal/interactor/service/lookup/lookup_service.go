@@ -0,0 +1,53 @@
+package lookup
+
+import (
+	db "crm/internal/entity/postgresql/db/lookups"
+	store "crm/internal/entity/postgresql/lookup"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	WithTrx(tx *gorm.DB) Service
+	GetByNames(kind, companyID string, names []string) (output map[string][]string, err error)
+}
+
+type service struct {
+	Repository store.Entity
+}
+
+func Init(db *gorm.DB) Service {
+	return &service{
+		Repository: store.Init(db),
+	}
+}
+
+func (s *service) WithTrx(tx *gorm.DB) Service {
+	return &service{
+		Repository: s.Repository.WithTrx(tx),
+	}
+}
+
+// GetByNames returns the IDs of the records of kind named by each name, a name may match several records.
+func (s *service) GetByNames(kind, companyID string, names []string) (output map[string][]string, err error) {
+	output = map[string][]string{}
+	for start := 0; start < len(names); start += 1000 {
+		fields, err := s.Repository.GetByList(&db.Base{
+			Kind:      util.PointerString(kind),
+			CompanyID: util.PointerString(companyID),
+			Names:     names[start:min(start+1000, len(names))],
+		})
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+
+		for _, field := range fields {
+			output[field.Name] = append(output[field.Name], field.ID)
+		}
+	}
+
+	return output, nil
+}
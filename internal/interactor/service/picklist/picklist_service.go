@@ -0,0 +1,220 @@
+package picklist
+
+import (
+	"encoding/json"
+	"sort"
+
+	db "crm/internal/entity/postgresql/db/picklists"
+	store "crm/internal/entity/postgresql/picklist"
+	model "crm/internal/interactor/models/picklists"
+	"crm/internal/interactor/pkg/cache"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/log"
+	"crm/internal/interactor/pkg/util/uuid"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	WithTrx(tx *gorm.DB) Service
+	Create(input *model.Create) (output *db.Base, err error)
+	GetByList(input *model.Field) (output []*db.Base, err error)
+	GetBySingle(input *model.Field) (output *db.Base, err error)
+	GetByCache(companyID, entity string) (output []*db.Base, err error)
+	Update(input *model.Update) (err error)
+	Delete(input *model.Field) (err error)
+}
+
+type service struct {
+	Repository store.Entity
+}
+
+func Init(db *gorm.DB) Service {
+	return &service{
+		Repository: store.Init(db),
+	}
+}
+
+func (s *service) WithTrx(tx *gorm.DB) Service {
+	return &service{
+		Repository: s.Repository.WithTrx(tx),
+	}
+}
+
+func (s *service) Create(input *model.Create) (output *db.Base, err error) {
+	base := &db.Base{}
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &base)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	base.Values = values(input.Values)
+	base.PicklistID = util.PointerString(uuid.CreatedUUIDString())
+	base.CreatedAt = util.PointerTime(util.NowToUTC())
+	base.UpdatedAt = util.PointerTime(util.NowToUTC())
+	base.UpdatedBy = util.PointerString(input.CreatedBy)
+	err = s.Repository.Create(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return base, nil
+}
+
+func (s *service) GetByList(input *model.Field) (output []*db.Base, err error) {
+	fields, err := s.Repository.GetByList(&db.Base{
+		CompanyID: util.PointerString(input.CompanyID),
+		Entity:    input.Entity,
+		Field:     input.Field,
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	marshal, err := json.Marshal(fields)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &output)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *service) GetBySingle(input *model.Field) (output *db.Base, err error) {
+	field := &db.Base{
+		CompanyID: util.PointerString(input.CompanyID),
+		Entity:    input.Entity,
+		Field:     input.Field,
+	}
+	if input.PicklistID != "" {
+		field.PicklistID = util.PointerString(input.PicklistID)
+	}
+
+	single, err := s.Repository.GetBySingle(field)
+	if err != nil {
+		return nil, err
+	}
+
+	marshal, err := json.Marshal(single)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &output)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// GetByCache returns the picklists of the fields of entity defined by a company through the cache.
+func (s *service) GetByCache(companyID, entity string) (output []*db.Base, err error) {
+	key := cache.Key(cache.Picklist, companyID+":"+entity)
+	found, err := cache.Default().Get(key, &output)
+	if err != nil {
+		log.Error(err)
+	}
+
+	if found {
+		return output, nil
+	}
+
+	output, err = s.GetByList(&model.Field{
+		CompanyID: companyID,
+		Entity:    util.PointerString(entity),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = cache.Default().Set(key, output, cache.TTL())
+	if err != nil {
+		log.Error(err)
+	}
+
+	return output, nil
+}
+
+func (s *service) Update(input *model.Update) (err error) {
+	field := &db.Base{}
+	marshal, err := json.Marshal(input)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	err = json.Unmarshal(marshal, &field)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if input.Values != nil {
+		field.Values = values(input.Values)
+	}
+
+	field.UpdatedAt = util.PointerTime(util.NowToUTC())
+	err = s.Repository.Update(field)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) Delete(input *model.Field) (err error) {
+	err = s.Repository.Delete(&db.Base{
+		PicklistID: util.PointerString(input.PicklistID),
+		CompanyID:  util.PointerString(input.CompanyID),
+	})
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// values stores the options in order, an option is active unless it is deactivated and is labeled by its value by default.
+func values(input []*model.Value) []*db.Value {
+	output := make([]*db.Value, 0, len(input))
+	for _, value := range input {
+		label := value.Label
+		if label == "" {
+			label = value.Value
+		}
+
+		output = append(output, &db.Value{
+			Value:             value.Value,
+			Label:             label,
+			Sequence:          value.Sequence,
+			IsDefault:         value.IsDefault,
+			IsActive:          value.IsActive == nil || *value.IsActive,
+			ControllingValues: value.ControllingValues,
+		})
+	}
+
+	sort.SliceStable(output, func(i, j int) bool {
+		return output[i].Sequence < output[j].Sequence
+	})
+
+	return output
+}
@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 
 	db "crm/internal/entity/postgresql/db/products"
+	"crm/internal/entity/postgresql/db/users"
 	store "crm/internal/entity/postgresql/product"
+	"crm/internal/interactor/models/page"
 	model "crm/internal/interactor/models/products"
+	"crm/internal/interactor/pkg/cache"
 	"crm/internal/interactor/pkg/util"
 	"crm/internal/interactor/pkg/util/log"
 	"crm/internal/interactor/pkg/util/uuid"
@@ -16,8 +19,9 @@ import (
 type Service interface {
 	WithTrx(tx *gorm.DB) Service
 	Create(input *model.Create) (output *db.Base, err error)
-	GetByList(input *model.Fields) (quantity int64, output []*db.Base, err error)
+	GetByList(input *model.Fields) (quantity int64, output []*db.Base, cursors page.Cursors, err error)
 	GetBySingle(input *model.Field) (output *db.Base, err error)
+	GetByCache(productID string) (output *db.Base, err error)
 	GetByQuantity(input *model.Field) (quantity int64, err error)
 	Update(input *model.Update) (err error)
 	Delete(input *model.Field) (err error)
@@ -80,39 +84,39 @@ func (s *service) Create(input *model.Create) (output *db.Base, err error) {
 	return output, nil
 }
 
-func (s *service) GetByList(input *model.Fields) (quantity int64, output []*db.Base, err error) {
+func (s *service) GetByList(input *model.Fields) (quantity int64, output []*db.Base, cursors page.Cursors, err error) {
 	field := &db.Base{}
 	marshal, err := json.Marshal(input)
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
 	err = json.Unmarshal(marshal, &field)
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
-	quantity, fields, err := s.Repository.GetByList(field)
+	quantity, fields, cursors, err := s.Repository.GetByList(field)
 	if err != nil {
 		log.Error(err)
-		return 0, output, err
+		return 0, nil, cursors, err
 	}
 
 	marshal, err = json.Marshal(fields)
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
 	err = json.Unmarshal(marshal, &output)
 	if err != nil {
 		log.Error(err)
-		return 0, nil, err
+		return 0, nil, cursors, err
 	}
 
-	return quantity, output, nil
+	return quantity, output, cursors, nil
 }
 
 func (s *service) GetBySingle(input *model.Field) (output *db.Base, err error) {
@@ -150,6 +154,38 @@ func (s *service) GetBySingle(input *model.Field) (output *db.Base, err error) {
 	return output, nil
 }
 
+// GetByCache returns the product by ID through the cache, without its creating and updating users.
+func (s *service) GetByCache(productID string) (output *db.Base, err error) {
+	key := cache.Key(cache.Product, productID)
+	output = &db.Base{}
+	found, err := cache.Default().Get(key, output)
+	if err != nil {
+		log.Error(err)
+	}
+
+	if found {
+		return output, nil
+	}
+
+	base, err := s.GetBySingle(&model.Field{
+		ProductID: productID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 不快取建立及更新者的完整資料
+	output = base
+	output.CreatedByUsers = users.Base{}
+	output.UpdatedByUsers = users.Base{}
+	err = cache.Default().Set(key, output, cache.TTL())
+	if err != nil {
+		log.Error(err)
+	}
+
+	return output, nil
+}
+
 func (s *service) Delete(input *model.Field) (err error) {
 	field := &db.Base{}
 	marshal, err := json.Marshal(input)
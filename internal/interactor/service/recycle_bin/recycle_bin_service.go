@@ -0,0 +1,248 @@
+package recycle_bin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	db "crm/internal/entity/postgresql/db/recycle_bins"
+	store "crm/internal/entity/postgresql/recycle_bin"
+	policyModel "crm/internal/interactor/models/delete_policies"
+	model "crm/internal/interactor/models/recycle_bins"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	WithTrx(tx *gorm.DB) Service
+	Preview(input *policyModel.Field) (output *policyModel.Preview, err error)
+	Delete(entity, id, deletedBy string) (preview *policyModel.Preview, cascaded map[string][]string, err error)
+	GetByList(input *model.Fields) (quantity int64, output []*model.Single, err error)
+	GetByRecords(input *model.Records) (output []*model.Single, err error)
+	Restore(input *model.Records) (cascaded map[string][]string, err error)
+	Purge(input *model.Records) (cascaded map[string][]string, err error)
+}
+
+type service struct {
+	Repository store.Entity
+}
+
+func Init(db *gorm.DB) Service {
+	return &service{
+		Repository: store.Init(db),
+	}
+}
+
+func (s *service) WithTrx(tx *gorm.DB) Service {
+	return &service{
+		Repository: s.Repository.WithTrx(tx),
+	}
+}
+
+// ErrRestricted is returned when a record is referenced by records whose delete policy restricts its delete.
+var ErrRestricted = errors.New("the record is referenced by records restricting its delete")
+
+// ErrParentDeleted is returned when restored records reference records still in the recycle bin.
+var ErrParentDeleted = errors.New("the records reference records in the recycle bin")
+
+// Preview returns what deleting the record of input would affect by the delete policies.
+func (s *service) Preview(input *policyModel.Field) (output *policyModel.Preview, err error) {
+	plan, err := s.Repository.GetByPlan(&db.Base{
+		Entity: input.Entity,
+		IDs:    []string{input.ID},
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return preview(input.Entity, input.ID, plan), nil
+}
+
+// Delete deletes a record by the delete policies, its cascaded records are moved to the recycle bin with it.
+// It returns ErrRestricted with the preview of the delete when a reference restricts it, otherwise the IDs of the
+// cascaded records by entity.
+func (s *service) Delete(entity, id, deletedBy string) (output *policyModel.Preview, cascaded map[string][]string, err error) {
+	plan, err := s.Repository.GetByPlan(&db.Base{
+		Entity: entity,
+		IDs:    []string{id},
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, nil, err
+	}
+
+	output = preview(entity, id, plan)
+	if !output.Deletable {
+		return output, nil, ErrRestricted
+	}
+
+	err = s.Repository.Delete(&db.Base{
+		Entity:     entity,
+		IDs:        []string{id},
+		ModifiedAt: util.NowToUTC(),
+		ModifiedBy: deletedBy,
+	}, plan)
+	if err != nil {
+		log.Error(err)
+		return nil, nil, err
+	}
+
+	return output, cascadedOf(plan), nil
+}
+
+// cascadedOf returns the IDs of the cascaded records of plan by entity, the rows of the link tables are left out.
+func cascadedOf(plan *db.Plan) map[string][]string {
+	output := map[string][]string{}
+	for _, step := range plan.Steps {
+		if step.Policy != policyModel.Cascade || step.Entity == "" {
+			continue
+		}
+
+		for _, id := range step.IDs {
+			if !slices.Contains(output[step.Entity], id) {
+				output[step.Entity] = append(output[step.Entity], id)
+			}
+		}
+	}
+
+	return output
+}
+
+// preview summarizes a plan, listing up to 20 IDs of each reference.
+func preview(entity, id string, plan *db.Plan) *policyModel.Preview {
+	output := &policyModel.Preview{
+		Entity:    entity,
+		ID:        id,
+		Deletable: true,
+		Affected:  []*policyModel.Affected{},
+	}
+
+	for _, step := range plan.Steps {
+		if step.Policy == policyModel.Restrict {
+			output.Deletable = false
+		}
+
+		output.Affected = append(output.Affected, &policyModel.Affected{
+			Table:  step.Table,
+			Column: step.Column,
+			Policy: step.Policy,
+			Count:  len(step.IDs),
+			IDs:    step.IDs[:min(len(step.IDs), 20)],
+		})
+	}
+
+	return output
+}
+
+func (s *service) GetByList(input *model.Fields) (quantity int64, output []*model.Single, err error) {
+	quantity, records, err := s.Repository.GetByList(&db.Base{
+		Entity:     input.Entity,
+		DelStartAt: input.DelStartAt,
+		DelEndAt:   input.DelEndAt,
+		Page:       input.Page,
+		Limit:      input.Limit,
+	})
+	if err != nil {
+		log.Error(err)
+		return 0, nil, err
+	}
+
+	output, err = convert(records)
+	if err != nil {
+		log.Error(err)
+		return 0, nil, err
+	}
+
+	return quantity, output, nil
+}
+
+// GetByRecords returns the deleted records among the IDs of input, they are locked until the transaction ends.
+func (s *service) GetByRecords(input *model.Records) (output []*model.Single, err error) {
+	records, err := s.Repository.GetByRecords(&db.Base{
+		Entity: input.Entity,
+		IDs:    input.IDs,
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return convert(records)
+}
+
+// Restore restores the records of input with the records cascaded by their delete and returns the IDs of the cascaded
+// records by entity. It returns ErrParentDeleted naming the records to restore first when the restored records
+// reference records still in the recycle bin.
+func (s *service) Restore(input *model.Records) (cascaded map[string][]string, err error) {
+	plan, err := s.Repository.GetByRestore(&db.Base{
+		Entity: input.Entity,
+		IDs:    input.IDs,
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if len(plan.Parents) > 0 {
+		parents := make([]string, 0, len(plan.Parents))
+		for _, parent := range plan.Parents {
+			parents = append(parents, parent.Entity+" "+strings.Join(parent.IDs, ", ")+" ("+parent.Column+")")
+		}
+
+		return nil, fmt.Errorf("%w, restore %s first", ErrParentDeleted, strings.Join(parents, "; "))
+	}
+
+	err = s.Repository.Restore(&db.Base{
+		Entity:     input.Entity,
+		IDs:        input.IDs,
+		ModifiedAt: util.NowToUTC(),
+		ModifiedBy: input.ModifiedBy,
+	}, plan)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return cascadedOf(plan), nil
+}
+
+// Purge permanently deletes the records of input with the records cascaded by their delete and returns the IDs of
+// the cascaded records by entity.
+func (s *service) Purge(input *model.Records) (cascaded map[string][]string, err error) {
+	plan, err := s.Repository.GetByPurge(&db.Base{
+		Entity: input.Entity,
+		IDs:    input.IDs,
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = s.Repository.Purge(plan)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return cascadedOf(plan), nil
+}
+
+func convert(records []*db.Table) (output []*model.Single, err error) {
+	marshal, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+
+	output = []*model.Single{}
+	err = json.Unmarshal(marshal, &output)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
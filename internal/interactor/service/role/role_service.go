@@ -6,6 +6,7 @@ import (
 	db "crm/internal/entity/postgresql/db/roles"
 	store "crm/internal/entity/postgresql/role"
 	model "crm/internal/interactor/models/roles"
+	"crm/internal/interactor/pkg/cache"
 	"crm/internal/interactor/pkg/util"
 	"crm/internal/interactor/pkg/util/log"
 	"crm/internal/interactor/pkg/util/uuid"
@@ -18,6 +19,7 @@ type Service interface {
 	Create(input *model.Create) (output *db.Base, err error)
 	GetByList(input *model.Fields) (quantity int64, output []*db.Base, err error)
 	GetBySingle(input *model.Field) (output *db.Base, err error)
+	GetByCache(roleID string) (output *db.Base, err error)
 	GetByQuantity(input *model.Field) (quantity int64, err error)
 	Update(input *model.Update) (err error)
 	Delete(input *model.Update) (err error)
@@ -151,6 +153,42 @@ func (s *service) GetBySingle(input *model.Field) (output *db.Base, err error) {
 	return output, nil
 }
 
+// GetByCache returns the role by ID through the cache, without its creating and updating users.
+func (s *service) GetByCache(roleID string) (output *db.Base, err error) {
+	key := cache.Key(cache.Role, roleID)
+	output = &db.Base{}
+	found, err := cache.Default().Get(key, output)
+	if err != nil {
+		log.Error(err)
+	}
+
+	if found {
+		return output, nil
+	}
+
+	base, err := s.GetBySingle(&model.Field{
+		RoleID: roleID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 權限檢查只需要角色本身,不快取建立及更新者
+	output = &db.Base{
+		RoleID:      base.RoleID,
+		Name:        base.Name,
+		DisplayName: base.DisplayName,
+		CompanyID:   base.CompanyID,
+		IsEnable:    base.IsEnable,
+	}
+	err = cache.Default().Set(key, output, cache.TTL())
+	if err != nil {
+		log.Error(err)
+	}
+
+	return output, nil
+}
+
 func (s *service) Delete(input *model.Update) (err error) {
 	field := &db.Base{}
 	marshal, err := json.Marshal(input)
@@ -0,0 +1,60 @@
+package search
+
+import (
+	"encoding/json"
+
+	db "crm/internal/entity/postgresql/db/searches"
+	store "crm/internal/entity/postgresql/search"
+	model "crm/internal/interactor/models/searches"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/log"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	WithTrx(tx *gorm.DB) Service
+	GetByList(input *model.Field, types []string) (output []*db.Base, err error)
+}
+
+type service struct {
+	Repository store.Entity
+}
+
+func Init(db *gorm.DB) Service {
+	return &service{
+		Repository: store.Init(db),
+	}
+}
+
+func (s *service) WithTrx(tx *gorm.DB) Service {
+	return &service{
+		Repository: s.Repository.WithTrx(tx),
+	}
+}
+
+func (s *service) GetByList(input *model.Field, types []string) (output []*db.Base, err error) {
+	fields, err := s.Repository.GetByList(&db.Base{
+		Q:     util.PointerString(input.Q),
+		Types: types,
+		Limit: util.PointerInt64(input.Limit),
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	marshal, err := json.Marshal(fields)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(marshal, &output)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -9,6 +9,7 @@ import (
 	"crm/internal/interactor/pkg/util/hash"
 
 	model "crm/internal/interactor/models/users"
+	"crm/internal/interactor/pkg/cache"
 	"crm/internal/interactor/pkg/util"
 	"crm/internal/interactor/pkg/util/log"
 	"crm/internal/interactor/pkg/util/uuid"
@@ -22,6 +23,7 @@ type Service interface {
 	GetByList(input *model.Fields) (quantity int64, output []*db.Base, err error)
 	GetByListNoPagination(input *model.Field) (output []*db.Base, err error)
 	GetBySingle(input *model.Field) (output *db.Base, err error)
+	GetByCache(userID string) (output *db.Base, err error)
 	GetByQuantity(input *model.Field) (quantity int64, err error)
 	Update(input *model.Update) (err error)
 	Delete(input *model.Update) (err error)
@@ -199,6 +201,41 @@ func (s *service) GetBySingle(input *model.Field) (output *db.Base, err error) {
 	return output, nil
 }
 
+// GetByCache returns the display fields of the user by ID through the cache.
+func (s *service) GetByCache(userID string) (output *db.Base, err error) {
+	key := cache.Key(cache.User, userID)
+	output = &db.Base{}
+	found, err := cache.Default().Get(key, output)
+	if err != nil {
+		log.Error(err)
+	}
+
+	if found {
+		return output, nil
+	}
+
+	base, err := s.GetBySingle(&model.Field{
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 只快取顯示用欄位,不快取密碼
+	output = &db.Base{
+		UserID:    base.UserID,
+		CompanyID: base.CompanyID,
+		Name:      base.Name,
+		RoleID:    base.RoleID,
+	}
+	err = cache.Default().Set(key, output, cache.TTL())
+	if err != nil {
+		log.Error(err)
+	}
+
+	return output, nil
+}
+
 func (s *service) Delete(input *model.Update) (err error) {
 	field := &db.Base{}
 	marshal, err := json.Marshal(input)
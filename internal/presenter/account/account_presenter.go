@@ -4,14 +4,25 @@ import (
 	"net/http"
 	"strconv"
 
+	"crm/config"
+
+	"crm/internal/interactor/helpers"
 	"crm/internal/interactor/pkg/util"
 
 	constant "crm/internal/interactor/constants"
 
 	"crm/internal/interactor/manager/account"
+	"crm/internal/interactor/manager/duplicate"
+	"crm/internal/interactor/manager/exporter"
+	"crm/internal/interactor/manager/recycle_bin"
 	accountModel "crm/internal/interactor/models/accounts"
+	policyModel "crm/internal/interactor/models/delete_policies"
+	duplicateModel "crm/internal/interactor/models/duplicates"
+	exportModel "crm/internal/interactor/models/exports"
+	"crm/internal/interactor/models/projection"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
+	"crm/internal/router/middleware"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -25,15 +36,25 @@ type Control interface {
 	GetBySingleContacts(ctx *gin.Context)
 	Delete(ctx *gin.Context)
 	Update(ctx *gin.Context)
+	Bulk(ctx *gin.Context)
+	Duplicates(ctx *gin.Context)
+	Merge(ctx *gin.Context)
+	DeletePreview(ctx *gin.Context)
 }
 
 type control struct {
-	Manager account.Manager
+	Manager    account.Manager
+	Exporter   exporter.Manager
+	Duplicate  duplicate.Manager
+	RecycleBin recycle_bin.Manager
 }
 
 func Init(db *gorm.DB) Control {
 	return &control{
-		Manager: account.Init(db),
+		Manager:    account.Init(db),
+		Exporter:   exporter.Init(db),
+		Duplicate:  duplicate.Init(db),
+		RecycleBin: recycle_bin.Init(db),
 	}
 }
 
@@ -48,6 +69,7 @@ func Init(db *gorm.DB) Control {
 // @param * body accounts.Create true "新增帳戶"
 // @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 422 object code.ErrorMessage{detailed=[]code.FieldError} "欄位值不在選項清單或自訂欄位錯誤"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /accounts [post]
 func (c *control) Create(ctx *gin.Context) {
@@ -56,6 +78,7 @@ func (c *control) Create(ctx *gin.Context) {
 	input.CreatedBy = ctx.MustGet("user_id").(string)
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -73,12 +96,19 @@ func (c *control) Create(ctx *gin.Context) {
 // @Accept json
 // @produce json
 // @param Authorization header string  true "JWE Token"
-// @param page query int true "目前頁數,請從1開始帶入"
+// @param page query int false "目前頁數,請從1開始帶入,不帶入時使用游標分頁"
 // @param limit query int true "一次回傳比數,請從1開始帶入,最高上限20"
-// @param sort query string false "排序"
-// @param direction query string false "排序方式"
+// @param sort query string false "排序,格式為 field:direction 並以逗號分隔,如 stage:asc,amount:desc,自訂欄位為 custom_fields.<key>"
+// @param cursor query string false "游標,帶入回傳的 next_cursor 或 prev_cursor"
+// @param count query string false "總筆數計算方式 exact, approximate 或 none,游標分頁預設為 none" Enums(exact, approximate, none)
+// @param fields query string false "回傳欄位,以逗號分隔,不帶入時回傳全部欄位"
+// @param expand query string false "展開的關聯,以逗號分隔,可用 industry, salesperson, created_by_user, updated_by_user, contacts"
+// @param format query string false "匯出格式,帶入時匯出全部符合條件的資料,不分頁,fields 為匯出欄位" Enums(csv, xlsx)
+// @param Accept-Language header string false "匯出檔標題及代碼的語系,可用 zh-TW, en"
 // @param * body accounts.Filter false "搜尋"
 // @success 200 object code.SuccessfulMessage{body=accounts.List} "成功後返回的值"
+// @success 202 object code.SuccessfulMessage{body=string} "匯出筆數過多時轉為背景工作,返回工作ID"
+// @failure 400 object code.ErrorMessage{detailed=string} "組合搜尋條件、排序、游標或回傳欄位錯誤"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /accounts/list [post]
@@ -89,13 +119,56 @@ func (c *control) GetByList(ctx *gin.Context) {
 	input.Limit, _ = strconv.ParseInt(limit, 10, 64)
 	input.Page, _ = strconv.ParseInt(page, 10, 64)
 
+	input.Sort.By = ctx.Query("sort")
+	input.Cursor.Cursor = ctx.Query("cursor")
+	input.Count = ctx.Query("count")
+	input.Projection.Fields = ctx.Query("fields")
+	input.Expand = ctx.Query("expand")
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	format := &exportModel.Format{}
+	if err := ctx.ShouldBindQuery(format); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	// 匯出不分頁,每次讀取的筆數由匯出決定
+	if format.Format != "" {
+		input.Limit = config.ExportPageSize
+	}
+
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
 	}
 
+	if format.Format != "" {
+		httpCode, codeMessage := c.Exporter.Create(&exportModel.Create{
+			Entity:    "accounts",
+			Format:    format.Format,
+			Fields:    input.Projection.Fields,
+			Language:  ctx.GetHeader("Accept-Language"),
+			CompanyID: ctx.MustGet("company_id").(string),
+			CreatedBy: ctx.MustGet("user_id").(string),
+		}, accountModel.Columns, func(cursor string, limit int64, count string) (int, any) {
+			list := *input
+			list.Page = 0
+			list.Limit = limit
+			list.Cursor.Cursor = cursor
+			list.Count = count
+			list.Projection = projection.Projection{}
+			return c.Manager.GetByList(&list)
+		})
+		helpers.WriteExport(ctx, httpCode, codeMessage)
+
+		return
+	}
+
 	if input.Limit >= constant.DefaultLimit {
 		input.Limit = constant.DefaultLimit
 	}
@@ -121,6 +194,7 @@ func (c *control) GetByListNoPagination(ctx *gin.Context) {
 	input := &accountModel.FieldsNoPagination{}
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -140,6 +214,7 @@ func (c *control) GetByListNoPagination(ctx *gin.Context) {
 // @param Authorization header string  true "JWE Token"
 // @param accountID path string true "帳戶ID"
 // @success 200 object code.SuccessfulMessage{body=accounts.Single} "成功後返回的值"
+// @header 200 {string} ETag "版本"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /accounts/{accountID} [get]
@@ -149,12 +224,14 @@ func (c *control) GetBySingle(ctx *gin.Context) {
 	input.AccountID = accountID
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
 	}
 
 	httpCode, codeMessage := c.Manager.GetBySingle(input)
+	helpers.SetETag(ctx, codeMessage)
 	ctx.JSON(httpCode, codeMessage)
 }
 
@@ -177,6 +254,7 @@ func (c *control) GetBySingleContacts(ctx *gin.Context) {
 	input.AccountID = accountID
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -196,21 +274,25 @@ func (c *control) GetBySingleContacts(ctx *gin.Context) {
 // @param Authorization header string  true "JWE Token"
 // @param accountID path string true "帳戶ID"
 // @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 409 object code.ErrorMessage{detailed=delete_policies.Preview} "有限制刪除的關聯資料"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /accounts/{accountID} [delete]
 func (c *control) Delete(ctx *gin.Context) {
+	trx := ctx.MustGet("db_trx").(*gorm.DB)
 	accountID := ctx.Param("accountID")
-	input := &accountModel.Field{}
+	input := &accountModel.Update{}
 	input.AccountID = accountID
+	input.UpdatedBy = util.PointerString(ctx.MustGet("user_id").(string))
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
 	}
 
-	httpCode, codeMessage := c.Manager.Delete(input)
+	httpCode, codeMessage := c.Manager.Delete(trx, input)
 	ctx.JSON(httpCode, codeMessage)
 }
 
@@ -224,8 +306,12 @@ func (c *control) Delete(ctx *gin.Context) {
 // @param Authorization header string  true "JWE Token"
 // @param accountID path string true "帳戶ID"
 // @param * body accounts.Update true "更新帳戶"
+// @param If-Match header string false "版本(ETag),版本不符時回傳412及目前資料"
 // @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 412 object code.ErrorMessage{detailed=accounts.Single} "版本不符"
+// @failure 428 object code.ErrorMessage{detailed=string} "未帶入If-Match"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 422 object code.ErrorMessage{detailed=[]code.FieldError} "欄位值不在選項清單或自訂欄位錯誤"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /accounts/{accountID} [patch]
 func (c *control) Update(ctx *gin.Context) {
@@ -234,13 +320,140 @@ func (c *control) Update(ctx *gin.Context) {
 	input := &accountModel.Update{}
 	input.AccountID = accountID
 	input.UpdatedBy = util.PointerString(ctx.MustGet("user_id").(string))
+	input.IfMatch = ctx.GetHeader("If-Match")
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
 	}
 
 	httpCode, codeMessage := c.Manager.Update(trx, input)
+	helpers.SetETag(ctx, codeMessage)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// Bulk
+// @Summary 批次新增、更新、刪除帳戶
+// @description 一次新增、更新及刪除多筆帳戶,各項目分別驗證並回傳結果。all_or_nothing 模式任一項目失敗時回傳422且全部不執行,best_effort 模式保留成功的項目。公司要求 If-Match 時,更新項目須帶入 if_match,未帶入的項目回傳428,版本不符的項目回傳412
+// @Tags account
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param * body accounts.Bulk true "批次作業"
+// @success 200 object code.SuccessfulMessage{body=bulk.List} "成功後返回的值"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 422 object code.ErrorMessage{detailed=bulk.List} "all_or_nothing 模式下有項目失敗"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /accounts/bulk [post]
+func (c *control) Bulk(ctx *gin.Context) {
+	trx := ctx.MustGet("db_trx").(*gorm.DB)
+	input := &accountModel.Bulk{}
+	input.UserID = ctx.MustGet("user_id").(string)
+	input.IfMatchRequired = middleware.IfMatchRequired(trx, ctx.GetString("company_id"))
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Bulk(trx, input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// Duplicates
+// @Summary 取得疑似重複的帳戶
+// @description 依啟用的重複比對規則取得與單一帳戶疑似重複的資料,依相似度排序
+// @Tags account
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param accountID path string true "帳戶ID"
+// @success 200 object code.SuccessfulMessage{body=duplicates.List} "成功後返回的值"
+// @failure 404 object code.ErrorMessage{detailed=string} "查無資料"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /accounts/{accountID}/duplicates [get]
+func (c *control) Duplicates(ctx *gin.Context) {
+	input := &duplicateModel.Field{}
+	input.Entity = "accounts"
+	input.ID = ctx.Param("accountID")
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Duplicate.GetByList(input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// Merge
+// @Summary 合併帳戶
+// @description 將多筆帳戶併入保留的帳戶,關聯資料移轉至保留的帳戶後刪除併入的帳戶
+// @Tags account
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param * body duplicates.Merge true "合併帳戶"
+// @success 200 object code.SuccessfulMessage{body=duplicates.Merged} "成功後返回的值"
+// @failure 400 object code.ErrorMessage{detailed=string} "合併的資料或欄位錯誤"
+// @failure 404 object code.ErrorMessage{detailed=string} "查無資料"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /accounts/merge [post]
+func (c *control) Merge(ctx *gin.Context) {
+	trx := ctx.MustGet("db_trx").(*gorm.DB)
+	input := &duplicateModel.Merge{}
+	input.Entity = "accounts"
+	input.UpdatedBy = ctx.MustGet("user_id").(string)
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Duplicate.Merge(trx, input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// DeletePreview
+// @Summary 預覽刪除單一帳戶
+// @description 依刪除規則列出刪除單一帳戶時受影響的關聯資料,restrict 限制刪除、cascade 一併刪除、nullify 清除關聯
+// @Tags account
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param accountID path string true "帳戶ID"
+// @success 200 object code.SuccessfulMessage{body=delete_policies.Preview} "成功後返回的值"
+// @failure 404 object code.ErrorMessage{detailed=string} "查無資料"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /accounts/{accountID}/delete-preview [get]
+func (c *control) DeletePreview(ctx *gin.Context) {
+	input := &policyModel.Field{}
+	input.Entity = "accounts"
+	input.ID = ctx.Param("accountID")
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.RecycleBin.Preview(input)
 	ctx.JSON(httpCode, codeMessage)
 }
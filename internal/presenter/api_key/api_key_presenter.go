@@ -0,0 +1,108 @@
+package api_key
+
+import (
+	"net/http"
+
+	"crm/internal/interactor/manager/api_key"
+	apiKeyModel "crm/internal/interactor/models/api_keys"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Control interface {
+	Create(ctx *gin.Context)
+	GetByList(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type control struct {
+	Manager api_key.Manager
+}
+
+func Init(db *gorm.DB) Control {
+	return &control{
+		Manager: api_key.Init(db),
+	}
+}
+
+// Create
+// @Summary 新增 API key
+// @description 新增供系統整合使用的 API key,呼叫 gRPC 時以 x-api-key 帶入,以指定使用者及其角色的權限執行。key 僅於新增時回傳一次
+// @Tags api-key
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param * body api_keys.Create true "新增 API key"
+// @success 200 object code.SuccessfulMessage{body=api_keys.Issued} "成功後返回的值"
+// @failure 404 object code.ErrorMessage{detailed=string} "使用者不存在"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /api-keys [post]
+func (c *control) Create(ctx *gin.Context) {
+	input := &apiKeyModel.Create{}
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	input.CreatedBy = ctx.MustGet("user_id").(string)
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Create(input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// GetByList
+// @Summary 取得全部 API key
+// @description 取得公司的全部 API key,不含 key 本身
+// @Tags api-key
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @success 200 object code.SuccessfulMessage{body=api_keys.List} "成功後返回的值"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /api-keys [get]
+func (c *control) GetByList(ctx *gin.Context) {
+	input := &apiKeyModel.Field{}
+	input.CompanyID = ctx.MustGet("company_id").(string)
+
+	httpCode, codeMessage := c.Manager.GetByList(input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// Delete
+// @Summary 撤銷單一 API key
+// @description 撤銷單一 API key,撤銷後立即無法使用
+// @Tags api-key
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param apiKeyID path string true "API key ID"
+// @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 404 object code.ErrorMessage{detailed=string} "API key 不存在"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /api-keys/{apiKeyID} [delete]
+func (c *control) Delete(ctx *gin.Context) {
+	input := &apiKeyModel.Field{}
+	input.APIKeyID = ctx.Param("apiKeyID")
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Delete(input)
+	ctx.JSON(httpCode, codeMessage)
+}
@@ -4,12 +4,20 @@ import (
 	"net/http"
 	"strconv"
 
+	"crm/config"
+
+	"crm/internal/interactor/helpers"
 	"crm/internal/interactor/pkg/util"
 
 	constant "crm/internal/interactor/constants"
 
 	"crm/internal/interactor/manager/campaign"
+	"crm/internal/interactor/manager/exporter"
+	"crm/internal/interactor/manager/recycle_bin"
 	campaignModel "crm/internal/interactor/models/campaigns"
+	policyModel "crm/internal/interactor/models/delete_policies"
+	exportModel "crm/internal/interactor/models/exports"
+	"crm/internal/interactor/models/projection"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
 
@@ -25,15 +33,20 @@ type Control interface {
 	GetBySingleOpportunities(ctx *gin.Context)
 	Delete(ctx *gin.Context)
 	Update(ctx *gin.Context)
+	DeletePreview(ctx *gin.Context)
 }
 
 type control struct {
-	Manager campaign.Manager
+	Manager    campaign.Manager
+	Exporter   exporter.Manager
+	RecycleBin recycle_bin.Manager
 }
 
 func Init(db *gorm.DB) Control {
 	return &control{
-		Manager: campaign.Init(db),
+		Manager:    campaign.Init(db),
+		Exporter:   exporter.Init(db),
+		RecycleBin: recycle_bin.Init(db),
 	}
 }
 
@@ -48,6 +61,7 @@ func Init(db *gorm.DB) Control {
 // @param * body campaigns.Create true "新增行銷活動"
 // @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 422 object code.ErrorMessage{detailed=[]code.FieldError} "欄位值不在選項清單"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /campaigns [post]
 func (c *control) Create(ctx *gin.Context) {
@@ -56,6 +70,7 @@ func (c *control) Create(ctx *gin.Context) {
 	input.CreatedBy = ctx.MustGet("user_id").(string)
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -73,12 +88,19 @@ func (c *control) Create(ctx *gin.Context) {
 // @Accept json
 // @produce json
 // @param Authorization header string  true "JWE Token"
-// @param page query int true "目前頁數,請從1開始帶入"
+// @param page query int false "目前頁數,請從1開始帶入,不帶入時使用游標分頁"
 // @param limit query int true "一次回傳比數,請從1開始帶入,最高上限20"
-// @param sort query string false "排序"
-// @param direction query string false "排序方式"
+// @param sort query string false "排序,格式為 field:direction 並以逗號分隔,如 stage:asc,amount:desc"
+// @param cursor query string false "游標,帶入回傳的 next_cursor 或 prev_cursor"
+// @param count query string false "總筆數計算方式 exact, approximate 或 none,游標分頁預設為 none" Enums(exact, approximate, none)
+// @param fields query string false "回傳欄位,以逗號分隔,不帶入時回傳全部欄位"
+// @param expand query string false "展開的關聯,以逗號分隔,可用 salesperson, created_by_user, updated_by_user, opportunities"
+// @param format query string false "匯出格式,帶入時匯出全部符合條件的資料,不分頁,fields 為匯出欄位" Enums(csv, xlsx)
+// @param Accept-Language header string false "匯出檔標題及代碼的語系,可用 zh-TW, en"
 // @param * body campaigns.Filter false "搜尋"
 // @success 200 object code.SuccessfulMessage{body=campaigns.List} "成功後返回的值"
+// @success 202 object code.SuccessfulMessage{body=string} "匯出筆數過多時轉為背景工作,返回工作ID"
+// @failure 400 object code.ErrorMessage{detailed=string} "組合搜尋條件、排序、游標或回傳欄位錯誤"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /campaigns/list [post]
@@ -89,13 +111,55 @@ func (c *control) GetByList(ctx *gin.Context) {
 	input.Limit, _ = strconv.ParseInt(limit, 10, 64)
 	input.Page, _ = strconv.ParseInt(page, 10, 64)
 
+	input.Sort.By = ctx.Query("sort")
+	input.Cursor.Cursor = ctx.Query("cursor")
+	input.Count = ctx.Query("count")
+	input.Projection.Fields = ctx.Query("fields")
+	input.Expand = ctx.Query("expand")
+	format := &exportModel.Format{}
+	if err := ctx.ShouldBindQuery(format); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	// 匯出不分頁,每次讀取的筆數由匯出決定
+	if format.Format != "" {
+		input.Limit = config.ExportPageSize
+	}
+
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
 	}
 
+	if format.Format != "" {
+		httpCode, codeMessage := c.Exporter.Create(&exportModel.Create{
+			Entity:    "campaigns",
+			Format:    format.Format,
+			Fields:    input.Projection.Fields,
+			Language:  ctx.GetHeader("Accept-Language"),
+			CompanyID: ctx.MustGet("company_id").(string),
+			CreatedBy: ctx.MustGet("user_id").(string),
+		}, campaignModel.Columns, func(cursor string, limit int64, count string) (int, any) {
+			list := *input
+			list.Page = 0
+			list.Limit = limit
+			list.Cursor.Cursor = cursor
+			list.Count = count
+			list.Projection = projection.Projection{}
+			return c.Manager.GetByList(&list)
+		})
+		helpers.WriteExport(ctx, httpCode, codeMessage)
+
+		return
+	}
+
 	if input.Limit >= constant.DefaultLimit {
 		input.Limit = constant.DefaultLimit
 	}
@@ -120,6 +184,7 @@ func (c *control) GetByListNoPagination(ctx *gin.Context) {
 	input := &campaignModel.Field{}
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -148,6 +213,7 @@ func (c *control) GetBySingle(ctx *gin.Context) {
 	input.CampaignID = campaignID
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -176,6 +242,7 @@ func (c *control) GetBySingleOpportunities(ctx *gin.Context) {
 	input.CampaignID = campaignID
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -195,21 +262,25 @@ func (c *control) GetBySingleOpportunities(ctx *gin.Context) {
 // @param Authorization header string  true "JWE Token"
 // @param campaignID path string true "行銷活動ID"
 // @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 409 object code.ErrorMessage{detailed=delete_policies.Preview} "有限制刪除的關聯資料"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /campaigns/{campaignID} [delete]
 func (c *control) Delete(ctx *gin.Context) {
+	trx := ctx.MustGet("db_trx").(*gorm.DB)
 	campaignID := ctx.Param("campaignID")
-	input := &campaignModel.Field{}
+	input := &campaignModel.Update{}
 	input.CampaignID = campaignID
+	input.UpdatedBy = util.PointerString(ctx.MustGet("user_id").(string))
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
 	}
 
-	httpCode, codeMessage := c.Manager.Delete(input)
+	httpCode, codeMessage := c.Manager.Delete(trx, input)
 	ctx.JSON(httpCode, codeMessage)
 }
 
@@ -225,6 +296,7 @@ func (c *control) Delete(ctx *gin.Context) {
 // @param * body campaigns.Update true "更新行銷活動"
 // @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 422 object code.ErrorMessage{detailed=[]code.FieldError} "欄位值不在選項清單"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /campaigns/{campaignID} [patch]
 func (c *control) Update(ctx *gin.Context) {
@@ -234,6 +306,7 @@ func (c *control) Update(ctx *gin.Context) {
 	input.UpdatedBy = util.PointerString(ctx.MustGet("user_id").(string))
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -242,3 +315,33 @@ func (c *control) Update(ctx *gin.Context) {
 	httpCode, codeMessage := c.Manager.Update(input)
 	ctx.JSON(httpCode, codeMessage)
 }
+
+// DeletePreview
+// @Summary 預覽刪除單一行銷活動
+// @description 依刪除規則列出刪除單一行銷活動時受影響的關聯資料,restrict 限制刪除、cascade 一併刪除、nullify 清除關聯
+// @Tags campaign
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param campaignID path string true "行銷活動ID"
+// @success 200 object code.SuccessfulMessage{body=delete_policies.Preview} "成功後返回的值"
+// @failure 404 object code.ErrorMessage{detailed=string} "查無資料"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /campaigns/{campaignID}/delete-preview [get]
+func (c *control) DeletePreview(ctx *gin.Context) {
+	input := &policyModel.Field{}
+	input.Entity = "campaigns"
+	input.ID = ctx.Param("campaignID")
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.RecycleBin.Preview(input)
+	ctx.JSON(httpCode, codeMessage)
+}
@@ -0,0 +1,86 @@
+package company_setting
+
+import (
+	"net/http"
+
+	"crm/internal/interactor/manager/company_setting"
+	companySettingModel "crm/internal/interactor/models/company_settings"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Control interface {
+	GetBySingle(ctx *gin.Context)
+	Update(ctx *gin.Context)
+}
+
+type control struct {
+	Manager company_setting.Manager
+}
+
+func Init(db *gorm.DB) Control {
+	return &control{
+		Manager: company_setting.Init(db),
+	}
+}
+
+// GetBySingle
+// @Summary 取得公司設定
+// @description 取得目前使用者所屬公司的設定,未曾設定時回傳預設值
+// @Tags company-setting
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @success 200 object code.SuccessfulMessage{body=company_settings.Single} "成功後返回的值"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /company-settings [get]
+func (c *control) GetBySingle(ctx *gin.Context) {
+	input := &companySettingModel.Field{}
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.GetBySingle(input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// Update
+// @Summary 更新公司設定
+// @description 更新目前使用者所屬公司的設定,if_match_required 為 true 時更新資料必須帶入 If-Match,變更立即生效
+// @Tags company-setting
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param * body company_settings.Update true "更新公司設定"
+// @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /company-settings [patch]
+func (c *control) Update(ctx *gin.Context) {
+	trx := ctx.MustGet("db_trx").(*gorm.DB)
+	input := &companySettingModel.Update{}
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	input.UpdatedBy = util.PointerString(ctx.MustGet("user_id").(string))
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Update(trx, input)
+	ctx.JSON(httpCode, codeMessage)
+}
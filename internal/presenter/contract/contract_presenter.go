@@ -4,12 +4,20 @@ import (
 	"net/http"
 	"strconv"
 
+	"crm/config"
+
+	"crm/internal/interactor/helpers"
 	"crm/internal/interactor/pkg/util"
 
 	constant "crm/internal/interactor/constants"
 
 	"crm/internal/interactor/manager/contract"
+	"crm/internal/interactor/manager/exporter"
+	"crm/internal/interactor/manager/recycle_bin"
 	contractModel "crm/internal/interactor/models/contracts"
+	policyModel "crm/internal/interactor/models/delete_policies"
+	exportModel "crm/internal/interactor/models/exports"
+	"crm/internal/interactor/models/projection"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
 
@@ -24,15 +32,20 @@ type Control interface {
 	GetBySingle(ctx *gin.Context)
 	Delete(ctx *gin.Context)
 	Update(ctx *gin.Context)
+	DeletePreview(ctx *gin.Context)
 }
 
 type control struct {
-	Manager contract.Manager
+	Manager    contract.Manager
+	Exporter   exporter.Manager
+	RecycleBin recycle_bin.Manager
 }
 
 func Init(db *gorm.DB) Control {
 	return &control{
-		Manager: contract.Init(db),
+		Manager:    contract.Init(db),
+		Exporter:   exporter.Init(db),
+		RecycleBin: recycle_bin.Init(db),
 	}
 }
 
@@ -55,6 +68,7 @@ func (c *control) Create(ctx *gin.Context) {
 	input.CreatedBy = ctx.MustGet("user_id").(string)
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -72,12 +86,19 @@ func (c *control) Create(ctx *gin.Context) {
 // @Accept json
 // @produce json
 // @param Authorization header string  true "JWE Token"
-// @param page query int true "目前頁數,請從1開始帶入"
+// @param page query int false "目前頁數,請從1開始帶入,不帶入時使用游標分頁"
 // @param limit query int true "一次回傳比數,請從1開始帶入,最高上限20"
-// @param sort query string false "排序"
-// @param direction query string false "排序方式"
+// @param sort query string false "排序,格式為 field:direction 並以逗號分隔,如 stage:asc,amount:desc"
+// @param cursor query string false "游標,帶入回傳的 next_cursor 或 prev_cursor"
+// @param count query string false "總筆數計算方式 exact, approximate 或 none,游標分頁預設為 none" Enums(exact, approximate, none)
+// @param fields query string false "回傳欄位,以逗號分隔,不帶入時回傳全部欄位"
+// @param expand query string false "展開的關聯,以逗號分隔,可用 opportunity, account, salesperson, created_by_user, updated_by_user"
+// @param format query string false "匯出格式,帶入時匯出全部符合條件的資料,不分頁,fields 為匯出欄位" Enums(csv, xlsx)
+// @param Accept-Language header string false "匯出檔標題及代碼的語系,可用 zh-TW, en"
 // @param * body contracts.Filter false "搜尋"
 // @success 200 object code.SuccessfulMessage{body=contracts.List} "成功後返回的值"
+// @success 202 object code.SuccessfulMessage{body=string} "匯出筆數過多時轉為背景工作,返回工作ID"
+// @failure 400 object code.ErrorMessage{detailed=string} "組合搜尋條件、排序、游標或回傳欄位錯誤"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /contracts/list [post]
@@ -88,13 +109,55 @@ func (c *control) GetByList(ctx *gin.Context) {
 	input.Limit, _ = strconv.ParseInt(limit, 10, 64)
 	input.Page, _ = strconv.ParseInt(page, 10, 64)
 
+	input.Sort.By = ctx.Query("sort")
+	input.Cursor.Cursor = ctx.Query("cursor")
+	input.Count = ctx.Query("count")
+	input.Projection.Fields = ctx.Query("fields")
+	input.Expand = ctx.Query("expand")
+	format := &exportModel.Format{}
+	if err := ctx.ShouldBindQuery(format); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	// 匯出不分頁,每次讀取的筆數由匯出決定
+	if format.Format != "" {
+		input.Limit = config.ExportPageSize
+	}
+
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
 	}
 
+	if format.Format != "" {
+		httpCode, codeMessage := c.Exporter.Create(&exportModel.Create{
+			Entity:    "contracts",
+			Format:    format.Format,
+			Fields:    input.Projection.Fields,
+			Language:  ctx.GetHeader("Accept-Language"),
+			CompanyID: ctx.MustGet("company_id").(string),
+			CreatedBy: ctx.MustGet("user_id").(string),
+		}, contractModel.Columns, func(cursor string, limit int64, count string) (int, any) {
+			list := *input
+			list.Page = 0
+			list.Limit = limit
+			list.Cursor.Cursor = cursor
+			list.Count = count
+			list.Projection = projection.Projection{}
+			return c.Manager.GetByList(&list)
+		})
+		helpers.WriteExport(ctx, httpCode, codeMessage)
+
+		return
+	}
+
 	if input.Limit >= constant.DefaultLimit {
 		input.Limit = constant.DefaultLimit
 	}
@@ -120,6 +183,7 @@ func (c *control) GetByListNoPagination(ctx *gin.Context) {
 	input := &contractModel.FieldsNoPagination{}
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -139,6 +203,7 @@ func (c *control) GetByListNoPagination(ctx *gin.Context) {
 // @param Authorization header string  true "JWE Token"
 // @param contractID path string true "契約ID"
 // @success 200 object code.SuccessfulMessage{body=contracts.Single} "成功後返回的值"
+// @header 200 {string} ETag "版本"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /contracts/{contractID} [get]
@@ -148,12 +213,14 @@ func (c *control) GetBySingle(ctx *gin.Context) {
 	input.ContractID = contractID
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
 	}
 
 	httpCode, codeMessage := c.Manager.GetBySingle(input)
+	helpers.SetETag(ctx, codeMessage)
 	ctx.JSON(httpCode, codeMessage)
 }
 
@@ -167,21 +234,25 @@ func (c *control) GetBySingle(ctx *gin.Context) {
 // @param Authorization header string  true "JWE Token"
 // @param contractID path string true "契約ID"
 // @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 409 object code.ErrorMessage{detailed=delete_policies.Preview} "有限制刪除的關聯資料"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /contracts/{contractID} [delete]
 func (c *control) Delete(ctx *gin.Context) {
+	trx := ctx.MustGet("db_trx").(*gorm.DB)
 	contractID := ctx.Param("contractID")
-	input := &contractModel.Field{}
+	input := &contractModel.Update{}
 	input.ContractID = contractID
+	input.UpdatedBy = util.PointerString(ctx.MustGet("user_id").(string))
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
 	}
 
-	httpCode, codeMessage := c.Manager.Delete(input)
+	httpCode, codeMessage := c.Manager.Delete(trx, input)
 	ctx.JSON(httpCode, codeMessage)
 }
 
@@ -195,7 +266,10 @@ func (c *control) Delete(ctx *gin.Context) {
 // @param Authorization header string  true "JWE Token"
 // @param contractID path string true "契約ID"
 // @param * body contracts.Update true "更新契約"
+// @param If-Match header string false "版本(ETag),版本不符時回傳412及目前資料"
 // @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 412 object code.ErrorMessage{detailed=contracts.Single} "版本不符"
+// @failure 428 object code.ErrorMessage{detailed=string} "未帶入If-Match"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /contracts/{contractID} [patch]
@@ -205,13 +279,46 @@ func (c *control) Update(ctx *gin.Context) {
 	input := &contractModel.Update{}
 	input.ContractID = contractID
 	input.UpdatedBy = util.PointerString(ctx.MustGet("user_id").(string))
+	input.IfMatch = ctx.GetHeader("If-Match")
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
 	}
 
 	httpCode, codeMessage := c.Manager.Update(trx, input)
+	helpers.SetETag(ctx, codeMessage)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// DeletePreview
+// @Summary 預覽刪除單一契約
+// @description 依刪除規則列出刪除單一契約時受影響的關聯資料,restrict 限制刪除、cascade 一併刪除、nullify 清除關聯
+// @Tags contract
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param contractID path string true "契約ID"
+// @success 200 object code.SuccessfulMessage{body=delete_policies.Preview} "成功後返回的值"
+// @failure 404 object code.ErrorMessage{detailed=string} "查無資料"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /contracts/{contractID}/delete-preview [get]
+func (c *control) DeletePreview(ctx *gin.Context) {
+	input := &policyModel.Field{}
+	input.Entity = "contracts"
+	input.ID = ctx.Param("contractID")
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.RecycleBin.Preview(input)
 	ctx.JSON(httpCode, codeMessage)
 }
@@ -0,0 +1,188 @@
+package custom_field
+
+import (
+	"net/http"
+
+	"crm/internal/interactor/manager/custom_field"
+	customFieldModel "crm/internal/interactor/models/custom_fields"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Control interface {
+	Create(ctx *gin.Context)
+	GetByList(ctx *gin.Context)
+	GetBySingle(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type control struct {
+	Manager custom_field.Manager
+}
+
+func Init(db *gorm.DB) Control {
+	return &control{
+		Manager: custom_field.Init(db),
+	}
+}
+
+// Create
+// @Summary 新增自訂欄位
+// @description 新增公司的自訂欄位,資料的值存於 custom_fields,新增及更新資料時依型別、必填及選項驗證
+// @Tags custom-field
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param * body custom_fields.Create true "新增自訂欄位"
+// @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 400 object code.ErrorMessage{detailed=string} "欄位鍵值、選項或預設值錯誤"
+// @failure 409 object code.ErrorMessage{detailed=string} "自訂欄位重複"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /custom-fields [post]
+func (c *control) Create(ctx *gin.Context) {
+	trx := ctx.MustGet("db_trx").(*gorm.DB)
+	input := &customFieldModel.Create{}
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	input.CreatedBy = ctx.MustGet("user_id").(string)
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Create(trx, input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// GetByList
+// @Summary 取得全部自訂欄位
+// @description 取得公司的自訂欄位供畫面顯示
+// @Tags custom-field
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param entity query string false "資料類型" Enums(accounts, contacts, leads, opportunities)
+// @param key query string false "欄位鍵值"
+// @success 200 object code.SuccessfulMessage{body=custom_fields.List} "成功後返回的值"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /custom-fields [get]
+func (c *control) GetByList(ctx *gin.Context) {
+	input := &customFieldModel.Field{}
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.GetByList(input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// GetBySingle
+// @Summary 取得單一自訂欄位
+// @description 取得單一自訂欄位
+// @Tags custom-field
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param customFieldID path string true "自訂欄位ID"
+// @success 200 object code.SuccessfulMessage{body=custom_fields.Single} "成功後返回的值"
+// @failure 404 object code.ErrorMessage{detailed=string} "自訂欄位不存在"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /custom-fields/{customFieldID} [get]
+func (c *control) GetBySingle(ctx *gin.Context) {
+	input := &customFieldModel.Field{}
+	input.CustomFieldID = ctx.Param("customFieldID")
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.GetBySingle(input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// Update
+// @Summary 更新單一自訂欄位
+// @description 更新自訂欄位的名稱、必填、選項、預設值或排序,鍵值及型別不可修改;已存的值不受影響
+// @Tags custom-field
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param customFieldID path string true "自訂欄位ID"
+// @param * body custom_fields.Update true "更新自訂欄位"
+// @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 400 object code.ErrorMessage{detailed=string} "選項或預設值錯誤"
+// @failure 404 object code.ErrorMessage{detailed=string} "自訂欄位不存在"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /custom-fields/{customFieldID} [patch]
+func (c *control) Update(ctx *gin.Context) {
+	trx := ctx.MustGet("db_trx").(*gorm.DB)
+	input := &customFieldModel.Update{}
+	input.CustomFieldID = ctx.Param("customFieldID")
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	input.UpdatedBy = util.PointerString(ctx.MustGet("user_id").(string))
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Update(trx, input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// Delete
+// @Summary 刪除單一自訂欄位
+// @description 刪除自訂欄位,並移除公司資料中該欄位的值
+// @Tags custom-field
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param customFieldID path string true "自訂欄位ID"
+// @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 404 object code.ErrorMessage{detailed=string} "自訂欄位不存在"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /custom-fields/{customFieldID} [delete]
+func (c *control) Delete(ctx *gin.Context) {
+	trx := ctx.MustGet("db_trx").(*gorm.DB)
+	input := &customFieldModel.Field{}
+	input.CustomFieldID = ctx.Param("customFieldID")
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Delete(trx, input)
+	ctx.JSON(httpCode, codeMessage)
+}
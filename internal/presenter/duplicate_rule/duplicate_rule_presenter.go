@@ -0,0 +1,154 @@
+package duplicate_rule
+
+import (
+	"net/http"
+
+	"crm/internal/interactor/manager/duplicate_rule"
+	duplicateRuleModel "crm/internal/interactor/models/duplicate_rules"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Control interface {
+	Create(ctx *gin.Context)
+	GetByList(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type control struct {
+	Manager duplicate_rule.Manager
+}
+
+func Init(db *gorm.DB) Control {
+	return &control{
+		Manager: duplicate_rule.Init(db),
+	}
+}
+
+// Create
+// @Summary 新增重複比對規則
+// @description 新增帳戶、聯絡人或線索的重複比對規則,trigram 比對方式需帶入相似度門檻
+// @Tags duplicate-rule
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param * body duplicate_rules.Create true "新增重複比對規則"
+// @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 400 object code.ErrorMessage{detailed=string} "欄位不可比對"
+// @failure 409 object code.ErrorMessage{detailed=string} "規則重複"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /duplicate-rules [post]
+func (c *control) Create(ctx *gin.Context) {
+	input := &duplicateRuleModel.Create{}
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	input.CreatedBy = ctx.MustGet("user_id").(string)
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Create(input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// GetByList
+// @Summary 取得全部重複比對規則
+// @description 取得全部重複比對規則
+// @Tags duplicate-rule
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param entity query string false "資料類型" Enums(accounts, contacts, leads)
+// @param is_enable query bool false "是否啟用"
+// @success 200 object code.SuccessfulMessage{body=duplicate_rules.List} "成功後返回的值"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /duplicate-rules [get]
+func (c *control) GetByList(ctx *gin.Context) {
+	input := &duplicateRuleModel.Field{}
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.GetByList(input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// Update
+// @Summary 更新單一重複比對規則
+// @description 啟用、停用重複比對規則或調整 trigram 規則的相似度門檻
+// @Tags duplicate-rule
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param duplicateRuleID path string true "重複規則ID"
+// @param * body duplicate_rules.Update true "更新重複比對規則"
+// @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 400 object code.ErrorMessage{detailed=string} "非 trigram 規則不可設定門檻"
+// @failure 404 object code.ErrorMessage{detailed=string} "規則不存在"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /duplicate-rules/{duplicateRuleID} [patch]
+func (c *control) Update(ctx *gin.Context) {
+	input := &duplicateRuleModel.Update{}
+	input.DuplicateRuleID = ctx.Param("duplicateRuleID")
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	input.UpdatedBy = util.PointerString(ctx.MustGet("user_id").(string))
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Update(input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// Delete
+// @Summary 刪除單一重複比對規則
+// @description 刪除單一重複比對規則
+// @Tags duplicate-rule
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param duplicateRuleID path string true "重複規則ID"
+// @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 404 object code.ErrorMessage{detailed=string} "規則不存在"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /duplicate-rules/{duplicateRuleID} [delete]
+func (c *control) Delete(ctx *gin.Context) {
+	input := &duplicateRuleModel.Field{}
+	input.DuplicateRuleID = ctx.Param("duplicateRuleID")
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Delete(input)
+	ctx.JSON(httpCode, codeMessage)
+}
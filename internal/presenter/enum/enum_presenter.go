@@ -0,0 +1,57 @@
+package enum
+
+import (
+	"net/http"
+
+	"crm/internal/interactor/manager/enum"
+	"crm/internal/interactor/models/enums"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Control interface {
+	GetByList(ctx *gin.Context)
+}
+
+type control struct {
+	Manager enum.Manager
+}
+
+func Init(db *gorm.DB) Control {
+	return &control{
+		Manager: enum.Init(),
+	}
+}
+
+// GetByList
+// @Summary 取得代碼及其翻譯
+// @description 取得狀態、歷程記錄等欄位儲存的代碼及其翻譯,供畫面顯示下拉選單
+// @Tags enum
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param Accept-Language header string false "翻譯語系,可用 zh-TW, en"
+// @param type query string false "類型,如 lead.status,不帶入時回傳全部"
+// @success 200 object code.SuccessfulMessage{body=enums.List} "成功後返回的值"
+// @failure 400 object code.ErrorMessage{detailed=string} "類型不存在"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @Router /enums [get]
+func (c *control) GetByList(ctx *gin.Context) {
+	input := &enums.Field{}
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	input.Language = ctx.GetHeader("Accept-Language")
+	ctx.Header("Vary", "Accept-Language")
+	httpCode, codeMessage := c.Manager.GetByList(input)
+	ctx.JSON(httpCode, codeMessage)
+}
@@ -3,12 +3,16 @@ package event
 import (
 	"net/http"
 
+	"crm/internal/interactor/helpers"
 	"crm/internal/interactor/pkg/util"
 
 	"crm/internal/interactor/manager/event"
+	"crm/internal/interactor/manager/recycle_bin"
+	policyModel "crm/internal/interactor/models/delete_policies"
 	eventModel "crm/internal/interactor/models/events"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
+	"crm/internal/router/middleware"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -20,15 +24,19 @@ type Control interface {
 	GetByList(ctx *gin.Context)
 	Delete(ctx *gin.Context)
 	Update(ctx *gin.Context)
+	Bulk(ctx *gin.Context)
+	DeletePreview(ctx *gin.Context)
 }
 
 type control struct {
-	Manager event.Manager
+	Manager    event.Manager
+	RecycleBin recycle_bin.Manager
 }
 
 func Init(db *gorm.DB) Control {
 	return &control{
-		Manager: event.Init(db),
+		Manager:    event.Init(db),
+		RecycleBin: recycle_bin.Init(db),
 	}
 }
 
@@ -43,6 +51,7 @@ func Init(db *gorm.DB) Control {
 // @param * body events.Create true "新增事件"
 // @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 422 object code.ErrorMessage{detailed=[]code.FieldError} "欄位值不在選項清單"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /events [post]
 func (c *control) Create(ctx *gin.Context) {
@@ -51,6 +60,7 @@ func (c *control) Create(ctx *gin.Context) {
 	input.CreatedBy = ctx.MustGet("user_id").(string)
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -70,6 +80,7 @@ func (c *control) Create(ctx *gin.Context) {
 // @param Authorization header string  true "JWE Token"
 // @param * body events.Filter false "搜尋"
 // @success 200 object code.SuccessfulMessage{body=events.List} "成功後返回的值"
+// @failure 400 object code.ErrorMessage{detailed=string} "組合搜尋條件錯誤"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /events/list [post]
@@ -77,6 +88,7 @@ func (c *control) GetByList(ctx *gin.Context) {
 	input := &eventModel.Fields{}
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -96,6 +108,7 @@ func (c *control) GetByList(ctx *gin.Context) {
 // @param Authorization header string  true "JWE Token"
 // @param eventID path string true "事件ID"
 // @success 200 object code.SuccessfulMessage{body=events.Single} "成功後返回的值"
+// @header 200 {string} ETag "版本"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /events/{eventID} [get]
@@ -105,12 +118,14 @@ func (c *control) GetBySingle(ctx *gin.Context) {
 	input.EventID = eventID
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
 	}
 
 	httpCode, codeMessage := c.Manager.GetBySingle(input)
+	helpers.SetETag(ctx, codeMessage)
 	ctx.JSON(httpCode, codeMessage)
 }
 
@@ -124,6 +139,7 @@ func (c *control) GetBySingle(ctx *gin.Context) {
 // @param Authorization header string  true "JWE Token"
 // @param eventID path string true "事件ID"
 // @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 409 object code.ErrorMessage{detailed=delete_policies.Preview} "有限制刪除的關聯資料"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /events/{eventID} [delete]
@@ -135,6 +151,7 @@ func (c *control) Delete(ctx *gin.Context) {
 	input.UpdatedBy = util.PointerString(ctx.MustGet("user_id").(string))
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -154,8 +171,12 @@ func (c *control) Delete(ctx *gin.Context) {
 // @param Authorization header string  true "JWE Token"
 // @param eventID path string true "事件ID"
 // @param * body events.Update true "更新事件"
+// @param If-Match header string false "版本(ETag),版本不符時回傳412及目前資料"
 // @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 412 object code.ErrorMessage{detailed=events.Single} "版本不符"
+// @failure 428 object code.ErrorMessage{detailed=string} "未帶入If-Match"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 422 object code.ErrorMessage{detailed=[]code.FieldError} "欄位值不在選項清單"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /events/{eventID} [patch]
 func (c *control) Update(ctx *gin.Context) {
@@ -164,13 +185,77 @@ func (c *control) Update(ctx *gin.Context) {
 	input := &eventModel.Update{}
 	input.EventID = eventID
 	input.UpdatedBy = util.PointerString(ctx.MustGet("user_id").(string))
+	input.IfMatch = ctx.GetHeader("If-Match")
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
 	}
 
 	httpCode, codeMessage := c.Manager.Update(trx, input)
+	helpers.SetETag(ctx, codeMessage)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// Bulk
+// @Summary 批次新增、更新、刪除事件
+// @description 一次新增、更新及刪除多筆事件,各項目分別驗證並回傳結果。all_or_nothing 模式任一項目失敗時回傳422且全部不執行,best_effort 模式保留成功的項目。公司要求 If-Match 時,更新項目須帶入 if_match,未帶入的項目回傳428,版本不符的項目回傳412
+// @Tags event
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param * body events.Bulk true "批次作業"
+// @success 200 object code.SuccessfulMessage{body=bulk.List} "成功後返回的值"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 422 object code.ErrorMessage{detailed=bulk.List} "all_or_nothing 模式下有項目失敗"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /events/bulk [post]
+func (c *control) Bulk(ctx *gin.Context) {
+	trx := ctx.MustGet("db_trx").(*gorm.DB)
+	input := &eventModel.Bulk{}
+	input.UserID = ctx.MustGet("user_id").(string)
+	input.IfMatchRequired = middleware.IfMatchRequired(trx, ctx.GetString("company_id"))
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Bulk(trx, input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// DeletePreview
+// @Summary 預覽刪除單一事件
+// @description 依刪除規則列出刪除單一事件時受影響的關聯資料,restrict 限制刪除、cascade 一併刪除、nullify 清除關聯
+// @Tags event
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param eventID path string true "事件ID"
+// @success 200 object code.SuccessfulMessage{body=delete_policies.Preview} "成功後返回的值"
+// @failure 404 object code.ErrorMessage{detailed=string} "查無資料"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /events/{eventID}/delete-preview [get]
+func (c *control) DeletePreview(ctx *gin.Context) {
+	input := &policyModel.Field{}
+	input.Entity = "events"
+	input.ID = ctx.Param("eventID")
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.RecycleBin.Preview(input)
 	ctx.JSON(httpCode, codeMessage)
 }
@@ -0,0 +1,94 @@
+package graphql
+
+import (
+	"net/http"
+
+	"crm/internal/interactor/manager/graphql"
+	graphqlModel "crm/internal/interactor/models/graphql"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+	"crm/internal/router/middleware"
+	casbin "crm/internal/router/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Control interface {
+	Execute(ctx *gin.Context)
+	GetBySchema(ctx *gin.Context)
+}
+
+type control struct {
+	Manager graphql.Manager
+}
+
+func Init(db *gorm.DB) Control {
+	return &control{
+		Manager: graphql.Init(db),
+	}
+}
+
+// Execute
+// @Summary GraphQL 查詢與異動
+// @description 以 GraphQL 查詢資料及其關聯,同一層的關聯以一次查詢讀取。角色可列出的類型才可讀取,異動須有對應 REST API 的權限,由相同的 manager 執行並寫入歷程記錄,失敗的異動單獨復原。欄位的錯誤於 errors 回傳,extensions.status 為對應的狀態碼
+// @Tags graphql
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param * body graphql.Request true "GraphQL 請求"
+// @success 200 object graphql.Response "成功後返回的值"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /graphql [post]
+func (c *control) Execute(ctx *gin.Context) {
+	trx := ctx.MustGet("db_trx").(*gorm.DB)
+	input := &graphqlModel.Request{}
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	input.UserID = ctx.MustGet("user_id").(string)
+	input.IfMatchRequired = middleware.IfMatchRequired(trx, input.CompanyID)
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	// a type is readable when the role may list its records, a mutation is allowed when the role may call its route
+	role := ctx.GetString("role_name")
+	for _, permissions := range []map[string]graphqlModel.Permission{graphqlModel.Reads, graphqlModel.Writes} {
+		for name, permission := range permissions {
+			allowed, err := casbin.Enforcer.Enforce(role, permission.Path, permission.Method)
+			if err != nil {
+				log.Error(err)
+				ctx.JSON(http.StatusInternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error()))
+
+				return
+			}
+
+			if allowed {
+				input.Allowed = append(input.Allowed, name)
+			}
+		}
+	}
+
+	httpCode, codeMessage := c.Manager.Execute(trx, input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// GetBySchema
+// @Summary 取得 GraphQL 結構
+// @description 取得 GraphQL 結構定義(SDL),欄位名稱與 REST API 的 JSON 欄位相同
+// @Tags graphql
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @Router /graphql/schema [get]
+func (c *control) GetBySchema(ctx *gin.Context) {
+	httpCode, codeMessage := c.Manager.GetBySchema()
+	ctx.JSON(httpCode, codeMessage)
+}
@@ -0,0 +1,124 @@
+package grpc
+
+import (
+	"context"
+
+	crmv1 "crm/api/proto/crm/v1"
+	"crm/internal/interactor/manager/account"
+	accountModel "crm/internal/interactor/models/accounts"
+	"crm/internal/interactor/pkg/util"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+type accountServer struct {
+	crmv1.UnimplementedAccountServiceServer
+	DB      *gorm.DB
+	Manager account.Manager
+}
+
+func InitAccount(db *gorm.DB) crmv1.AccountServiceServer {
+	return &accountServer{
+		DB:      db,
+		Manager: account.Init(db),
+	}
+}
+
+func (s *accountServer) GetAccount(ctx context.Context, request *crmv1.GetAccountRequest) (*crmv1.Account, error) {
+	input := &accountModel.Field{}
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	output := &crmv1.Account{}
+	httpCode, codeMessage := s.Manager.GetBySingle(input)
+	if err := encode(httpCode, codeMessage, output); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *accountServer) ListAccounts(ctx context.Context, request *crmv1.ListRequest) (*crmv1.ListAccountsResponse, error) {
+	input := &accountModel.Fields{}
+	input.CompanyID = caller(ctx).CompanyID
+	if err := list(request, input); err != nil {
+		return nil, err
+	}
+
+	output := &crmv1.ListAccountsResponse{}
+	httpCode, codeMessage := s.Manager.GetByList(input)
+	if err := encode(httpCode, codeMessage, output); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *accountServer) StreamAccounts(request *crmv1.StreamRequest, server crmv1.AccountService_StreamAccountsServer) error {
+	input := &accountModel.Fields{}
+	input.CompanyID = caller(server.Context()).CompanyID
+	return stream(server, request, "accounts", input, func() (int, any) {
+		return s.Manager.GetByList(input)
+	}, func() proto.Message {
+		return &crmv1.Account{}
+	})
+}
+
+func (s *accountServer) CreateAccount(ctx context.Context, request *crmv1.CreateAccountRequest) (*crmv1.Account, error) {
+	input := &accountModel.Create{}
+	input.CreatedBy = caller(ctx).UserID
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Create(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return s.GetAccount(ctx, &crmv1.GetAccountRequest{AccountId: created(codeMessage)})
+}
+
+func (s *accountServer) UpdateAccount(ctx context.Context, request *crmv1.UpdateAccountRequest) (*crmv1.Account, error) {
+	if err := ifMatch(ctx, s.DB, request.GetIfMatch()); err != nil {
+		return nil, err
+	}
+
+	input := &accountModel.Update{}
+	input.IfMatch = request.GetIfMatch()
+	input.UpdatedBy = util.PointerString(caller(ctx).UserID)
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Update(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return s.GetAccount(ctx, &crmv1.GetAccountRequest{AccountId: request.GetAccountId()})
+}
+
+func (s *accountServer) DeleteAccount(ctx context.Context, request *crmv1.DeleteAccountRequest) (*emptypb.Empty, error) {
+	input := &accountModel.Update{}
+	input.UpdatedBy = util.PointerString(caller(ctx).UserID)
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Delete(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,124 @@
+package grpc
+
+import (
+	"context"
+
+	crmv1 "crm/api/proto/crm/v1"
+	"crm/internal/interactor/manager/contact"
+	contactModel "crm/internal/interactor/models/contacts"
+	"crm/internal/interactor/pkg/util"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+type contactServer struct {
+	crmv1.UnimplementedContactServiceServer
+	DB      *gorm.DB
+	Manager contact.Manager
+}
+
+func InitContact(db *gorm.DB) crmv1.ContactServiceServer {
+	return &contactServer{
+		DB:      db,
+		Manager: contact.Init(db),
+	}
+}
+
+func (s *contactServer) GetContact(ctx context.Context, request *crmv1.GetContactRequest) (*crmv1.Contact, error) {
+	input := &contactModel.Field{}
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	output := &crmv1.Contact{}
+	httpCode, codeMessage := s.Manager.GetBySingle(input)
+	if err := encode(httpCode, codeMessage, output); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *contactServer) ListContacts(ctx context.Context, request *crmv1.ListRequest) (*crmv1.ListContactsResponse, error) {
+	input := &contactModel.Fields{}
+	input.CompanyID = caller(ctx).CompanyID
+	if err := list(request, input); err != nil {
+		return nil, err
+	}
+
+	output := &crmv1.ListContactsResponse{}
+	httpCode, codeMessage := s.Manager.GetByList(input)
+	if err := encode(httpCode, codeMessage, output); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *contactServer) StreamContacts(request *crmv1.StreamRequest, server crmv1.ContactService_StreamContactsServer) error {
+	input := &contactModel.Fields{}
+	input.CompanyID = caller(server.Context()).CompanyID
+	return stream(server, request, "contacts", input, func() (int, any) {
+		return s.Manager.GetByList(input)
+	}, func() proto.Message {
+		return &crmv1.Contact{}
+	})
+}
+
+func (s *contactServer) CreateContact(ctx context.Context, request *crmv1.CreateContactRequest) (*crmv1.Contact, error) {
+	input := &contactModel.Create{}
+	input.CreatedBy = caller(ctx).UserID
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Create(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return s.GetContact(ctx, &crmv1.GetContactRequest{ContactId: created(codeMessage)})
+}
+
+func (s *contactServer) UpdateContact(ctx context.Context, request *crmv1.UpdateContactRequest) (*crmv1.Contact, error) {
+	if err := ifMatch(ctx, s.DB, request.GetIfMatch()); err != nil {
+		return nil, err
+	}
+
+	input := &contactModel.Update{}
+	input.IfMatch = request.GetIfMatch()
+	input.UpdatedBy = util.PointerString(caller(ctx).UserID)
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Update(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return s.GetContact(ctx, &crmv1.GetContactRequest{ContactId: request.GetContactId()})
+}
+
+func (s *contactServer) DeleteContact(ctx context.Context, request *crmv1.DeleteContactRequest) (*emptypb.Empty, error) {
+	input := &contactModel.Update{}
+	input.UpdatedBy = util.PointerString(caller(ctx).UserID)
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Delete(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,122 @@
+package grpc
+
+import (
+	"context"
+
+	crmv1 "crm/api/proto/crm/v1"
+	"crm/internal/interactor/manager/contract"
+	contractModel "crm/internal/interactor/models/contracts"
+	"crm/internal/interactor/pkg/util"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+type contractServer struct {
+	crmv1.UnimplementedContractServiceServer
+	DB      *gorm.DB
+	Manager contract.Manager
+}
+
+func InitContract(db *gorm.DB) crmv1.ContractServiceServer {
+	return &contractServer{
+		DB:      db,
+		Manager: contract.Init(db),
+	}
+}
+
+func (s *contractServer) GetContract(ctx context.Context, request *crmv1.GetContractRequest) (*crmv1.Contract, error) {
+	input := &contractModel.Field{}
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	output := &crmv1.Contract{}
+	httpCode, codeMessage := s.Manager.GetBySingle(input)
+	if err := encode(httpCode, codeMessage, output); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *contractServer) ListContracts(ctx context.Context, request *crmv1.ListRequest) (*crmv1.ListContractsResponse, error) {
+	input := &contractModel.Fields{}
+	if err := list(request, input); err != nil {
+		return nil, err
+	}
+
+	output := &crmv1.ListContractsResponse{}
+	httpCode, codeMessage := s.Manager.GetByList(input)
+	if err := encode(httpCode, codeMessage, output); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *contractServer) StreamContracts(request *crmv1.StreamRequest, server crmv1.ContractService_StreamContractsServer) error {
+	input := &contractModel.Fields{}
+	return stream(server, request, "contracts", input, func() (int, any) {
+		return s.Manager.GetByList(input)
+	}, func() proto.Message {
+		return &crmv1.Contract{}
+	})
+}
+
+func (s *contractServer) CreateContract(ctx context.Context, request *crmv1.CreateContractRequest) (*crmv1.Contract, error) {
+	input := &contractModel.Create{}
+	input.CreatedBy = caller(ctx).UserID
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Create(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return s.GetContract(ctx, &crmv1.GetContractRequest{ContractId: created(codeMessage)})
+}
+
+func (s *contractServer) UpdateContract(ctx context.Context, request *crmv1.UpdateContractRequest) (*crmv1.Contract, error) {
+	if err := ifMatch(ctx, s.DB, request.GetIfMatch()); err != nil {
+		return nil, err
+	}
+
+	input := &contractModel.Update{}
+	input.IfMatch = request.GetIfMatch()
+	input.UpdatedBy = util.PointerString(caller(ctx).UserID)
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Update(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return s.GetContract(ctx, &crmv1.GetContractRequest{ContractId: request.GetContractId()})
+}
+
+func (s *contractServer) DeleteContract(ctx context.Context, request *crmv1.DeleteContractRequest) (*emptypb.Empty, error) {
+	input := &contractModel.Update{}
+	input.UpdatedBy = util.PointerString(caller(ctx).UserID)
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Delete(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
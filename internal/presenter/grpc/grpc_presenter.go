@@ -0,0 +1,427 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	crmv1 "crm/api/proto/crm/v1"
+	"crm/config"
+	constant "crm/internal/interactor/constants"
+	"crm/internal/interactor/helpers"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+	"crm/internal/router/middleware"
+
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpcLib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// Identity is the caller of an RPC, it is set by the interceptors of the gRPC router.
+type Identity struct {
+	// 使用者ID
+	UserID string
+	// 公司ID
+	CompanyID string
+	// 角色名稱
+	RoleName string
+}
+
+type identityKey struct{}
+
+// NewContext returns a copy of ctx carrying the caller.
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// caller returns the caller set by the interceptors, the interceptors reject an RPC without one.
+func caller(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityKey{}).(*Identity)
+	if identity == nil {
+		return &Identity{}
+	}
+
+	return identity
+}
+
+// statuses are the gRPC codes of the HTTP statuses returned by the managers.
+var statuses = map[int]codes.Code{
+	code.BadRequest:           codes.InvalidArgument,
+	code.JWTRejected:          codes.Unauthenticated,
+	code.PermissionDenied:     codes.PermissionDenied,
+	code.DoesNotExist:         codes.NotFound,
+	code.Conflict:             codes.AlreadyExists,
+	code.PreconditionFailed:   codes.Aborted,
+	code.FormatError:          codes.InvalidArgument,
+	code.UnprocessableEntity:  codes.InvalidArgument,
+	code.PreconditionRequired: codes.FailedPrecondition,
+	code.TooManyRequests:      codes.ResourceExhausted,
+	code.InternalServerError:  codes.Internal,
+	code.ServerDown:           codes.Unavailable,
+}
+
+// failure turns the code message of a manager into a gRPC status, the detail of a 500 is only logged.
+func failure(httpCode int, codeMessage any) error {
+	statusCode, ok := statuses[httpCode]
+	if !ok {
+		statusCode = codes.Unknown
+	}
+
+	message, ok := codeMessage.(*code.ErrorMessage)
+	if !ok {
+		return status.Error(statusCode, fmt.Sprint(codeMessage))
+	}
+
+	if httpCode == code.InternalServerError || message.Detailed == nil {
+		return status.Error(statusCode, message.Message)
+	}
+
+	if fields, ok := message.Detailed.([]*code.FieldError); ok {
+		return violations(message.Message, fields)
+	}
+
+	detailed, ok := message.Detailed.(string)
+	if !ok {
+		marshal, err := json.Marshal(message.Detailed)
+		if err != nil {
+			log.Error(err)
+		}
+
+		detailed = string(marshal)
+	}
+
+	return status.Error(statusCode, message.Message+" "+detailed)
+}
+
+// internal logs an unexpected error, the client only receives a generic message.
+func internal(err error) error {
+	log.Error(err)
+	return failure(code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error()))
+}
+
+// decode copies the fields of a request into the input of a manager, the fields of the input that are not
+// in the request are kept, and input is then validated by its binding tags like a REST request body.
+func decode(request proto.Message, input any) error {
+	marshal, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(request)
+	if err != nil {
+		log.Error(err)
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return bind(marshal, input)
+}
+
+// bind reads JSON into input and validates it.
+func bind(data []byte, input any) error {
+	if err := json.Unmarshal(data, input); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := binding.Validator.ValidateStruct(input); err != nil {
+		return invalid(err)
+	}
+
+	return nil
+}
+
+// invalid returns the InvalidArgument status of a failed validation, the invalid fields are listed in its details.
+func invalid(err error) error {
+	var validationError *middleware.ValidationError
+	if !errors.As(err, &validationError) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return violations(err.Error(), validationError.Fields)
+}
+
+// violations returns the InvalidArgument status listing the invalid fields in its details.
+func violations(message string, fields []*code.FieldError) error {
+	badRequest := &errdetails.BadRequest{}
+	for _, field := range fields {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field.Field,
+			Description: field.Message,
+		})
+	}
+
+	withDetails, err := status.New(codes.InvalidArgument, message).WithDetails(badRequest)
+	if err != nil {
+		log.Error(err)
+		return status.Error(codes.InvalidArgument, message)
+	}
+
+	return withDetails.Err()
+}
+
+// check returns the status of a manager that failed, the body of a successful write is not returned.
+func check(httpCode int, codeMessage any) error {
+	if httpCode != code.Successful && httpCode != code.Sync {
+		return failure(httpCode, codeMessage)
+	}
+
+	return nil
+}
+
+// encode copies the body of a successful code message into output.
+func encode(httpCode int, codeMessage any, output proto.Message) error {
+	message, ok := codeMessage.(*code.SuccessfulMessage)
+	if httpCode != code.Successful || !ok {
+		return failure(httpCode, codeMessage)
+	}
+
+	marshal, err := json.Marshal(message.Body)
+	if err != nil {
+		return internal(err)
+	}
+
+	return unmarshal(marshal, output)
+}
+
+// unmarshal reads a record of a manager into output, the fields only the REST API returns are left out.
+func unmarshal(data []byte, output proto.Message) error {
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, output); err != nil {
+		return internal(err)
+	}
+
+	tag(output.ProtoReflect())
+	return nil
+}
+
+// tag sets the etag of every record from its updated_at, it is the version the REST API returns as ETag.
+func tag(message protoreflect.Message) {
+	fields := message.Descriptor().Fields()
+	etag, updatedAt := fields.ByName("etag"), fields.ByName("updated_at")
+	if etag != nil && updatedAt != nil && message.Has(updatedAt) {
+		timestamp := message.Get(updatedAt).Message().Interface().(*timestamppb.Timestamp)
+		message.Set(etag, protoreflect.ValueOfString(helpers.ETag(timestamp.AsTime())))
+	}
+
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if !field.IsList() || field.Message() == nil {
+			continue
+		}
+
+		list := message.Get(field).List()
+		for j := 0; j < list.Len(); j++ {
+			tag(list.Get(j).Message())
+		}
+	}
+}
+
+// created returns the ID of a record created by a manager.
+func created(codeMessage any) string {
+	message, ok := codeMessage.(*code.SuccessfulMessage)
+	if !ok {
+		return ""
+	}
+
+	switch id := message.Body.(type) {
+	case *string:
+		if id != nil {
+			return *id
+		}
+	case string:
+		return id
+	}
+
+	return ""
+}
+
+// transaction runs a write in its own transaction like middleware.Transaction,
+// it is committed when the manager succeeds and rolled back otherwise.
+func transaction(db *gorm.DB, fn func(trx *gorm.DB) (int, any)) (httpCode int, codeMessage any) {
+	ctx, afterCommit := helpers.WithAfterCommit(db.Statement.Context)
+	trx := db.WithContext(ctx).Begin()
+	if trx.Error != nil {
+		log.Error(trx.Error)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, trx.Error.Error())
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			trx.Rollback()
+			panic(r)
+		}
+	}()
+
+	httpCode, codeMessage = fn(trx)
+	if httpCode != code.Successful && httpCode != code.Sync {
+		if err := trx.Rollback().Error; err != nil {
+			log.Error(err)
+		}
+
+		return httpCode, codeMessage
+	}
+
+	if err := trx.Commit().Error; err != nil {
+		log.Error(err)
+		return code.InternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error())
+	}
+
+	afterCommit()
+	return httpCode, codeMessage
+}
+
+// ifMatch rejects an update without a version for the companies enforcing optimistic concurrency control.
+func ifMatch(ctx context.Context, db *gorm.DB, version string) error {
+	if version == "" && middleware.IfMatchRequired(db, caller(ctx).CompanyID) {
+		return status.Error(codes.FailedPrecondition, "if_match is required.")
+	}
+
+	return nil
+}
+
+// page is the input of a list manager, it is read into the Fields of an entity.
+type page struct {
+	// 頁數
+	Page int64 `json:"page"`
+	// 筆數
+	Limit int64 `json:"limit"`
+	// 游標
+	Cursor string `json:"cursor,omitempty"`
+	// 總筆數計算方式
+	Count string `json:"count,omitempty"`
+	// 排序
+	Sort struct {
+		// 多欄排序
+		By string `json:"by,omitempty"`
+	} `json:"sort"`
+	// 搜尋欄位
+	Filter json.RawMessage `json:"filter,omitempty"`
+}
+
+// list reads a ListRequest into the Fields of an entity, the limit is capped like the REST API.
+func list(request *crmv1.ListRequest, input any) error {
+	fields := &page{
+		Page:   int64(request.GetPage()),
+		Limit:  int64(request.GetLimit()),
+		Cursor: request.GetCursor(),
+		Count:  request.GetCount(),
+	}
+	fields.Sort.By = request.GetSort()
+	if fields.Limit <= 0 || fields.Limit >= constant.DefaultLimit {
+		fields.Limit = constant.DefaultLimit
+	}
+
+	if request.GetFilter() != nil {
+		filter, err := protojson.Marshal(request.GetFilter())
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		fields.Filter = filter
+	}
+
+	marshal, err := json.Marshal(fields)
+	if err != nil {
+		return internal(err)
+	}
+
+	return bind(marshal, input)
+}
+
+// stream sends every record matching the request, page by page through the cursor of the list manager.
+// Every page is read into input before list is called, key is the name of the records in the body of the list,
+// such as accounts, and record returns an empty record.
+func stream(server grpcLib.ServerStream, request *crmv1.StreamRequest, key string, input any, list func() (int, any), record func() proto.Message) error {
+	filter := map[string]any{}
+	if request.GetFilter() != nil {
+		filter = request.GetFilter().AsMap()
+	}
+
+	// updated_since is added to the where expression of the filter
+	if request.GetUpdatedSince() != nil {
+		since := map[string]any{"field": "updated_at", "op": "gte", "value": request.GetUpdatedSince().AsTime().Format(time.RFC3339Nano)}
+		if where, ok := filter["where"]; ok {
+			filter["where"] = map[string]any{"and": []any{where, since}}
+		} else {
+			filter["where"] = since
+		}
+	}
+
+	fields := &page{
+		Limit: config.GRPCStreamPageSize,
+		Count: "none",
+	}
+	fields.Sort.By = request.GetSort()
+	if fields.Sort.By == "" {
+		fields.Sort.By = "updated_at:asc"
+	}
+
+	marshal, err := json.Marshal(filter)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	fields.Filter = marshal
+	for {
+		if err = server.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		marshal, err = json.Marshal(fields)
+		if err != nil {
+			return internal(err)
+		}
+
+		if err = bind(marshal, input); err != nil {
+			return err
+		}
+
+		httpCode, codeMessage := list()
+		message, ok := codeMessage.(*code.SuccessfulMessage)
+		if httpCode != code.Successful || !ok {
+			return failure(httpCode, codeMessage)
+		}
+
+		marshal, err = json.Marshal(message.Body)
+		if err != nil {
+			return internal(err)
+		}
+
+		body := map[string]json.RawMessage{}
+		if err = json.Unmarshal(marshal, &body); err != nil {
+			return internal(err)
+		}
+
+		records := make([]json.RawMessage, 0)
+		if err = json.Unmarshal(body[key], &records); err != nil {
+			return internal(err)
+		}
+
+		for _, data := range records {
+			output := record()
+			if err = unmarshal(data, output); err != nil {
+				return err
+			}
+
+			if err = server.SendMsg(output); err != nil {
+				return err
+			}
+		}
+
+		next := ""
+		if len(body["next_cursor"]) > 0 {
+			if err = json.Unmarshal(body["next_cursor"], &next); err != nil {
+				return internal(err)
+			}
+		}
+
+		if next == "" || len(records) == 0 {
+			return nil
+		}
+
+		fields.Cursor = next
+	}
+}
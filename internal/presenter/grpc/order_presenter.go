@@ -0,0 +1,122 @@
+package grpc
+
+import (
+	"context"
+
+	crmv1 "crm/api/proto/crm/v1"
+	"crm/internal/interactor/manager/order"
+	orderModel "crm/internal/interactor/models/orders"
+	"crm/internal/interactor/pkg/util"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+type orderServer struct {
+	crmv1.UnimplementedOrderServiceServer
+	DB      *gorm.DB
+	Manager order.Manager
+}
+
+func InitOrder(db *gorm.DB) crmv1.OrderServiceServer {
+	return &orderServer{
+		DB:      db,
+		Manager: order.Init(db),
+	}
+}
+
+func (s *orderServer) GetOrder(ctx context.Context, request *crmv1.GetOrderRequest) (*crmv1.Order, error) {
+	input := &orderModel.Field{}
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	output := &crmv1.Order{}
+	httpCode, codeMessage := s.Manager.GetBySingle(input)
+	if err := encode(httpCode, codeMessage, output); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *orderServer) ListOrders(ctx context.Context, request *crmv1.ListRequest) (*crmv1.ListOrdersResponse, error) {
+	input := &orderModel.Fields{}
+	if err := list(request, input); err != nil {
+		return nil, err
+	}
+
+	output := &crmv1.ListOrdersResponse{}
+	httpCode, codeMessage := s.Manager.GetByList(input)
+	if err := encode(httpCode, codeMessage, output); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *orderServer) StreamOrders(request *crmv1.StreamRequest, server crmv1.OrderService_StreamOrdersServer) error {
+	input := &orderModel.Fields{}
+	return stream(server, request, "orders", input, func() (int, any) {
+		return s.Manager.GetByList(input)
+	}, func() proto.Message {
+		return &crmv1.Order{}
+	})
+}
+
+func (s *orderServer) CreateOrder(ctx context.Context, request *crmv1.CreateOrderRequest) (*crmv1.Order, error) {
+	input := &orderModel.Create{}
+	input.CreatedBy = caller(ctx).UserID
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Create(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return s.GetOrder(ctx, &crmv1.GetOrderRequest{OrderId: created(codeMessage)})
+}
+
+func (s *orderServer) UpdateOrder(ctx context.Context, request *crmv1.UpdateOrderRequest) (*crmv1.Order, error) {
+	if err := ifMatch(ctx, s.DB, request.GetIfMatch()); err != nil {
+		return nil, err
+	}
+
+	input := &orderModel.Update{}
+	input.IfMatch = request.GetIfMatch()
+	input.UpdatedBy = util.PointerString(caller(ctx).UserID)
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Update(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return s.GetOrder(ctx, &crmv1.GetOrderRequest{OrderId: request.GetOrderId()})
+}
+
+func (s *orderServer) DeleteOrder(ctx context.Context, request *crmv1.DeleteOrderRequest) (*emptypb.Empty, error) {
+	input := &orderModel.Update{}
+	input.UpdatedBy = util.PointerString(caller(ctx).UserID)
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Delete(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
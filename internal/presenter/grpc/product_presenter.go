@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"context"
+
+	crmv1 "crm/api/proto/crm/v1"
+	"crm/internal/interactor/manager/product"
+	productModel "crm/internal/interactor/models/products"
+	"crm/internal/interactor/pkg/util"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+type productServer struct {
+	crmv1.UnimplementedProductServiceServer
+	DB      *gorm.DB
+	Manager product.Manager
+}
+
+func InitProduct(db *gorm.DB) crmv1.ProductServiceServer {
+	return &productServer{
+		DB:      db,
+		Manager: product.Init(db),
+	}
+}
+
+func (s *productServer) GetProduct(ctx context.Context, request *crmv1.GetProductRequest) (*crmv1.Product, error) {
+	input := &productModel.Field{}
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	output := &crmv1.Product{}
+	httpCode, codeMessage := s.Manager.GetBySingle(input)
+	if err := encode(httpCode, codeMessage, output); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *productServer) ListProducts(ctx context.Context, request *crmv1.ListRequest) (*crmv1.ListProductsResponse, error) {
+	input := &productModel.Fields{}
+	if err := list(request, input); err != nil {
+		return nil, err
+	}
+
+	output := &crmv1.ListProductsResponse{}
+	httpCode, codeMessage := s.Manager.GetByList(input)
+	if err := encode(httpCode, codeMessage, output); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *productServer) StreamProducts(request *crmv1.StreamRequest, server crmv1.ProductService_StreamProductsServer) error {
+	input := &productModel.Fields{}
+	return stream(server, request, "products", input, func() (int, any) {
+		return s.Manager.GetByList(input)
+	}, func() proto.Message {
+		return &crmv1.Product{}
+	})
+}
+
+func (s *productServer) CreateProduct(ctx context.Context, request *crmv1.CreateProductRequest) (*crmv1.Product, error) {
+	input := &productModel.Create{}
+	input.CreatedBy = caller(ctx).UserID
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Create(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return s.GetProduct(ctx, &crmv1.GetProductRequest{ProductId: created(codeMessage)})
+}
+
+func (s *productServer) UpdateProduct(ctx context.Context, request *crmv1.UpdateProductRequest) (*crmv1.Product, error) {
+	input := &productModel.Update{}
+	input.UpdatedBy = util.PointerString(caller(ctx).UserID)
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Update(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return s.GetProduct(ctx, &crmv1.GetProductRequest{ProductId: request.GetProductId()})
+}
+
+func (s *productServer) DeleteProduct(ctx context.Context, request *crmv1.DeleteProductRequest) (*emptypb.Empty, error) {
+	input := &productModel.Update{}
+	input.UpdatedBy = util.PointerString(caller(ctx).UserID)
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Delete(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,122 @@
+package grpc
+
+import (
+	"context"
+
+	crmv1 "crm/api/proto/crm/v1"
+	"crm/internal/interactor/manager/quote"
+	quoteModel "crm/internal/interactor/models/quotes"
+	"crm/internal/interactor/pkg/util"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+type quoteServer struct {
+	crmv1.UnimplementedQuoteServiceServer
+	DB      *gorm.DB
+	Manager quote.Manager
+}
+
+func InitQuote(db *gorm.DB) crmv1.QuoteServiceServer {
+	return &quoteServer{
+		DB:      db,
+		Manager: quote.Init(db),
+	}
+}
+
+func (s *quoteServer) GetQuote(ctx context.Context, request *crmv1.GetQuoteRequest) (*crmv1.Quote, error) {
+	input := &quoteModel.Field{}
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	output := &crmv1.Quote{}
+	httpCode, codeMessage := s.Manager.GetBySingle(input)
+	if err := encode(httpCode, codeMessage, output); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *quoteServer) ListQuotes(ctx context.Context, request *crmv1.ListRequest) (*crmv1.ListQuotesResponse, error) {
+	input := &quoteModel.Fields{}
+	if err := list(request, input); err != nil {
+		return nil, err
+	}
+
+	output := &crmv1.ListQuotesResponse{}
+	httpCode, codeMessage := s.Manager.GetByList(input)
+	if err := encode(httpCode, codeMessage, output); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *quoteServer) StreamQuotes(request *crmv1.StreamRequest, server crmv1.QuoteService_StreamQuotesServer) error {
+	input := &quoteModel.Fields{}
+	return stream(server, request, "quotes", input, func() (int, any) {
+		return s.Manager.GetByList(input)
+	}, func() proto.Message {
+		return &crmv1.Quote{}
+	})
+}
+
+func (s *quoteServer) CreateQuote(ctx context.Context, request *crmv1.CreateQuoteRequest) (*crmv1.Quote, error) {
+	input := &quoteModel.Create{}
+	input.CreatedBy = caller(ctx).UserID
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Create(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return s.GetQuote(ctx, &crmv1.GetQuoteRequest{QuoteId: created(codeMessage)})
+}
+
+func (s *quoteServer) UpdateQuote(ctx context.Context, request *crmv1.UpdateQuoteRequest) (*crmv1.Quote, error) {
+	if err := ifMatch(ctx, s.DB, request.GetIfMatch()); err != nil {
+		return nil, err
+	}
+
+	input := &quoteModel.Update{}
+	input.IfMatch = request.GetIfMatch()
+	input.UpdatedBy = util.PointerString(caller(ctx).UserID)
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Update(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return s.GetQuote(ctx, &crmv1.GetQuoteRequest{QuoteId: request.GetQuoteId()})
+}
+
+func (s *quoteServer) DeleteQuote(ctx context.Context, request *crmv1.DeleteQuoteRequest) (*emptypb.Empty, error) {
+	input := &quoteModel.Update{}
+	input.UpdatedBy = util.PointerString(caller(ctx).UserID)
+	if err := decode(request, input); err != nil {
+		return nil, err
+	}
+
+	httpCode, codeMessage := transaction(s.DB, func(trx *gorm.DB) (int, any) {
+		return s.Manager.Delete(trx, input)
+	})
+	if err := check(httpCode, codeMessage); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
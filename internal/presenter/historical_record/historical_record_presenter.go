@@ -40,6 +40,7 @@ func Init(db *gorm.DB) Control {
 // @Accept json
 // @produce json
 // @param Authorization header string  true "JWE Token"
+// @param Accept-Language header string false "內容語系,可用 zh-TW, en"
 // @param page query int true "目前頁數,請從1開始帶入"
 // @param limit query int true "一次回傳比數,請從1開始帶入,最高上限20"
 // @param sourceID path string true "來源ID"
@@ -51,6 +52,7 @@ func (c *control) GetByList(ctx *gin.Context) {
 	sourceID := ctx.Param("sourceID")
 	input := &historicalRecordModel.Fields{}
 	input.SourceID = util.PointerString(sourceID)
+	input.Language = ctx.GetHeader("Accept-Language")
 	limit := ctx.Query("limit")
 	page := ctx.Query("page")
 	input.Limit, _ = strconv.ParseInt(limit, 10, 64)
@@ -58,6 +60,7 @@ func (c *control) GetByList(ctx *gin.Context) {
 
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -79,6 +82,7 @@ func (c *control) GetByList(ctx *gin.Context) {
 // @Accept json
 // @produce json
 // @param Authorization header string  true "JWE Token"
+// @param Accept-Language header string false "內容語系,可用 zh-TW, en"
 // @param historicalRecordID path string true "歷程記錄ID"
 // @success 200 object code.SuccessfulMessage{body=historical_records.Single} "成功後返回的值"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
@@ -88,8 +92,10 @@ func (c *control) GetBySingle(ctx *gin.Context) {
 	historicalRecordID := ctx.Param("historicalRecordID")
 	input := &historicalRecordModel.Field{}
 	input.HistoricalRecordID = historicalRecordID
+	input.Language = ctx.GetHeader("Accept-Language")
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -0,0 +1,117 @@
+package import_mapping
+
+import (
+	"net/http"
+
+	"crm/internal/interactor/manager/import_mapping"
+	importMappingModel "crm/internal/interactor/models/import_mappings"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Control interface {
+	Create(ctx *gin.Context)
+	GetByList(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type control struct {
+	Manager import_mapping.Manager
+}
+
+func Init(db *gorm.DB) Control {
+	return &control{
+		Manager: import_mapping.Init(db),
+	}
+}
+
+// Create
+// @Summary 新增匯入對應
+// @description 儲存匯入檔案欄位與資料欄位的對應,供匯入時以 mapping_id 帶入
+// @Tags import-mapping
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param * body import_mappings.Create true "新增匯入對應"
+// @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 409 object code.ErrorMessage{detailed=string} "名稱重複"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /import-mappings [post]
+func (c *control) Create(ctx *gin.Context) {
+	input := &importMappingModel.Create{}
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	input.CreatedBy = ctx.MustGet("user_id").(string)
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Create(input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// GetByList
+// @Summary 取得全部匯入對應
+// @description 取得公司的全部匯入對應
+// @Tags import-mapping
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param entity query string false "匯入資料類型" Enums(leads, contacts, accounts)
+// @success 200 object code.SuccessfulMessage{body=import_mappings.List} "成功後返回的值"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /import-mappings [get]
+func (c *control) GetByList(ctx *gin.Context) {
+	input := &importMappingModel.Field{}
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.GetByList(input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// Delete
+// @Summary 刪除單一匯入對應
+// @description 刪除單一匯入對應
+// @Tags import-mapping
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param importMappingID path string true "匯入對應ID"
+// @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 404 object code.ErrorMessage{detailed=string} "匯入對應不存在"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /import-mappings/{importMappingID} [delete]
+func (c *control) Delete(ctx *gin.Context) {
+	input := &importMappingModel.Field{}
+	input.ImportMappingID = ctx.Param("importMappingID")
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Delete(input)
+	ctx.JSON(httpCode, codeMessage)
+}
@@ -0,0 +1,119 @@
+package importer
+
+import (
+	"io"
+	"net/http"
+
+	"crm/config"
+	"crm/internal/interactor/manager/importer"
+	importModel "crm/internal/interactor/models/imports"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+	casbin "crm/internal/router/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Control interface {
+	Create(ctx *gin.Context)
+}
+
+type control struct {
+	Manager importer.Manager
+}
+
+func Init(db *gorm.DB) Control {
+	return &control{
+		Manager: importer.Init(db),
+	}
+}
+
+// Create
+// @Summary 匯入CSV或XLSX
+// @description 依欄位對應匯入線索、聯絡人或帳戶,行業、父系帳戶、帳戶依名稱查詢,業務員依帳號查詢,自訂欄位對應至 custom_fields.<key>。
+// @description 帶入主鍵欄位,或帳戶名稱、聯絡人電子郵件符合一筆既有資料時更新該筆資料,其餘新增。
+// @description 試算時回傳驗證結果及預覽;否則以背景工作匯入並回傳工作ID,完成後由 /jobs/{jobID}/file 下載錯誤檔
+// @Tags import
+// @version 1.0
+// @Accept multipart/form-data
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param file formData file true "CSV 或 XLSX 檔案,第1列為標題"
+// @param entity formData string true "匯入資料類型" Enums(leads, contacts, accounts)
+// @param mapping formData string false "欄位對應 JSON,如 {\"公司名稱\":\"name\",\"行業\":\"industry\"},與 mapping_id 擇一帶入"
+// @param mapping_id formData string false "已儲存的匯入對應ID"
+// @param save_mapping formData string false "以此名稱儲存帶入的欄位對應"
+// @param dry_run formData bool false "試算,只驗證並預覽結果,不寫入資料"
+// @success 200 object code.SuccessfulMessage{body=imports.Report} "試算結果"
+// @success 202 object code.SuccessfulMessage{body=string} "匯入工作ID"
+// @failure 400 object code.ErrorMessage{detailed=string} "檔案或欄位對應錯誤"
+// @failure 403 object code.ErrorMessage{detailed=string} "無新增該資料類型的權限"
+// @failure 404 object code.ErrorMessage{detailed=string} "匯入對應不存在"
+// @failure 409 object code.ErrorMessage{detailed=string} "匯入對應名稱重複"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /imports [post]
+func (c *control) Create(ctx *gin.Context) {
+	input := &importModel.Create{}
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	input.CreatedBy = ctx.MustGet("user_id").(string)
+	if err := ctx.ShouldBind(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	// 匯入須有新增該資料類型的權限
+	allowed, err := casbin.Enforcer.Enforce(ctx.GetString("role_name"), "/crm/v1.0/"+input.Entity, http.MethodPost)
+	if err != nil {
+		log.Error(err)
+		ctx.JSON(http.StatusInternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error()))
+
+		return
+	}
+
+	if !allowed {
+		ctx.JSON(http.StatusForbidden, code.GetCodeMessage(code.PermissionDenied, "import of "+input.Entity+" is not allowed"))
+
+		return
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	if header.Size > config.ImportMaxFileSize<<20 {
+		ctx.JSON(http.StatusBadRequest, code.GetCodeMessage(code.BadRequest, "the file is too large"))
+
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		log.Error(err)
+		ctx.JSON(http.StatusInternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error()))
+
+		return
+	}
+	defer file.Close()
+
+	input.FileName = header.Filename
+	input.File, err = io.ReadAll(file)
+	if err != nil {
+		log.Error(err)
+		ctx.JSON(http.StatusInternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Create(input)
+	ctx.JSON(httpCode, codeMessage)
+}
@@ -4,7 +4,10 @@ import (
 	"net/http"
 
 	"crm/internal/interactor/manager/industry"
+	"crm/internal/interactor/manager/recycle_bin"
+	policyModel "crm/internal/interactor/models/delete_policies"
 	industryModel "crm/internal/interactor/models/industries"
+	"crm/internal/interactor/pkg/util"
 	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
 
@@ -18,15 +21,18 @@ type Control interface {
 	GetBySingle(ctx *gin.Context)
 	Delete(ctx *gin.Context)
 	Update(ctx *gin.Context)
+	DeletePreview(ctx *gin.Context)
 }
 
 type control struct {
-	Manager industry.Manager
+	Manager    industry.Manager
+	RecycleBin recycle_bin.Manager
 }
 
 func Init(db *gorm.DB) Control {
 	return &control{
-		Manager: industry.Init(db),
+		Manager:    industry.Init(db),
+		RecycleBin: recycle_bin.Init(db),
 	}
 }
 
@@ -48,6 +54,7 @@ func (c *control) Create(ctx *gin.Context) {
 	input := &industryModel.Create{}
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -73,6 +80,7 @@ func (c *control) GetByList(ctx *gin.Context) {
 	input := &industryModel.Field{}
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -101,6 +109,7 @@ func (c *control) GetBySingle(ctx *gin.Context) {
 	input.IndustryID = industryID
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -120,21 +129,25 @@ func (c *control) GetBySingle(ctx *gin.Context) {
 // @param Authorization header string  true "JWE Token"
 // @param industryID path string true "行業ID"
 // @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 409 object code.ErrorMessage{detailed=delete_policies.Preview} "有限制刪除的關聯資料"
 // @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
 // @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
 // @Router /industries/{industryID} [delete]
 func (c *control) Delete(ctx *gin.Context) {
+	trx := ctx.MustGet("db_trx").(*gorm.DB)
 	industryID := ctx.Param("industryID")
-	input := &industryModel.Field{}
+	input := &industryModel.Update{}
 	input.IndustryID = industryID
+	input.UpdatedBy = util.PointerString(ctx.MustGet("user_id").(string))
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
 	}
 
-	httpCode, codeMessage := c.Manager.Delete(input)
+	httpCode, codeMessage := c.Manager.Delete(trx, input)
 	ctx.JSON(httpCode, codeMessage)
 }
 
@@ -156,8 +169,10 @@ func (c *control) Update(ctx *gin.Context) {
 	industryID := ctx.Param("industryID")
 	input := &industryModel.Update{}
 	input.IndustryID = industryID
+	input.UpdatedBy = util.PointerString(ctx.MustGet("user_id").(string))
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -166,3 +181,33 @@ func (c *control) Update(ctx *gin.Context) {
 	httpCode, codeMessage := c.Manager.Update(input)
 	ctx.JSON(httpCode, codeMessage)
 }
+
+// DeletePreview
+// @Summary 預覽刪除單一行業
+// @description 依刪除規則列出刪除單一行業時受影響的關聯資料,restrict 限制刪除、cascade 一併刪除、nullify 清除關聯
+// @Tags industry
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param industryID path string true "行業ID"
+// @success 200 object code.SuccessfulMessage{body=delete_policies.Preview} "成功後返回的值"
+// @failure 404 object code.ErrorMessage{detailed=string} "查無資料"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /industries/{industryID}/delete-preview [get]
+func (c *control) DeletePreview(ctx *gin.Context) {
+	input := &policyModel.Field{}
+	input.Entity = "industries"
+	input.ID = ctx.Param("industryID")
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.RecycleBin.Preview(input)
+	ctx.JSON(httpCode, codeMessage)
+}
@@ -0,0 +1,116 @@
+package job
+
+import (
+	"mime"
+	"net/http"
+
+	"crm/internal/interactor/manager/job"
+	jobModel "crm/internal/interactor/models/jobs"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"gorm.io/gorm"
+)
+
+type Control interface {
+	GetBySingle(ctx *gin.Context)
+	GetByFile(ctx *gin.Context)
+}
+
+type control struct {
+	Manager job.Manager
+}
+
+func Init(db *gorm.DB) Control {
+	return &control{
+		Manager: job.Init(db),
+	}
+}
+
+// GetBySingle
+// @Summary 取得單一背景工作
+// @description 取得匯入或匯出工作的狀態及筆數,僅能取得自己建立的工作
+// @Tags job
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param jobID path string true "工作ID"
+// @success 200 object code.SuccessfulMessage{body=jobs.Single} "成功後返回的值"
+// @failure 404 object code.ErrorMessage{detailed=string} "工作不存在"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /jobs/{jobID} [get]
+func (c *control) GetBySingle(ctx *gin.Context) {
+	input := &jobModel.Field{}
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	// 查詢參數不可取代工作ID及創建者
+	input.JobID = ctx.Param("jobID")
+	input.CreatedBy = ctx.MustGet("user_id").(string)
+	if err := binding.Validator.ValidateStruct(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.GetBySingle(input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// GetByFile
+// @Summary 下載背景工作檔案
+// @description 下載匯入工作的錯誤檔或匯出工作的匯出檔
+// @Tags job
+// @version 1.0
+// @Accept json
+// @produce octet-stream
+// @param Authorization header string  true "JWE Token"
+// @param jobID path string true "工作ID"
+// @success 200 {file} file "檔案"
+// @failure 404 object code.ErrorMessage{detailed=string} "工作不存在或沒有檔案"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /jobs/{jobID}/file [get]
+func (c *control) GetByFile(ctx *gin.Context) {
+	input := &jobModel.Field{}
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	// 查詢參數不可取代工作ID及創建者
+	input.JobID = ctx.Param("jobID")
+	input.CreatedBy = ctx.MustGet("user_id").(string)
+	if err := binding.Validator.ValidateStruct(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.GetByFile(input)
+	if httpCode != http.StatusOK {
+		ctx.JSON(httpCode, codeMessage)
+
+		return
+	}
+
+	file := codeMessage.(*code.SuccessfulMessage).Body.(*jobModel.File)
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}))
+	ctx.Data(http.StatusOK, file.ContentType, file.Content)
+}
@@ -45,6 +45,7 @@ func (c *control) Login(ctx *gin.Context) {
 
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 		return
 	}
@@ -69,6 +70,7 @@ func (c *control) Refresh(ctx *gin.Context) {
 	input := &jwxModel.Refresh{}
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 		return
 	}
@@ -52,6 +52,7 @@ func (c *control) Create(ctx *gin.Context) {
 	input := &orderProductModel.CreateList{}
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -84,6 +85,7 @@ func (c *control) GetByList(ctx *gin.Context) {
 
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -116,6 +118,7 @@ func (c *control) GetBySingle(ctx *gin.Context) {
 	input.OrderProductID = orderProductID
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -142,6 +145,7 @@ func (c *control) Delete(ctx *gin.Context) {
 	inputIDList := &orderProductModel.DeleteList{}
 	if err := ctx.ShouldBindJSON(inputIDList); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -178,6 +182,7 @@ func (c *control) Update(ctx *gin.Context) {
 	input := &orderProductModel.UpdateList{}
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -0,0 +1,194 @@
+package picklist
+
+import (
+	"net/http"
+
+	"crm/internal/interactor/manager/picklist"
+	picklistModel "crm/internal/interactor/models/picklists"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Control interface {
+	Create(ctx *gin.Context)
+	GetByList(ctx *gin.Context)
+	GetBySingle(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type control struct {
+	Manager picklist.Manager
+}
+
+func Init(db *gorm.DB) Control {
+	return &control{
+		Manager: picklist.Init(db),
+	}
+}
+
+// Create
+// @Summary 新增選項清單
+// @description 新增公司的欄位選項清單,新增及更新資料時依選項清單驗證;線索狀態未帶入的系統選項(如已轉換)會自動加入
+// @Tags picklist
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param * body picklists.Create true "新增選項清單"
+// @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 400 object code.ErrorMessage{detailed=string} "欄位不可設定選項清單或選項錯誤"
+// @failure 409 object code.ErrorMessage{detailed=string} "選項清單重複"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /picklists [post]
+func (c *control) Create(ctx *gin.Context) {
+	trx := ctx.MustGet("db_trx").(*gorm.DB)
+	input := &picklistModel.Create{}
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	input.CreatedBy = ctx.MustGet("user_id").(string)
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Create(trx, input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// GetByList
+// @Summary 取得全部選項清單
+// @description 取得公司的選項清單供畫面顯示,公司未定義線索狀態時回傳系統選項
+// @Tags picklist
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param Accept-Language header string false "系統選項的語系,可用 zh-TW, en"
+// @param entity query string false "資料類型" Enums(opportunities, leads, campaigns, events, accounts)
+// @param field query string false "欄位"
+// @success 200 object code.SuccessfulMessage{body=picklists.List} "成功後返回的值"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /picklists [get]
+func (c *control) GetByList(ctx *gin.Context) {
+	input := &picklistModel.Field{}
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	input.Language = ctx.GetHeader("Accept-Language")
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	ctx.Header("Vary", "Accept-Language")
+	httpCode, codeMessage := c.Manager.GetByList(input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// GetBySingle
+// @Summary 取得單一選項清單
+// @description 取得單一選項清單
+// @Tags picklist
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param Accept-Language header string false "系統選項的語系,可用 zh-TW, en"
+// @param picklistID path string true "選項清單ID"
+// @success 200 object code.SuccessfulMessage{body=picklists.Single} "成功後返回的值"
+// @failure 404 object code.ErrorMessage{detailed=string} "選項清單不存在"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /picklists/{picklistID} [get]
+func (c *control) GetBySingle(ctx *gin.Context) {
+	input := &picklistModel.Field{}
+	input.PicklistID = ctx.Param("picklistID")
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	input.Language = ctx.GetHeader("Accept-Language")
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	ctx.Header("Vary", "Accept-Language")
+	httpCode, codeMessage := c.Manager.GetBySingle(input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// Update
+// @Summary 更新單一選項清單
+// @description 更新選項清單的控制欄位或取代全部選項,停用的選項不可再選用,已使用的資料保留原值
+// @Tags picklist
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param picklistID path string true "選項清單ID"
+// @param * body picklists.Update true "更新選項清單"
+// @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 400 object code.ErrorMessage{detailed=string} "選項錯誤"
+// @failure 404 object code.ErrorMessage{detailed=string} "選項清單不存在"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /picklists/{picklistID} [patch]
+func (c *control) Update(ctx *gin.Context) {
+	trx := ctx.MustGet("db_trx").(*gorm.DB)
+	input := &picklistModel.Update{}
+	input.PicklistID = ctx.Param("picklistID")
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	input.UpdatedBy = util.PointerString(ctx.MustGet("user_id").(string))
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Update(trx, input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// Delete
+// @Summary 刪除單一選項清單
+// @description 刪除選項清單後欄位不再驗證,線索狀態恢復使用系統選項
+// @Tags picklist
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param picklistID path string true "選項清單ID"
+// @success 200 object code.SuccessfulMessage{body=string} "成功後返回的值"
+// @failure 404 object code.ErrorMessage{detailed=string} "選項清單不存在"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /picklists/{picklistID} [delete]
+func (c *control) Delete(ctx *gin.Context) {
+	trx := ctx.MustGet("db_trx").(*gorm.DB)
+	input := &picklistModel.Field{}
+	input.PicklistID = ctx.Param("picklistID")
+	input.CompanyID = ctx.MustGet("company_id").(string)
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Delete(trx, input)
+	ctx.JSON(httpCode, codeMessage)
+}
@@ -34,6 +34,7 @@ func AddPolicy(ctx *gin.Context) {
 	input := &casbin.CasbinModel{}
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 		return
 	}
@@ -104,6 +105,7 @@ func DeletePolicy(ctx *gin.Context) {
 	input := &casbin.CasbinModel{}
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 		return
 	}
@@ -52,6 +52,7 @@ func (c *control) Create(ctx *gin.Context) {
 	input := &quoteProductModel.CreateList{}
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -84,6 +85,7 @@ func (c *control) GetByList(ctx *gin.Context) {
 
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -116,6 +118,7 @@ func (c *control) GetBySingle(ctx *gin.Context) {
 	input.QuoteProductID = quoteProductID
 	if err := ctx.ShouldBindQuery(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -142,6 +145,7 @@ func (c *control) Delete(ctx *gin.Context) {
 	inputIDList := &quoteProductModel.DeleteList{}
 	if err := ctx.ShouldBindJSON(inputIDList); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
@@ -177,6 +181,7 @@ func (c *control) Update(ctx *gin.Context) {
 	input := &quoteProductModel.UpdateList{}
 	if err := ctx.ShouldBindJSON(input); err != nil {
 		log.Error(err)
+		_ = ctx.Error(err)
 		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
 
 		return
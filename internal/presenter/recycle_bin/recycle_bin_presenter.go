@@ -0,0 +1,131 @@
+package recycle_bin
+
+import (
+	"net/http"
+
+	constant "crm/internal/interactor/constants"
+	"crm/internal/interactor/manager/recycle_bin"
+	recycleBinModel "crm/internal/interactor/models/recycle_bins"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Control interface {
+	GetByList(ctx *gin.Context)
+	Restore(ctx *gin.Context)
+	Purge(ctx *gin.Context)
+}
+
+type control struct {
+	Manager recycle_bin.Manager
+}
+
+func Init(db *gorm.DB) Control {
+	return &control{
+		Manager: recycle_bin.Init(db),
+	}
+}
+
+// GetByList
+// @Summary 取得資源回收筒
+// @description 取得刪除時間區間內已刪除的資料,依刪除時間由新到舊排序
+// @Tags recycle-bin
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param entity path string true "資料類型" Enums(accounts, contacts, leads, contracts, opportunities, orders, quotes, products, campaigns, events)
+// @param del_start_at query string false "刪除的開始時間"
+// @param del_end_at query string false "刪除的結束時間"
+// @param page query int false "目前頁數,請從1開始帶入"
+// @param limit query int true "一次回傳比數,請從1開始帶入,最高上限20"
+// @success 200 object code.SuccessfulMessage{body=recycle_bins.List} "成功後返回的值"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /recycle-bin/{entity} [get]
+func (c *control) GetByList(ctx *gin.Context) {
+	input := &recycleBinModel.Fields{}
+	input.Entity = ctx.Param("entity")
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	if input.Limit >= constant.DefaultLimit {
+		input.Limit = constant.DefaultLimit
+	}
+
+	httpCode, codeMessage := c.Manager.GetByList(input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// Restore
+// @Summary 還原已刪除的資料
+// @description 還原資源回收筒中的資料,與資料同時刪除的附屬資料一併還原。關聯的資料仍在資源回收筒時回傳409,須先還原關聯的資料
+// @Tags recycle-bin
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param entity path string true "資料類型" Enums(accounts, contacts, leads, contracts, opportunities, orders, quotes, products, campaigns, events)
+// @param * body recycle_bins.Records true "還原的資料"
+// @success 200 object code.SuccessfulMessage{body=recycle_bins.Result} "成功後返回的值"
+// @failure 404 object code.ErrorMessage{detailed=string} "資料不在資源回收筒"
+// @failure 409 object code.ErrorMessage{detailed=string} "關聯的資料仍在資源回收筒"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /recycle-bin/{entity}/restore [post]
+func (c *control) Restore(ctx *gin.Context) {
+	trx := ctx.MustGet("db_trx").(*gorm.DB)
+	input := &recycleBinModel.Records{}
+	input.Entity = ctx.Param("entity")
+	input.ModifiedBy = ctx.MustGet("user_id").(string)
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Restore(trx, input)
+	ctx.JSON(httpCode, codeMessage)
+}
+
+// Purge
+// @Summary 永久刪除資料
+// @description 永久刪除資源回收筒中的資料及其附屬資料,刪除後無法還原
+// @Tags recycle-bin
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param entity path string true "資料類型" Enums(accounts, contacts, leads, contracts, opportunities, orders, quotes, products, campaigns, events)
+// @param * body recycle_bins.Records true "永久刪除的資料"
+// @success 200 object code.SuccessfulMessage{body=recycle_bins.Result} "成功後返回的值"
+// @failure 404 object code.ErrorMessage{detailed=string} "資料不在資源回收筒"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /recycle-bin/{entity}/purge [post]
+func (c *control) Purge(ctx *gin.Context) {
+	trx := ctx.MustGet("db_trx").(*gorm.DB)
+	input := &recycleBinModel.Records{}
+	input.Entity = ctx.Param("entity")
+	input.ModifiedBy = ctx.MustGet("user_id").(string)
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	httpCode, codeMessage := c.Manager.Purge(trx, input)
+	ctx.JSON(httpCode, codeMessage)
+}
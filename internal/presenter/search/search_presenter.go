@@ -0,0 +1,78 @@
+package search
+
+import (
+	"net/http"
+
+	constant "crm/internal/interactor/constants"
+	"crm/internal/interactor/manager/search"
+	searchModel "crm/internal/interactor/models/searches"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+	casbin "crm/internal/router/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Control interface {
+	GetByList(ctx *gin.Context)
+}
+
+type control struct {
+	Manager search.Manager
+}
+
+func Init(db *gorm.DB) Control {
+	return &control{
+		Manager: search.Init(db),
+	}
+}
+
+// GetByList
+// @Summary 全域搜尋
+// @description 同時搜尋帳戶、聯絡人、線索、商機、報價、契約、訂單與產品,依相關度排序,僅回傳角色可讀取的類型
+// @Tags search
+// @version 1.0
+// @Accept json
+// @produce json
+// @param Authorization header string  true "JWE Token"
+// @param q query string true "搜尋字串(至少2個字)"
+// @param types query string false "搜尋類型,以逗號分隔,可用 account, contact, lead, opportunity, quote, contract, order, product"
+// @param limit query int false "筆數(最高上限20)"
+// @success 200 object code.SuccessfulMessage{body=searches.List} "成功後返回的值"
+// @failure 400 object code.ErrorMessage{detailed=string} "搜尋類型錯誤"
+// @failure 415 object code.ErrorMessage{detailed=string} "必要欄位帶入錯誤"
+// @failure 500 object code.ErrorMessage{detailed=string} "伺服器非預期錯誤"
+// @Router /search [get]
+func (c *control) GetByList(ctx *gin.Context) {
+	input := &searchModel.Field{}
+	if err := ctx.ShouldBindQuery(input); err != nil {
+		log.Error(err)
+		_ = ctx.Error(err)
+		ctx.JSON(http.StatusUnsupportedMediaType, code.GetCodeMessage(code.FormatError, err.Error()))
+
+		return
+	}
+
+	if input.Limit <= 0 || input.Limit >= constant.DefaultLimit {
+		input.Limit = constant.DefaultLimit
+	}
+
+	// a type is visible when the role may list its records
+	for name, path := range searchModel.Types {
+		visible, err := casbin.Enforcer.Enforce(ctx.GetString("role_name"), path, http.MethodPost)
+		if err != nil {
+			log.Error(err)
+			ctx.JSON(http.StatusInternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error()))
+
+			return
+		}
+
+		if visible {
+			input.Visible = append(input.Visible, name)
+		}
+	}
+
+	httpCode, codeMessage := c.Manager.GetByList(input)
+	ctx.JSON(httpCode, codeMessage)
+}
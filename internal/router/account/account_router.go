@@ -1,6 +1,7 @@
 package account
 
 import (
+	"crm/config"
 	present "crm/internal/presenter/account"
 	"crm/internal/router/middleware"
 	"crm/internal/router/middleware/auth"
@@ -13,13 +14,17 @@ func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
 	control := present.Init(db)
 	v10 := router.Group("crm").Group("v1.0").Group("accounts")
 	{
-		v10.POST("", middleware.Verify(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Create)
-		v10.POST("list", middleware.Verify(), auth.AuthCheckRole(db), control.GetByList)
-		v10.POST("list/no-pagination", middleware.Verify(), auth.AuthCheckRole(db), control.GetByListNoPagination)
-		v10.GET(":accountID", middleware.Verify(), auth.AuthCheckRole(db), control.GetBySingle)
-		v10.GET("contacts/:accountID", middleware.Verify(), auth.AuthCheckRole(db), control.GetBySingleContacts)
-		v10.DELETE(":accountID", middleware.Verify(), auth.AuthCheckRole(db), control.Delete)
-		v10.PATCH(":accountID", middleware.Verify(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Update)
+		v10.POST("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Create)
+		v10.POST("list", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.GetByList)
+		v10.POST("list/no-pagination", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.GetByListNoPagination)
+		v10.GET(":accountID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetBySingle)
+		v10.GET("contacts/:accountID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetBySingleContacts)
+		v10.DELETE(":accountID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Delete)
+		v10.GET(":accountID/delete-preview", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.DeletePreview)
+		v10.PATCH(":accountID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.RequireIfMatch(db), middleware.Transaction(db), control.Update)
+		v10.POST("bulk", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Idempotency(db), middleware.Transaction(db), control.Bulk)
+		v10.GET(":accountID/duplicates", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.Duplicates)
+		v10.POST("merge", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Idempotency(db), middleware.Transaction(db), control.Merge)
 	}
 
 	return router
@@ -0,0 +1,22 @@
+package api_key
+
+import (
+	present "crm/internal/presenter/api_key"
+	"crm/internal/router/middleware"
+	"crm/internal/router/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
+	control := present.Init(db)
+	v10 := router.Group("crm").Group("v1.0").Group("api-keys")
+	{
+		v10.POST("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.Create)
+		v10.GET("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.GetByList)
+		v10.DELETE(":apiKeyID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.Delete)
+	}
+
+	return router
+}
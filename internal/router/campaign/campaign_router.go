@@ -1,6 +1,7 @@
 package campaign
 
 import (
+	"crm/config"
 	present "crm/internal/presenter/campaign"
 	"crm/internal/router/middleware"
 	"crm/internal/router/middleware/auth"
@@ -13,13 +14,14 @@ func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
 	control := present.Init(db)
 	v10 := router.Group("crm").Group("v1.0").Group("campaigns")
 	{
-		v10.POST("", middleware.Verify(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Create)
-		v10.POST("list", middleware.Verify(), auth.AuthCheckRole(db), control.GetByList)
-		v10.GET("", middleware.Verify(), auth.AuthCheckRole(db), control.GetByListNoPagination)
-		v10.GET(":campaignID", middleware.Verify(), auth.AuthCheckRole(db), control.GetBySingle)
-		v10.GET("opportunities/:campaignID", middleware.Verify(), auth.AuthCheckRole(db), control.GetBySingleOpportunities)
-		v10.DELETE(":campaignID", middleware.Verify(), auth.AuthCheckRole(db), control.Delete)
-		v10.PATCH(":campaignID", middleware.Verify(), auth.AuthCheckRole(db), control.Update)
+		v10.POST("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Create)
+		v10.POST("list", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.GetByList)
+		v10.GET("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetByListNoPagination)
+		v10.GET(":campaignID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetBySingle)
+		v10.GET("opportunities/:campaignID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetBySingleOpportunities)
+		v10.DELETE(":campaignID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Delete)
+		v10.GET(":campaignID/delete-preview", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.DeletePreview)
+		v10.PATCH(":campaignID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.Update)
 	}
 
 	return router
@@ -0,0 +1,22 @@
+package company_setting
+
+import (
+	"crm/config"
+	present "crm/internal/presenter/company_setting"
+	"crm/internal/router/middleware"
+	"crm/internal/router/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
+	control := present.Init(db)
+	v10 := router.Group("crm").Group("v1.0").Group("company-settings")
+	{
+		v10.GET("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetBySingle)
+		v10.PATCH("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Update)
+	}
+
+	return router
+}
@@ -1,6 +1,7 @@
 package contract
 
 import (
+	"crm/config"
 	present "crm/internal/presenter/contract"
 	"crm/internal/router/middleware"
 	"crm/internal/router/middleware/auth"
@@ -13,12 +14,13 @@ func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
 	control := present.Init(db)
 	v10 := router.Group("crm").Group("v1.0").Group("contracts")
 	{
-		v10.POST("", middleware.Verify(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Create)
-		v10.POST("list", middleware.Verify(), auth.AuthCheckRole(db), control.GetByList)
-		v10.POST("list/no-pagination", middleware.Verify(), auth.AuthCheckRole(db), control.GetByListNoPagination)
-		v10.GET(":contractID", middleware.Verify(), auth.AuthCheckRole(db), control.GetBySingle)
-		v10.DELETE(":contractID", middleware.Verify(), auth.AuthCheckRole(db), control.Delete)
-		v10.PATCH(":contractID", middleware.Verify(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Update)
+		v10.POST("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Create)
+		v10.POST("list", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.GetByList)
+		v10.POST("list/no-pagination", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.GetByListNoPagination)
+		v10.GET(":contractID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetBySingle)
+		v10.DELETE(":contractID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Delete)
+		v10.GET(":contractID/delete-preview", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.DeletePreview)
+		v10.PATCH(":contractID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.RequireIfMatch(db), middleware.Transaction(db), control.Update)
 	}
 
 	return router
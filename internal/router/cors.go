@@ -0,0 +1,58 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"crm/config"
+	"crm/internal/interactor/pkg/util/log"
+
+	"github.com/gin-contrib/cors"
+)
+
+// corsConfig builds the CORS policy of the environment from the config package.
+// It returns an error describing the setting to fix when the policy is invalid, cors.New panics on it otherwise.
+func corsConfig() (cors.Config, error) {
+	corsConfig := cors.Config{
+		AllowOrigins:     split(config.CORSAllowOrigins),
+		AllowMethods:     split(config.CORSAllowMethods),
+		AllowHeaders:     split(config.CORSAllowHeaders),
+		ExposeHeaders:    split(config.CORSExposeHeaders),
+		AllowCredentials: config.CORSAllowCredentials,
+		MaxAge:           time.Duration(config.CORSMaxAge) * time.Second,
+		// 允許 https://*.example.com 形式的來源
+		AllowWildcard: true,
+	}
+
+	// 瀏覽器不接受同時允許所有來源及憑證
+	for _, origin := range corsConfig.AllowOrigins {
+		if origin == "*" && corsConfig.AllowCredentials {
+			log.Error("CORS credentials are ignored because all origins are allowed.")
+			corsConfig.AllowCredentials = false
+		}
+	}
+
+	if len(corsConfig.AllowOrigins) == 0 {
+		return corsConfig, errors.New("config.CORSAllowOrigins must list at least one origin, or \"*\" to allow all origins")
+	}
+
+	if err := corsConfig.Validate(); err != nil {
+		return corsConfig, fmt.Errorf("invalid CORS config: %w", err)
+	}
+
+	return corsConfig, nil
+}
+
+// split turns a comma separated config value into a list.
+func split(value string) []string {
+	var output []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			output = append(output, item)
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,25 @@
+package custom_field
+
+import (
+	"crm/config"
+	present "crm/internal/presenter/custom_field"
+	"crm/internal/router/middleware"
+	"crm/internal/router/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
+	control := present.Init(db)
+	v10 := router.Group("crm").Group("v1.0").Group("custom-fields")
+	{
+		v10.POST("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Create)
+		v10.GET("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetByList)
+		v10.GET(":customFieldID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetBySingle)
+		v10.PATCH(":customFieldID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Update)
+		v10.DELETE(":customFieldID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Delete)
+	}
+
+	return router
+}
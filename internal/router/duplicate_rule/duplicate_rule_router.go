@@ -0,0 +1,24 @@
+package duplicate_rule
+
+import (
+	"crm/config"
+	present "crm/internal/presenter/duplicate_rule"
+	"crm/internal/router/middleware"
+	"crm/internal/router/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
+	control := present.Init(db)
+	v10 := router.Group("crm").Group("v1.0").Group("duplicate-rules")
+	{
+		v10.POST("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.Create)
+		v10.GET("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetByList)
+		v10.PATCH(":duplicateRuleID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.Update)
+		v10.DELETE(":duplicateRuleID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.Delete)
+	}
+
+	return router
+}
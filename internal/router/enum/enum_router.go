@@ -0,0 +1,21 @@
+package enum
+
+import (
+	"crm/config"
+	present "crm/internal/presenter/enum"
+	"crm/internal/router/middleware"
+	"crm/internal/router/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
+	control := present.Init(db)
+	v10 := router.Group("crm").Group("v1.0").Group("enums")
+	{
+		v10.GET("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlReference), control.GetByList)
+	}
+
+	return router
+}
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"crm/config"
 	present "crm/internal/presenter/event"
 	"crm/internal/router/middleware"
 	"crm/internal/router/middleware/auth"
@@ -13,11 +14,13 @@ func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
 	control := present.Init(db)
 	v10 := router.Group("crm").Group("v1.0").Group("events")
 	{
-		v10.POST("", middleware.Verify(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Create)
-		v10.POST("list", middleware.Verify(), auth.AuthCheckRole(db), control.GetByList)
-		v10.GET(":eventID", middleware.Verify(), auth.AuthCheckRole(db), control.GetBySingle)
-		v10.DELETE(":eventID", middleware.Verify(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Delete)
-		v10.PATCH(":eventID", middleware.Verify(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Update)
+		v10.POST("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Create)
+		v10.POST("list", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.GetByList)
+		v10.GET(":eventID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetBySingle)
+		v10.DELETE(":eventID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Delete)
+		v10.GET(":eventID/delete-preview", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.DeletePreview)
+		v10.PATCH(":eventID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.RequireIfMatch(db), middleware.Transaction(db), control.Update)
+		v10.POST("bulk", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Idempotency(db), middleware.Transaction(db), control.Bulk)
 	}
 
 	return router
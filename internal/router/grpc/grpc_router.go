@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"net/http"
+
+	crmv1 "crm/api/proto/crm/v1"
+	"crm/config"
+	present "crm/internal/presenter/grpc"
+	"crm/internal/router/middleware"
+
+	grpcLib "google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"gorm.io/gorm"
+)
+
+// permission is the REST route guarding an RPC, a role allowed on the route is allowed on the RPC.
+type permission struct {
+	// 路徑
+	Path string
+	// 方法
+	Method string
+	// 請求頻率限制範圍
+	Scope string
+}
+
+// permissions guard every RPC, an RPC without a permission is rejected.
+// Streams are limited like exports since they read every record.
+var permissions = map[string]permission{
+	crmv1.AccountService_GetAccount_FullMethodName:       {Path: "/crm/v1.0/accounts/:accountID", Method: http.MethodGet, Scope: middleware.ScopeRead},
+	crmv1.AccountService_ListAccounts_FullMethodName:     {Path: "/crm/v1.0/accounts/list", Method: http.MethodPost, Scope: middleware.ScopeRead},
+	crmv1.AccountService_StreamAccounts_FullMethodName:   {Path: "/crm/v1.0/accounts/list", Method: http.MethodPost, Scope: middleware.ScopeExport},
+	crmv1.AccountService_CreateAccount_FullMethodName:    {Path: "/crm/v1.0/accounts", Method: http.MethodPost, Scope: middleware.ScopeWrite},
+	crmv1.AccountService_UpdateAccount_FullMethodName:    {Path: "/crm/v1.0/accounts/:accountID", Method: http.MethodPatch, Scope: middleware.ScopeWrite},
+	crmv1.AccountService_DeleteAccount_FullMethodName:    {Path: "/crm/v1.0/accounts/:accountID", Method: http.MethodDelete, Scope: middleware.ScopeWrite},
+	crmv1.ContactService_GetContact_FullMethodName:       {Path: "/crm/v1.0/contacts/:contactID", Method: http.MethodGet, Scope: middleware.ScopeRead},
+	crmv1.ContactService_ListContacts_FullMethodName:     {Path: "/crm/v1.0/contacts/list", Method: http.MethodPost, Scope: middleware.ScopeRead},
+	crmv1.ContactService_StreamContacts_FullMethodName:   {Path: "/crm/v1.0/contacts/list", Method: http.MethodPost, Scope: middleware.ScopeExport},
+	crmv1.ContactService_CreateContact_FullMethodName:    {Path: "/crm/v1.0/contacts", Method: http.MethodPost, Scope: middleware.ScopeWrite},
+	crmv1.ContactService_UpdateContact_FullMethodName:    {Path: "/crm/v1.0/contacts/:contactID", Method: http.MethodPatch, Scope: middleware.ScopeWrite},
+	crmv1.ContactService_DeleteContact_FullMethodName:    {Path: "/crm/v1.0/contacts/:contactID", Method: http.MethodDelete, Scope: middleware.ScopeWrite},
+	crmv1.ProductService_GetProduct_FullMethodName:       {Path: "/crm/v1.0/products/:productID", Method: http.MethodGet, Scope: middleware.ScopeRead},
+	crmv1.ProductService_ListProducts_FullMethodName:     {Path: "/crm/v1.0/products/list", Method: http.MethodPost, Scope: middleware.ScopeRead},
+	crmv1.ProductService_StreamProducts_FullMethodName:   {Path: "/crm/v1.0/products/list", Method: http.MethodPost, Scope: middleware.ScopeExport},
+	crmv1.ProductService_CreateProduct_FullMethodName:    {Path: "/crm/v1.0/products", Method: http.MethodPost, Scope: middleware.ScopeWrite},
+	crmv1.ProductService_UpdateProduct_FullMethodName:    {Path: "/crm/v1.0/products/:productID", Method: http.MethodPatch, Scope: middleware.ScopeWrite},
+	crmv1.ProductService_DeleteProduct_FullMethodName:    {Path: "/crm/v1.0/products/:productID", Method: http.MethodDelete, Scope: middleware.ScopeWrite},
+	crmv1.QuoteService_GetQuote_FullMethodName:           {Path: "/crm/v1.0/quotes/:quoteID", Method: http.MethodGet, Scope: middleware.ScopeRead},
+	crmv1.QuoteService_ListQuotes_FullMethodName:         {Path: "/crm/v1.0/quotes/list", Method: http.MethodPost, Scope: middleware.ScopeRead},
+	crmv1.QuoteService_StreamQuotes_FullMethodName:       {Path: "/crm/v1.0/quotes/list", Method: http.MethodPost, Scope: middleware.ScopeExport},
+	crmv1.QuoteService_CreateQuote_FullMethodName:        {Path: "/crm/v1.0/quotes", Method: http.MethodPost, Scope: middleware.ScopeWrite},
+	crmv1.QuoteService_UpdateQuote_FullMethodName:        {Path: "/crm/v1.0/quotes/:quoteID", Method: http.MethodPatch, Scope: middleware.ScopeWrite},
+	crmv1.QuoteService_DeleteQuote_FullMethodName:        {Path: "/crm/v1.0/quotes/:quoteID", Method: http.MethodDelete, Scope: middleware.ScopeWrite},
+	crmv1.ContractService_GetContract_FullMethodName:     {Path: "/crm/v1.0/contracts/:contractID", Method: http.MethodGet, Scope: middleware.ScopeRead},
+	crmv1.ContractService_ListContracts_FullMethodName:   {Path: "/crm/v1.0/contracts/list", Method: http.MethodPost, Scope: middleware.ScopeRead},
+	crmv1.ContractService_StreamContracts_FullMethodName: {Path: "/crm/v1.0/contracts/list", Method: http.MethodPost, Scope: middleware.ScopeExport},
+	crmv1.ContractService_CreateContract_FullMethodName:  {Path: "/crm/v1.0/contracts", Method: http.MethodPost, Scope: middleware.ScopeWrite},
+	crmv1.ContractService_UpdateContract_FullMethodName:  {Path: "/crm/v1.0/contracts/:contractID", Method: http.MethodPatch, Scope: middleware.ScopeWrite},
+	crmv1.ContractService_DeleteContract_FullMethodName:  {Path: "/crm/v1.0/contracts/:contractID", Method: http.MethodDelete, Scope: middleware.ScopeWrite},
+	crmv1.OrderService_GetOrder_FullMethodName:           {Path: "/crm/v1.0/orders/:orderID", Method: http.MethodGet, Scope: middleware.ScopeRead},
+	crmv1.OrderService_ListOrders_FullMethodName:         {Path: "/crm/v1.0/orders/list", Method: http.MethodPost, Scope: middleware.ScopeRead},
+	crmv1.OrderService_StreamOrders_FullMethodName:       {Path: "/crm/v1.0/orders/list", Method: http.MethodPost, Scope: middleware.ScopeExport},
+	crmv1.OrderService_CreateOrder_FullMethodName:        {Path: "/crm/v1.0/orders", Method: http.MethodPost, Scope: middleware.ScopeWrite},
+	crmv1.OrderService_UpdateOrder_FullMethodName:        {Path: "/crm/v1.0/orders/:orderID", Method: http.MethodPatch, Scope: middleware.ScopeWrite},
+	crmv1.OrderService_DeleteOrder_FullMethodName:        {Path: "/crm/v1.0/orders/:orderID", Method: http.MethodDelete, Scope: middleware.ScopeWrite},
+}
+
+// NewServer returns the gRPC server for internal system integrations, it runs beside the gin engine
+// and calls the same managers. Callers authenticate with an API key or the JWE token of a user.
+func NewServer(db *gorm.DB) *grpcLib.Server {
+	server := grpcLib.NewServer(
+		grpcLib.ChainUnaryInterceptor(recoverUnary(), authenticateUnary(db)),
+		grpcLib.ChainStreamInterceptor(recoverStream(), authenticateStream(db)),
+	)
+	crmv1.RegisterAccountServiceServer(server, present.InitAccount(db))
+	crmv1.RegisterContactServiceServer(server, present.InitContact(db))
+	crmv1.RegisterProductServiceServer(server, present.InitProduct(db))
+	crmv1.RegisterQuoteServiceServer(server, present.InitQuote(db))
+	crmv1.RegisterContractServiceServer(server, present.InitContract(db))
+	crmv1.RegisterOrderServiceServer(server, present.InitOrder(db))
+	if config.GRPCReflection {
+		reflection.Register(server)
+	}
+
+	return server
+}
@@ -0,0 +1,202 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"runtime/debug"
+	"strings"
+
+	"crm/config"
+	apiKeyModel "crm/internal/interactor/models/api_keys"
+	"crm/internal/interactor/pkg/jwx"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/hash"
+	"crm/internal/interactor/pkg/util/log"
+	apiKeyService "crm/internal/interactor/service/api_key"
+	"crm/internal/interactor/service/role"
+	"crm/internal/interactor/service/user"
+	present "crm/internal/presenter/grpc"
+	"crm/internal/router/middleware"
+	"crm/internal/router/middleware/auth"
+
+	grpcLib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// reflectionPrefix is the prefix of the reflection RPCs, they only describe the services and need no role.
+const reflectionPrefix = "/grpc.reflection."
+
+// recoverUnary turns a panic of a handler into an internal error.
+func recoverUnary() grpcLib.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpcLib.UnaryServerInfo, handler grpcLib.UnaryHandler) (response any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Error(r, string(debug.Stack()))
+				err = status.Error(codes.Internal, "Unexpected server error.")
+			}
+		}()
+
+		return handler(ctx, request)
+	}
+}
+
+// recoverStream turns a panic of a handler into an internal error.
+func recoverStream() grpcLib.StreamServerInterceptor {
+	return func(server any, stream grpcLib.ServerStream, info *grpcLib.StreamServerInfo, handler grpcLib.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Error(r, string(debug.Stack()))
+				err = status.Error(codes.Internal, "Unexpected server error.")
+			}
+		}()
+
+		return handler(server, stream)
+	}
+}
+
+// authenticateUnary does for an RPC what Verify, RateLimit and AuthCheckRole do for a REST request.
+func authenticateUnary(db *gorm.DB) grpcLib.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpcLib.UnaryServerInfo, handler grpcLib.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, db, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, request)
+	}
+}
+
+// authenticateStream does for a stream what Verify, RateLimit and AuthCheckRole do for a REST request.
+func authenticateStream(db *gorm.DB) grpcLib.StreamServerInterceptor {
+	return func(server any, stream grpcLib.ServerStream, info *grpcLib.StreamServerInfo, handler grpcLib.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), db, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(server, &identified{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// identified is a stream whose context carries the caller.
+type identified struct {
+	grpcLib.ServerStream
+	ctx context.Context
+}
+
+func (s *identified) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate identifies the caller by the x-api-key or authorization metadata,
+// checks the role against the REST route of the method and takes a request from the rate limits.
+func authenticate(ctx context.Context, db *gorm.DB, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	identity := &present.Identity{}
+	keys := map[string]string{}
+	roleID := ""
+	if apiKey := first(md, "x-api-key"); apiKey != "" {
+		apiKeyBase, err := apiKeyService.Init(db).GetBySingle(&apiKeyModel.Field{Key: apiKey})
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				log.Error(err)
+				return nil, status.Error(codes.Internal, "Unexpected server error.")
+			}
+
+			return nil, status.Error(codes.Unauthenticated, "API key is error.")
+		}
+
+		if apiKeyBase.ExpiredAt != nil && apiKeyBase.ExpiredAt.Before(util.NowToUTC()) {
+			return nil, status.Error(codes.Unauthenticated, "API key is expired.")
+		}
+
+		// the key acts as its user, a user moved to another company loses the key
+		userBase, err := user.Init(db).GetByCache(*apiKeyBase.UserID)
+		if err != nil || userBase.CompanyID == nil || *userBase.CompanyID != *apiKeyBase.CompanyID || userBase.RoleID == nil {
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				log.Error(err)
+			}
+
+			return nil, status.Error(codes.Unauthenticated, "API key is error.")
+		}
+
+		identity.UserID = *userBase.UserID
+		identity.CompanyID = *apiKeyBase.CompanyID
+		roleID = *userBase.RoleID
+		keys["api_key"] = hash.Sha256(apiKey)
+	} else {
+		j := &jwx.JWE{
+			PrivateKey: config.AccessPrivateKey,
+			Token:      first(md, "authorization"),
+		}
+		if len(j.Token) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "AccessToken is null.")
+		}
+
+		j, err := j.Verify()
+		if err != nil {
+			log.Error(err)
+			return nil, status.Error(codes.Unauthenticated, "AccessToken is error.")
+		}
+
+		identity.UserID, _ = j.Other["user_id"].(string)
+		identity.CompanyID, _ = j.Other["company_id"].(string)
+		roleID, _ = j.Other["role_id"].(string)
+	}
+
+	checkRole, err := role.Init(db).GetByCache(roleID)
+	if err != nil {
+		log.Error(err)
+		return nil, status.Error(codes.Internal, "Unexpected server error.")
+	}
+
+	identity.RoleName = *checkRole.Name
+	if !strings.HasPrefix(method, reflectionPrefix) {
+		rule, ok := permissions[method]
+		if !ok {
+			return nil, status.Error(codes.PermissionDenied, "Sorry, you don't have permission.")
+		}
+
+		log.Info("Casbin policy:", identity.RoleName, rule.Path, rule.Method)
+		allowed, err := auth.Enforcer.Enforce(identity.RoleName, rule.Path, rule.Method)
+		if err != nil {
+			log.Error(err)
+			return nil, status.Error(codes.Internal, "Unexpected server error.")
+		}
+
+		if !allowed {
+			return nil, status.Error(codes.PermissionDenied, "Sorry, you don't have permission.")
+		}
+
+		if config.RateLimitEnabled {
+			keys["user"] = identity.UserID
+			keys["company"] = identity.CompanyID
+			if client, ok := peer.FromContext(ctx); ok {
+				keys["ip"] = client.Addr.String()
+				if host, _, err := net.SplitHostPort(keys["ip"]); err == nil {
+					keys["ip"] = host
+				}
+			}
+
+			if result := middleware.Limit(keys, rule.Scope); result != nil && !result.Allowed {
+				return nil, status.Error(codes.ResourceExhausted, "Rate limit exceeded.")
+			}
+		}
+	}
+
+	return present.NewContext(ctx, identity), nil
+}
+
+// first returns the first value of a metadata key.
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
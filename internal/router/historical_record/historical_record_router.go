@@ -1,6 +1,7 @@
 package historical_record
 
 import (
+	"crm/config"
 	present "crm/internal/presenter/historical_record"
 	"crm/internal/router/middleware"
 
@@ -13,8 +14,8 @@ func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
 	v10 := router.Group("crm").Group("v1.0").Group("historical-records")
 	{
 		// Todo:加上auth.AuthCheckRole(db)
-		v10.POST("list/:sourceID", middleware.Verify(), control.GetByList)
-		v10.GET(":historicalRecordID", middleware.Verify(), control.GetBySingle)
+		v10.POST("list/:sourceID", middleware.Verify(), middleware.RateLimit(), control.GetByList)
+		v10.GET(":historicalRecordID", middleware.Verify(), middleware.RateLimit(), middleware.Cache(config.CacheControlDefault), control.GetBySingle)
 	}
 
 	return router
@@ -0,0 +1,23 @@
+package import_mapping
+
+import (
+	"crm/config"
+	present "crm/internal/presenter/import_mapping"
+	"crm/internal/router/middleware"
+	"crm/internal/router/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
+	control := present.Init(db)
+	v10 := router.Group("crm").Group("v1.0").Group("import-mappings")
+	{
+		v10.POST("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.Create)
+		v10.GET("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetByList)
+		v10.DELETE(":importMappingID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.Delete)
+	}
+
+	return router
+}
@@ -1,6 +1,7 @@
 package industry
 
 import (
+	"crm/config"
 	present "crm/internal/presenter/industry"
 	"crm/internal/router/middleware"
 	"crm/internal/router/middleware/auth"
@@ -13,11 +14,12 @@ func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
 	control := present.Init(db)
 	v10 := router.Group("crm").Group("v1.0").Group("industries")
 	{
-		v10.POST("", middleware.Verify(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Create)
-		v10.GET("", middleware.Verify(), auth.AuthCheckRole(db), control.GetByList)
-		v10.GET(":industryID", middleware.Verify(), auth.AuthCheckRole(db), control.GetBySingle)
-		v10.DELETE(":industryID", middleware.Verify(), auth.AuthCheckRole(db), control.Delete)
-		v10.PATCH(":industryID", middleware.Verify(), auth.AuthCheckRole(db), control.Update)
+		v10.POST("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Create)
+		v10.GET("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlReference), control.GetByList)
+		v10.GET(":industryID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlReference), control.GetBySingle)
+		v10.DELETE(":industryID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Delete)
+		v10.GET(":industryID/delete-preview", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.DeletePreview)
+		v10.PATCH(":industryID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.Update)
 	}
 
 	return router
@@ -0,0 +1,22 @@
+package job
+
+import (
+	"crm/config"
+	present "crm/internal/presenter/job"
+	"crm/internal/router/middleware"
+	"crm/internal/router/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
+	control := present.Init(db)
+	v10 := router.Group("crm").Group("v1.0").Group("jobs")
+	{
+		v10.GET(":jobID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetBySingle)
+		v10.GET(":jobID/file", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.GetByFile)
+	}
+
+	return router
+}
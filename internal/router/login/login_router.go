@@ -2,6 +2,7 @@ package login
 
 import (
 	present "crm/internal/presenter/login"
+	"crm/internal/router/middleware"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -11,8 +12,8 @@ func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
 	control := present.Init(db)
 	v10 := router.Group("crm").Group("v1.0")
 	{
-		v10.POST("login", control.Login)
-		v10.POST("refresh", control.Refresh)
+		v10.POST("login", middleware.RateLimit(), control.Login)
+		v10.POST("refresh", middleware.RateLimit(), control.Refresh)
 	}
 
 	return router
@@ -3,13 +3,13 @@ package auth
 import (
 	"net/http"
 
-	roleModel "crm/internal/interactor/models/roles"
 	"crm/internal/interactor/service/role"
 
 	"github.com/casbin/casbin/v2/model"
 	"gorm.io/gorm"
 
 	_ "crm/internal/interactor/pkg/connect"
+	"crm/internal/interactor/pkg/util/code"
 	"crm/internal/interactor/pkg/util/log"
 
 	"github.com/casbin/casbin/v2"
@@ -73,7 +73,7 @@ func Casbin(a *jsonAdapter.Adapter) *casbin.Enforcer {
 	#[matchers]
 	#m = r.sub == p.sub && r.obj == p.obj && r.act == p.act`)
 	if err != nil {
-		log.Info("model error: %s;", err)
+		log.Error("model error:", err)
 	}
 
 	e, err := casbin.NewEnforcer(m, a)
@@ -100,14 +100,18 @@ func DeletePolicy(cm CasbinModel) (bool, error) {
 }
 
 func GetAllPolicies() [][]string {
-	return Enforcer.GetPolicy()
+	policies, err := Enforcer.GetPolicy()
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+
+	return policies
 }
 
 func AuthCheckRole(db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		checkRole, err := role.Init(db).GetBySingle(&roleModel.Field{
-			RoleID: c.MustGet("role_id").(string),
-		})
+		checkRole, err := role.Init(db).GetByCache(c.MustGet("role_id").(string))
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"status": -1,
@@ -138,8 +142,15 @@ func AuthCheckRole(db *gorm.DB) gin.HandlerFunc {
 		}
 
 		if res {
+			c.Set("role_name", *checkRole.Name)
 			c.Next()
 		} else {
+			// problem details 使用相符的 403, 其他客戶端維持原有的 203
+			if code.AcceptsProblem(c.GetHeader("Accept")) {
+				c.AbortWithStatusJSON(http.StatusForbidden, code.GetCodeMessage(code.PermissionDenied, "Sorry, you don't have permission."))
+				return
+			}
+
 			c.JSON(http.StatusNonAuthoritativeInfo, gin.H{
 				"status": 203,
 				"msg":    "Sorry, you don't have permission.",
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"crm/internal/interactor/pkg/util/hash"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Cache sets Cache-Control on GET responses and answers a matching If-None-Match with 304.
+// The ETag set by the handler (the record version) is kept, otherwise it is computed from the body of the payload.
+func Cache(cacheControl string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		writer := &bufferedWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+		ctx.Next()
+		ctx.Writer = writer.ResponseWriter
+
+		if writer.Status() != http.StatusOK {
+			writer.flush()
+			return
+		}
+
+		etag := ctx.Writer.Header().Get("ETag")
+		if etag == "" {
+			etag = payloadETag(writer.body.Bytes())
+			ctx.Header("ETag", etag)
+		}
+
+		ctx.Header("Cache-Control", cacheControl)
+		if noneMatch(ctx.GetHeader("If-None-Match"), etag) {
+			ctx.Writer.WriteHeader(http.StatusNotModified)
+			ctx.Writer.WriteHeaderNow()
+			return
+		}
+
+		writer.flush()
+	}
+}
+
+// payloadETag hashes the body of a code message, the timestamp of the message is left out.
+func payloadETag(data []byte) string {
+	message := struct {
+		Body json.RawMessage `json:"body"`
+	}{}
+	if err := json.Unmarshal(data, &message); err == nil && len(message.Body) > 0 {
+		data = message.Body
+	}
+
+	return `"` + hash.Sha256(string(data))[:32] + `"`
+}
+
+// noneMatch reports whether the If-None-Match header names etag, compared weakly.
+func noneMatch(ifNoneMatch, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
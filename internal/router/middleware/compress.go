@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"crm/config"
+	"crm/internal/interactor/pkg/util/log"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	gzipPool = sync.Pool{New: func() any {
+		writer, _ := gzip.NewWriterLevel(io.Discard, config.CompressGzipLevel)
+		return writer
+	}}
+	brotliPool = sync.Pool{New: func() any {
+		return brotli.NewWriterLevel(io.Discard, config.CompressBrotliLevel)
+	}}
+)
+
+// Compress encodes text responses with br or gzip as negotiated by Accept-Encoding.
+func Compress() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Writer.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(ctx.GetHeader("Accept-Encoding"))
+		if encoding == "" || ctx.Request.Method == http.MethodHead {
+			ctx.Next()
+			return
+		}
+
+		writer := &compressWriter{ResponseWriter: ctx.Writer, encoding: encoding}
+		ctx.Writer = writer
+		defer func() {
+			writer.close()
+			ctx.Writer = writer.ResponseWriter
+		}()
+
+		ctx.Next()
+	}
+}
+
+// negotiateEncoding picks br or gzip by their q-values, br wins a tie.
+func negotiateEncoding(acceptEncoding string) string {
+	quality := map[string]float64{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			q, _ = strconv.ParseFloat(value, 64)
+		}
+
+		quality[strings.ToLower(strings.TrimSpace(name))] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range []string{"br", "gzip"} {
+		q, ok := quality[encoding]
+		if !ok {
+			q = quality["*"]
+		}
+
+		if q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+
+	return best
+}
+
+// compressWriter decides on the first write whether the response is compressed.
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	started  bool
+	encoder  io.WriteCloser
+}
+
+func (w *compressWriter) start() {
+	if w.started {
+		return
+	}
+
+	w.started = true
+	header := w.ResponseWriter.Header()
+	status := w.ResponseWriter.Status()
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified ||
+		header.Get("Content-Encoding") != "" || !compressible(header.Get("Content-Type")) {
+		return
+	}
+
+	header.Set("Content-Encoding", w.encoding)
+	header.Del("Content-Length")
+	switch w.encoding {
+	case "br":
+		encoder := brotliPool.Get().(*brotli.Writer)
+		encoder.Reset(w.ResponseWriter)
+		w.encoder = encoder
+	case "gzip":
+		encoder := gzipPool.Get().(*gzip.Writer)
+		encoder.Reset(w.ResponseWriter)
+		w.encoder = encoder
+	}
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	w.start()
+	if w.encoder == nil {
+		return w.ResponseWriter.Write(data)
+	}
+
+	return w.encoder.Write(data)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressWriter) WriteHeaderNow() {
+	w.start()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *compressWriter) Flush() {
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok {
+		if err := flusher.Flush(); err != nil {
+			log.Error(err)
+		}
+	}
+
+	w.ResponseWriter.Flush()
+}
+
+// close finishes the encoded stream and returns the encoder to its pool.
+func (w *compressWriter) close() {
+	if w.encoder == nil {
+		return
+	}
+
+	if err := w.encoder.Close(); err != nil {
+		log.Error(err)
+	}
+
+	switch encoder := w.encoder.(type) {
+	case *brotli.Writer:
+		encoder.Reset(io.Discard)
+		brotliPool.Put(encoder)
+	case *gzip.Writer:
+		encoder.Reset(io.Discard)
+		gzipPool.Put(encoder)
+	}
+
+	w.encoder = nil
+}
+
+// compressible reports whether the content type is text that benefits from compression.
+func compressible(contentType string) bool {
+	for _, kind := range []string{"json", "text/", "xml", "javascript", "csv"} {
+		if strings.Contains(contentType, kind) {
+			return true
+		}
+	}
+
+	return false
+}
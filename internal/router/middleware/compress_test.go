@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		want           string
+	}{
+		{"none", "", ""},
+		{"identity only", "identity", ""},
+		{"gzip", "gzip", "gzip"},
+		{"br", "br", "br"},
+		{"br wins a tie", "gzip, deflate, br", "br"},
+		{"higher q wins", "br;q=0.5, gzip;q=0.8", "gzip"},
+		{"q with spaces", "br ; q=0.2, gzip ; q=0.9", "gzip"},
+		{"case insensitive", "GZIP", "gzip"},
+		{"refused", "br;q=0, gzip;q=0", ""},
+		{"wildcard", "*", "br"},
+		{"wildcard with refusal", "br;q=0, *;q=0.5", "gzip"},
+		{"malformed q is refused", "br;q=high, gzip", "gzip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := negotiateEncoding(tt.acceptEncoding); got != tt.want {
+				t.Errorf("negotiateEncoding(%q) = %q, want %q", tt.acceptEncoding, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	body := strings.Repeat(`{"name":"Acme"}`, 100)
+	tests := []struct {
+		name           string
+		method         string
+		acceptEncoding string
+		status         int
+		contentType    string
+		wantEncoding   string
+	}{
+		{"gzip json", http.MethodGet, "gzip", http.StatusOK, "application/json", "gzip"},
+		{"br json", http.MethodGet, "br, gzip", http.StatusOK, "application/json", "br"},
+		{"not accepted", http.MethodGet, "", http.StatusOK, "application/json", ""},
+		{"binary", http.MethodGet, "gzip", http.StatusOK, "image/png", ""},
+		{"no content", http.MethodDelete, "gzip", http.StatusNoContent, "application/json", ""},
+		{"head", http.MethodHead, "gzip", http.StatusOK, "application/json", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.Use(Compress())
+			engine.Handle(tt.method, "/", func(ctx *gin.Context) {
+				if tt.status == http.StatusNoContent {
+					ctx.Status(tt.status)
+					return
+				}
+
+				ctx.Data(tt.status, tt.contentType, []byte(body))
+			})
+
+			request := httptest.NewRequest(tt.method, "/", nil)
+			request.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, request)
+
+			if got := recorder.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Fatalf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+
+			if got := recorder.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("Vary = %q, want Accept-Encoding", got)
+			}
+
+			var reader io.Reader = recorder.Body
+			switch tt.wantEncoding {
+			case "gzip":
+				gzipReader, err := gzip.NewReader(recorder.Body)
+				if err != nil {
+					t.Fatalf("gzip.NewReader() error = %v", err)
+				}
+
+				reader = gzipReader
+			case "br":
+				reader = brotli.NewReader(recorder.Body)
+			}
+
+			decoded, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("read body: %v", err)
+			}
+
+			want := body
+			if tt.status == http.StatusNoContent {
+				want = ""
+			}
+
+			if !bytes.Equal(decoded, []byte(want)) {
+				t.Errorf("body = %d bytes, want %d bytes", len(decoded), len(want))
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"crm/config"
+	idempotencyKeyModel "crm/internal/interactor/models/idempotency_keys"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/hash"
+	"crm/internal/interactor/pkg/util/log"
+	idempotencyKeyService "crm/internal/interactor/service/idempotency_key"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Idempotency honors the Idempotency-Key header of create endpoints.
+// The first response is stored per company, user and key and replayed on retries within config.IdempotencyKeyTTL,
+// a retry with the same key but another request URL or body is rejected.
+// It must be placed after Verify and before Transaction.
+func Idempotency(db *gorm.DB) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader("Idempotency-Key")
+		if key == "" {
+			ctx.Next()
+			return
+		}
+
+		if len(key) > 255 {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, code.GetCodeMessage(code.BadRequest, "Idempotency-Key must not exceed 255 characters."))
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			log.Error(err)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, code.GetCodeMessage(code.BadRequest, err.Error()))
+			return
+		}
+
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+		service := idempotencyKeyService.Init(db)
+		field := &idempotencyKeyModel.Field{
+			CompanyID: ctx.GetString("company_id"),
+			UserID:    ctx.GetString("user_id"),
+			Key:       key,
+		}
+		requestHash := hash.Sha256(ctx.Request.Method + " " + ctx.Request.URL.RequestURI() + "\n" + string(body))
+
+		// 清除已過期的同一冪等鍵
+		field.ExpiredBefore = util.PointerTime(util.NowToUTC().Add(-config.IdempotencyKeyTTL * time.Hour))
+		if err = service.Delete(field); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error()))
+			return
+		}
+
+		field.ExpiredBefore = nil
+		idempotencyKeyBase, err := service.GetBySingle(field)
+		if err == nil {
+			replay(ctx, idempotencyKeyBase.RequestHash, idempotencyKeyBase.StatusCode, idempotencyKeyBase.ResponseBody, requestHash)
+			return
+		}
+
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error(err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error()))
+			return
+		}
+
+		idempotencyKeyBase, err = service.Create(&idempotencyKeyModel.Create{
+			CompanyID:   field.CompanyID,
+			UserID:      field.UserID,
+			Key:         key,
+			RequestHash: requestHash,
+		})
+		if err != nil {
+			// 唯一索引衝突代表同一冪等鍵的請求正在處理中
+			log.Error(err)
+			ctx.AbortWithStatusJSON(http.StatusConflict, code.GetCodeMessage(code.Conflict, "A request with this Idempotency-Key is in progress."))
+			return
+		}
+
+		// 處理中發生 panic 時刪除冪等鍵,允許以同一冪等鍵重試
+		defer func() {
+			if r := recover(); r != nil {
+				err := service.Delete(&idempotencyKeyModel.Field{
+					IdempotencyKeyID: *idempotencyKeyBase.IdempotencyKeyID,
+				})
+				if err != nil {
+					log.Error(err)
+				}
+
+				panic(r)
+			}
+		}()
+
+		writer := &recordingWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+		ctx.Next()
+		ctx.Writer = writer.ResponseWriter
+
+		// 伺服器錯誤不保存,允許以同一冪等鍵重試
+		status := writer.Status()
+		if status >= http.StatusInternalServerError {
+			err = service.Delete(&idempotencyKeyModel.Field{
+				IdempotencyKeyID: *idempotencyKeyBase.IdempotencyKeyID,
+			})
+		} else {
+			err = service.Update(&idempotencyKeyModel.Update{
+				IdempotencyKeyID: *idempotencyKeyBase.IdempotencyKeyID,
+				StatusCode:       status,
+				ResponseBody:     writer.body.String(),
+			})
+		}
+
+		if err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+// replay answers a retry with the stored response of the first request.
+func replay(ctx *gin.Context, storedHash *string, statusCode *int, responseBody *string, requestHash string) {
+	if storedHash == nil || *storedHash != requestHash {
+		ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, code.GetCodeMessage(code.UnprocessableEntity, "Idempotency-Key was already used with another request."))
+		return
+	}
+
+	if statusCode == nil || responseBody == nil {
+		ctx.AbortWithStatusJSON(http.StatusConflict, code.GetCodeMessage(code.Conflict, "A request with this Idempotency-Key is in progress."))
+		return
+	}
+
+	ctx.Header("Idempotent-Replayed", "true")
+	ctx.Data(*statusCode, "application/json; charset=utf-8", []byte(*responseBody))
+	ctx.Abort()
+}
+
+// recordingWriter copies the response body while it is written to the client.
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+	companySettingService "crm/internal/interactor/service/company_setting"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// RequireIfMatch rejects updates without an If-Match header for the companies whose settings require it.
+// Other companies may still send If-Match, it is checked by the managers either way.
+func RequireIfMatch(db *gorm.DB) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.GetHeader("If-Match") == "" && IfMatchRequired(db, ctx.GetString("company_id")) {
+			ctx.AbortWithStatusJSON(http.StatusPreconditionRequired, code.GetCodeMessage(code.PreconditionRequired, "If-Match header is required."))
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// IfMatchRequired reports whether the company enforces optimistic concurrency control.
+// The company settings are read through the cache on every request, so a change applies without a redeploy.
+func IfMatchRequired(db *gorm.DB, companyID string) bool {
+	companySettingBase, err := companySettingService.Init(db).GetByCache(companyID)
+	if err != nil {
+		// 設定無法取得時不要求 If-Match
+		log.Error(err)
+		return false
+	}
+
+	return companySettingBase.IfMatchRequired != nil && *companySettingBase.IfMatchRequired
+}
@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+	"crm/internal/interactor/pkg/util/uuid"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Problem tags every request with the X-Request-ID header, the one sent by the client is kept when it is valid.
+// When the client accepts application/problem+json the error responses are rewritten into problem details (RFC 7807),
+// other clients keep receiving code messages. Bind errors recorded with ctx.Error are reported per field
+// with 400 or 422 instead of 415.
+func Problem() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader("X-Request-ID")
+		if !validRequestID(requestID) {
+			requestID = uuid.CreatedUUIDString()
+		}
+
+		ctx.Set("request_id", requestID)
+		ctx.Header("X-Request-ID", requestID)
+		if !code.AcceptsProblem(ctx.GetHeader("Accept")) {
+			ctx.Next()
+			return
+		}
+
+		writer := &problemWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+		ctx.Next()
+		ctx.Writer = writer.ResponseWriter
+
+		// 錯誤狀態未寫入內容時(如查無路由)同樣回傳 problem details
+		if !writer.failed() && (ctx.Writer.Written() || ctx.Writer.Status() < http.StatusBadRequest) {
+			return
+		}
+
+		writeProblem(ctx, problem(ctx, writer.Status(), writer.body.Bytes()))
+	}
+}
+
+// validRequestID accepts the request IDs of clients that are short and cannot break a log line.
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > 128 {
+		return false
+	}
+
+	for _, char := range requestID {
+		if !(char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char >= '0' && char <= '9' || char == '-' || char == '_' || char == '.' || char == ':') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// problem turns an error response into problem details, body is the code message written by the handler.
+func problem(ctx *gin.Context, status int, body []byte) *code.Problem {
+	var detailed any
+	message := &code.ErrorMessage{}
+	if err := json.Unmarshal(body, message); err == nil && message.Message != "" {
+		detailed = message.Detailed
+	}
+
+	errorCode := ""
+	var fields []*code.FieldError
+	switch {
+	case status == http.StatusUnsupportedMediaType && len(ctx.Errors) > 0:
+		status, errorCode, fields, detailed = bindFailure(ctx.Errors.Last().Err)
+	case status == http.StatusUnprocessableEntity && fieldErrors(body, &fields):
+		// 欄位值不符合選項清單等由 manager 驗證的欄位
+		errorCode, detailed = code.ErrValidationFailed, "Some fields are invalid."
+	}
+
+	output := code.GetProblem(status, errorCode, detailed)
+	output.Errors = fields
+	output.Instance = ctx.Request.URL.Path
+	output.RequestID = ctx.GetString("request_id")
+	return output
+}
+
+// fieldErrors reads the invalid fields a manager reports as the detail of its error message.
+func fieldErrors(body []byte, fields *[]*code.FieldError) bool {
+	message := &struct {
+		Detailed []*code.FieldError `json:"detailed"`
+	}{}
+	if err := json.Unmarshal(body, message); err != nil || len(message.Detailed) == 0 {
+		return false
+	}
+
+	*fields = message.Detailed
+	return true
+}
+
+// bindFailure classifies the error of binding a request, invalid fields are 422 and unreadable requests are 400.
+func bindFailure(err error) (int, string, []*code.FieldError, string) {
+	var validationError *ValidationError
+	var typeError *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &validationError):
+		return http.StatusUnprocessableEntity, code.ErrValidationFailed, validationError.Fields, "Some fields are invalid."
+	case errors.As(err, &typeError):
+		return http.StatusBadRequest, code.ErrMalformedRequest, []*code.FieldError{{
+			Field:   typeError.Field,
+			Code:    "type",
+			Message: "must be of type " + typeError.Type.String() + ".",
+		}}, "A field has the wrong type."
+	default:
+		return http.StatusBadRequest, code.ErrMalformedRequest, nil, err.Error()
+	}
+}
+
+// writeProblem writes the problem details in place of the response of the handler, the other headers are kept.
+func writeProblem(ctx *gin.Context, output *code.Problem) {
+	data, err := json.Marshal(output)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	ctx.Writer.Header().Set("Content-Type", code.ProblemJSON)
+	ctx.Writer.Header().Del("Content-Length")
+	ctx.Writer.WriteHeader(output.Status)
+	if _, err = ctx.Writer.Write(data); err != nil {
+		log.Error(err)
+	}
+}
+
+// problemWriter holds back the body of error responses, successful responses are written through.
+type problemWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// failed reports whether the handler answers with an error status.
+func (w *problemWriter) failed() bool {
+	return w.status >= http.StatusBadRequest
+}
+
+func (w *problemWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+
+	if !w.failed() {
+		w.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (w *problemWriter) WriteHeaderNow() {
+	if !w.failed() {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *problemWriter) Write(data []byte) (int, error) {
+	if w.failed() {
+		return w.body.Write(data)
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *problemWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *problemWriter) Status() int {
+	if w.status == 0 {
+		return w.ResponseWriter.Status()
+	}
+
+	return w.status
+}
+
+func (w *problemWriter) Size() int {
+	if w.failed() {
+		return w.body.Len()
+	}
+
+	return w.ResponseWriter.Size()
+}
+
+func (w *problemWriter) Written() bool {
+	return w.failed() || w.ResponseWriter.Written()
+}
+
+func (w *problemWriter) Flush() {
+	if !w.failed() {
+		w.ResponseWriter.Flush()
+	}
+}
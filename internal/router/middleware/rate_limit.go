@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"crm/config"
+	"crm/internal/interactor/pkg/connect"
+	"crm/internal/interactor/pkg/limiter"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/hash"
+	"crm/internal/interactor/pkg/util/log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Scopes of the rate limit rules.
+const (
+	ScopeRead   = "read"
+	ScopeWrite  = "write"
+	ScopeExport = "export"
+)
+
+// rateLimitRules are the requests per minute of every key type and scope.
+var rateLimitRules = map[string]map[string]int{
+	"user": {
+		ScopeRead:   config.RateLimitUserRead,
+		ScopeWrite:  config.RateLimitUserWrite,
+		ScopeExport: config.RateLimitUserExport,
+	},
+	"company": {
+		ScopeRead:   config.RateLimitCompanyRead,
+		ScopeWrite:  config.RateLimitCompanyWrite,
+		ScopeExport: config.RateLimitCompanyExport,
+	},
+	"api_key": {
+		ScopeRead:   config.RateLimitAPIKeyRead,
+		ScopeWrite:  config.RateLimitAPIKeyWrite,
+		ScopeExport: config.RateLimitAPIKeyExport,
+	},
+	"ip": {
+		ScopeRead:   config.RateLimitIPRead,
+		ScopeWrite:  config.RateLimitIPWrite,
+		ScopeExport: config.RateLimitIPExport,
+	},
+}
+
+var (
+	rateLimitStore limiter.Store
+	rateLimitOnce  sync.Once
+)
+
+// store uses redis when it is configured so that every instance shares the same buckets.
+func store() limiter.Store {
+	rateLimitOnce.Do(func() {
+		redisDB, err := connect.Redis()
+		if err != nil || redisDB == nil {
+			rateLimitStore = limiter.NewMemory()
+			return
+		}
+
+		rateLimitStore = limiter.NewRedis(redisDB.Client())
+	})
+
+	return rateLimitStore
+}
+
+// RateLimit limits the requests per user, company, API key and IP.
+// It must be placed after Verify so that the user and company are known.
+func RateLimit() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !config.RateLimitEnabled {
+			ctx.Next()
+			return
+		}
+
+		tightest := Limit(rateLimitKeys(ctx), rateLimitScope(ctx))
+		if tightest == nil {
+			ctx.Next()
+			return
+		}
+
+		ctx.Header("RateLimit-Limit", strconv.Itoa(tightest.Limit))
+		ctx.Header("RateLimit-Remaining", strconv.Itoa(tightest.Remaining))
+		ctx.Header("RateLimit-Reset", strconv.Itoa(int(tightest.Reset.Seconds())))
+		ctx.Header("RateLimit-Policy", fmt.Sprintf("%d;w=60", tightest.Limit))
+		if !tightest.Allowed {
+			ctx.Header("Retry-After", strconv.Itoa(int(tightest.RetryAfter.Seconds())))
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, code.GetCodeMessage(code.TooManyRequests, "Rate limit exceeded."))
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// Limit takes a request from the bucket of every key and returns the tightest result, nil when no bucket applies.
+// The buckets are checked and taken atomically, a denied request takes no token from any bucket.
+// The keys are typed by the key types of the rules, such as user, company, api_key and ip.
+func Limit(keys map[string]string, scope string) *limiter.Result {
+	var buckets []limiter.Bucket
+	for keyType, value := range keys {
+		limit := rateLimitRules[keyType][scope]
+		if limit <= 0 || value == "" {
+			continue
+		}
+
+		buckets = append(buckets, limiter.Bucket{
+			Key: fmt.Sprintf("%s:%s:%s", keyType, value, scope),
+			Rule: limiter.Rule{
+				Limit:  limit,
+				Period: time.Minute,
+			},
+		})
+	}
+
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	results, err := store().Take(buckets, time.Now())
+	if err != nil {
+		// 限制狀態無法取得時不阻擋請求
+		log.Error(err)
+		return nil
+	}
+
+	var tightest *limiter.Result
+	for _, result := range results {
+		if tightest == nil || tighter(result, tightest) {
+			tightest = result
+		}
+	}
+
+	return tightest
+}
+
+// tighter reports whether a is the result that should be reported to the client instead of b.
+func tighter(a, b *limiter.Result) bool {
+	if a.Allowed != b.Allowed {
+		return !a.Allowed
+	}
+
+	return a.Remaining < b.Remaining
+}
+
+func rateLimitKeys(ctx *gin.Context) map[string]string {
+	keys := map[string]string{
+		"user":    ctx.GetString("user_id"),
+		"company": ctx.GetString("company_id"),
+		"ip":      ctx.ClientIP(),
+	}
+
+	// API key只保存雜湊值
+	if apiKey := ctx.GetHeader("X-API-Key"); apiKey != "" {
+		keys["api_key"] = hash.Sha256(apiKey)
+	}
+
+	return keys
+}
+
+// rateLimitScope classifies the request, POST .../list endpoints only read data.
+func rateLimitScope(ctx *gin.Context) string {
+	path := ctx.Request.URL.Path
+	switch format := ctx.Query("format"); {
+	case format == "csv" || format == "xlsx" || strings.Contains(path, "/export"):
+		return ScopeExport
+	case ctx.Request.Method == http.MethodGet || strings.HasSuffix(path, "/list") || strings.HasSuffix(path, "/list/no-pagination"):
+		return ScopeRead
+	default:
+		return ScopeWrite
+	}
+}
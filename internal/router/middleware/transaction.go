@@ -1,20 +1,110 @@
 package middleware
 
 import (
+	"bytes"
+	"net/http"
+
+	"crm/internal/interactor/helpers"
+	"crm/internal/interactor/pkg/util/code"
+	"crm/internal/interactor/pkg/util/log"
+
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
+// Transaction opens the unit of work of the request.
+// The transaction is committed when the handler responds with a 2xx status and records no error,
+// otherwise it is rolled back, so managers do not commit or roll back themselves.
+// The response is held back until the commit succeeds, the functions given to helpers.AfterCommit run after it.
 func Transaction(db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		txHandle := db.Begin()
+		ctx, afterCommit := helpers.WithAfterCommit(db.Statement.Context)
+		txHandle := db.WithContext(ctx).Begin()
+		if txHandle.Error != nil {
+			log.Error(txHandle.Error)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, code.GetCodeMessage(code.InternalServerError, txHandle.Error.Error()))
+			return
+		}
+
+		writer := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
 		defer func() {
 			if r := recover(); r != nil {
 				txHandle.Rollback()
+				c.Writer = writer.ResponseWriter
+				panic(r)
 			}
 		}()
+
 		c.Set("db", db)
 		c.Set("db_trx", txHandle)
 		c.Next()
+
+		c.Writer = writer.ResponseWriter
+		status := writer.Status()
+		if status < http.StatusOK || status >= http.StatusMultipleChoices || len(c.Errors) > 0 {
+			if err := txHandle.Rollback().Error; err != nil {
+				log.Error(err)
+			}
+
+			writer.flush()
+			return
+		}
+
+		if err := txHandle.Commit().Error; err != nil {
+			log.Error(err)
+			c.JSON(http.StatusInternalServerError, code.GetCodeMessage(code.InternalServerError, err.Error()))
+			return
+		}
+
+		afterCommit()
+		writer.flush()
+	}
+}
+
+// bufferedWriter keeps the status and body until the transaction is finished.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.status != 0 || w.body.Len() > 0
+}
+
+// flush sends the held response to the client.
+func (w *bufferedWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.Status())
+	if _, err := w.ResponseWriter.Write(w.body.Bytes()); err != nil {
+		log.Error(err)
 	}
 }
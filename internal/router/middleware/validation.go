@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"crm/internal/interactor/models/enums"
+	"crm/internal/interactor/pkg/util/code"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// ValidationError is a failed validation of a request, it names the JSON path of every invalid field.
+// Its message is the message of the validator so that the error messages stay as they were.
+type ValidationError struct {
+	validator.ValidationErrors
+	// 欄位驗證錯誤
+	Fields []*code.FieldError
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.ValidationErrors
+}
+
+// fieldValidator wraps the validator of gin, it is used by every ShouldBind of the presenters.
+type fieldValidator struct {
+	binding.StructValidator
+}
+
+func init() {
+	// enum=lead.status accepts the codes of an enumeration and their translations
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		if err := engine.RegisterValidation("enum", func(field validator.FieldLevel) bool {
+			_, ok := enums.Code(field.Param(), field.Field().String())
+			return ok
+		}); err != nil {
+			panic(err)
+		}
+	}
+
+	binding.Validator = &fieldValidator{StructValidator: binding.Validator}
+}
+
+func (v *fieldValidator) ValidateStruct(obj any) error {
+	err := v.StructValidator.ValidateStruct(obj)
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	fields := make([]*code.FieldError, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		fields = append(fields, &code.FieldError{
+			Field:   jsonPath(reflect.TypeOf(obj), fieldError.StructNamespace()),
+			Code:    fieldError.Tag(),
+			Message: fieldMessage(fieldError),
+		})
+	}
+
+	return &ValidationError{ValidationErrors: validationErrors, Fields: fields}
+}
+
+// jsonPath turns the struct namespace of a field, such as Create.Contacts[0].Email, into its JSON path, contacts[0].email.
+// Embedded structs are flattened like encoding/json does.
+func jsonPath(typ reflect.Type, namespace string) string {
+	segments := strings.Split(namespace, ".")
+	path := make([]string, 0, len(segments))
+	for _, segment := range segments[1:] {
+		name, index, _ := strings.Cut(segment, "[")
+		if index != "" {
+			index = "[" + index
+		}
+
+		typ = indirect(typ)
+		if typ.Kind() != reflect.Struct {
+			path = append(path, segment)
+			continue
+		}
+
+		field, ok := typ.FieldByName(name)
+		if !ok {
+			path = append(path, segment)
+			continue
+		}
+
+		typ = field.Type
+		for i := strings.Count(index, "["); i > 0; i-- {
+			if typ = indirect(typ); typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+				typ = typ.Elem()
+			}
+		}
+
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch {
+		case field.Anonymous && tag == "":
+			continue
+		case tag == "" || tag == "-":
+			tag = field.Name
+		}
+
+		path = append(path, tag+index)
+	}
+
+	return strings.Join(path, ".")
+}
+
+// indirect returns the type a pointer type points to.
+func indirect(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	return typ
+}
+
+// fieldMessage translates the binding tag a field failed on.
+func fieldMessage(fieldError validator.FieldError) string {
+	param := fieldError.Param()
+	text := fieldError.Kind() == reflect.String
+	switch fieldError.Tag() {
+	case "required", "required_if", "required_unless", "required_with", "required_without":
+		return "is required."
+	case "uuid", "uuid4":
+		return "must be a UUID."
+	case "email":
+		return "must be an email address."
+	case "url", "uri", "http_url":
+		return "must be a URL."
+	case "numeric", "number":
+		return "must be a number."
+	case "datetime":
+		return "must be a date time in the format " + param + "."
+	case "oneof":
+		return "must be one of " + strings.Join(strings.Fields(param), ", ") + "."
+	case "enum":
+		return "must be one of " + strings.Join(enums.Codes(param), ", ") + "."
+	case "len":
+		if text {
+			return "must be " + param + " characters long."
+		}
+
+		return "must have " + param + " items."
+	case "min", "gte":
+		if text {
+			return "must be at least " + param + " characters long."
+		}
+
+		return "must be at least " + param + "."
+	case "max", "lte":
+		if text {
+			return "must be at most " + param + " characters long."
+		}
+
+		return "must be at most " + param + "."
+	case "gt":
+		return "must be greater than " + param + "."
+	case "lt":
+		return "must be less than " + param + "."
+	default:
+		return "failed on the '" + fieldError.Tag() + "' rule."
+	}
+}
@@ -1,6 +1,7 @@
 package opportunity
 
 import (
+	"crm/config"
 	present "crm/internal/presenter/opportunity"
 	"crm/internal/router/middleware"
 	"crm/internal/router/middleware/auth"
@@ -13,13 +14,15 @@ func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
 	control := present.Init(db)
 	v10 := router.Group("crm").Group("v1.0").Group("opportunities")
 	{
-		v10.POST("", middleware.Verify(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Create)
-		v10.POST("list", middleware.Verify(), auth.AuthCheckRole(db), control.GetByList)
-		v10.POST("list/no-pagination", middleware.Verify(), auth.AuthCheckRole(db), control.GetByListNoPagination)
-		v10.GET(":opportunityID", middleware.Verify(), auth.AuthCheckRole(db), control.GetBySingle)
-		v10.GET("campaigns/:opportunityID", middleware.Verify(), auth.AuthCheckRole(db), control.GetBySingleCampaigns)
-		v10.DELETE(":opportunityID", middleware.Verify(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Delete)
-		v10.PATCH(":opportunityID", middleware.Verify(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Update)
+		v10.POST("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Idempotency(db), middleware.Transaction(db), control.Create)
+		v10.POST("list", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.GetByList)
+		v10.POST("list/no-pagination", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.GetByListNoPagination)
+		v10.GET(":opportunityID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetBySingle)
+		v10.GET("campaigns/:opportunityID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetBySingleCampaigns)
+		v10.DELETE(":opportunityID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Delete)
+		v10.GET(":opportunityID/delete-preview", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.DeletePreview)
+		v10.PATCH(":opportunityID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.RequireIfMatch(db), middleware.Transaction(db), control.Update)
+		v10.POST("bulk", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Idempotency(db), middleware.Transaction(db), control.Bulk)
 	}
 
 	return router
@@ -1,6 +1,7 @@
 package order_product
 
 import (
+	"crm/config"
 	present "crm/internal/presenter/order_product"
 	"crm/internal/router/middleware"
 	"crm/internal/router/middleware/auth"
@@ -13,11 +14,11 @@ func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
 	control := present.Init(db)
 	v10 := router.Group("crm").Group("v1.0").Group("orders-products")
 	{
-		v10.POST("", middleware.Verify(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Create)
-		v10.GET("", middleware.Verify(), auth.AuthCheckRole(db), control.GetByList)
-		v10.GET(":orderProductID", middleware.Verify(), auth.AuthCheckRole(db), control.GetBySingle)
-		v10.DELETE("", middleware.Verify(), auth.AuthCheckRole(db), control.Delete)
-		v10.PATCH("", middleware.Verify(), auth.AuthCheckRole(db), control.Update)
+		v10.POST("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Create)
+		v10.GET("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetByList)
+		v10.GET(":orderProductID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetBySingle)
+		v10.DELETE("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.Delete)
+		v10.PATCH("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.Update)
 	}
 
 	return router
@@ -1,6 +1,7 @@
 package policy
 
 import (
+	"crm/config"
 	present "crm/internal/presenter/policy"
 	"crm/internal/router/middleware"
 	"crm/internal/router/middleware/auth"
@@ -12,9 +13,9 @@ import (
 func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
 	v10 := router.Group("crm").Group("v1.0").Group("policies")
 	{
-		v10.POST("", middleware.Verify(), auth.AuthCheckRole(db), present.AddPolicy)
-		v10.GET("", middleware.Verify(), auth.AuthCheckRole(db), present.GetAllPolicies)
-		v10.DELETE("", middleware.Verify(), auth.AuthCheckRole(db), present.DeletePolicy)
+		v10.POST("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), present.AddPolicy)
+		v10.GET("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlReference), present.GetAllPolicies)
+		v10.DELETE("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), present.DeletePolicy)
 	}
 
 	return router
@@ -1,6 +1,7 @@
 package product
 
 import (
+	"crm/config"
 	present "crm/internal/presenter/product"
 	"crm/internal/router/middleware"
 	"crm/internal/router/middleware/auth"
@@ -13,12 +14,13 @@ func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
 	control := present.Init(db)
 	v10 := router.Group("crm").Group("v1.0").Group("products")
 	{
-		v10.POST("", middleware.Verify(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Create)
-		v10.POST("list", middleware.Verify(), auth.AuthCheckRole(db), control.GetByList)
-		v10.POST("get-by-order/:orderID", middleware.Verify(), auth.AuthCheckRole(db), control.GetByOrderIDList)
-		v10.GET(":productID", middleware.Verify(), auth.AuthCheckRole(db), control.GetBySingle)
-		v10.DELETE(":productID", middleware.Verify(), auth.AuthCheckRole(db), control.Delete)
-		v10.PATCH(":productID", middleware.Verify(), auth.AuthCheckRole(db), control.Update)
+		v10.POST("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Create)
+		v10.POST("list", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.GetByList)
+		v10.POST("get-by-order/:orderID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.GetByOrderIDList)
+		v10.GET(":productID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlReference), control.GetBySingle)
+		v10.DELETE(":productID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Delete)
+		v10.GET(":productID/delete-preview", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.DeletePreview)
+		v10.PATCH(":productID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Update)
 	}
 
 	return router
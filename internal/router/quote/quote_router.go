@@ -1,6 +1,7 @@
 package quote
 
 import (
+	"crm/config"
 	present "crm/internal/presenter/quote"
 	"crm/internal/router/middleware"
 	"crm/internal/router/middleware/auth"
@@ -13,13 +14,14 @@ func GetRouter(router *gin.Engine, db *gorm.DB) *gin.Engine {
 	control := present.Init(db)
 	v10 := router.Group("crm").Group("v1.0").Group("quotes")
 	{
-		v10.POST("", middleware.Verify(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Create)
-		v10.POST("list", middleware.Verify(), auth.AuthCheckRole(db), control.GetByList)
-		v10.GET(":quoteID", middleware.Verify(), auth.AuthCheckRole(db), control.GetBySingle)
-		v10.GET("products/:quoteID", middleware.Verify(), auth.AuthCheckRole(db), control.GetBySingleProducts)
-		v10.GET("get-by-opportunity/:opportunityID", middleware.Verify(), auth.AuthCheckRole(db), control.GetByOpportunityIDSingle)
-		v10.DELETE(":quoteID", middleware.Verify(), auth.AuthCheckRole(db), control.Delete)
-		v10.PATCH(":quoteID", middleware.Verify(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Update)
+		v10.POST("", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Idempotency(db), middleware.Transaction(db), control.Create)
+		v10.POST("list", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.GetByList)
+		v10.GET(":quoteID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetBySingle)
+		v10.GET("products/:quoteID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetBySingleProducts)
+		v10.GET("get-by-opportunity/:opportunityID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Cache(config.CacheControlDefault), control.GetByOpportunityIDSingle)
+		v10.DELETE(":quoteID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.Transaction(db), control.Delete)
+		v10.GET(":quoteID/delete-preview", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), control.DeletePreview)
+		v10.PATCH(":quoteID", middleware.Verify(), middleware.RateLimit(), auth.AuthCheckRole(db), middleware.RequireIfMatch(db), middleware.Transaction(db), control.Update)
 	}
 
 	return router
@@ -1,17 +1,24 @@
 package router
 
 import (
+	"crm/internal/router/middleware"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
-func Default() *gin.Engine {
+// Default returns the engine with the common middlewares, it fails when the CORS config is invalid.
+func Default() (*gin.Engine, error) {
+	corsConfig, err := corsConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	router := gin.New()
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
-	router.Use(cors.New(cors.Config{
-		AllowOrigins: []string{"*"},
-		AllowHeaders: []string{"Origin", "Authorization"},
-	}))
-	return router
+	router.Use(cors.New(corsConfig))
+	router.Use(middleware.Compress())
+	router.Use(middleware.Problem())
+	return router, nil
 }
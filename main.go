@@ -2,30 +2,45 @@ package main
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 
 	_ "crm/api"
+	"crm/config"
 	"crm/internal/interactor/pkg/connect"
 	"crm/internal/interactor/pkg/util/log"
 	"crm/internal/router"
 	"crm/internal/router/account"
+	"crm/internal/router/api_key"
 	"crm/internal/router/campaign"
+	"crm/internal/router/company_setting"
 	"crm/internal/router/contact"
 	"crm/internal/router/contract"
+	"crm/internal/router/custom_field"
+	"crm/internal/router/duplicate_rule"
+	"crm/internal/router/enum"
 	"crm/internal/router/event"
+	"crm/internal/router/graphql"
+	"crm/internal/router/grpc"
 	"crm/internal/router/historical_record"
+	"crm/internal/router/import_mapping"
+	"crm/internal/router/importer"
 	"crm/internal/router/industry"
+	"crm/internal/router/job"
 	"crm/internal/router/lead"
 	"crm/internal/router/login"
 	"crm/internal/router/opportunity"
 	"crm/internal/router/opportunity_campaign"
 	"crm/internal/router/order"
 	"crm/internal/router/order_product"
+	"crm/internal/router/picklist"
 	"crm/internal/router/policy"
 	"crm/internal/router/product"
 	"crm/internal/router/quote"
 	"crm/internal/router/quote_product"
+	"crm/internal/router/recycle_bin"
 	"crm/internal/router/role"
+	"crm/internal/router/search"
 	"crm/internal/router/user"
 
 	swaggerFiles "github.com/swaggo/files"
@@ -56,7 +71,12 @@ func main() {
 		return
 	}
 
-	engine := router.Default()
+	engine, err := router.Default()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
 	user.GetRouter(engine, db)
 	login.GetRouter(engine, db)
 	lead.GetRouter(engine, db)
@@ -76,6 +96,29 @@ func main() {
 	role.GetRouter(engine, db)
 	historical_record.GetRouter(engine, db)
 	event.GetRouter(engine, db)
+	search.GetRouter(engine, db)
+	importer.GetRouter(engine, db)
+	import_mapping.GetRouter(engine, db)
+	job.GetRouter(engine, db)
+	duplicate_rule.GetRouter(engine, db)
+	recycle_bin.GetRouter(engine, db)
+	graphql.GetRouter(engine, db)
+	api_key.GetRouter(engine, db)
+	enum.GetRouter(engine, db)
+	picklist.GetRouter(engine, db)
+	custom_field.GetRouter(engine, db)
+	company_setting.GetRouter(engine, db)
+
+	// gRPC 伺服器與 gin 並行,供內部系統整合使用
+	listener, err := net.Listen("tcp", config.GRPCAddress)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	go func() {
+		log.Fatal(grpc.NewServer(db).Serve(listener))
+	}()
 
 	url := ginSwagger.URL(fmt.Sprintf("http://localhost:8080/swagger/doc.json"))
 	engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
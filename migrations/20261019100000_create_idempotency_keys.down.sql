@@ -0,0 +1,3 @@
+drop index idx_idempotency_keys_company_id_user_id_key;
+drop index idx_idempotency_keys_created_at;
+drop table idempotency_keys;
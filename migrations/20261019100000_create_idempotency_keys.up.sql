@@ -0,0 +1,18 @@
+create table idempotency_keys
+(
+    idempotency_key_id uuid      default uuid_generate_v4() not null
+        primary key,
+    company_id         uuid                                 not null,
+    user_id            uuid                                 not null,
+    key                text                                 not null,
+    request_hash       text                                 not null,
+    status_code        integer,
+    response_body      text,
+    created_at         timestamp default now()              not null
+);
+
+create unique index idx_idempotency_keys_company_id_user_id_key
+    on idempotency_keys (company_id, user_id, key);
+
+create index idx_idempotency_keys_created_at
+    on idempotency_keys (created_at desc);
@@ -0,0 +1,24 @@
+drop index idx_accounts_name_trgm;
+drop index idx_accounts_phone_number_trgm;
+drop index idx_accounts_search;
+drop index idx_contacts_name_trgm;
+drop index idx_contacts_phone_number_trgm;
+drop index idx_contacts_cell_phone_trgm;
+drop index idx_contacts_email_trgm;
+drop index idx_contacts_search;
+drop index idx_leads_description_trgm;
+drop index idx_leads_search;
+drop index idx_opportunities_name_trgm;
+drop index idx_opportunities_search;
+drop index idx_quotes_code_trgm;
+drop index idx_quotes_name_trgm;
+drop index idx_quotes_search;
+drop index idx_contracts_code_trgm;
+drop index idx_contracts_description_trgm;
+drop index idx_contracts_search;
+drop index idx_orders_code_trgm;
+drop index idx_orders_description_trgm;
+drop index idx_orders_search;
+drop index idx_products_name_trgm;
+drop index idx_products_code_trgm;
+drop index idx_products_search;
@@ -0,0 +1,73 @@
+create extension if not exists pg_trgm;
+
+create index idx_accounts_name_trgm
+    on accounts using gin (name gin_trgm_ops);
+
+create index idx_accounts_phone_number_trgm
+    on accounts using gin (phone_number gin_trgm_ops);
+
+create index idx_accounts_search
+    on accounts using gin (to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(phone_number, '')));
+
+create index idx_contacts_name_trgm
+    on contacts using gin (name gin_trgm_ops);
+
+create index idx_contacts_phone_number_trgm
+    on contacts using gin (phone_number gin_trgm_ops);
+
+create index idx_contacts_cell_phone_trgm
+    on contacts using gin (cell_phone gin_trgm_ops);
+
+create index idx_contacts_email_trgm
+    on contacts using gin (email gin_trgm_ops);
+
+create index idx_contacts_search
+    on contacts using gin (to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(phone_number, '') || ' ' || coalesce(cell_phone, '') || ' ' || coalesce(email, '')));
+
+create index idx_leads_description_trgm
+    on leads using gin (description gin_trgm_ops);
+
+create index idx_leads_search
+    on leads using gin (to_tsvector('simple', coalesce(description, '')));
+
+create index idx_opportunities_name_trgm
+    on opportunities using gin (name gin_trgm_ops);
+
+create index idx_opportunities_search
+    on opportunities using gin (to_tsvector('simple', coalesce(name, '')));
+
+create index idx_quotes_code_trgm
+    on quotes using gin (code gin_trgm_ops);
+
+create index idx_quotes_name_trgm
+    on quotes using gin (name gin_trgm_ops);
+
+create index idx_quotes_search
+    on quotes using gin (to_tsvector('simple', coalesce(code, '') || ' ' || coalesce(name, '')));
+
+create index idx_contracts_code_trgm
+    on contracts using gin (code gin_trgm_ops);
+
+create index idx_contracts_description_trgm
+    on contracts using gin (description gin_trgm_ops);
+
+create index idx_contracts_search
+    on contracts using gin (to_tsvector('simple', coalesce(code, '') || ' ' || coalesce(description, '')));
+
+create index idx_orders_code_trgm
+    on orders using gin (code gin_trgm_ops);
+
+create index idx_orders_description_trgm
+    on orders using gin (description gin_trgm_ops);
+
+create index idx_orders_search
+    on orders using gin (to_tsvector('simple', coalesce(code, '') || ' ' || coalesce(description, '')));
+
+create index idx_products_name_trgm
+    on products using gin (name gin_trgm_ops);
+
+create index idx_products_code_trgm
+    on products using gin (code gin_trgm_ops);
+
+create index idx_products_search
+    on products using gin (to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(code, '')));
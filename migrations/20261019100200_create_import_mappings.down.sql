@@ -0,0 +1,2 @@
+drop index idx_import_mappings_company_id_entity_name;
+drop table import_mappings;
@@ -0,0 +1,16 @@
+create table import_mappings
+(
+    import_mapping_id uuid      default uuid_generate_v4() not null
+        primary key,
+    company_id        uuid                                 not null,
+    entity            text                                 not null,
+    name              text                                 not null,
+    columns           jsonb     default '{}'::jsonb        not null,
+    created_at        timestamp default now()              not null,
+    created_by        uuid                                 not null,
+    updated_at        timestamp default now()              not null,
+    updated_by        uuid                                 not null
+);
+
+create unique index idx_import_mappings_company_id_entity_name
+    on import_mappings (company_id, entity, name);
@@ -0,0 +1,2 @@
+drop index idx_jobs_created_by_created_at;
+drop table jobs;
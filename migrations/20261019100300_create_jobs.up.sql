@@ -0,0 +1,22 @@
+create table jobs
+(
+    job_id       uuid      default uuid_generate_v4() not null
+        primary key,
+    company_id   uuid                                 not null,
+    kind         text                                 not null,
+    entity       text                                 not null,
+    status       text      default 'pending'          not null,
+    inserted     integer   default 0                  not null,
+    updated      integer   default 0                  not null,
+    rejected     integer   default 0                  not null,
+    file_name    text,
+    content_type text,
+    file         bytea,
+    error        text,
+    created_at   timestamp default now()              not null,
+    created_by   uuid                                 not null,
+    finished_at  timestamp
+);
+
+create index idx_jobs_created_by_created_at
+    on jobs (created_by, created_at desc);
@@ -0,0 +1,4 @@
+drop index idx_contacts_email_normalized;
+drop index idx_accounts_name_normalized;
+drop index idx_duplicate_rules_company_id_entity_field_method;
+drop table duplicate_rules;
@@ -0,0 +1,36 @@
+create table duplicate_rules
+(
+    duplicate_rule_id uuid          default uuid_generate_v4() not null
+        primary key,
+    company_id        uuid                                     not null,
+    entity            text                                     not null,
+    field             text                                     not null,
+    method            text                                     not null,
+    threshold         numeric(3, 2) default 1                  not null,
+    is_enable         boolean       default true               not null,
+    created_at        timestamp     default now()              not null,
+    created_by        uuid                                     not null,
+    updated_at        timestamp     default now()              not null,
+    updated_by        uuid                                     not null
+);
+
+create unique index idx_duplicate_rules_company_id_entity_field_method
+    on duplicate_rules (company_id, entity, field, method);
+
+create index idx_accounts_name_normalized
+    on accounts (regexp_replace(regexp_replace(lower(name), '\m(co|corp|corporation|inc|ltd|limited|llc|company)\M|股份有限公司|有限公司|公司', '', 'g'), '[^[:alnum:]]', '', 'g'));
+
+create index idx_contacts_email_normalized
+    on contacts (lower(trim(email)));
+
+insert into duplicate_rules(company_id, entity, field, method, threshold, created_by, updated_by)
+select companies.company_id, rules.entity, rules.field, rules.method, rules.threshold,
+       '00000000-0000-4000-a000-000000000000', '00000000-0000-4000-a000-000000000000'
+from (select distinct company_id from users) as companies
+         cross join (values ('accounts', 'name', 'normalized', 1),
+                            ('accounts', 'name', 'trigram', 0.6),
+                            ('accounts', 'phone_number', 'normalized', 1),
+                            ('contacts', 'email', 'normalized', 1),
+                            ('contacts', 'cell_phone', 'normalized', 1),
+                            ('contacts', 'name', 'trigram', 0.8),
+                            ('leads', 'description', 'trigram', 0.8)) as rules(entity, field, method, threshold);
@@ -0,0 +1,7 @@
+drop index idx_accounts_deleted_at;
+drop index idx_contacts_deleted_at;
+drop index idx_leads_deleted_at;
+drop index idx_contracts_deleted_at;
+drop index idx_opportunities_deleted_at;
+drop index idx_orders_deleted_at;
+drop index idx_quotes_deleted_at;
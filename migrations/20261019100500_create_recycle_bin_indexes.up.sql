@@ -0,0 +1,20 @@
+create index idx_accounts_deleted_at
+    on accounts (deleted_at desc) where deleted_at is not null;
+
+create index idx_contacts_deleted_at
+    on contacts (deleted_at desc) where deleted_at is not null;
+
+create index idx_leads_deleted_at
+    on leads (deleted_at desc) where deleted_at is not null;
+
+create index idx_contracts_deleted_at
+    on contracts (deleted_at desc) where deleted_at is not null;
+
+create index idx_opportunities_deleted_at
+    on opportunities (deleted_at desc) where deleted_at is not null;
+
+create index idx_orders_deleted_at
+    on orders (deleted_at desc) where deleted_at is not null;
+
+create index idx_quotes_deleted_at
+    on quotes (deleted_at desc) where deleted_at is not null;
@@ -0,0 +1,3 @@
+drop index idx_products_deleted_at;
+drop index idx_campaigns_deleted_at;
+drop index idx_events_deleted_at;
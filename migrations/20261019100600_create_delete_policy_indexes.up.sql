@@ -0,0 +1,8 @@
+create index idx_products_deleted_at
+    on products (deleted_at desc) where deleted_at is not null;
+
+create index idx_campaigns_deleted_at
+    on campaigns (deleted_at desc) where deleted_at is not null;
+
+create index idx_events_deleted_at
+    on events (deleted_at desc) where deleted_at is not null;
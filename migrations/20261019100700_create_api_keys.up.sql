@@ -0,0 +1,19 @@
+create table api_keys
+(
+    api_key_id uuid      default uuid_generate_v4() not null
+        primary key,
+    company_id uuid                                 not null,
+    user_id    uuid                                 not null,
+    name       text                                 not null,
+    prefix     text                                 not null,
+    key_hash   text                                 not null,
+    expired_at timestamp,
+    created_at timestamp default now()              not null,
+    created_by uuid                                 not null
+);
+
+create unique index idx_api_keys_key_hash
+    on api_keys (key_hash);
+
+create index idx_api_keys_company_id
+    on api_keys (company_id);
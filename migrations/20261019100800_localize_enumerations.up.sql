@@ -0,0 +1,122 @@
+update leads
+set status = enumerations.code
+from (values ('lead.status.new', '新線索'),
+             ('lead.status.working', '發展中'),
+             ('lead.status.unqualified', '不合格'),
+             ('lead.status.converted', '已轉換')) as enumerations (code, label)
+where leads.status = enumerations.label;
+
+update orders
+set status = enumerations.code
+from (values ('order.status.draft', '草稿'),
+             ('order.status.activated', '啟動中')) as enumerations (code, label)
+where orders.status = enumerations.label;
+
+update contracts
+set status = enumerations.code
+from (values ('contract.status.draft', '草稿'),
+             ('contract.status.in_approval', '審核中'),
+             ('contract.status.signed', '已簽署')) as enumerations (code, label)
+where contracts.status = enumerations.label;
+
+update quotes
+set status = enumerations.code
+from (values ('quote.status.draft', '草稿'),
+             ('quote.status.in_review', '審核中'),
+             ('quote.status.accepted', '已接受'),
+             ('quote.status.rejected', '已拒絕')) as enumerations (code, label)
+where quotes.status = enumerations.label;
+
+-- 有效期限的異動值只保留月數
+update historical_records
+set value = regexp_replace(value, '個月$', '')
+where field in ('有效期限', '有效期限為');
+
+update historical_records
+set field = enumerations.code
+from (values ('historical_record.field.name', '名稱'),
+             ('historical_record.field.status', '狀態'),
+             ('historical_record.field.description', '描述'),
+             ('historical_record.field.salesperson', '業務員'),
+             ('historical_record.field.account', '帳戶'),
+             ('historical_record.field.source', '來源'),
+             ('historical_record.field.phone_number', '電話號碼'),
+             ('historical_record.field.phone', '電話'),
+             ('historical_record.field.cell_phone', '行動電話'),
+             ('historical_record.field.email', '電子郵件'),
+             ('historical_record.field.department', '部門'),
+             ('historical_record.field.title', '職稱'),
+             ('historical_record.field.salutation', '稱謂'),
+             ('historical_record.field.rating', '分級'),
+             ('historical_record.field.start_date', '開始日期'),
+             ('historical_record.field.close_date', '結束日期'),
+             ('historical_record.field.expiration_date', '到期日期'),
+             ('historical_record.field.opportunity', '商機'),
+             ('historical_record.field.industry', '行業'),
+             ('historical_record.field.supervisor', '直屬上司'),
+             ('historical_record.field.parent_account', '父系帳戶'),
+             ('historical_record.field.sync', '同步化'),
+             ('historical_record.field.amount', '金額'),
+             ('historical_record.field.shipping_and_handling', '運費及其他費用'),
+             ('historical_record.field.tax', '稅額'),
+             ('historical_record.field.type', '類型'),
+             ('historical_record.field.forecast_category', '預測種類'),
+             ('historical_record.field.stage', '階段'),
+             ('historical_record.field.term', '有效期限'),
+             ('historical_record.field.contract_code', '契約號碼'),
+             ('historical_record.field.data', '資料')) as enumerations (code, label)
+where regexp_replace(historical_records.field, '為$', '') = enumerations.label;
+
+update historical_records
+set action = enumerations.code
+from (values ('historical_record.action.created', '建立'),
+             ('historical_record.action.updated', '修改'),
+             ('historical_record.action.deleted', '刪除'),
+             ('historical_record.action.cleared', '清除'),
+             ('historical_record.action.removed', '移除'),
+             ('historical_record.action.confirmed', '確認'),
+             ('historical_record.action.cancelled', '取消'),
+             ('historical_record.action.merged', '合併'),
+             ('historical_record.action.restored', '還原'),
+             ('historical_record.action.purged', '永久刪除')) as enumerations (code, label)
+where historical_records.action = enumerations.label;
+
+update historical_records
+set source_type = enumerations.code
+from (values ('historical_record.source_type.account', '帳戶'),
+             ('historical_record.source_type.contact', '聯絡人'),
+             ('historical_record.source_type.lead', '線索'),
+             ('historical_record.source_type.opportunity', '商機'),
+             ('historical_record.source_type.contract', '契約'),
+             ('historical_record.source_type.order', '訂單'),
+             ('historical_record.source_type.quote', '報價'),
+             ('historical_record.source_type.product', '產品'),
+             ('historical_record.source_type.campaign', '行銷活動'),
+             ('historical_record.source_type.event', '事件')) as enumerations (code, label)
+where historical_records.source_type = enumerations.label;
+
+-- 狀態的異動值依來源類型轉為代碼
+update historical_records
+set value = enumerations.code
+from (values ('historical_record.source_type.lead', 'lead.status.new', '新線索'),
+             ('historical_record.source_type.lead', 'lead.status.working', '發展中'),
+             ('historical_record.source_type.lead', 'lead.status.unqualified', '不合格'),
+             ('historical_record.source_type.lead', 'lead.status.converted', '已轉換'),
+             ('historical_record.source_type.order', 'order.status.draft', '草稿'),
+             ('historical_record.source_type.order', 'order.status.activated', '啟動中'),
+             ('historical_record.source_type.contract', 'contract.status.draft', '草稿'),
+             ('historical_record.source_type.contract', 'contract.status.in_approval', '審核中'),
+             ('historical_record.source_type.contract', 'contract.status.signed', '已簽署'),
+             ('historical_record.source_type.quote', 'quote.status.draft', '草稿'),
+             ('historical_record.source_type.quote', 'quote.status.in_review', '審核中'),
+             ('historical_record.source_type.quote', 'quote.status.accepted', '已接受'),
+             ('historical_record.source_type.quote', 'quote.status.rejected', '已拒絕')) as enumerations (source_type, code, label)
+where historical_records.field = 'historical_record.field.status'
+  and historical_records.source_type = enumerations.source_type
+  and historical_records.value = enumerations.label;
+
+update historical_records
+set value = enumerations.code
+from (values ('historical_record.value.quote_synced', '此報價至商機'),
+             ('historical_record.value.quote_final', '此報價為最終版')) as enumerations (code, label)
+where historical_records.value = enumerations.label;
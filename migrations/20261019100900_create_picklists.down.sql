@@ -0,0 +1,2 @@
+drop index idx_picklists_company_id_entity_field;
+drop table picklists;
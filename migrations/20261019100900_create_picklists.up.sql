@@ -0,0 +1,17 @@
+create table picklists
+(
+    picklist_id       uuid      default uuid_generate_v4() not null
+        primary key,
+    company_id        uuid                                 not null,
+    entity            text                                 not null,
+    field             text                                 not null,
+    controlling_field text      default ''::text           not null,
+    "values"          jsonb     default '[]'::jsonb        not null,
+    created_at        timestamp default now()              not null,
+    created_by        uuid                                 not null,
+    updated_at        timestamp default now()              not null,
+    updated_by        uuid                                 not null
+);
+
+create unique index idx_picklists_company_id_entity_field
+    on picklists (company_id, entity, field);
@@ -0,0 +1,19 @@
+drop index idx_opportunities_custom_fields;
+drop index idx_leads_custom_fields;
+drop index idx_contacts_custom_fields;
+drop index idx_accounts_custom_fields;
+
+alter table opportunities
+    drop column custom_fields;
+
+alter table leads
+    drop column custom_fields;
+
+alter table contacts
+    drop column custom_fields;
+
+alter table accounts
+    drop column custom_fields;
+
+drop index idx_custom_fields_company_id_entity_key;
+drop table custom_fields;
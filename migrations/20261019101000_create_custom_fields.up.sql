@@ -0,0 +1,45 @@
+create table custom_fields
+(
+    custom_field_id uuid      default uuid_generate_v4() not null
+        primary key,
+    company_id      uuid                                 not null,
+    entity          text                                 not null,
+    key             text                                 not null,
+    label           text                                 not null,
+    type            text                                 not null,
+    required        boolean   default false              not null,
+    options         text[]    default '{}'::text[]       not null,
+    default_value   jsonb,
+    sequence        integer   default 0                  not null,
+    created_at      timestamp default now()              not null,
+    created_by      uuid                                 not null,
+    updated_at      timestamp default now()              not null,
+    updated_by      uuid                                 not null
+);
+
+create unique index idx_custom_fields_company_id_entity_key
+    on custom_fields (company_id, entity, key);
+
+alter table accounts
+    add custom_fields jsonb default '{}'::jsonb not null;
+
+alter table contacts
+    add custom_fields jsonb default '{}'::jsonb not null;
+
+alter table leads
+    add custom_fields jsonb default '{}'::jsonb not null;
+
+alter table opportunities
+    add custom_fields jsonb default '{}'::jsonb not null;
+
+create index idx_accounts_custom_fields
+    on accounts using gin (custom_fields jsonb_path_ops);
+
+create index idx_contacts_custom_fields
+    on contacts using gin (custom_fields jsonb_path_ops);
+
+create index idx_leads_custom_fields
+    on leads using gin (custom_fields jsonb_path_ops);
+
+create index idx_opportunities_custom_fields
+    on opportunities using gin (custom_fields jsonb_path_ops);
@@ -0,0 +1 @@
+drop table company_settings;
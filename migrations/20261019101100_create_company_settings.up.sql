@@ -0,0 +1,10 @@
+create table company_settings
+(
+    company_id        uuid                    not null
+        primary key,
+    if_match_required boolean   default false not null,
+    created_at        timestamp default now() not null,
+    created_by        uuid                    not null,
+    updated_at        timestamp default now() not null,
+    updated_by        uuid                    not null
+);
@@ -0,0 +1,673 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	accountManager "crm/internal/interactor/manager/account"
+	campaignManager "crm/internal/interactor/manager/campaign"
+	contactManager "crm/internal/interactor/manager/contact"
+	contractManager "crm/internal/interactor/manager/contract"
+	eventManager "crm/internal/interactor/manager/event"
+	industryManager "crm/internal/interactor/manager/industry"
+	leadManager "crm/internal/interactor/manager/lead"
+	opportunityManager "crm/internal/interactor/manager/opportunity"
+	opportunityCampaignManager "crm/internal/interactor/manager/opportunity_campaign"
+	orderManager "crm/internal/interactor/manager/order"
+	orderProductManager "crm/internal/interactor/manager/order_product"
+	productManager "crm/internal/interactor/manager/product"
+	quoteManager "crm/internal/interactor/manager/quote"
+	quoteProductManager "crm/internal/interactor/manager/quote_product"
+	userManager "crm/internal/interactor/manager/user"
+	accountModel "crm/internal/interactor/models/accounts"
+	campaignModel "crm/internal/interactor/models/campaigns"
+	contactModel "crm/internal/interactor/models/contacts"
+	contractModel "crm/internal/interactor/models/contracts"
+	"crm/internal/interactor/models/enums"
+	eventModel "crm/internal/interactor/models/events"
+	industryModel "crm/internal/interactor/models/industries"
+	leadModel "crm/internal/interactor/models/leads"
+	opportunityModel "crm/internal/interactor/models/opportunities"
+	opportunityCampaignModel "crm/internal/interactor/models/opportunity_campaigns"
+	orderProductModel "crm/internal/interactor/models/order_products"
+	orderModel "crm/internal/interactor/models/orders"
+	productModel "crm/internal/interactor/models/products"
+	quoteProductModel "crm/internal/interactor/models/quote_products"
+	quoteModel "crm/internal/interactor/models/quotes"
+	userModel "crm/internal/interactor/models/users"
+	"crm/internal/interactor/pkg/util"
+	"crm/internal/interactor/pkg/util/code"
+
+	"gorm.io/gorm"
+)
+
+// volume is the number of root records generated for one size.
+// Child records (contacts, leads, quotes...) are derived per account.
+type volume struct {
+	Salespeople int
+	Accounts    int
+	Products    int
+	Campaigns   int
+}
+
+var volumes = map[string]volume{
+	"small":  {Salespeople: 3, Accounts: 10, Products: 8, Campaigns: 3},
+	"medium": {Salespeople: 8, Accounts: 60, Products: 25, Campaigns: 8},
+	"large":  {Salespeople: 20, Accounts: 300, Products: 80, Campaigns: 20},
+}
+
+type options struct {
+	// 公司ID
+	CompanyID string
+	// 業務員角色ID
+	RoleID string
+	// 操作者ID
+	OperatorID string
+	// 亂數種子
+	Seed int64
+	// 資料量
+	Volume volume
+}
+
+// generator writes every record through the managers, so the same
+// history records and side effects as the API are produced.
+type generator struct {
+	db     *gorm.DB
+	trx    *gorm.DB
+	opts   *options
+	random *rand.Rand
+	now    time.Time
+
+	accounts             accountManager.Manager
+	campaigns            campaignManager.Manager
+	contacts             contactManager.Manager
+	contracts            contractManager.Manager
+	events               eventManager.Manager
+	industries           industryManager.Manager
+	leads                leadManager.Manager
+	opportunities        opportunityManager.Manager
+	opportunityCampaigns opportunityCampaignManager.Manager
+	orders               orderManager.Manager
+	orderProducts        orderProductManager.Manager
+	products             productManager.Manager
+	quotes               quoteManager.Manager
+	quoteProducts        quoteProductManager.Manager
+	users                userManager.Manager
+
+	salespeople []string
+	industryIDs []string
+	productIDs  []string
+	prices      map[string]float64
+	campaignIDs []string
+	counts      map[string]int
+}
+
+func newGenerator(db *gorm.DB, opts *options) *generator {
+	return &generator{
+		db:     db,
+		opts:   opts,
+		random: rand.New(rand.NewSource(opts.Seed)),
+		// 以固定日期為基準,確保相同種子產生相同日期
+		now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(opts.Seed%365)),
+
+		accounts:             accountManager.Init(db),
+		campaigns:            campaignManager.Init(db),
+		contacts:             contactManager.Init(db),
+		contracts:            contractManager.Init(db),
+		events:               eventManager.Init(db),
+		industries:           industryManager.Init(db),
+		leads:                leadManager.Init(db),
+		opportunities:        opportunityManager.Init(db),
+		opportunityCampaigns: opportunityCampaignManager.Init(db),
+		orders:               orderManager.Init(db),
+		orderProducts:        orderProductManager.Init(db),
+		products:             productManager.Init(db),
+		quotes:               quoteManager.Init(db),
+		quoteProducts:        quoteProductManager.Init(db),
+		users:                userManager.Init(db),
+
+		prices: map[string]float64{},
+		counts: map[string]int{},
+	}
+}
+
+var (
+	companyNames     = []string{"台灣精密", "宏達科技", "永豐實業", "大同電子", "東方貿易", "新光物流", "華新材料", "聯合食品", "國泰建設", "中興醫療", "北辰能源", "遠揚航運"}
+	companySuffixes  = []string{"股份有限公司", "有限公司", "企業社", "國際股份有限公司"}
+	familyNames      = []string{"陳", "林", "黃", "張", "李", "王", "吳", "劉", "蔡", "楊"}
+	givenNames       = []string{"志明", "春嬌", "家豪", "怡君", "冠宇", "雅婷", "俊傑", "淑芬", "建宏", "美玲"}
+	titles           = []string{"執行長", "採購經理", "業務協理", "資訊長", "財務主管", "專案經理"}
+	departments      = []string{"管理部", "採購部", "業務部", "資訊部", "財務部"}
+	salutations      = []string{"先生", "小姐", "博士"}
+	industryNames    = []string{"製造業", "零售業", "金融業", "醫療保健", "資訊科技", "物流運輸", "營建業"}
+	accountTypes     = []string{"客戶", "合作夥伴", "競爭對手", "經銷商"}
+	leadStatuses     = []string{enums.LeadStatusNew, enums.LeadStatusWorking, enums.LeadStatusUnqualified}
+	leadSources      = []string{"網站", "展演", "推薦", "電話行銷", "廣告"}
+	leadRatings      = []string{"Hot", "Warm", "Cold"}
+	stages           = []string{"資格審查", "需求分析", "提案", "議價", "結案成交", "結案失敗"}
+	forecasts        = []string{"管道", "最佳情況", "承諾", "已結案", "省略"}
+	quoteStatuses    = []string{enums.QuoteStatusDraft, enums.QuoteStatusInReview, enums.QuoteStatusAccepted, enums.QuoteStatusRejected}
+	contractStatuses = []string{enums.ContractStatusDraft, enums.ContractStatusInApproval, enums.ContractStatusSigned}
+	campaignTypes    = []string{"研討會", "電子郵件", "展演", "廣告", "活動"}
+	campaignStatuses = []string{"規劃中", "進行中", "已完成"}
+	eventTypes       = []string{"會議", "電話", "拜訪", "展示"}
+	productNames     = []string{"雲端主機", "資料備份", "防火牆", "企業信箱", "顧問服務", "教育訓練", "維護合約", "授權軟體"}
+)
+
+// Run generates the whole dataset in dependency order.
+func (g *generator) Run() error {
+	steps := []func() error{
+		g.createSalespeople,
+		g.createIndustries,
+		g.createProducts,
+		g.createCampaigns,
+		g.createAccounts,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Summary returns the number of records created, or reused from a previous run, per entity.
+func (g *generator) Summary() string {
+	return fmt.Sprintf("seed %d created: %v", g.opts.Seed, g.counts)
+}
+
+func (g *generator) createSalespeople() error {
+	for i := 0; i < g.opts.Volume.Salespeople; i++ {
+		input := &userModel.Create{
+			CompanyID: g.opts.CompanyID,
+			UserName:  fmt.Sprintf("demo%d_sales%02d", g.opts.Seed, i+1),
+			Name:      g.personName(),
+			Password:  "12345",
+			Email:     fmt.Sprintf("demo%d.sales%02d@example.com", g.opts.Seed, i+1),
+			RoleID:    g.opts.RoleID,
+			CreatedBy: g.opts.OperatorID,
+		}
+		id, err := g.existing("users", "user_id", "company_id = ? and user_name = ? and deleted_at is null", input.CompanyID, input.UserName)
+		if err != nil {
+			return err
+		}
+
+		if id == "" {
+			httpCode, message := g.users.Create(g.begin(), input)
+			if id, err = g.created("users", httpCode, message); err != nil {
+				return err
+			}
+		}
+
+		g.salespeople = append(g.salespeople, id)
+	}
+
+	return nil
+}
+
+func (g *generator) createIndustries() error {
+	for _, name := range industryNames {
+		id, err := g.existing("industries", "industry_id", "name = ?", name)
+		if err != nil {
+			return err
+		}
+
+		if id == "" {
+			httpCode, message := g.industries.Create(g.begin(), &industryModel.Create{
+				Name: name,
+			})
+			if id, err = g.created("industries", httpCode, message); err != nil {
+				return err
+			}
+		}
+
+		g.industryIDs = append(g.industryIDs, id)
+	}
+
+	return nil
+}
+
+func (g *generator) createProducts() error {
+	for i := 0; i < g.opts.Volume.Products; i++ {
+		price := float64(g.between(10, 500) * 100)
+		input := &productModel.Create{
+			Name:        fmt.Sprintf("%s %d", pick(g, productNames), i+1),
+			Code:        fmt.Sprintf("DEMO%d-%03d", g.opts.Seed, i+1),
+			IsEnable:    g.chance(90),
+			Description: "示範產品",
+			Price:       price,
+			CreatedBy:   g.opts.OperatorID,
+		}
+		id, err := g.existing("products", "product_id", "code = ? and deleted_at is null", input.Code)
+		if err != nil {
+			return err
+		}
+
+		if id == "" {
+			httpCode, message := g.products.Create(g.begin(), input)
+			if id, err = g.created("products", httpCode, message); err != nil {
+				return err
+			}
+		}
+
+		g.productIDs = append(g.productIDs, id)
+		g.prices[id] = price
+	}
+
+	return nil
+}
+
+func (g *generator) createCampaigns() error {
+	for i := 0; i < g.opts.Volume.Campaigns; i++ {
+		start := g.date(-180, 60)
+		input := &campaignModel.Create{
+			Name:              fmt.Sprintf("%s %d", pick(g, campaignTypes), i+1),
+			Status:            pick(g, campaignStatuses),
+			IsEnable:          true,
+			Type:              pick(g, campaignTypes),
+			StartDate:         start,
+			EndDate:           start.AddDate(0, 0, g.between(7, 60)),
+			Description:       "示範行銷活動",
+			Sent:              g.between(100, 5000),
+			BudgetCost:        float64(g.between(10, 200) * 1000),
+			ExpectedResponses: float64(g.between(1, 20)),
+			ActualCost:        float64(g.between(10, 200) * 1000),
+			ExpectedIncome:    float64(g.between(50, 1000) * 1000),
+			CreatedBy:         pick(g, g.salespeople),
+		}
+		// 部分行銷活動掛在既有行銷活動之下
+		if len(g.campaignIDs) > 0 && g.chance(30) {
+			input.ParentCampaignID = pick(g, g.campaignIDs)
+		}
+
+		httpCode, message := g.campaigns.Create(g.begin(), input)
+		id, err := g.created("campaigns", httpCode, message)
+		if err != nil {
+			return err
+		}
+
+		g.campaignIDs = append(g.campaignIDs, id)
+	}
+
+	return nil
+}
+
+func (g *generator) createAccounts() error {
+	var accountIDs []string
+	for i := 0; i < g.opts.Volume.Accounts; i++ {
+		salespersonID := pick(g, g.salespeople)
+		input := &accountModel.Create{
+			Name:        pick(g, companyNames) + pick(g, companySuffixes) + fmt.Sprintf(" %d", i+1),
+			PhoneNumber: g.phoneNumber("02"),
+			Type:        []string{pick(g, accountTypes)},
+			IndustryID:  pick(g, g.industryIDs),
+			CreatedBy:   salespersonID,
+		}
+		// 約四分之一的帳戶為既有帳戶的子公司
+		if len(accountIDs) > 0 && g.chance(25) {
+			input.ParentAccountID = pick(g, accountIDs)
+		}
+
+		httpCode, message := g.accounts.Create(g.begin(), input)
+		accountID, err := g.created("accounts", httpCode, message)
+		if err != nil {
+			return err
+		}
+
+		accountIDs = append(accountIDs, accountID)
+		if err = g.createAccountChildren(accountID, salespersonID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createAccountChildren creates contacts, leads, opportunities and events of one account.
+func (g *generator) createAccountChildren(accountID, salespersonID string) error {
+	contactIDs, err := g.createContacts(accountID, salespersonID)
+	if err != nil {
+		return err
+	}
+
+	// 部分線索轉換為商機,其餘停留在各種狀態
+	for i, n := 0, g.between(1, 4); i < n; i++ {
+		httpCode, message := g.leads.Create(g.begin(), &leadModel.Create{
+			Status:      pick(g, leadStatuses),
+			Description: "示範線索",
+			Source:      pick(g, leadSources),
+			AccountID:   accountID,
+			Rating:      pick(g, leadRatings),
+			CreatedBy:   salespersonID,
+		})
+		leadID, err := g.created("leads", httpCode, message)
+		if err != nil {
+			return err
+		}
+
+		if g.chance(60) {
+			if err = g.createOpportunity(leadID, accountID, salespersonID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, n := 0, g.between(0, 3); i < n; i++ {
+		start := g.date(-90, 30).Add(time.Duration(g.between(9, 17)) * time.Hour)
+		input := &eventModel.Create{
+			Subject:     pick(g, eventTypes) + " - 示範事件",
+			Main:        []string{salespersonID},
+			IsWhole:     g.chance(10),
+			StartDate:   start,
+			EndDate:     start.Add(time.Duration(g.between(1, 3)) * time.Hour),
+			AccountID:   accountID,
+			Type:        pick(g, eventTypes),
+			Location:    "台北市",
+			Description: "示範事件",
+			CreatedBy:   salespersonID,
+		}
+		if other := pick(g, g.salespeople); other != salespersonID {
+			input.Attendee = []string{other}
+		}
+		if len(contactIDs) > 0 {
+			input.Contact = []string{pick(g, contactIDs)}
+		}
+
+		httpCode, message := g.events.Create(g.begin(), input)
+		if _, err = g.created("events", httpCode, message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createContacts creates contacts of one account, the first one acts as supervisor of the others.
+func (g *generator) createContacts(accountID, salespersonID string) (contactIDs []string, err error) {
+	for i, n := 0, g.between(1, 5); i < n; i++ {
+		input := &contactModel.Create{
+			Name:        g.personName(),
+			Title:       pick(g, titles),
+			PhoneNumber: g.phoneNumber("02"),
+			CellPhone:   g.phoneNumber("09"),
+			Email:       fmt.Sprintf("contact%d.%d@example.com", g.opts.Seed, g.counts["contacts"]+1),
+			Salutation:  pick(g, salutations),
+			Department:  pick(g, departments),
+			AccountID:   accountID,
+			CreatedBy:   salespersonID,
+		}
+		if i > 0 {
+			input.SupervisorID = contactIDs[0]
+		}
+
+		httpCode, message := g.contacts.Create(g.begin(), input)
+		contactID, err := g.created("contacts", httpCode, message)
+		if err != nil {
+			return nil, err
+		}
+
+		contactIDs = append(contactIDs, contactID)
+	}
+
+	return contactIDs, nil
+}
+
+// createOpportunity converts a lead and continues down the sales pipeline depending on the stage.
+func (g *generator) createOpportunity(leadID, accountID, salespersonID string) error {
+	stage := g.random.Intn(len(stages))
+	httpCode, message := g.opportunities.Create(g.begin(), &opportunityModel.Create{
+		Name:             fmt.Sprintf("商機 %d", g.counts["opportunities"]+1),
+		Stage:            stages[stage],
+		ForecastCategory: pick(g, forecasts),
+		CloseDate:        g.date(-30, 120),
+		LeadID:           leadID,
+		AccountID:        accountID,
+		Amount:           float64(g.between(10, 2000) * 1000),
+		CreatedBy:        salespersonID,
+	})
+	opportunityID, err := g.created("opportunities", httpCode, message)
+	if err != nil {
+		return err
+	}
+
+	if len(g.campaignIDs) > 0 && g.chance(40) {
+		httpCode, message = g.opportunityCampaigns.Create(g.begin(), &opportunityCampaignModel.Create{
+			OpportunityID: opportunityID,
+			CampaignID:    pick(g, g.campaignIDs),
+			CreatedBy:     salespersonID,
+		})
+		_, err = g.created("opportunity_campaigns", httpCode, message)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 提案階段之後才有報價
+	if stage < 2 {
+		return nil
+	}
+
+	httpCode, message = g.quotes.Create(g.begin(), &quoteModel.Create{
+		Name:                fmt.Sprintf("報價 %d", g.counts["quotes"]+1),
+		Status:              pick(g, quoteStatuses),
+		IsSyncing:           g.chance(50),
+		OpportunityID:       opportunityID,
+		ExpirationDate:      g.date(0, 60),
+		Description:         "示範報價",
+		Tax:                 float64(g.between(0, 5) * 100),
+		ShippingAndHandling: float64(g.between(0, 10) * 100),
+		CreatedBy:           salespersonID,
+	})
+	quoteID, err := g.created("quotes", httpCode, message)
+	if err != nil {
+		return err
+	}
+
+	quoteProducts := &quoteProductModel.CreateList{}
+	for _, productID := range g.productSample() {
+		quoteProducts.QuoteProducts = append(quoteProducts.QuoteProducts, &quoteProductModel.Create{
+			QuoteID:     quoteID,
+			ProductID:   productID,
+			Quantity:    g.between(1, 20),
+			UnitPrice:   g.prices[productID],
+			Discount:    float64(g.between(0, 3) * 5),
+			Description: "示範報價產品",
+			CreatedBy:   salespersonID,
+		})
+	}
+	httpCode, message = g.quoteProducts.Create(g.begin(), quoteProducts)
+	if _, err = g.createdList("quote_products", httpCode, message); err != nil {
+		return err
+	}
+
+	// 僅成交的商機會簽訂契約及訂單
+	if stages[stage] != "結案成交" {
+		return nil
+	}
+
+	return g.createContract(opportunityID, salespersonID)
+}
+
+func (g *generator) createContract(opportunityID, salespersonID string) error {
+	start := g.date(-60, 0)
+	httpCode, message := g.contracts.Create(g.begin(), &contractModel.Create{
+		Status:        pick(g, contractStatuses),
+		StartDate:     start,
+		Term:          12 * g.between(1, 4),
+		OpportunityID: opportunityID,
+		Description:   "示範契約",
+		CreatedBy:     salespersonID,
+	})
+	contractID, err := g.created("contracts", httpCode, message)
+	if err != nil {
+		return err
+	}
+
+	for i, n := 0, g.between(1, 3); i < n; i++ {
+		httpCode, message := g.orders.Create(g.begin(), &orderModel.Create{
+			Status:      enums.OrderStatusDraft,
+			StartDate:   start.AddDate(0, i, 0),
+			ContractID:  contractID,
+			Description: "示範訂單",
+			CreatedBy:   salespersonID,
+		})
+		orderID, err := g.created("orders", httpCode, message)
+		if err != nil {
+			return err
+		}
+
+		orderProducts := &orderProductModel.CreateList{}
+		for _, productID := range g.productSample() {
+			orderProducts.OrderProducts = append(orderProducts.OrderProducts, &orderProductModel.Create{
+				OrderID:     orderID,
+				ProductID:   productID,
+				Quantity:    g.between(1, 20),
+				UnitPrice:   g.prices[productID],
+				QuotePrice:  g.prices[productID] * 0.9,
+				Description: "示範訂單產品",
+				CreatedBy:   salespersonID,
+			})
+		}
+		httpCode, message = g.orderProducts.Create(g.begin(), orderProducts)
+		if _, err = g.createdList("order_products", httpCode, message); err != nil {
+			return err
+		}
+
+		// 部分訂單啟動,產生啟用者及歷程記錄
+		if g.chance(50) {
+			httpCode, message = g.orders.Update(g.begin(), &orderModel.Update{
+				OrderID:   orderID,
+				Status:    util.PointerString(enums.OrderStatusActivated),
+				UpdatedBy: util.PointerString(salespersonID),
+			})
+			if err = g.finish(httpCode); err != nil {
+				return err
+			}
+
+			if httpCode != code.Successful {
+				return fmt.Errorf("activate order %s: %s", orderID, describe(message))
+			}
+		}
+	}
+
+	return nil
+}
+
+// existing returns the ID of the row of table matching query, empty when there is none.
+// Users, industries and products of a previous run with the same company and seed are reused,
+// so a rerun does not fail on their unique names and codes.
+func (g *generator) existing(table, primaryKey, query string, args ...any) (string, error) {
+	var ids []string
+	err := g.db.Table(table).Where(query, args...).Limit(1).Pluck(primaryKey+"::text", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return "", err
+	}
+
+	g.counts[table+" reused"]++
+	return ids[0], nil
+}
+
+// begin opens the transaction of the next manager call.
+func (g *generator) begin() *gorm.DB {
+	g.trx = g.db.Begin()
+	return g.trx
+}
+
+// finish commits the transaction of the last manager call when it succeeded,
+// as middleware.Transaction does for the API.
+func (g *generator) finish(httpCode int) error {
+	if httpCode != code.Successful {
+		return g.trx.Rollback().Error
+	}
+
+	return g.trx.Commit().Error
+}
+
+// created counts a successful manager response and returns the created ID.
+func (g *generator) created(entity string, httpCode int, message any) (string, error) {
+	if err := g.finish(httpCode); err != nil {
+		return "", err
+	}
+
+	if httpCode != code.Successful {
+		return "", fmt.Errorf("create %s: %s", entity, describe(message))
+	}
+
+	id, ok := message.(*code.SuccessfulMessage).Body.(*string)
+	if !ok || id == nil {
+		return "", fmt.Errorf("create %s: unexpected response body", entity)
+	}
+
+	g.counts[entity]++
+	return *id, nil
+}
+
+// createdList is created for managers that create several records at once.
+func (g *generator) createdList(entity string, httpCode int, message any) ([]*string, error) {
+	if err := g.finish(httpCode); err != nil {
+		return nil, err
+	}
+
+	if httpCode != code.Successful {
+		return nil, fmt.Errorf("create %s: %s", entity, describe(message))
+	}
+
+	ids, ok := message.(*code.SuccessfulMessage).Body.([]*string)
+	if !ok {
+		return nil, fmt.Errorf("create %s: unexpected response body", entity)
+	}
+
+	g.counts[entity] += len(ids)
+	return ids, nil
+}
+
+func describe(message any) string {
+	if errorMessage, ok := message.(*code.ErrorMessage); ok {
+		return fmt.Sprint(errorMessage.Message, " ", errorMessage.Detailed)
+	}
+
+	return fmt.Sprint(message)
+}
+
+// productSample returns one to three distinct products.
+func (g *generator) productSample() []string {
+	n := g.between(1, 3)
+	if n > len(g.productIDs) {
+		n = len(g.productIDs)
+	}
+
+	output := make([]string, 0, n)
+	for _, i := range g.random.Perm(len(g.productIDs))[:n] {
+		output = append(output, g.productIDs[i])
+	}
+
+	return output
+}
+
+// between returns a number in [min, max].
+func (g *generator) between(min, max int) int {
+	return min + g.random.Intn(max-min+1)
+}
+
+func (g *generator) chance(percent int) bool {
+	return g.random.Intn(100) < percent
+}
+
+// date returns a day between from and to days relative to the generator base date.
+func (g *generator) date(from, to int) time.Time {
+	return g.now.AddDate(0, 0, g.between(from, to))
+}
+
+func (g *generator) personName() string {
+	return pick(g, familyNames) + pick(g, givenNames)
+}
+
+func (g *generator) phoneNumber(prefix string) string {
+	return fmt.Sprintf("%s%08d", prefix, g.random.Intn(100000000))
+}
+
+func pick[T any](g *generator, values []T) T {
+	return values[g.random.Intn(len(values))]
+}
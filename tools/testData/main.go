@@ -1,4 +1,49 @@
-package rbac
+package main
 
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"crm/internal/interactor/pkg/connect"
+	"crm/internal/interactor/pkg/util/log"
+)
+
+// main generates a coherent demo dataset for one company.
+// A rerun reuses the salespeople, industries and products already created and adds another set of accounts.
+//
+//	go run -tags debug ./tools/testData -company 00000000-0000-4000-a000-000000000000 -size medium -seed 42
 func main() {
+	companyID := flag.String("company", "00000000-0000-4000-a000-000000000000", "公司ID")
+	roleID := flag.String("role", "d56fc184-9441-4396-be6c-d48580650171", "業務員角色ID")
+	operatorID := flag.String("operator", "a1bb0141-68e3-420c-8a92-9332fc21bd25", "建立使用者及產品的操作者ID")
+	size := flag.String("size", "small", "資料量(small, medium, large)")
+	seed := flag.Int64("seed", 1, "亂數種子,相同種子產生相同資料")
+	flag.Parse()
+
+	volume, ok := volumes[*size]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown size %q, expected small, medium or large\n", *size)
+		os.Exit(2)
+	}
+
+	db, err := connect.PostgresSQL()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	g := newGenerator(db, &options{
+		CompanyID:  *companyID,
+		RoleID:     *roleID,
+		OperatorID: *operatorID,
+		Seed:       *seed,
+		Volume:     volume,
+	})
+	if err = g.Run(); err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	log.Info(g.Summary())
 }